@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -22,6 +23,28 @@ func Bind(r *http.Request, dest interface{}) error {
 		return err
 	}
 
+	if err := bindFields(dest, r.FormValue); err != nil {
+		return err
+	}
+
+	if r.Header.Get("Content-Type") == "application/json" {
+		reader := bufio.NewReader(r.Body)
+		decoder := json.NewDecoder(reader)
+		if err := decoder.Decode(dest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BindValues binds the given values into dest like Bind binds form values,
+// for accepting the same parameters outside of HTTP requests.
+func BindValues(values url.Values, dest interface{}) error {
+	return bindFields(dest, values.Get)
+}
+
+func bindFields(dest interface{}, formValue func(string) string) error {
 	val := reflect.ValueOf(dest)
 	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("%w: %T", errInvalidType, dest)
@@ -39,6 +62,14 @@ func Bind(r *http.Request, dest interface{}) error {
 
 		fieldValue := val.Field(i)
 
+		// Fields of embedded structs are bound as if they were fields of dest.
+		if fieldType.Anonymous && fieldValue.Kind() == reflect.Struct {
+			if err := bindFields(fieldValue.Addr().Interface(), formValue); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// If the field value is a pointer, we unwrap it.
 		if fieldValue.Kind() == reflect.Ptr {
 			if fieldValue.IsNil() {
@@ -48,7 +79,7 @@ func Bind(r *http.Request, dest interface{}) error {
 			fieldValue = fieldValue.Elem()
 		}
 
-		formValue := r.FormValue(formField)
+		formValue := formValue(formField)
 		if fieldValue.CanAddr() && fieldValue.Addr().Type().Implements(reflect.TypeOf((*Binder)(nil)).Elem()) {
 			if err := fieldValue.Addr().Interface().(Binder).Bind(formValue); err != nil {
 				return err
@@ -92,13 +123,5 @@ func Bind(r *http.Request, dest interface{}) error {
 		}
 	}
 
-	if r.Header.Get("Content-Type") == "application/json" {
-		reader := bufio.NewReader(r.Body)
-		decoder := json.NewDecoder(reader)
-		if err := decoder.Decode(dest); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
@@ -72,3 +72,19 @@ func TestBindFormPointer(t *testing.T) {
 	}
 	runTestBindForm(t, dest, validate)
 }
+
+type TestStructEmbedded struct {
+	TestStructNonPointer
+	Role string `form:"role"`
+}
+
+func TestBindFormEmbedded(t *testing.T) {
+	dest := &TestStructEmbedded{}
+	req, _ := http.NewRequest("GET", "?name=John+Doe&tags=tag1,tag2&role=admin", nil)
+	if err := Bind(req, dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, "John Doe", dest.Name)
+	assert.Equal(t, CSV{"tag1", "tag2"}, dest.Tags)
+	assert.Equal(t, "admin", dest.Role)
+}
@@ -41,4 +41,31 @@ func Error(err error) *ErrorResponse {
 	}
 }
 
+func UnauthorizedError(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:     err,
+		Code:    401,
+		Status:  http.StatusText(401),
+		Message: err.Error(),
+	}
+}
+
+func ForbiddenError(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:     err,
+		Code:    403,
+		Status:  http.StatusText(403),
+		Message: err.Error(),
+	}
+}
+
+func TooManyRequestsError(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:     err,
+		Code:    429,
+		Status:  http.StatusText(429),
+		Message: err.Error(),
+	}
+}
+
 var ErrNotFound = &ErrorResponse{Code: 404, Status: "Resource not found."}
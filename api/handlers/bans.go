@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ssvlabs/ssv/api"
+	networkpeers "github.com/ssvlabs/ssv/network/peers"
+)
+
+// BanList manages the peers and IP addresses which are banned from connecting to this node.
+type BanList interface {
+	Bans() []*networkpeers.Ban
+	Ban(ban *networkpeers.Ban) error
+	Unban(target string) (bool, error)
+}
+
+// Bans serves the ban list of the P2P network.
+type Bans struct {
+	BanList BanList
+}
+
+// List returns the bans which haven't expired, the most recent first.
+func (h *Bans) List(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data []*networkpeers.Ban `json:"data"`
+	}
+
+	response.Data = h.BanList.Bans()
+	sort.Slice(response.Data, func(i, j int) bool {
+		return response.Data[i].BannedAt.After(response.Data[j].BannedAt)
+	})
+
+	return api.Render(w, r, response)
+}
+
+// Ban bans a peer ID or an IP address, optionally for a limited duration, and disconnects from it.
+func (h *Bans) Ban(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		PeerID   string `json:"peer_id" form:"peer_id"`
+		IP       string `json:"ip" form:"ip"`
+		Reason   string `json:"reason" form:"reason"`
+		Duration string `json:"duration" form:"duration"`
+	}
+	var response struct {
+		Data *networkpeers.Ban `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	ban := &networkpeers.Ban{
+		Reason:   request.Reason,
+		BannedAt: time.Now(),
+	}
+	switch {
+	case request.PeerID != "" && request.IP != "":
+		return api.BadRequestError(fmt.Errorf("either 'peer_id' or 'ip' is required, not both"))
+	case request.PeerID != "":
+		id, err := peer.Decode(request.PeerID)
+		if err != nil {
+			return api.BadRequestError(fmt.Errorf("invalid peer ID: %w", err))
+		}
+		ban.PeerID = id
+	case request.IP != "":
+		ban.IP = net.ParseIP(request.IP)
+		if ban.IP == nil {
+			return api.BadRequestError(fmt.Errorf("invalid IP address: %s", request.IP))
+		}
+	default:
+		return api.BadRequestError(fmt.Errorf("either 'peer_id' or 'ip' is required"))
+	}
+	if request.Duration != "" {
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil || duration <= 0 {
+			return api.BadRequestError(fmt.Errorf("invalid duration: %s", request.Duration))
+		}
+		expiresAt := ban.BannedAt.Add(duration)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	if err := h.BanList.Ban(ban); err != nil {
+		return api.Error(fmt.Errorf("could not ban: %w", err))
+	}
+
+	response.Data = ban
+	return api.Render(w, r, response)
+}
+
+// Unban lifts the ban of a peer ID or an IP address.
+func (h *Bans) Unban(w http.ResponseWriter, r *http.Request) error {
+	target := chi.URLParam(r, "target")
+	if ip := net.ParseIP(target); ip != nil {
+		// Match the form which bans of IP addresses are stored in.
+		target = ip.String()
+	}
+
+	found, err := h.BanList.Unban(target)
+	if err != nil {
+		return api.Error(fmt.Errorf("could not unban: %w", err))
+	}
+	if !found {
+		return api.ErrNotFound
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	networkpeers "github.com/ssvlabs/ssv/network/peers"
+)
+
+func TestBans(t *testing.T) {
+	reputation, err := networkpeers.NewReputation(logging.TestLogger(t), nil, time.Hour)
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	h := &Bans{BanList: reputation}
+	router.Get("/v1/admin/bans", api.Handler(h.List))
+	router.Post("/v1/admin/bans", api.Handler(h.Ban))
+	router.Delete("/v1/admin/bans/{target}", api.Handler(h.Unban))
+	request := func(method, path string, data any) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		if w.Code == http.StatusOK && data != nil {
+			response := struct{ Data any }{Data: data}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code
+	}
+
+	id := test.RandPeerIDFatal(t)
+
+	var ban *networkpeers.Ban
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "/v1/admin/bans?peer_id="+id.String()+"&reason=spam", &ban))
+	require.Equal(t, id, ban.PeerID)
+	require.Equal(t, "spam", ban.Reason)
+	require.Nil(t, ban.ExpiresAt)
+	require.True(t, reputation.IsPeerBanned(id))
+
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "/v1/admin/bans?ip=10.0.0.1&duration=1h", &ban))
+	require.Equal(t, "10.0.0.1", ban.IP.String())
+	require.WithinDuration(t, time.Now().Add(time.Hour), *ban.ExpiresAt, time.Minute)
+
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/v1/admin/bans", nil))
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/v1/admin/bans?peer_id="+id.String()+"&ip=10.0.0.1", nil))
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/v1/admin/bans?peer_id=invalid", nil))
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/v1/admin/bans?ip=10.0.0", nil))
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/v1/admin/bans?ip=10.0.0.2&duration=-1h", nil))
+
+	var bans []*networkpeers.Ban
+	require.Equal(t, http.StatusOK, request(http.MethodGet, "/v1/admin/bans", &bans))
+	require.Len(t, bans, 2)
+	require.Equal(t, "10.0.0.1", bans[0].Target())
+
+	require.Equal(t, http.StatusNoContent, request(http.MethodDelete, "/v1/admin/bans/10.0.0.1", nil))
+	require.Equal(t, http.StatusNotFound, request(http.MethodDelete, "/v1/admin/bans/10.0.0.1", nil))
+	require.Equal(t, http.StatusNoContent, request(http.MethodDelete, "/v1/admin/bans/"+id.String(), nil))
+	require.False(t, reputation.IsPeerBanned(id))
+
+	require.Equal(t, http.StatusOK, request(http.MethodGet, "/v1/admin/bans", &bans))
+	require.Empty(t, bans)
+}
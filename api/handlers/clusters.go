@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+type Clusters struct {
+	Shares     registrystorage.Shares
+	Recipients registrystorage.Recipients
+}
+
+// ClusterJSON is the representation of a cluster, the validators of an owner sharing a committee, in the API.
+type ClusterJSON struct {
+	// ID is the hash of the owner and operator IDs which identifies the cluster in the contract.
+	ID             api.Hex                `json:"id"`
+	Owner          api.Hex                `json:"owner"`
+	Operators      []spectypes.OperatorID `json:"operators"`
+	ValidatorCount int                    `json:"validator_count"`
+	Liquidated     bool                   `json:"liquidated"`
+	FeeRecipient   api.Hex                `json:"fee_recipient"`
+}
+
+// List returns the clusters of the stored validators, matching those which match any of the values of every given filter.
+func (h *Clusters) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		IDs        api.HexSlice       `json:"ids" form:"ids"`
+		Owners     api.HexSlice       `json:"owners" form:"owners"`
+		Operators  api.Uint64Slice    `json:"operators" form:"operators"`
+		Liquidated api.Optional[bool] `json:"liquidated" form:"liquidated"`
+	}
+	var response struct {
+		Data []*ClusterJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	var filters []registrystorage.SharesFilter
+	if len(request.Owners) > 0 {
+		filters = append(filters, byOwners(request.Owners))
+	}
+	if len(request.Operators) > 0 {
+		filters = append(filters, byOperators(request.Operators))
+	}
+
+	clusters := make(map[string]*ClusterJSON)
+	for _, share := range h.Shares.List(nil, filters...) {
+		operators := share.OperatorIDs()
+		slices.Sort(operators)
+		id := types.ComputeClusterIDHash(share.OwnerAddress, operators)
+		cluster, ok := clusters[string(id)]
+		if !ok {
+			cluster = &ClusterJSON{
+				ID:        id,
+				Owner:     api.Hex(share.OwnerAddress[:]),
+				Operators: operators,
+			}
+			clusters[string(id)] = cluster
+		}
+		cluster.ValidatorCount++
+		// Liquidation applies to the whole cluster, so its validators are all either liquidated or not.
+		cluster.Liquidated = cluster.Liquidated || share.Liquidated
+	}
+
+	response.Data = []*ClusterJSON{}
+	for _, cluster := range clusters {
+		if len(request.IDs) > 0 && !slices.ContainsFunc(request.IDs, func(id api.Hex) bool {
+			return bytes.Equal(id, cluster.ID)
+		}) {
+			continue
+		}
+		if request.Liquidated.Set && cluster.Liquidated != request.Liquidated.Value {
+			continue
+		}
+		response.Data = append(response.Data, cluster)
+	}
+	sort.Slice(response.Data, func(i, j int) bool {
+		if c := bytes.Compare(response.Data[i].Owner, response.Data[j].Owner); c != 0 {
+			return c < 0
+		}
+		return slices.Compare(response.Data[i].Operators, response.Data[j].Operators) < 0
+	})
+
+	if err := h.setFeeRecipients(response.Data); err != nil {
+		return api.Error(err)
+	}
+	return api.Render(w, r, response)
+}
+
+// setFeeRecipients sets the fee recipients of the owners of the given clusters.
+func (h *Clusters) setFeeRecipients(clusters []*ClusterJSON) error {
+	owners := make([]common.Address, 0, len(clusters))
+	for _, cluster := range clusters {
+		owners = append(owners, common.Address(cluster.Owner))
+	}
+	feeRecipients, err := ownersFeeRecipients(h.Recipients, owners)
+	if err != nil {
+		return err
+	}
+	for i, cluster := range clusters {
+		cluster.FeeRecipient = feeRecipients[i]
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestClusters(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	recipients := registrystorage.NewRecipientsStorage(logger, db, []byte("test"))
+
+	// Owner 1 has two validators with operators 1-4 and a liquidated one with operators 1,2,3,5,
+	// owner 2 has a validator with operators 1-4 and set a fee recipient.
+	addShare := func(pubKey byte, owner byte, liquidated bool, operators ...uint64) {
+		share := mockShare(operators...)
+		share.ValidatorPubKey = spectypes.ValidatorPK{pubKey}
+		share.OwnerAddress = common.Address{owner}
+		share.Liquidated = liquidated
+		require.NoError(t, shares.Save(nil, share))
+	}
+	addShare(1, 1, false, 1, 2, 3, 4)
+	addShare(2, 1, false, 1, 2, 3, 4)
+	addShare(3, 1, true, 1, 2, 3, 5)
+	addShare(4, 2, false, 1, 2, 3, 4)
+	_, err = recipients.SaveRecipientData(nil, &registrystorage.RecipientData{
+		Owner:        common.Address{2},
+		FeeRecipient: bellatrix.ExecutionAddress{9},
+	})
+	require.NoError(t, err)
+
+	handler := api.Handler((&Clusters{Shares: shares, Recipients: recipients}).List)
+	list := func(query string) []*ClusterJSON {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/clusters?"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var response struct {
+			Data []*ClusterJSON `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	clusters := list("")
+	require.Len(t, clusters, 3)
+	require.Equal(t, &ClusterJSON{
+		ID:             types.ComputeClusterIDHash(common.Address{1}, []uint64{1, 2, 3, 4}),
+		Owner:          common.Address{1}.Bytes(),
+		Operators:      []spectypes.OperatorID{1, 2, 3, 4},
+		ValidatorCount: 2,
+		FeeRecipient:   common.Address{1}.Bytes(),
+	}, clusters[0])
+	require.True(t, clusters[1].Liquidated)
+	require.Equal(t, api.Hex(common.Address{9}.Bytes()), clusters[2].FeeRecipient)
+
+	require.Len(t, list("operators=5"), 1)
+	require.Len(t, list("liquidated=false"), 2)
+	require.Len(t, list("owners=0x"+common.Bytes2Hex(common.Address{2}.Bytes())), 1)
+	require.Len(t, list("ids=0x"+common.Bytes2Hex(clusters[1].ID)), 1)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/observability/events"
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+const (
+	// eventsBuffer is the number of events buffered for a client before they're dropped.
+	eventsBuffer = 256
+	// eventsKeepAlive is how often a comment is sent to keep idle streams open through proxies.
+	eventsKeepAlive = 15 * time.Second
+	// committeeRole is the role of consensus events of committee duties, which contain attester and sync committee duties.
+	committeeRole = "COMMITTEE"
+)
+
+// Events streams the lifecycle of this operator's duties as Server-Sent Events.
+type Events struct {
+	Bus    *events.Bus
+	Shares registrystorage.Shares
+}
+
+// EventsRequest filters events, matching those which match any of the values of every given filter.
+type EventsRequest struct {
+	PubKeys    api.HexSlice `json:"pubkeys" form:"pubkeys"`
+	Committees api.HexSlice `json:"committees" form:"committees"`
+	Roles      eventRoles   `json:"roles" form:"roles"`
+	Types      eventTypes   `json:"types" form:"types"`
+}
+
+func (h *Events) Stream(w http.ResponseWriter, r *http.Request) error {
+	var request EventsRequest
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+	filter, err := h.newEventFilter(&request)
+	if err != nil {
+		return api.BadRequestError(err)
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return api.Error(fmt.Errorf("streaming is not supported"))
+	}
+	// Streams outlive the write timeout of the server.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	subscription := h.Bus.Subscribe(eventsBuffer)
+	defer subscription.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	var dropped uint64
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			flusher.Flush()
+
+		case event := <-subscription.Events():
+			// Let the client know it missed events because it was too slow to receive them.
+			if d := subscription.Dropped(); d > dropped {
+				if err := writeEvent(w, "dropped", map[string]uint64{"dropped": d - dropped}); err != nil {
+					return nil
+				}
+				dropped = d
+			}
+			if !filter.match(event) {
+				continue
+			}
+			if err := writeEvent(w, string(event.Type), event); err != nil {
+				return nil
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, name string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, b)
+	return err
+}
+
+// eventFilter matches events by their validators, committees, roles and types.
+type eventFilter struct {
+	shares     registrystorage.Shares
+	pubKeys    map[phase0.BLSPubKey]struct{}
+	committees map[string]struct{}
+	// pubKeyCommittees are the committees of the requested validators,
+	// for matching the events of committee duties, which don't list their validators.
+	pubKeyCommittees map[string]struct{}
+	roles            eventRoles
+	types            eventTypes
+}
+
+func (h *Events) newEventFilter(request *EventsRequest) (*eventFilter, error) {
+	f := &eventFilter{
+		shares:           h.Shares,
+		pubKeys:          make(map[phase0.BLSPubKey]struct{}),
+		committees:       make(map[string]struct{}),
+		pubKeyCommittees: make(map[string]struct{}),
+		roles:            request.Roles,
+		types:            request.Types,
+	}
+	for _, pubKey := range request.PubKeys {
+		if len(pubKey) != len(phase0.BLSPubKey{}) {
+			return nil, fmt.Errorf("invalid validator public key %x", []byte(pubKey))
+		}
+		f.pubKeys[phase0.BLSPubKey(pubKey)] = struct{}{}
+
+		if share, ok := h.Shares.Get(nil, pubKey); ok {
+			committeeID := share.CommitteeID()
+			f.pubKeyCommittees[hex.EncodeToString(committeeID[:])] = struct{}{}
+		}
+	}
+	for _, committee := range request.Committees {
+		if len(committee) != len(spectypes.CommitteeID{}) {
+			return nil, fmt.Errorf("invalid committee ID %x", []byte(committee))
+		}
+		f.committees[hex.EncodeToString(committee)] = struct{}{}
+	}
+	return f, nil
+}
+
+func (f *eventFilter) match(event events.Event) bool {
+	if len(f.types) > 0 && !slices.Contains(f.types, event.Type) {
+		return false
+	}
+	if len(f.roles) > 0 && !f.matchRole(event.Role) {
+		return false
+	}
+	if len(f.pubKeys) == 0 && len(f.committees) == 0 {
+		return true
+	}
+
+	if _, ok := f.pubKeyCommittees[event.CommitteeID]; ok && len(event.Validators) == 0 {
+		return true
+	}
+	if _, ok := f.committees[event.CommitteeID]; ok {
+		return true
+	}
+	for _, pubKey := range event.Validators {
+		if _, ok := f.pubKeys[pubKey]; ok {
+			return true
+		}
+		if len(f.committees) > 0 && event.CommitteeID == "" {
+			if share, ok := f.shares.Get(nil, pubKey[:]); ok {
+				committeeID := share.CommitteeID()
+				if _, ok := f.committees[hex.EncodeToString(committeeID[:])]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// matchRole matches roles, and matches the consensus of committee duties with their attester and sync committee duties.
+func (f *eventFilter) matchRole(role string) bool {
+	for _, r := range f.roles {
+		switch {
+		case r == role:
+			return true
+		case role == committeeRole && (r == spectypes.BNRoleAttester.String() || r == spectypes.BNRoleSyncCommittee.String()):
+			return true
+		case r == committeeRole && (role == spectypes.BNRoleAttester.String() || role == spectypes.BNRoleSyncCommittee.String()):
+			return true
+		}
+	}
+	return false
+}
+
+// eventRoles are beacon roles, or COMMITTEE for the consensus of committee duties.
+type eventRoles []string
+
+func (er *eventRoles) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range strings.Split(value, ",") {
+		if s != committeeRole {
+			if _, err := message.BeaconRoleFromString(s); err != nil {
+				return err
+			}
+		}
+		*er = append(*er, s)
+	}
+	return nil
+}
+
+type eventTypes []events.Type
+
+func (et *eventTypes) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range strings.Split(value, ",") {
+		if !slices.Contains(events.Types, events.Type(s)) {
+			return fmt.Errorf("unknown event type: %s", s)
+		}
+		*et = append(*et, events.Type(s))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/observability/events"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestEventsStream(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+
+	share := mockShare(1, 2, 3, 4)
+	share.ValidatorPubKey = spectypes.ValidatorPK{1}
+	require.NoError(t, shares.Save(nil, share))
+	committeeID := share.CommitteeID()
+
+	bus := events.NewBus()
+	server := httptest.NewServer(api.Handler((&Events{Bus: bus, Shares: shares}).Stream))
+	defer server.Close()
+
+	// The handler subscribes before responding, so events published once the response arrives are streamed.
+	stream := func(t *testing.T, query string) *bufio.Reader {
+		resp, err := http.Get(server.URL + "?" + query)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		return bufio.NewReader(resp.Body)
+	}
+	next := func(reader *bufio.Reader) (string, events.Event) {
+		name, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		data, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		_, err = reader.ReadString('\n')
+		require.NoError(t, err)
+
+		var event events.Event
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &event))
+		return strings.TrimSpace(strings.TrimPrefix(name, "event: ")), event
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		reader := stream(t, "pubkeys="+hex.EncodeToString(share.ValidatorPubKey[:])+"&roles=ATTESTER")
+
+		// The consensus of the validator's committee matches, as committee duties contain attester duties.
+		bus.Publish(events.Event{Type: events.DutyScheduled, Role: "PROPOSER", Validators: []phase0.BLSPubKey{{1}}})
+		bus.Publish(events.Event{Type: events.QBFTStarted, Role: "COMMITTEE", CommitteeID: hex.EncodeToString([]byte{2})})
+		bus.Publish(events.Event{Type: events.QBFTStarted, Role: "COMMITTEE", Slot: 5, CommitteeID: hex.EncodeToString(committeeID[:])})
+		bus.Publish(events.Event{Type: events.BeaconSubmission, Role: "ATTESTER", Slot: 5, Validators: []phase0.BLSPubKey{{1}}})
+
+		name, event := next(reader)
+		require.Equal(t, string(events.QBFTStarted), name)
+		require.Equal(t, phase0.Slot(5), event.Slot)
+
+		name, event = next(reader)
+		require.Equal(t, string(events.BeaconSubmission), name)
+		require.Equal(t, []phase0.BLSPubKey{{1}}, event.Validators)
+	})
+
+	t.Run("committees and types", func(t *testing.T) {
+		reader := stream(t, "committees="+hex.EncodeToString(committeeID[:])+"&types=duty_scheduled")
+
+		// Events of the committee's validators match even without a committee ID.
+		bus.Publish(events.Event{Type: events.QBFTStarted, CommitteeID: hex.EncodeToString(committeeID[:])})
+		bus.Publish(events.Event{Type: events.DutyScheduled, Role: "PROPOSER", Slot: 6, Validators: []phase0.BLSPubKey{{2}}})
+		bus.Publish(events.Event{Type: events.DutyScheduled, Role: "PROPOSER", Slot: 7, Validators: []phase0.BLSPubKey{{1}}})
+
+		_, event := next(reader)
+		require.Equal(t, phase0.Slot(7), event.Slot)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, query := range []string{"pubkeys=01", "committees=01", "roles=UNKNOWN", "types=unknown"} {
+			resp, err := http.Get(server.URL + "?" + query)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
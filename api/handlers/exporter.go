@@ -11,13 +11,22 @@ import (
 	exporterapi "github.com/ssvlabs/ssv/exporter/api"
 	"github.com/ssvlabs/ssv/exporter/convert"
 	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 	"github.com/ssvlabs/ssv/utils/casts"
 )
 
 type Exporter struct {
 	DomainType spectypes.DomainType
 	QBFTStores *ibftstorage.QBFTStores
+	// SyncedQBFTStores store the decided participants synced from peers,
+	// which are served marked as synced in the slots where none were observed. Optional.
+	SyncedQBFTStores *ibftstorage.QBFTStores
+	Shares           registrystorage.Shares
+	BeaconNetwork    beacon.BeaconNetwork
+	// Archive is set when the exporter archives the decided messages and signatures of duties.
+	Archive *ibftstorage.Archive
 }
 
 type ParticipantResponse struct {
@@ -28,6 +37,8 @@ type ParticipantResponse struct {
 		// We're keeping "Signers" capitalized to avoid breaking existing clients that rely on the current structure
 		Signers []uint64 `json:"Signers"`
 	} `json:"message"`
+	// Synced is set when the participants were synced from peers rather than observed by this node.
+	Synced bool `json:"synced,omitempty"`
 }
 
 func (e *Exporter) Decideds(w http.ResponseWriter, r *http.Request) error {
@@ -79,7 +90,7 @@ func (e *Exporter) Decideds(w http.ResponseWriter, r *http.Request) error {
 			from := phase0.Slot(request.From)
 			to := phase0.Slot(request.To)
 
-			participantsList, err := qbftStore.GetParticipantsInRange(msgID, from, to)
+			participantsList, err := ibftstorage.ParticipantsInRange(qbftStore, e.SyncedQBFTStores.Get(runnerRole), msgID, from, to)
 			if err != nil {
 				return api.Error(fmt.Errorf("error getting participants: %w", err))
 			}
@@ -112,8 +123,69 @@ func transformToParticipantResponse(apiMsg *exporterapi.ParticipantsAPI) *Partic
 		Role:      apiMsg.Role,
 		Slot:      uint64(apiMsg.Slot),
 		PublicKey: apiMsg.ValidatorPK,
+		Synced:    apiMsg.Synced,
 	}
 	response.Message.Signers = apiMsg.Signers
 
 	return response
 }
+
+// Duties returns the archived duties of validators, including their decided messages and signatures.
+func (e *Exporter) Duties(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		From    uint64        `json:"from"`
+		To      uint64        `json:"to"`
+		Roles   api.RoleSlice `json:"roles"`
+		PubKeys api.HexSlice  `json:"pubkeys"`
+	}
+	var response struct {
+		Data []*exporterapi.DutyAPI `json:"data"`
+	}
+
+	if e.Archive == nil {
+		return api.BadRequestError(fmt.Errorf("archive is not enabled"))
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	if request.From > request.To {
+		return api.BadRequestError(fmt.Errorf("'from' must be less than or equal to 'to'"))
+	}
+
+	if len(request.PubKeys) == 0 {
+		return api.BadRequestError(fmt.Errorf("at least one public key is required"))
+	}
+
+	if len(request.Roles) == 0 {
+		return api.BadRequestError(fmt.Errorf("at least one role is required"))
+	}
+
+	response.Data = []*exporterapi.DutyAPI{}
+
+	for _, role := range request.Roles {
+		runnerRole := casts.BeaconRoleToConvertRole(spectypes.BeaconRole(role))
+
+		for _, pubKey := range request.PubKeys {
+			var validatorPK spectypes.ValidatorPK
+			if len(pubKey) != len(validatorPK) {
+				return api.BadRequestError(fmt.Errorf("invalid public key length: %d", len(pubKey)))
+			}
+			copy(validatorPK[:], pubKey)
+
+			duties, err := e.Archive.GetDutiesInRange(runnerRole, validatorPK, phase0.Slot(request.From), phase0.Slot(request.To))
+			if err != nil {
+				return api.Error(fmt.Errorf("error getting archived duties: %w", err))
+			}
+
+			data, err := exporterapi.DutiesAPIData(duties...)
+			if err != nil {
+				return api.Error(fmt.Errorf("error getting duties API data: %w", err))
+			}
+			response.Data = append(response.Data, data...)
+		}
+	}
+
+	return api.Render(w, r, response)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestExporterDecideds(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	network := networkconfig.TestNetwork
+	exporter := &Exporter{
+		DomainType: network.DomainType,
+		QBFTStores: ibftstorage.NewStoresFromRoles(db, convert.RoleAttester),
+		SyncedQBFTStores: func() *ibftstorage.QBFTStores {
+			stores := ibftstorage.NewStores()
+			stores.Add(convert.RoleAttester, ibftstorage.New(db, "synced_"+convert.RoleAttester.String()))
+			return stores
+		}(),
+		BeaconNetwork: network.Beacon,
+	}
+
+	pubKey := spectypes.ValidatorPK{1}
+	msgID := convert.NewMsgID(network.DomainType, pubKey[:], convert.RoleAttester)
+	save := func(stores *ibftstorage.QBFTStores, slot phase0.Slot, signers ...spectypes.OperatorID) {
+		_, err := stores.Get(convert.RoleAttester).UpdateParticipants(msgID, slot, signers)
+		require.NoError(t, err)
+	}
+	// Slot 10 was observed, slot 11 was backfilled from peers while the node was offline,
+	// and the observed participants of slot 12 take precedence over the synced ones.
+	save(exporter.QBFTStores, 10, 1, 2, 3)
+	save(exporter.SyncedQBFTStores, 11, 2, 3, 4)
+	save(exporter.QBFTStores, 12, 1, 2, 3, 4)
+	save(exporter.SyncedQBFTStores, 12, 1, 2, 3)
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/exporter/decideds?from=10&to=12&roles=ATTESTER&pubkeys="+hex.EncodeToString(pubKey[:]), nil)
+	w := httptest.NewRecorder()
+	api.Handler(exporter.Decideds)(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data []*ParticipantResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 3)
+
+	expected := []struct {
+		slot    uint64
+		signers []uint64
+		synced  bool
+	}{
+		{slot: 10, signers: []uint64{1, 2, 3}},
+		{slot: 11, signers: []uint64{2, 3, 4}, synced: true},
+		{slot: 12, signers: []uint64{1, 2, 3, 4}},
+	}
+	for i, e := range expected {
+		require.Equal(t, "ATTESTER", response.Data[i].Role)
+		require.Equal(t, e.slot, response.Data[i].Slot)
+		require.Equal(t, e.signers, response.Data[i].Message.Signers)
+		require.Equal(t, e.synced, response.Data[i].Synced)
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/operator/dutyjournal"
+)
+
+// maxJournalSlots is the widest slot range which can be queried from the duty journal at once, a day on mainnet.
+const maxJournalSlots = 7200
+
+// Journal serves the duty journal of this operator's validators.
+type Journal struct {
+	Journal *dutyjournal.Journal
+}
+
+// Duties returns the journaled duties of validators within a slot range,
+// with the timing of each phase, the operators who contributed signatures and the Beacon node response.
+func (h *Journal) Duties(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		From    uint64       `json:"from" form:"from"`
+		To      uint64       `json:"to" form:"to"`
+		PubKeys api.HexSlice `json:"pubkeys" form:"pubkeys"`
+	}
+	var response struct {
+		Data []*dutyjournal.Duty `json:"data"`
+	}
+
+	if h.Journal == nil {
+		return api.BadRequestError(fmt.Errorf("duty journal is not enabled"))
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	if request.From > request.To {
+		return api.BadRequestError(fmt.Errorf("'from' must be less than or equal to 'to'"))
+	}
+
+	if request.To-request.From >= maxJournalSlots {
+		return api.BadRequestError(fmt.Errorf("slot range must not exceed %d slots", maxJournalSlots))
+	}
+
+	if len(request.PubKeys) == 0 {
+		return api.BadRequestError(fmt.Errorf("at least one public key is required"))
+	}
+
+	response.Data = []*dutyjournal.Duty{}
+
+	for _, pubKey := range request.PubKeys {
+		var validatorPK phase0.BLSPubKey
+		if len(pubKey) != len(validatorPK) {
+			return api.BadRequestError(fmt.Errorf("invalid public key length: %d", len(pubKey)))
+		}
+		copy(validatorPK[:], pubKey)
+
+		duties, err := h.Journal.Duties(validatorPK, phase0.Slot(request.From), phase0.Slot(request.To))
+		if err != nil {
+			return api.Error(fmt.Errorf("error getting journaled duties: %w", err))
+		}
+		response.Data = append(response.Data, duties...)
+	}
+
+	return api.Render(w, r, response)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/observability/events"
+	"github.com/ssvlabs/ssv/operator/dutyjournal"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestJournal(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	journal := dutyjournal.New(logger, db)
+	pubKey := phase0.BLSPubKey{1}
+	for slot := phase0.Slot(10); slot <= 12; slot++ {
+		require.NoError(t, journal.Record(events.Event{Type: events.DutyScheduled, Role: "PROPOSER", Slot: slot, Validators: []phase0.BLSPubKey{pubKey}}))
+		require.NoError(t, journal.Record(events.Event{Type: events.BeaconSubmission, Role: "PROPOSER", Slot: slot, Validators: []phase0.BLSPubKey{pubKey}}))
+	}
+
+	router := chi.NewRouter()
+	h := &Journal{Journal: journal}
+	router.Get("/v1/journal/duties", api.Handler(h.Duties))
+	get := func(query string) (int, []*dutyjournal.Duty) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/journal/duties?"+query, nil))
+		var response struct {
+			Data []*dutyjournal.Duty `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	code, duties := get(fmt.Sprintf("pubkeys=%s&from=11&to=20", hex.EncodeToString(pubKey[:])))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, duties, 2)
+	require.Equal(t, phase0.Slot(11), duties[0].Slot)
+	require.Equal(t, pubKey, duties[0].PubKey)
+	require.NotNil(t, duties[0].ScheduledAt)
+	require.NotNil(t, duties[0].Submission)
+
+	code, _ = get(fmt.Sprintf("pubkeys=%s&from=20&to=11", hex.EncodeToString(pubKey[:])))
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = get(fmt.Sprintf("pubkeys=%s&from=0&to=%d", hex.EncodeToString(pubKey[:]), maxJournalSlots))
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("pubkeys=0102&from=0&to=20")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = get("from=0&to=20")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	h.Journal = nil
+	code, _ = get(fmt.Sprintf("pubkeys=%s&from=11&to=20", hex.EncodeToString(pubKey[:])))
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -12,18 +12,28 @@ import (
 
 	"github.com/ssvlabs/ssv/api"
 	networkpeers "github.com/ssvlabs/ssv/network/peers"
+	"github.com/ssvlabs/ssv/network/topics"
 	"github.com/ssvlabs/ssv/nodeprobe"
+	"github.com/ssvlabs/ssv/operator/doppelganger"
 )
 
 const (
 	healthyPeerCount = 20
 	healthyInbounds  = 4
+
+	// defaultTrafficLimit is the number of top talkers returned when no limit is requested.
+	defaultTrafficLimit = 20
 )
 
 type TopicIndex interface {
 	PeersByTopic() ([]peer.ID, map[string][]peer.ID)
 }
 
+// TrafficIndex accounts the messages and bytes on topics, per topic, per peer and per committee.
+type TrafficIndex interface {
+	Traffic(by topics.TrafficKind, limit int) ([]*topics.Talker, error)
+}
+
 type AllPeersAndTopicsJSON struct {
 	AllPeers     []peer.ID        `json:"all_peers"`
 	PeersByTopic []topicIndexJSON `json:"peers_by_topic"`
@@ -67,10 +77,11 @@ func (h healthStatus) MarshalJSON() ([]byte, error) {
 }
 
 type healthCheckJSON struct {
-	P2P           healthStatus `json:"p2p"`
-	BeaconNode    healthStatus `json:"beacon_node"`
-	ExecutionNode healthStatus `json:"execution_node"`
-	EventSyncer   healthStatus `json:"event_syncer"`
+	P2P           healthStatus         `json:"p2p"`
+	BeaconNode    healthStatus         `json:"beacon_node"`
+	ExecutionNode healthStatus         `json:"execution_node"`
+	EventSyncer   healthStatus         `json:"event_syncer"`
+	Doppelganger  *doppelganger.Status `json:"doppelganger,omitempty"`
 	Advanced      struct {
 		Peers           int      `json:"peers"`
 		InboundConns    int      `json:"inbound_conns"`
@@ -91,8 +102,10 @@ type Node struct {
 	ListenAddresses []string
 	PeersIndex      networkpeers.Index
 	TopicIndex      TopicIndex
+	TrafficIndex    TrafficIndex
 	Network         network.Network
 	NodeProber      *nodeprobe.Prober
+	Doppelganger    doppelganger.Handler
 }
 
 func (h *Node) Identity(w http.ResponseWriter, r *http.Request) error {
@@ -127,6 +140,46 @@ func (h *Node) Topics(w http.ResponseWriter, r *http.Request) error {
 	return api.Render(w, r, resp)
 }
 
+// Traffic returns the topics, peers or committees with the most inbound messages,
+// with their message rates and, for topics, the rates which their score params expect.
+func (h *Node) Traffic(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		By    string `json:"by" form:"by"`
+		Limit int    `json:"limit" form:"limit"`
+	}
+	var response struct {
+		Data []*topics.Talker `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	by := topics.TrafficKind(request.By)
+	switch by {
+	case "":
+		by = topics.TrafficByPeer
+	case topics.TrafficByTopic, topics.TrafficByPeer, topics.TrafficByCommittee:
+	default:
+		return api.BadRequestError(fmt.Errorf("'by' must be one of %q, %q or %q",
+			topics.TrafficByTopic, topics.TrafficByPeer, topics.TrafficByCommittee))
+	}
+	if request.Limit < 0 {
+		return api.BadRequestError(fmt.Errorf("'limit' must not be negative"))
+	}
+	if request.Limit == 0 {
+		request.Limit = defaultTrafficLimit
+	}
+
+	talkers, err := h.TrafficIndex.Traffic(by, request.Limit)
+	if err != nil {
+		return api.Error(fmt.Errorf("error getting traffic: %w", err))
+	}
+	response.Data = talkers
+
+	return api.Render(w, r, response)
+}
+
 func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	ctx := context.Background()
 	var resp healthCheckJSON
@@ -163,6 +216,12 @@ func (h *Node) Health(w http.ResponseWriter, r *http.Request) error {
 	resp.ExecutionNode = healthStatus{h.NodeProber.CheckExecutionNodeHealth(ctx)}
 	resp.EventSyncer = healthStatus{h.NodeProber.CheckEventSyncerHealth(ctx)}
 
+	// Report whether signing is held back by doppelganger protection.
+	if h.Doppelganger != nil {
+		status := h.Doppelganger.Status()
+		resp.Doppelganger = &status
+	}
+
 	return api.Render(w, r, resp)
 }
 
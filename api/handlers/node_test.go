@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/network/topics"
+)
+
+type mockTrafficIndex map[topics.TrafficKind][]*topics.Talker
+
+func (m mockTrafficIndex) Traffic(by topics.TrafficKind, limit int) ([]*topics.Talker, error) {
+	talkers := m[by]
+	if len(talkers) > limit {
+		talkers = talkers[:limit]
+	}
+	return talkers, nil
+}
+
+func TestNodeTraffic(t *testing.T) {
+	h := &Node{TrafficIndex: mockTrafficIndex{
+		topics.TrafficByPeer: {
+			{ID: "peer1", TrafficStats: topics.TrafficStats{MessagesIn: 20, BytesIn: 2000}},
+			{ID: "peer2", TrafficStats: topics.TrafficStats{MessagesIn: 10, BytesIn: 1000}},
+		},
+		topics.TrafficByTopic: {
+			{ID: "ssv.v2.1", MessageRate: 2, ExpectedMessageRate: 0.5},
+		},
+	}}
+	request := func(query string) (int, []*topics.Talker) {
+		w := httptest.NewRecorder()
+		api.Handler(h.Traffic)(w, httptest.NewRequest(http.MethodGet, "/v1/node/traffic"+query, nil))
+		var response struct {
+			Data []*topics.Talker `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response.Data
+	}
+
+	code, talkers := request("")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, talkers, 2)
+	require.Equal(t, "peer1", talkers[0].ID)
+	require.EqualValues(t, 2000, talkers[0].BytesIn)
+
+	code, talkers = request("?by=peer&limit=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, talkers, 1)
+
+	code, talkers = request("?by=topic")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, talkers, 1)
+	require.Equal(t, 0.5, talkers[0].ExpectedMessageRate)
+
+	code, _ = request("?by=operator")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("?limit=-1")
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+type Operators struct {
+	Operators registrystorage.Operators
+	Shares    registrystorage.Shares
+}
+
+// OperatorJSON is the representation of an operator in the API.
+type OperatorJSON struct {
+	ID spectypes.OperatorID `json:"id"`
+	// PublicKey is the base64 encoded RSA public key of the operator, as registered in the contract.
+	PublicKey string  `json:"public_key"`
+	Owner     api.Hex `json:"owner"`
+	// ValidatorCount is the number of validators whose committee includes the operator.
+	ValidatorCount int `json:"validator_count"`
+}
+
+// List returns the operators, optionally only those with the given IDs, owners or public key.
+// Public keys are base64 encoded, so in the query they must be URL-encoded, or else a "+" would be read as a space.
+// Alternatively, they can be sent in a JSON body.
+func (h *Operators) List(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		IDs    api.Uint64Slice `json:"ids" form:"ids"`
+		Owners api.HexSlice    `json:"owners" form:"owners"`
+		// PublicKey is the base64 encoded public key, URL-encoded in the query.
+		PublicKey string `json:"public_key" form:"public_key"`
+	}
+	var response struct {
+		Data []*OperatorJSON `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	var operators []registrystorage.OperatorData
+	if request.PublicKey != "" {
+		od, found, err := h.Operators.GetOperatorDataByPubKey(nil, []byte(request.PublicKey))
+		if err != nil {
+			return api.Error(fmt.Errorf("could not get operator: %w", err))
+		}
+		if found {
+			operators = append(operators, *od)
+		}
+	} else {
+		var err error
+		operators, err = h.Operators.ListOperators(nil, 0, 0)
+		if err != nil {
+			return api.Error(fmt.Errorf("could not list operators: %w", err))
+		}
+	}
+	sort.Slice(operators, func(i, j int) bool { return operators[i].ID < operators[j].ID })
+
+	validatorCounts := h.validatorCounts()
+	response.Data = []*OperatorJSON{}
+	for _, od := range operators {
+		if len(request.IDs) > 0 && !slices.Contains(request.IDs, od.ID) {
+			continue
+		}
+		if len(request.Owners) > 0 && !slices.ContainsFunc(request.Owners, func(owner api.Hex) bool {
+			return bytes.Equal(owner, od.OwnerAddress[:])
+		}) {
+			continue
+		}
+		response.Data = append(response.Data, operatorFromData(&od, validatorCounts[od.ID]))
+	}
+	return api.Render(w, r, response)
+}
+
+// Get returns the operator with the ID in the path.
+func (h *Operators) Get(w http.ResponseWriter, r *http.Request) error {
+	var response struct {
+		Data *OperatorJSON `json:"data"`
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		return api.BadRequestError(fmt.Errorf("invalid operator ID: %w", err))
+	}
+
+	od, found, err := h.Operators.GetOperatorData(nil, id)
+	if err != nil {
+		return api.Error(fmt.Errorf("could not get operator: %w", err))
+	}
+	if !found {
+		return api.ErrNotFound
+	}
+
+	response.Data = operatorFromData(od, h.validatorCounts()[id])
+	return api.Render(w, r, response)
+}
+
+// validatorCounts returns the number of validators of every operator.
+func (h *Operators) validatorCounts() map[spectypes.OperatorID]int {
+	counts := make(map[spectypes.OperatorID]int)
+	for _, share := range h.Shares.List(nil) {
+		for _, member := range share.Committee {
+			counts[member.Signer]++
+		}
+	}
+	return counts
+}
+
+func operatorFromData(od *registrystorage.OperatorData, validatorCount int) *OperatorJSON {
+	return &OperatorJSON{
+		ID:             od.ID,
+		PublicKey:      string(od.PublicKey),
+		Owner:          api.Hex(od.OwnerAddress[:]),
+		ValidatorCount: validatorCount,
+	}
+}
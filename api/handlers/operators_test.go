@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestOperators(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	operators := registrystorage.NewOperatorsStorage(logger, db, []byte("test"))
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+
+	for id := spectypes.OperatorID(1); id <= 5; id++ {
+		_, err := operators.SaveOperatorData(nil, &registrystorage.OperatorData{
+			ID:           id,
+			PublicKey:    []byte("pk+" + string(rune('0'+id))),
+			OwnerAddress: common.Address{byte(id % 2)},
+		})
+		require.NoError(t, err)
+	}
+	for i, committee := range [][]uint64{{1, 2, 3, 4}, {1, 2, 3, 5}} {
+		share := mockShare(committee...)
+		share.ValidatorPubKey = spectypes.ValidatorPK{byte(i)}
+		require.NoError(t, shares.Save(nil, share))
+	}
+
+	router := chi.NewRouter()
+	h := &Operators{Operators: operators, Shares: shares}
+	router.Get("/v1/operators", api.Handler(h.List))
+	router.Get("/v1/operators/{id}", api.Handler(h.Get))
+	get := func(path string, data any, body ...string) int {
+		r := httptest.NewRequest(http.MethodGet, path, strings.NewReader(strings.Join(body, "")))
+		if len(body) > 0 {
+			r.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Code == http.StatusOK {
+			response := struct{ Data any }{Data: data}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code
+	}
+
+	var list []*OperatorJSON
+	require.Equal(t, http.StatusOK, get("/v1/operators", &list))
+	require.Len(t, list, 5)
+	require.Equal(t, spectypes.OperatorID(1), list[0].ID)
+	require.Equal(t, 2, list[0].ValidatorCount)
+	require.Equal(t, 1, list[3].ValidatorCount)
+
+	require.Equal(t, http.StatusOK, get("/v1/operators?ids=2,3,4&owners=0x"+common.Bytes2Hex(common.Address{1}.Bytes()), &list))
+	require.Len(t, list, 1)
+	require.Equal(t, spectypes.OperatorID(3), list[0].ID)
+
+	require.Equal(t, http.StatusOK, get("/v1/operators?public_key="+url.QueryEscape("pk+4"), &list))
+	require.Len(t, list, 1)
+	require.Equal(t, spectypes.OperatorID(4), list[0].ID)
+	require.Equal(t, "pk+4", list[0].PublicKey)
+
+	// Unless it's URL-encoded, the "+" of a public key in the query is read as a space.
+	require.Equal(t, http.StatusOK, get("/v1/operators?public_key=pk+5", &list))
+	require.Empty(t, list)
+	require.Equal(t, http.StatusOK, get("/v1/operators", &list, `{"public_key":"pk+5"}`))
+	require.Len(t, list, 1)
+	require.Equal(t, spectypes.OperatorID(5), list[0].ID)
+
+	var operator *OperatorJSON
+	require.Equal(t, http.StatusOK, get("/v1/operators/5", &operator))
+	require.Equal(t, spectypes.OperatorID(5), operator.ID)
+	require.Equal(t, 1, operator.ValidatorCount)
+	require.Equal(t, http.StatusNotFound, get("/v1/operators/6", &operator))
+	require.Equal(t, http.StatusBadRequest, get("/v1/operators/first", &operator))
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/utils/casts"
+)
+
+// maxPerformanceEpochs bounds the epoch range of a performance request,
+// since the participants of every slot in the range are read for every validator.
+const maxPerformanceEpochs = 225
+
+// maxPerformanceSlotReads bounds the number of slots read for a performance request, which is
+// the number of validators times the number of roles times the number of slots in the epoch range.
+// Requests for many validators must be filtered down or cover fewer epochs.
+const maxPerformanceSlotReads = 1_000_000
+
+type PerformanceResponse struct {
+	From       phase0.Epoch                    `json:"from"`
+	To         phase0.Epoch                    `json:"to"`
+	Validators []*ValidatorPerformanceResponse `json:"validators"`
+	Operators  []*OperatorPerformanceResponse  `json:"operators"`
+}
+
+type ValidatorPerformanceResponse struct {
+	PubKey    api.Hex                `json:"public_key"`
+	Index     phase0.ValidatorIndex  `json:"index"`
+	Committee []spectypes.OperatorID `json:"committee"`
+	// Decided is the number of decided duties of each role.
+	Decided map[string]uint64 `json:"decided"`
+	// Synced is how many of the decided duties were synced from peers rather than observed by this node.
+	Synced uint64 `json:"synced,omitempty"`
+	// Attestations is present when attester duties are requested, since only these are due every epoch.
+	Attestations *AttestationsPerformanceResponse `json:"attestations,omitempty"`
+	// Participation is the number of decided duties each committee member signed.
+	Participation map[spectypes.OperatorID]uint64 `json:"participation"`
+}
+
+type AttestationsPerformanceResponse struct {
+	Expected     uint64         `json:"expected"`
+	Decided      uint64         `json:"decided"`
+	Missed       uint64         `json:"missed"`
+	MissedEpochs []phase0.Epoch `json:"missed_epochs"`
+}
+
+type OperatorPerformanceResponse struct {
+	ID         spectypes.OperatorID `json:"id"`
+	Validators uint64               `json:"validators"`
+	// Decided is the number of decided duties of the operator's validators.
+	Decided uint64 `json:"decided"`
+	// Participated is the number of decided duties the operator signed.
+	Participated      uint64  `json:"participated"`
+	ParticipationRate float64 `json:"participation_rate"`
+	// MissedAttestations is the number of attester duties of the operator's validators which weren't decided.
+	MissedAttestations uint64 `json:"missed_attestations"`
+}
+
+// Performance reports the duty performance of validators and their operators over an epoch range,
+// from the participants of decided duties joined with the validator shares.
+func (e *Exporter) Performance(w http.ResponseWriter, r *http.Request) error {
+	var request struct {
+		ValidatorsRequest
+		From  uint64        `json:"from" form:"from"`
+		To    uint64        `json:"to" form:"to"`
+		Roles api.RoleSlice `json:"roles" form:"roles"`
+	}
+	var response struct {
+		Data *PerformanceResponse `json:"data"`
+	}
+
+	if err := api.Bind(r, &request); err != nil {
+		return api.BadRequestError(err)
+	}
+
+	if request.From > request.To {
+		return api.BadRequestError(fmt.Errorf("'from' must be less than or equal to 'to'"))
+	}
+
+	if request.To-request.From >= maxPerformanceEpochs {
+		return api.BadRequestError(fmt.Errorf("at most %d epochs can be requested", maxPerformanceEpochs))
+	}
+
+	if len(request.Roles) == 0 {
+		request.Roles = api.RoleSlice{api.Role(spectypes.BNRoleAttester)}
+	}
+
+	roles := make([]convert.RunnerRole, 0, len(request.Roles))
+	for _, role := range request.Roles {
+		runnerRole := casts.BeaconRoleToConvertRole(spectypes.BeaconRole(role))
+		if e.QBFTStores.Get(runnerRole) == nil {
+			return api.Error(fmt.Errorf("role storage doesn't exist: %v", role))
+		}
+		if !slices.Contains(roles, runnerRole) {
+			roles = append(roles, runnerRole)
+		}
+	}
+
+	shares := e.Shares.List(nil, request.Filters()...)
+
+	slots := (request.To - request.From + 1) * e.BeaconNetwork.SlotsPerEpoch()
+	if reads := uint64(len(shares)) * uint64(len(roles)) * slots; reads > maxPerformanceSlotReads {
+		return api.BadRequestError(fmt.Errorf(
+			"request covers %d validators, %d roles and %d slots, exceeding the limit of %d slot reads; filter the validators or request fewer epochs",
+			len(shares), len(roles), slots, maxPerformanceSlotReads,
+		))
+	}
+
+	performance, err := e.performance(shares, roles, phase0.Epoch(request.From), phase0.Epoch(request.To))
+	if err != nil {
+		return api.Error(fmt.Errorf("error computing performance: %w", err))
+	}
+	response.Data = performance
+
+	return api.Render(w, r, response)
+}
+
+func (e *Exporter) performance(shares []*types.SSVShare, roles []convert.RunnerRole, from, to phase0.Epoch) (*PerformanceResponse, error) {
+	fromSlot := e.BeaconNetwork.FirstSlotAtEpoch(from)
+	toSlot := e.BeaconNetwork.FirstSlotAtEpoch(to+1) - 1
+	currentEpoch := e.BeaconNetwork.EstimatedCurrentEpoch()
+
+	sort.Slice(shares, func(i, j int) bool {
+		return bytes.Compare(shares[i].ValidatorPubKey[:], shares[j].ValidatorPubKey[:]) < 0
+	})
+
+	response := &PerformanceResponse{
+		From:       from,
+		To:         to,
+		Validators: make([]*ValidatorPerformanceResponse, 0, len(shares)),
+		Operators:  make([]*OperatorPerformanceResponse, 0),
+	}
+	operators := make(map[spectypes.OperatorID]*OperatorPerformanceResponse)
+
+	for _, share := range shares {
+		v := &ValidatorPerformanceResponse{
+			PubKey:        api.Hex(share.ValidatorPubKey[:]),
+			Decided:       make(map[string]uint64, len(roles)),
+			Participation: make(map[spectypes.OperatorID]uint64, len(share.Committee)),
+		}
+		if share.HasBeaconMetadata() {
+			v.Index = share.BeaconMetadata.Index
+		}
+		for _, member := range share.Committee {
+			v.Committee = append(v.Committee, member.Signer)
+			v.Participation[member.Signer] = 0
+
+			op, ok := operators[member.Signer]
+			if !ok {
+				op = &OperatorPerformanceResponse{ID: member.Signer}
+				operators[member.Signer] = op
+			}
+			op.Validators++
+		}
+
+		for _, role := range roles {
+			msgID := convert.NewMsgID(e.DomainType, share.ValidatorPubKey[:], role)
+			entries, err := ibftstorage.ParticipantsInRange(e.QBFTStores.Get(role), e.SyncedQBFTStores.Get(role), msgID, fromSlot, toSlot)
+			if err != nil {
+				return nil, fmt.Errorf("error getting participants: %w", err)
+			}
+
+			v.Decided[role.ToBeaconRole()] = uint64(len(entries))
+			for _, entry := range entries {
+				if entry.Synced {
+					v.Synced++
+				}
+				for _, signer := range entry.Signers {
+					if _, ok := v.Participation[signer]; ok {
+						v.Participation[signer]++
+					}
+				}
+			}
+
+			if role == convert.RoleAttester {
+				v.Attestations = attestationsPerformance(share, entries, from, to, currentEpoch, e.BeaconNetwork)
+			}
+		}
+
+		for _, id := range v.Committee {
+			op := operators[id]
+			for _, decided := range v.Decided {
+				op.Decided += decided
+			}
+			op.Participated += v.Participation[id]
+			if v.Attestations != nil {
+				op.MissedAttestations += v.Attestations.Missed
+			}
+		}
+
+		response.Validators = append(response.Validators, v)
+	}
+
+	for _, op := range operators {
+		if op.Decided > 0 {
+			op.ParticipationRate = float64(op.Participated) / float64(op.Decided)
+		}
+		response.Operators = append(response.Operators, op)
+	}
+	sort.Slice(response.Operators, func(i, j int) bool {
+		return response.Operators[i].ID < response.Operators[j].ID
+	})
+
+	return response, nil
+}
+
+// attestationsPerformance compares the decided attester duties of a validator with the epochs it was due to attest in.
+// The due epochs are estimated from the validator's current status, which may have changed since.
+// Attestations of the current epoch and onwards aren't due yet.
+func attestationsPerformance(
+	share *types.SSVShare,
+	entries []qbftstorage.ParticipantsRangeEntry,
+	from, to, currentEpoch phase0.Epoch,
+	beaconNetwork beacon.BeaconNetwork,
+) *AttestationsPerformanceResponse {
+	decidedEpochs := make(map[phase0.Epoch]struct{}, len(entries))
+	for _, entry := range entries {
+		decidedEpochs[beaconNetwork.EstimatedEpochAtSlot(entry.Slot)] = struct{}{}
+	}
+
+	perf := &AttestationsPerformanceResponse{
+		Decided:      uint64(len(decidedEpochs)),
+		MissedEpochs: make([]phase0.Epoch, 0),
+	}
+	for epoch := from; epoch <= to && epoch < currentEpoch; epoch++ {
+		if share.Liquidated || !share.IsAttesting(epoch) || share.BeaconMetadata.ActivationEpoch > epoch {
+			continue
+		}
+		perf.Expected++
+		if _, ok := decidedEpochs[epoch]; !ok {
+			perf.Missed++
+			perf.MissedEpochs = append(perf.MissedEpochs, epoch)
+		}
+	}
+	return perf
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestExporterPerformance(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+
+	network := networkconfig.TestNetwork
+	exporter := &Exporter{
+		DomainType:    network.DomainType,
+		QBFTStores:    ibftstorage.NewStoresFromRoles(db, convert.RoleAttester, convert.RoleProposer),
+		Shares:        shares,
+		BeaconNetwork: network.Beacon,
+	}
+
+	newShare := func(pubKey byte, committee ...spectypes.OperatorID) *types.SSVShare {
+		share := mockShare(committee...)
+		share.ValidatorPubKey = spectypes.ValidatorPK{pubKey}
+		share.BeaconMetadata = &beaconprotocol.ValidatorMetadata{
+			Index:  phase0.ValidatorIndex(pubKey),
+			Status: eth2apiv1.ValidatorStateActiveOngoing,
+		}
+		return share
+	}
+	shareA := newShare(1, 1, 2, 3, 4)
+	shareB := newShare(2, 2, 3, 4, 5)
+	require.NoError(t, shares.Save(nil, shareA, shareB))
+
+	// A decides its attestations in epochs 10 and 12 but misses epoch 11, where B decides all of them.
+	decide := func(share *types.SSVShare, role convert.RunnerRole, epoch phase0.Epoch, signers ...spectypes.OperatorID) {
+		msgID := convert.NewMsgID(network.DomainType, share.ValidatorPubKey[:], role)
+		slot := network.Beacon.FirstSlotAtEpoch(epoch) + 3
+		_, err := exporter.QBFTStores.Get(role).UpdateParticipants(msgID, slot, signers)
+		require.NoError(t, err)
+	}
+	decide(shareA, convert.RoleAttester, 10, 1, 2, 3)
+	decide(shareA, convert.RoleAttester, 12, 1, 2, 3)
+	decide(shareA, convert.RoleAttester, 13, 1, 2, 3, 4)
+	decide(shareA, convert.RoleProposer, 12, 1, 2, 4)
+	for epoch := phase0.Epoch(10); epoch <= 12; epoch++ {
+		decide(shareB, convert.RoleAttester, epoch, 2, 3, 4)
+	}
+
+	request := func(query string) (*PerformanceResponse, int) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/exporter/performance?"+query, nil)
+		w := httptest.NewRecorder()
+		api.Handler(exporter.Performance)(w, r)
+
+		var response struct {
+			Data *PerformanceResponse `json:"data"`
+		}
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return response.Data, w.Code
+	}
+
+	t.Run("attestations", func(t *testing.T) {
+		perf, code := request("from=10&to=12")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, perf.Validators, 2)
+
+		a := perf.Validators[0]
+		require.Equal(t, api.Hex(shareA.ValidatorPubKey[:]), a.PubKey)
+		require.Equal(t, map[string]uint64{"ATTESTER": 2}, a.Decided)
+		require.Equal(t, &AttestationsPerformanceResponse{
+			Expected:     3,
+			Decided:      2,
+			Missed:       1,
+			MissedEpochs: []phase0.Epoch{11},
+		}, a.Attestations)
+		require.Equal(t, map[spectypes.OperatorID]uint64{1: 2, 2: 2, 3: 2, 4: 0}, a.Participation)
+
+		b := perf.Validators[1]
+		require.Equal(t, uint64(0), b.Attestations.Missed)
+		require.Equal(t, map[spectypes.OperatorID]uint64{2: 3, 3: 3, 4: 3, 5: 0}, b.Participation)
+
+		require.Equal(t, []*OperatorPerformanceResponse{
+			{ID: 1, Validators: 1, Decided: 2, Participated: 2, ParticipationRate: 1, MissedAttestations: 1},
+			{ID: 2, Validators: 2, Decided: 5, Participated: 5, ParticipationRate: 1, MissedAttestations: 1},
+			{ID: 3, Validators: 2, Decided: 5, Participated: 5, ParticipationRate: 1, MissedAttestations: 1},
+			{ID: 4, Validators: 2, Decided: 5, Participated: 3, ParticipationRate: 0.6, MissedAttestations: 1},
+			{ID: 5, Validators: 1, Decided: 3, Participated: 0, ParticipationRate: 0, MissedAttestations: 0},
+		}, perf.Operators)
+	})
+
+	t.Run("roles and filters", func(t *testing.T) {
+		perf, code := request("from=12&to=12&roles=ATTESTER,PROPOSER&pubkeys=" + hex.EncodeToString(shareA.ValidatorPubKey[:]))
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, perf.Validators, 1)
+		require.Equal(t, map[string]uint64{"ATTESTER": 1, "PROPOSER": 1}, perf.Validators[0].Decided)
+		require.Equal(t, map[spectypes.OperatorID]uint64{1: 2, 2: 2, 3: 1, 4: 1}, perf.Validators[0].Participation)
+
+		perf, code = request("from=10&to=12&roles=PROPOSER&operators=5")
+		require.Equal(t, http.StatusOK, code)
+		require.Len(t, perf.Validators, 1)
+		require.Nil(t, perf.Validators[0].Attestations)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, code := request("from=12&to=10")
+		require.Equal(t, http.StatusBadRequest, code)
+
+		_, code = request("from=0&to=1000")
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
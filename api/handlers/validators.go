@@ -2,11 +2,19 @@ package handlers
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
 	"github.com/ssvlabs/ssv/api"
@@ -14,55 +22,270 @@ import (
 	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 )
 
+// maxValidatorsLimit is the largest page of validators which can be requested.
+const maxValidatorsLimit = 1000
+
 type Validators struct {
 	Shares registrystorage.Shares
+	// Recipients provides the fee recipients of validators, when requested.
+	Recipients registrystorage.Recipients
+}
+
+// ValidatorsRequest filters validators, matching those which match any of the values of every given filter.
+type ValidatorsRequest struct {
+	Owners      api.HexSlice    `json:"owners" form:"owners"`
+	Operators   api.Uint64Slice `json:"operators" form:"operators"`
+	Clusters    requestClusters `json:"clusters" form:"clusters"`
+	Subclusters requestClusters `json:"subclusters" form:"subclusters"`
+	PubKeys     api.HexSlice    `json:"pubkeys" form:"pubkeys"`
+	Indices     api.Uint64Slice `json:"indices" form:"indices"`
+	// Statuses are beacon statuses, such as active_ongoing. Validators without beacon metadata have the unknown status.
+	Statuses   requestStatuses    `json:"statuses" form:"statuses"`
+	Liquidated api.Optional[bool] `json:"liquidated" form:"liquidated"`
+	// ActiveEpoch matches validators which are attesting at the given epoch.
+	ActiveEpoch api.Optional[uint64] `json:"active_epoch" form:"active_epoch"`
+}
+
+// Filters returns the shares filters of the request.
+func (r *ValidatorsRequest) Filters() []registrystorage.SharesFilter {
+	var filters []registrystorage.SharesFilter
+	if len(r.Owners) > 0 {
+		filters = append(filters, byOwners(r.Owners))
+	}
+	if len(r.Operators) > 0 {
+		filters = append(filters, byOperators(r.Operators))
+	}
+	if len(r.Clusters) > 0 {
+		filters = append(filters, byClusters(r.Clusters, false))
+	}
+	if len(r.Subclusters) > 0 {
+		filters = append(filters, byClusters(r.Subclusters, true))
+	}
+	if len(r.PubKeys) > 0 {
+		filters = append(filters, byPubKeys(r.PubKeys))
+	}
+	if len(r.Indices) > 0 {
+		filters = append(filters, byIndices(r.Indices))
+	}
+	if len(r.Statuses) > 0 {
+		filters = append(filters, byStatuses(r.Statuses))
+	}
+	if r.Liquidated.Set {
+		filters = append(filters, byLiquidated(r.Liquidated.Value))
+	}
+	if r.ActiveEpoch.Set {
+		filters = append(filters, byActiveEpoch(phase0.Epoch(r.ActiveEpoch.Value)))
+	}
+	return filters
+}
+
+// ValidatorsPage selects a page of the validators sorted by the given field.
+// Without a limit, all the validators are returned.
+type ValidatorsPage struct {
+	// Cursor is the next_cursor of the previous page.
+	Cursor string `json:"cursor" form:"cursor"`
+	Limit  uint64 `json:"limit" form:"limit"`
+	// Sort is one of public_key (the default), index, activation_epoch or balance.
+	Sort  string `json:"sort" form:"sort"`
+	Order string `json:"order" form:"order"`
+	// Include lists optional fields to add to the validators: fee_recipient and balance.
+	Include requestIncludes `json:"include" form:"include"`
 }
 
 func (h *Validators) List(w http.ResponseWriter, r *http.Request) error {
 	var request struct {
-		Owners      api.HexSlice    `json:"owners" form:"owners"`
-		Operators   api.Uint64Slice `json:"operators" form:"operators"`
-		Clusters    requestClusters `json:"clusters" form:"clusters"`
-		Subclusters requestClusters `json:"subclusters" form:"subclusters"`
-		PubKeys     api.HexSlice    `json:"pubkeys" form:"pubkeys"`
-		Indices     api.Uint64Slice `json:"indices" form:"indices"`
+		ValidatorsRequest
+		ValidatorsPage
 	}
 	var response struct {
-		Data []*validatorJSON `json:"data"`
+		Data []*ValidatorJSON `json:"data"`
+		// NextCursor is set when there are more validators after this page.
+		NextCursor string `json:"next_cursor,omitempty"`
 	}
 
 	if err := api.Bind(r, &request); err != nil {
-		return err
+		return api.BadRequestError(err)
 	}
 
-	var filters []registrystorage.SharesFilter
-	if len(request.Owners) > 0 {
-		filters = append(filters, byOwners(request.Owners))
-	}
-	if len(request.Operators) > 0 {
-		filters = append(filters, byOperators(request.Operators))
+	order, err := newValidatorsOrder(request.Sort, request.Order)
+	if err != nil {
+		return api.BadRequestError(err)
 	}
-	if len(request.Clusters) > 0 {
-		filters = append(filters, byClusters(request.Clusters, false))
+	if request.Limit > maxValidatorsLimit {
+		return api.BadRequestError(fmt.Errorf("limit must be at most %d", maxValidatorsLimit))
 	}
-	if len(request.Subclusters) > 0 {
-		filters = append(filters, byClusters(request.Subclusters, true))
+	if slices.Contains(request.Include, includeFeeRecipient) && h.Recipients == nil {
+		return api.BadRequestError(fmt.Errorf("fee recipients are not available"))
 	}
-	if len(request.PubKeys) > 0 {
-		filters = append(filters, byPubKeys(request.PubKeys))
+
+	shares := h.Shares.List(nil, request.Filters()...)
+	sort.Slice(shares, func(i, j int) bool {
+		return order.less(order.position(shares[i]), order.position(shares[j]))
+	})
+
+	if request.Cursor != "" {
+		cursor, err := order.decodeCursor(request.Cursor)
+		if err != nil {
+			return api.BadRequestError(fmt.Errorf("invalid cursor: %w", err))
+		}
+		start := sort.Search(len(shares), func(i int) bool {
+			return order.less(cursor, order.position(shares[i]))
+		})
+		shares = shares[start:]
 	}
-	if len(request.Indices) > 0 {
-		filters = append(filters, byIndices(request.Indices))
+	if request.Limit > 0 && uint64(len(shares)) > request.Limit {
+		shares = shares[:request.Limit]
+		response.NextCursor = order.encodeCursor(order.position(shares[len(shares)-1]))
 	}
 
-	shares := h.Shares.List(nil, filters...)
-	response.Data = make([]*validatorJSON, len(shares))
+	response.Data = make([]*ValidatorJSON, len(shares))
 	for i, share := range shares {
-		response.Data[i] = validatorFromShare(share)
+		response.Data[i] = ValidatorFromShare(share)
+	}
+	if err := h.include(request.Include, shares, response.Data); err != nil {
+		return api.Error(err)
 	}
 	return api.Render(w, r, response)
 }
 
+const (
+	includeFeeRecipient = "fee_recipient"
+	includeBalance      = "balance"
+)
+
+// include adds the requested optional fields to the validators of the given shares.
+func (h *Validators) include(include requestIncludes, shares []*types.SSVShare, validators []*ValidatorJSON) error {
+	if slices.Contains(include, includeFeeRecipient) {
+		owners := make([]common.Address, 0, len(shares))
+		for _, share := range shares {
+			owners = append(owners, share.OwnerAddress)
+		}
+		feeRecipients, err := ownersFeeRecipients(h.Recipients, owners)
+		if err != nil {
+			return err
+		}
+		for i := range validators {
+			validators[i].FeeRecipient = feeRecipients[i]
+		}
+	}
+
+	if slices.Contains(include, includeBalance) {
+		for i, share := range shares {
+			if share.HasBeaconMetadata() {
+				balance, effectiveBalance := share.BeaconMetadata.Balance, share.BeaconMetadata.EffectiveBalance
+				validators[i].Balance = &balance
+				validators[i].EffectiveBalance = &effectiveBalance
+			}
+		}
+	}
+	return nil
+}
+
+// ownersFeeRecipients returns the fee recipients of the given owners, in the same order.
+func ownersFeeRecipients(recipients registrystorage.Recipients, owners []common.Address) ([]api.Hex, error) {
+	data, err := recipients.GetRecipientDataMany(nil, owners)
+	if err != nil {
+		return nil, fmt.Errorf("could not get fee recipients: %w", err)
+	}
+	feeRecipients := make([]api.Hex, len(owners))
+	for i, owner := range owners {
+		// Owners which never set a fee recipient receive the fees themselves.
+		feeRecipient, ok := data[owner]
+		if !ok {
+			feeRecipient = bellatrix.ExecutionAddress(owner)
+		}
+		feeRecipients[i] = api.Hex(feeRecipient[:])
+	}
+	return feeRecipients, nil
+}
+
+// validatorsPosition is the position of a validator in a sorted list.
+type validatorsPosition struct {
+	value  uint64
+	pubKey spectypes.ValidatorPK
+}
+
+// validatorsOrder sorts validators by a field, breaking ties by public key.
+type validatorsOrder struct {
+	sort       string
+	descending bool
+}
+
+func newValidatorsOrder(sortBy, order string) (*validatorsOrder, error) {
+	if sortBy == "" {
+		sortBy = "public_key"
+	}
+	switch sortBy {
+	case "public_key", "index", "activation_epoch", "balance":
+	default:
+		return nil, fmt.Errorf("unknown sort: %s", sortBy)
+	}
+	switch order {
+	case "", "asc", "desc":
+	default:
+		return nil, fmt.Errorf("unknown order: %s", order)
+	}
+	return &validatorsOrder{sort: sortBy, descending: order == "desc"}, nil
+}
+
+func (o *validatorsOrder) position(share *types.SSVShare) validatorsPosition {
+	p := validatorsPosition{pubKey: share.ValidatorPubKey}
+	if share.HasBeaconMetadata() {
+		switch o.sort {
+		case "index":
+			p.value = uint64(share.BeaconMetadata.Index)
+		case "activation_epoch":
+			p.value = uint64(share.BeaconMetadata.ActivationEpoch)
+		case "balance":
+			p.value = uint64(share.BeaconMetadata.Balance)
+		}
+	}
+	return p
+}
+
+func (o *validatorsOrder) less(a, b validatorsPosition) bool {
+	if o.descending {
+		a, b = b, a
+	}
+	if a.value != b.value {
+		return a.value < b.value
+	}
+	return bytes.Compare(a.pubKey[:], b.pubKey[:]) < 0
+}
+
+// encodeCursor encodes the position of the last validator of a page, and the sort it's valid for.
+func (o *validatorsOrder) encodeCursor(p validatorsPosition) string {
+	cursor := fmt.Sprintf("%s:%d:%x", o.sort, p.value, p.pubKey[:])
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func (o *validatorsOrder) decodeCursor(cursor string) (validatorsPosition, error) {
+	var p validatorsPosition
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return p, err
+	}
+	parts := strings.Split(string(b), ":")
+	if len(parts) != 3 {
+		return p, fmt.Errorf("malformed cursor")
+	}
+	if parts[0] != o.sort {
+		return p, fmt.Errorf("cursor is of sort %s", parts[0])
+	}
+	if p.value, err = strconv.ParseUint(parts[1], 10, 64); err != nil {
+		return p, err
+	}
+	pubKey, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return p, err
+	}
+	if len(pubKey) != len(p.pubKey) {
+		return p, fmt.Errorf("malformed cursor")
+	}
+	copy(p.pubKey[:], pubKey)
+	return p, nil
+}
+
 func byOwners(owners []api.Hex) registrystorage.SharesFilter {
 	return func(share *types.SSVShare) bool {
 		for _, a := range owners {
@@ -136,6 +359,61 @@ func byIndices(indices []uint64) registrystorage.SharesFilter {
 	}
 }
 
+func byStatuses(statuses []eth2apiv1.ValidatorState) registrystorage.SharesFilter {
+	return func(share *types.SSVShare) bool {
+		status := eth2apiv1.ValidatorStateUnknown
+		if share.HasBeaconMetadata() {
+			status = share.BeaconMetadata.Status
+		}
+		return slices.Contains(statuses, status)
+	}
+}
+
+func byLiquidated(liquidated bool) registrystorage.SharesFilter {
+	return func(share *types.SSVShare) bool {
+		return share.Liquidated == liquidated
+	}
+}
+
+func byActiveEpoch(epoch phase0.Epoch) registrystorage.SharesFilter {
+	return func(share *types.SSVShare) bool {
+		return share.IsAttesting(epoch)
+	}
+}
+
+// requestStatuses is a comma-separated list of beacon statuses.
+type requestStatuses []eth2apiv1.ValidatorState
+
+func (rs *requestStatuses) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range strings.Split(value, ",") {
+		var status eth2apiv1.ValidatorState
+		if err := status.UnmarshalJSON([]byte(strconv.Quote(strings.ToLower(s)))); err != nil {
+			return err
+		}
+		*rs = append(*rs, status)
+	}
+	return nil
+}
+
+// requestIncludes is a comma-separated list of optional fields.
+type requestIncludes []string
+
+func (ri *requestIncludes) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, s := range strings.Split(value, ",") {
+		if s != includeFeeRecipient && s != includeBalance {
+			return fmt.Errorf("unknown include: %s", s)
+		}
+		*ri = append(*ri, s)
+	}
+	return nil
+}
+
 // requestClusters is a space-separated list of comma-separated lists of operator IDs.
 type requestClusters [][]uint64
 
@@ -157,7 +435,8 @@ func (c *requestClusters) Bind(value string) error {
 	return nil
 }
 
-type validatorJSON struct {
+// ValidatorJSON is the representation of a validator share in the API.
+type ValidatorJSON struct {
 	PubKey          api.Hex                `json:"public_key"`
 	Index           phase0.ValidatorIndex  `json:"index"`
 	Status          string                 `json:"status"`
@@ -168,10 +447,16 @@ type validatorJSON struct {
 	PartialQuorum   uint64                 `json:"partial_quorum"`
 	Graffiti        string                 `json:"graffiti"`
 	Liquidated      bool                   `json:"liquidated"`
+
+	// Optional fields, added when requested.
+	FeeRecipient     api.Hex      `json:"fee_recipient,omitempty"`
+	Balance          *phase0.Gwei `json:"balance,omitempty"`
+	EffectiveBalance *phase0.Gwei `json:"effective_balance,omitempty"`
 }
 
-func validatorFromShare(share *types.SSVShare) *validatorJSON {
-	v := &validatorJSON{
+// ValidatorFromShare returns the API representation of the given share.
+func ValidatorFromShare(share *types.SSVShare) *ValidatorJSON {
+	v := &ValidatorJSON{
 		PubKey: api.Hex(share.ValidatorPubKey[:]),
 		Owner:  api.Hex(share.OwnerAddress[:]),
 		Committee: func() []spectypes.OperatorID {
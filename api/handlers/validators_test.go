@@ -1,10 +1,25 @@
 package handlers
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
 )
 
 func mockShare(operatorIDs ...uint64) *types.SSVShare {
@@ -92,3 +107,97 @@ func TestByClusters(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatorsList(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	recipients := registrystorage.NewRecipientsStorage(logger, db, []byte("test"))
+
+	// Validators 1-5 have indices 5-1, validator 6 has no beacon metadata and is liquidated.
+	for i := byte(1); i <= 6; i++ {
+		share := mockShare(1, 2, 3, 4)
+		share.ValidatorPubKey = spectypes.ValidatorPK{i}
+		share.OwnerAddress[0] = i % 2
+		if i <= 5 {
+			share.BeaconMetadata = &beacon.ValidatorMetadata{
+				Index:            phase0.ValidatorIndex(6 - i),
+				Status:           eth2apiv1.ValidatorStateActiveOngoing,
+				Balance:          phase0.Gwei(i),
+				EffectiveBalance: 32_000_000_000,
+			}
+		} else {
+			share.Liquidated = true
+		}
+		require.NoError(t, shares.Save(nil, share))
+	}
+	_, err = recipients.SaveRecipientData(nil, &registrystorage.RecipientData{FeeRecipient: bellatrix.ExecutionAddress{9}})
+	require.NoError(t, err)
+
+	handler := api.Handler((&Validators{Shares: shares, Recipients: recipients}).List)
+	list := func(query string) (pubKeys []byte, nextCursor string, validators []*ValidatorJSON) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/validators?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response struct {
+			Data       []*ValidatorJSON `json:"data"`
+			NextCursor string           `json:"next_cursor"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		for _, v := range response.Data {
+			pubKeys = append(pubKeys, v.PubKey[0])
+		}
+		return pubKeys, response.NextCursor, response.Data
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		pubKeys, cursor, _ := list("sort=index&limit=4")
+		require.Equal(t, []byte{6, 5, 4, 3}, pubKeys)
+
+		pubKeys, cursor, _ = list("sort=index&limit=4&cursor=" + cursor)
+		require.Equal(t, []byte{2, 1}, pubKeys)
+		require.Empty(t, cursor)
+
+		pubKeys, _, _ = list("sort=balance&order=desc")
+		require.Equal(t, []byte{5, 4, 3, 2, 1, 6}, pubKeys)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		pubKeys, _, _ := list("liquidated=true")
+		require.Equal(t, []byte{6}, pubKeys)
+
+		pubKeys, _, _ = list("statuses=unknown")
+		require.Equal(t, []byte{6}, pubKeys)
+
+		pubKeys, _, _ = list("liquidated=false&active_epoch=0&limit=2")
+		require.Equal(t, []byte{1, 2}, pubKeys)
+	})
+
+	t.Run("include", func(t *testing.T) {
+		// The owner of even validators set a fee recipient, the owner of odd validators receives the fees.
+		_, _, validators := list("limit=2&include=fee_recipient,balance")
+		require.Len(t, validators, 2)
+		require.Equal(t, api.Hex(common.Address{1}.Bytes()), validators[0].FeeRecipient)
+		require.Equal(t, api.Hex(common.Address{9}.Bytes()), validators[1].FeeRecipient)
+		require.Equal(t, phase0.Gwei(2), *validators[1].Balance)
+		require.Equal(t, phase0.Gwei(32_000_000_000), *validators[1].EffectiveBalance)
+
+		_, _, validators = list("limit=1")
+		require.Nil(t, validators[0].FeeRecipient)
+		require.Nil(t, validators[0].Balance)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, query := range []string{"sort=owner", "order=up", "limit=1001", "cursor=invalid", "statuses=sleeping", "include=everything"} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/validators?"+query, nil))
+			require.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/jellydator/ttlcache/v3"
+	"golang.org/x/time/rate"
+
+	"github.com/ssvlabs/ssv/api"
+)
+
+// Scope grants an API key access to a group of endpoints.
+type Scope string
+
+const (
+	// ScopeNode grants access to the /v1/node endpoints, except for /v1/node/health which is served without API keys.
+	ScopeNode Scope = "node"
+	// ScopeValidators grants access to the /v1/validators, /v1/operators and /v1/clusters endpoints.
+	ScopeValidators Scope = "validators"
+	// ScopeExporter grants access to the /v1/exporter endpoints.
+	ScopeExporter Scope = "exporter"
+	// ScopeEvents grants access to the /v1/events stream and the /v1/journal endpoints.
+	ScopeEvents Scope = "events"
+	// ScopeAdmin grants access to the /v1/admin endpoints, which change the state of the node.
+	ScopeAdmin Scope = "admin"
+)
+
+var scopes = []Scope{ScopeNode, ScopeValidators, ScopeExporter, ScopeEvents, ScopeAdmin}
+
+// APIKey is a key which clients authenticate with,
+// either as a bearer token or in the X-API-Key header.
+type APIKey struct {
+	// Name identifies the client in audit logs and rate limits.
+	Name   string  `yaml:"Name"`
+	Key    string  `yaml:"Key"`
+	Scopes []Scope `yaml:"Scopes"`
+}
+
+// Config configures the transport security, authentication, rate limits and audit logging of the SSV API.
+type Config struct {
+	TLSCertFile string `yaml:"TLSCertFile" env:"SSV_API_TLS_CERT_FILE" env-description:"Path to a TLS certificate to serve the SSV API over HTTPS"`
+	TLSKeyFile  string `yaml:"TLSKeyFile" env:"SSV_API_TLS_KEY_FILE" env-description:"Path to the private key of the TLS certificate"`
+
+	// APIKeys, when not empty, are required to access the SSV API.
+	APIKeys []APIKey `yaml:"APIKeys"`
+
+	RateLimit      float64 `yaml:"RateLimit" env:"SSV_API_RATE_LIMIT" env-default:"0" env-description:"Requests per second allowed per IP address, and per API key once authenticated, 0 for no limit"`
+	RateLimitBurst int     `yaml:"RateLimitBurst" env:"SSV_API_RATE_LIMIT_BURST" env-default:"10" env-description:"Requests a client may burst above the rate limit"`
+
+	AuditLog bool `yaml:"AuditLog" env:"SSV_API_AUDIT_LOG" env-default:"false" env-description:"Log every request to the SSV API with the client which made it"`
+}
+
+// Validate checks that the configuration is consistent.
+func (c *Config) Validate() error {
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return errors.New("both a TLS certificate and key are required")
+	}
+	if c.RateLimit < 0 || c.RateLimitBurst < 0 {
+		return errors.New("rate limit can't be negative")
+	}
+
+	names := make(map[string]struct{}, len(c.APIKeys))
+	keys := make(map[string]struct{}, len(c.APIKeys))
+	for _, key := range c.APIKeys {
+		if key.Name == "" {
+			return errors.New("API key has no name")
+		}
+		if _, ok := names[key.Name]; ok {
+			return fmt.Errorf("duplicate API key name %q", key.Name)
+		}
+		names[key.Name] = struct{}{}
+
+		if key.Key == "" {
+			return fmt.Errorf("API key %q is empty", key.Name)
+		}
+		if _, ok := keys[key.Key]; ok {
+			return fmt.Errorf("API key %q is used by another name", key.Name)
+		}
+		keys[key.Key] = struct{}{}
+
+		if len(key.Scopes) == 0 {
+			return fmt.Errorf("API key %q has no scopes", key.Name)
+		}
+		for _, scope := range key.Scopes {
+			if !slices.Contains(scopes, scope) {
+				return fmt.Errorf("API key %q has unknown scope %q", key.Name, scope)
+			}
+		}
+	}
+	return nil
+}
+
+type clientContextKey struct{}
+
+// client is the identity of whoever made a request.
+// Middlewares fill it in as they learn more about the client.
+type client struct {
+	addr string
+	key  *APIKey
+}
+
+// String returns the API key name of an authenticated client, or its IP address otherwise.
+func (c *client) String() string {
+	if c.key != nil {
+		return c.key.Name
+	}
+	return c.addr
+}
+
+func requestClient(r *http.Request) *client {
+	c, _ := r.Context().Value(clientContextKey{}).(*client)
+	return c
+}
+
+func middlewareClient(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			addr = r.RemoteAddr
+		}
+		ctx := context.WithValue(r.Context(), clientContextKey{}, &client{addr: addr})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticator matches API keys by their hashes, so that comparing them takes constant time.
+type authenticator struct {
+	hashes [][sha256.Size]byte
+	keys   []*APIKey
+}
+
+func newAuthenticator(keys []APIKey) *authenticator {
+	a := &authenticator{}
+	for i := range keys {
+		a.hashes = append(a.hashes, sha256.Sum256([]byte(keys[i].Key)))
+		a.keys = append(a.keys, &keys[i])
+	}
+	return a
+}
+
+func (a *authenticator) enabled() bool {
+	return len(a.keys) > 0
+}
+
+func (a *authenticator) authenticate(r *http.Request) (*APIKey, error) {
+	token := r.Header.Get("X-API-Key")
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		bearer, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok {
+			return nil, errors.New("unsupported authorization scheme")
+		}
+		token = bearer
+	}
+	if token == "" {
+		return nil, errors.New("missing API key")
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	var match *APIKey
+	for i := range a.hashes {
+		if subtle.ConstantTimeCompare(hash[:], a.hashes[i][:]) == 1 {
+			match = a.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, errors.New("invalid API key")
+	}
+	return match, nil
+}
+
+func (a *authenticator) middleware(next http.Handler) http.Handler {
+	if !a.enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, err := a.authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ssv"`)
+			renderError(w, r, api.UnauthorizedError(err))
+			return
+		}
+		requestClient(r).key = key
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireScope only lets authenticated clients whose key has the given scope through.
+func (a *authenticator) requireScope(scope Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !a.enabled() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := requestClient(r).key; key == nil || !slices.Contains(key.Scopes, scope) {
+				renderError(w, r, api.ForbiddenError(fmt.Errorf("API key lacks the %q scope", scope)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimiterTTL is how long the rate limiter of an idle client is kept.
+const rateLimiterTTL = 10 * time.Minute
+
+// rateLimiter limits the rate of requests of each client.
+type rateLimiter struct {
+	limit    rate.Limit
+	burst    int
+	limiters *ttlcache.Cache[string, *rate.Limiter]
+}
+
+func newRateLimiter(limit float64, burst int) *rateLimiter {
+	limiters := ttlcache.New(ttlcache.WithTTL[string, *rate.Limiter](rateLimiterTTL))
+	go limiters.Start()
+
+	return &rateLimiter{
+		limit:    rate.Limit(limit),
+		burst:    burst,
+		limiters: limiters,
+	}
+}
+
+// stop stops expiring the rate limiters of idle clients.
+func (rl *rateLimiter) stop() {
+	rl.limiters.Stop()
+}
+
+func (rl *rateLimiter) allow(client string) bool {
+	item, _ := rl.limiters.GetOrSet(client, rate.NewLimiter(rl.limit, rl.burst))
+	return item.Value().Allow()
+}
+
+// addrMiddleware limits requests by address before they're authenticated,
+// so that clients without a valid key can't make the node check keys at any rate.
+func (rl *rateLimiter) addrMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rl.throttle(w, r, next, "addr/"+requestClient(r).addr)
+	})
+}
+
+// keyMiddleware limits the requests of authenticated clients by their key, wherever they're made from.
+func (rl *rateLimiter) keyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := requestClient(r).key
+		if key == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		// Keys and addresses are limited separately, in case a key is named like an address.
+		rl.throttle(w, r, next, "key/"+key.Name)
+	})
+}
+
+func (rl *rateLimiter) throttle(w http.ResponseWriter, r *http.Request, next http.Handler, id string) {
+	if !rl.allow(id) {
+		w.Header().Set("Retry-After", "1")
+		renderError(w, r, api.TooManyRequestsError(errors.New("rate limit exceeded")))
+		return
+	}
+	next.ServeHTTP(w, r)
+}
+
+func renderError(w http.ResponseWriter, r *http.Request, err *api.ErrorResponse) {
+	if err := render.Render(w, r, err); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
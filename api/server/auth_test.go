@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ssvlabs/ssv/api/handlers"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/nodeprobe"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// peerlessNetwork is a P2P network without peers.
+type peerlessNetwork struct {
+	network.Network
+}
+
+func (peerlessNetwork) Peers() []peer.ID {
+	return nil
+}
+
+// healthyNode is a node which is always healthy.
+type healthyNode struct{}
+
+func (healthyNode) Healthy(context.Context) error {
+	return nil
+}
+
+func TestServerAuth(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+
+	newServer := func(logger *zap.Logger, config Config) http.Handler {
+		require.NoError(t, config.Validate())
+		node := &handlers.Node{
+			Network: peerlessNetwork{},
+			NodeProber: nodeprobe.NewProber(logger, nil, map[string]nodeprobe.Node{
+				"consensus client": healthyNode{},
+				"execution client": healthyNode{},
+				"event syncer":     healthyNode{},
+			}),
+		}
+		s := New(logger, ":0", config, node, &handlers.Validators{Shares: shares}, &handlers.Exporter{}, &handlers.Events{Shares: shares}, &handlers.Operators{}, &handlers.Clusters{}, &handlers.Journal{}, &handlers.Bans{})
+		return s.router()
+	}
+	request := func(handler http.Handler, path string, header ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("no keys", func(t *testing.T) {
+		handler := newServer(logger, Config{})
+		require.Equal(t, http.StatusOK, request(handler, "/v1/validators").Code)
+		// Admin endpoints aren't served without API keys.
+		require.Equal(t, http.StatusNotFound, request(handler, "/v1/admin/bans").Code)
+	})
+
+	t.Run("keys and scopes", func(t *testing.T) {
+		handler := newServer(logger, Config{
+			APIKeys: []APIKey{
+				{Name: "dashboard", Key: "secret1", Scopes: []Scope{ScopeNode, ScopeValidators}},
+				{Name: "explorer", Key: "secret2", Scopes: []Scope{ScopeExporter}},
+			},
+		})
+
+		w := request(handler, "/v1/validators")
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+		require.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+		require.Equal(t, http.StatusUnauthorized, request(handler, "/v1/validators", "X-API-Key", "wrong").Code)
+		require.Equal(t, http.StatusUnauthorized, request(handler, "/v1/validators", "Authorization", "Basic secret1").Code)
+		require.Equal(t, http.StatusOK, request(handler, "/v1/validators", "X-API-Key", "secret1").Code)
+		require.Equal(t, http.StatusOK, request(handler, "/v1/validators", "Authorization", "Bearer secret1").Code)
+
+		require.Equal(t, http.StatusForbidden, request(handler, "/v1/validators", "X-API-Key", "secret2").Code)
+		require.Equal(t, http.StatusForbidden, request(handler, "/v1/exporter/duties", "X-API-Key", "secret1").Code)
+		require.Equal(t, http.StatusForbidden, request(handler, "/v1/events", "X-API-Key", "secret1").Code)
+		// The exporter has no archive, so the request passes authentication and is rejected by the handler.
+		require.Equal(t, http.StatusBadRequest, request(handler, "/v1/exporter/duties", "X-API-Key", "secret2").Code)
+
+		// Health probes don't need a key, unlike the rest of the node endpoints.
+		require.Equal(t, http.StatusOK, request(handler, "/v1/node/health").Code)
+		require.Equal(t, http.StatusUnauthorized, request(handler, "/v1/node/identity").Code)
+		require.Equal(t, http.StatusForbidden, request(handler, "/v1/node/identity", "X-API-Key", "secret2").Code)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		handler := newServer(logger, Config{
+			APIKeys:        []APIKey{{Name: "dashboard", Key: "secret", Scopes: []Scope{ScopeValidators}}},
+			RateLimit:      0.001,
+			RateLimitBurst: 2,
+		})
+
+		requestFrom := func(addr string, header ...string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodGet, "/v1/validators", nil)
+			r.RemoteAddr = addr
+			for i := 0; i < len(header); i += 2 {
+				r.Header.Set(header[i], header[i+1])
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			return w
+		}
+
+		// Keys are limited wherever they're used from.
+		require.Equal(t, http.StatusOK, requestFrom("10.0.0.1:1234", "X-API-Key", "secret").Code)
+		require.Equal(t, http.StatusOK, requestFrom("10.0.0.2:1234", "X-API-Key", "secret").Code)
+		w := requestFrom("10.0.0.3:1234", "X-API-Key", "secret")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.Equal(t, "1", w.Header().Get("Retry-After"))
+
+		// Addresses are limited before authentication, separately from keys.
+		require.Equal(t, http.StatusUnauthorized, requestFrom("10.0.0.4:1234").Code)
+		require.Equal(t, http.StatusUnauthorized, requestFrom("10.0.0.4:1234", "X-API-Key", "wrong").Code)
+		require.Equal(t, http.StatusTooManyRequests, requestFrom("10.0.0.4:1234", "X-API-Key", "wrong").Code)
+	})
+
+	t.Run("audit log", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		handler := newServer(zap.New(core), Config{
+			APIKeys:  []APIKey{{Name: "dashboard", Key: "secret", Scopes: []Scope{ScopeValidators}}},
+			AuditLog: true,
+		})
+
+		request(handler, "/v1/validators?owners=0x01", "X-API-Key", "secret")
+		request(handler, "/v1/validators")
+
+		entries := logs.FilterMessage("SSV API request").AllUntimed()
+		require.Len(t, entries, 2)
+		require.Equal(t, "dashboard", entries[0].ContextMap()["client"])
+		require.Equal(t, "owners=0x01", entries[0].ContextMap()["query"])
+		require.Equal(t, int64(http.StatusOK), entries[0].ContextMap()["status"])
+		require.Equal(t, "10.0.0.1", entries[1].ContextMap()["client"])
+		require.Equal(t, int64(http.StatusUnauthorized), entries[1].ContextMap()["status"])
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := APIKey{Name: "a", Key: "secret", Scopes: []Scope{ScopeNode}}
+
+	require.NoError(t, (&Config{APIKeys: []APIKey{valid}}).Validate())
+	require.Error(t, (&Config{TLSCertFile: "cert"}).Validate())
+	require.Error(t, (&Config{RateLimit: -1}).Validate())
+	require.Error(t, (&Config{APIKeys: []APIKey{valid, valid}}).Validate())
+	require.Error(t, (&Config{APIKeys: []APIKey{{Name: "a", Key: "secret"}}}).Validate())
+	require.Error(t, (&Config{APIKeys: []APIKey{{Name: "a", Key: "secret", Scopes: []Scope{"root"}}}}).Validate())
+	require.Error(t, (&Config{APIKeys: []APIKey{valid, {Name: "b", Key: "secret", Scopes: []Scope{ScopeNode}}}}).Validate())
+}
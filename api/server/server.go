@@ -1,6 +1,10 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"net/http"
 	"runtime"
 	"time"
@@ -14,58 +18,175 @@ import (
 	"github.com/ssvlabs/ssv/utils/commons"
 )
 
+// shutdownTimeout is how long requests in flight are waited for when the server shuts down.
+const shutdownTimeout = 5 * time.Second
+
 type Server struct {
 	logger *zap.Logger
 	addr   string
+	config Config
 
 	node       *handlers.Node
 	validators *handlers.Validators
 	exporter   *handlers.Exporter
+	events     *handlers.Events
+	operators  *handlers.Operators
+	clusters   *handlers.Clusters
+	journal    *handlers.Journal
+	bans       *handlers.Bans
+
+	rateLimiter *rateLimiter
 }
 
 func New(
 	logger *zap.Logger,
 	addr string,
+	config Config,
 	node *handlers.Node,
 	validators *handlers.Validators,
 	exporter *handlers.Exporter,
+	events *handlers.Events,
+	operators *handlers.Operators,
+	clusters *handlers.Clusters,
+	journal *handlers.Journal,
+	bans *handlers.Bans,
 ) *Server {
 	return &Server{
 		logger:     logger,
 		addr:       addr,
+		config:     config,
 		node:       node,
 		validators: validators,
 		exporter:   exporter,
+		events:     events,
+		operators:  operators,
+		clusters:   clusters,
+		journal:    journal,
+		bans:       bans,
+	}
+}
+
+// Run serves the SSV API until the context is done, and then shuts the server down.
+func (s *Server) Run(ctx context.Context) error {
+	if err := s.config.Validate(); err != nil {
+		return fmt.Errorf("invalid SSV API config: %w", err)
+	}
+
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.router(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       12 * time.Second,
+		WriteTimeout:      12 * time.Second,
 	}
+
+	s.logger.Info("Serving SSV API",
+		zap.String("addr", s.addr),
+		zap.Bool("tls", s.config.TLSCertFile != ""),
+		zap.Int("api_keys", len(s.config.APIKeys)),
+		zap.Float64("rate_limit", s.config.RateLimit),
+		zap.Bool("audit_log", s.config.AuditLog),
+	)
+
+	defer func() {
+		if s.rateLimiter != nil {
+			s.rateLimiter.stop()
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Warn("could not shut down SSV API gracefully", zap.Error(err))
+		}
+	}()
+
+	var err error
+	if s.config.TLSCertFile != "" {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		err = server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
 }
 
-func (s *Server) Run() error {
+func (s *Server) router() http.Handler {
+	auth := newAuthenticator(s.config.APIKeys)
+
 	router := chi.NewRouter()
 	router.Use(middleware.Recoverer)
-	router.Use(middleware.Throttle(runtime.NumCPU() * 4))
-	router.Use(middleware.Compress(5, "application/json"))
 	router.Use(middlewareLogger(s.logger))
 	router.Use(middlewareNodeVersion)
+	router.Use(middlewareClient)
+	if s.config.AuditLog {
+		router.Use(middlewareAudit(s.logger.Named("audit")))
+	}
+	if s.config.RateLimit > 0 {
+		s.rateLimiter = newRateLimiter(s.config.RateLimit, s.config.RateLimitBurst)
+		router.Use(s.rateLimiter.addrMiddleware)
+	}
 
-	router.Get("/v1/node/identity", api.Handler(s.node.Identity))
-	router.Get("/v1/node/peers", api.Handler(s.node.Peers))
-	router.Get("/v1/node/topics", api.Handler(s.node.Topics))
+	// Health probes, such as those of load balancers and orchestrators, usually can't send API keys,
+	// so health is served without them.
 	router.Get("/v1/node/health", api.Handler(s.node.Health))
-	router.Get("/v1/validators", api.Handler(s.validators.List))
-	// We kept both GET and POST methods to ensure compatibility and avoid breaking changes for clients that may rely on either method
-	router.Get("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
-	router.Post("/v1/exporter/decideds", api.Handler(s.exporter.Decideds))
 
-	s.logger.Info("Serving SSV API", zap.String("addr", s.addr))
+	router.Group(func(router chi.Router) {
+		router.Use(auth.middleware)
+		if s.rateLimiter != nil {
+			router.Use(s.rateLimiter.keyMiddleware)
+		}
+
+		// Streams are long-lived, so they're neither throttled nor compressed.
+		router.With(auth.requireScope(ScopeEvents)).Get("/v1/events", api.Handler(s.events.Stream))
 
-	server := &http.Server{
-		Addr:              s.addr,
-		Handler:           router,
-		ReadHeaderTimeout: 10 * time.Second,
-		ReadTimeout:       12 * time.Second,
-		WriteTimeout:      12 * time.Second,
-	}
-	return server.ListenAndServe()
+		router.Group(func(router chi.Router) {
+			router.Use(middleware.Throttle(runtime.NumCPU() * 4))
+			router.Use(middleware.Compress(5, "application/json"))
+
+			router.With(auth.requireScope(ScopeNode)).Route("/v1/node", func(r chi.Router) {
+				r.Get("/identity", api.Handler(s.node.Identity))
+				r.Get("/peers", api.Handler(s.node.Peers))
+				r.Get("/topics", api.Handler(s.node.Topics))
+				r.Get("/traffic", api.Handler(s.node.Traffic))
+			})
+			router.Group(func(router chi.Router) {
+				router.Use(auth.requireScope(ScopeValidators))
+				router.Get("/v1/validators", api.Handler(s.validators.List))
+				router.Get("/v1/operators", api.Handler(s.operators.List))
+				router.Get("/v1/operators/{id}", api.Handler(s.operators.Get))
+				router.Get("/v1/clusters", api.Handler(s.clusters.List))
+			})
+			router.With(auth.requireScope(ScopeExporter)).Route("/v1/exporter", func(r chi.Router) {
+				// We kept both GET and POST methods to ensure compatibility and avoid breaking changes for clients that may rely on either method
+				r.Get("/decideds", api.Handler(s.exporter.Decideds))
+				r.Post("/decideds", api.Handler(s.exporter.Decideds))
+				r.Get("/performance", api.Handler(s.exporter.Performance))
+				r.Post("/performance", api.Handler(s.exporter.Performance))
+				r.Get("/duties", api.Handler(s.exporter.Duties))
+				r.Post("/duties", api.Handler(s.exporter.Duties))
+			})
+			router.With(auth.requireScope(ScopeEvents)).Route("/v1/journal", func(r chi.Router) {
+				r.Get("/duties", api.Handler(s.journal.Duties))
+				r.Post("/duties", api.Handler(s.journal.Duties))
+			})
+			// Admin endpoints change the state of the node, so they're only served to clients with API keys.
+			if auth.enabled() {
+				router.With(auth.requireScope(ScopeAdmin)).Route("/v1/admin", func(r chi.Router) {
+					r.Get("/bans", api.Handler(s.bans.List))
+					r.Post("/bans", api.Handler(s.bans.Ban))
+					r.Delete("/bans/{target}", api.Handler(s.bans.Unban))
+				})
+			}
+		})
+	})
+
+	return router
 }
 
 func middlewareLogger(logger *zap.Logger) func(next http.Handler) http.Handler {
@@ -90,6 +211,31 @@ func middlewareLogger(logger *zap.Logger) func(next http.Handler) http.Handler {
 	}
 }
 
+// middlewareAudit logs who made every request, including the rejected ones.
+func middlewareAudit(logger *zap.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			defer func() {
+				c := requestClient(r)
+				logger.Info(
+					"SSV API request",
+					zap.String("client", c.String()),
+					zap.Bool("authenticated", c.key != nil),
+					zap.String("remote_addr", c.addr),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.String("query", r.URL.RawQuery),
+					zap.Int("status", ww.Status()),
+					zap.Duration("took", time.Since(start)),
+				)
+			}()
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
 func middlewareNodeVersion(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-SSV-Node-Version", commons.GetNodeVersion())
@@ -112,3 +112,39 @@ func (rs *RoleSlice) Bind(value string) error {
 	}
 	return nil
 }
+
+// Optional is a parameter which may be omitted, to tell it apart from its zero value.
+type Optional[T bool | uint64] struct {
+	Value T
+	Set   bool
+}
+
+func (o *Optional[T]) Bind(value string) error {
+	if value == "" {
+		return nil
+	}
+	var v any
+	var err error
+	switch any(o.Value).(type) {
+	case bool:
+		v, err = strconv.ParseBool(value)
+	case uint64:
+		v, err = strconv.ParseUint(value, 10, 64)
+	}
+	if err != nil {
+		return err
+	}
+	o.Value, o.Set = v.(T), true
+	return nil
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.Value); err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
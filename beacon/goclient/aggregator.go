@@ -40,12 +40,15 @@ func (gc *GoClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 		return nil, DataVersionNil, fmt.Errorf("failed to get attestation data root: %w", err)
 	}
 
-	aggDataReqStart := time.Now()
-	aggDataResp, err := gc.client.AggregateAttestation(gc.ctx, &api.AggregateAttestationOpts{
-		Slot:                slot,
-		AttestationDataRoot: root,
+	aggDataResp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*phase0.Attestation], error) {
+		aggDataReqStart := time.Now()
+		aggDataResp, err := client.AggregateAttestation(gc.ctx, &api.AggregateAttestationOpts{
+			Slot:                slot,
+			AttestationDataRoot: root,
+		})
+		recordRequestDuration(gc.ctx, "AggregateAttestation", client.Address(), http.MethodGet, time.Since(aggDataReqStart), err)
+		return aggDataResp, err
 	})
-	recordRequestDuration(gc.ctx, "AggregateAttestation", gc.client.Address(), http.MethodGet, time.Since(aggDataReqStart), err)
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "AggregateAttestation"),
@@ -78,9 +81,12 @@ func (gc *GoClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 
 // SubmitSignedAggregateSelectionProof broadcasts a signed aggregator msg
 func (gc *GoClient) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
-	start := time.Now()
-	err := gc.client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
-	recordRequestDuration(gc.ctx, "SubmitAggregateAttestations", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
+		recordRequestDuration(gc.ctx, "SubmitAggregateAttestations", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	return err
 }
 
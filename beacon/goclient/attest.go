@@ -16,12 +16,15 @@ import (
 
 // AttesterDuties returns attester duties for a given epoch.
 func (gc *GoClient) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.AttesterDuty, error) {
-	start := time.Now()
-	resp, err := gc.client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	resp, err := withFailover(gc, ctx, func(client Client) (*api.Response[[]*eth2apiv1.AttesterDuty], error) {
+		start := time.Now()
+		resp, err := client.AttesterDuties(ctx, &api.AttesterDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		recordRequestDuration(gc.ctx, "AttesterDuties", client.Address(), http.MethodPost, time.Since(start), err)
+		return resp, err
 	})
-	recordRequestDuration(gc.ctx, "AttesterDuties", gc.client.Address(), http.MethodPost, time.Since(start), err)
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "AttesterDuties"),
@@ -60,13 +63,15 @@ func (gc *GoClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.C
 
 	// Have to make beacon node request and cache the result.
 	result, err, _ := gc.attestationReqInflight.Do(slot, func() (*phase0.AttestationData, error) {
-		attDataReqStart := time.Now()
-		resp, err := gc.client.AttestationData(gc.ctx, &api.AttestationDataOpts{
-			Slot: slot,
+		resp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*phase0.AttestationData], error) {
+			attDataReqStart := time.Now()
+			resp, err := client.AttestationData(gc.ctx, &api.AttestationDataOpts{
+				Slot: slot,
+			})
+			recordRequestDuration(gc.ctx, "AttestationData", client.Address(), http.MethodGet, time.Since(attDataReqStart), err)
+			return resp, err
 		})
 
-		recordRequestDuration(gc.ctx, "AttestationData", gc.client.Address(), http.MethodGet, time.Since(attDataReqStart), err)
-
 		if err != nil {
 			gc.log.Error(clResponseErrMsg,
 				zap.String("api", "AttestationData"),
@@ -122,9 +127,12 @@ func withCommitteeIndex(data *phase0.AttestationData, committeeIndex phase0.Comm
 
 // SubmitAttestations implements Beacon interface
 func (gc *GoClient) SubmitAttestations(attestations []*phase0.Attestation) error {
-	start := time.Now()
-	err := gc.client.SubmitAttestations(gc.ctx, attestations)
-	recordRequestDuration(gc.ctx, "SubmitAttestations", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitAttestations(gc.ctx, attestations)
+		recordRequestDuration(gc.ctx, "SubmitAttestations", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitAttestations"),
@@ -11,9 +11,12 @@ import (
 
 // SubmitBeaconCommitteeSubscriptions is implementation for subscribing committee to subnet (p2p topic)
 func (gc *GoClient) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.BeaconCommitteeSubscription) error {
-	start := time.Now()
-	err := gc.client.SubmitBeaconCommitteeSubscriptions(ctx, subscription)
-	recordRequestDuration(gc.ctx, "SubmitBeaconCommitteeSubscriptions", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitBeaconCommitteeSubscriptions(ctx, subscription)
+		recordRequestDuration(gc.ctx, "SubmitBeaconCommitteeSubscriptions", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitBeaconCommitteeSubscriptions"),
@@ -25,9 +28,12 @@ func (gc *GoClient) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subs
 
 // SubmitSyncCommitteeSubscriptions is implementation for subscribing sync committee to subnet (p2p topic)
 func (gc *GoClient) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscription []*eth2apiv1.SyncCommitteeSubscription) error {
-	start := time.Now()
-	err := gc.client.SubmitSyncCommitteeSubscriptions(ctx, subscription)
-	recordRequestDuration(gc.ctx, "SubmitSyncCommitteeSubscriptions", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitSyncCommitteeSubscriptions(ctx, subscription)
+		recordRequestDuration(gc.ctx, "SubmitSyncCommitteeSubscriptions", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitSyncCommitteeSubscriptions"),
@@ -0,0 +1,116 @@
+package goclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// eventSubscription is a subscription to beacon node events, which follows the active node
+// so that events keep coming when requests fail over to another node.
+type eventSubscription struct {
+	ctx     context.Context
+	topics  []string
+	handler eth2client.EventHandlerFunc
+
+	// node is the node the subscription is currently on, and cancel ends the subscription on it.
+	node   *beaconNode
+	cancel context.CancelFunc
+}
+
+// Events subscribes to the given topics on the active node.
+// The subscription is moved to another node whenever the active node changes, until the context is done.
+func (gc *GoClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	sub := &eventSubscription{
+		ctx:     ctx,
+		topics:  topics,
+		handler: handler,
+	}
+
+	gc.eventSubsMu.Lock()
+	defer gc.eventSubsMu.Unlock()
+
+	var errs error
+	for _, node := range gc.rankedNodes() {
+		err := gc.subscribe(sub, node)
+		if err == nil {
+			gc.eventSubs = append(gc.eventSubs, sub)
+			return nil
+		}
+		if len(gc.nodes) == 1 {
+			errs = err
+			break
+		}
+		errs = errors.Join(errs, fmt.Errorf("%s: %w", redactAddr(node.addr), err))
+	}
+
+	gc.log.Error(clResponseErrMsg,
+		zap.String("api", "Events"),
+		zap.Error(errs),
+	)
+	return errs
+}
+
+// subscribe subscribes to the events on the given node, ending the subscription on the previous node if it succeeds.
+func (gc *GoClient) subscribe(sub *eventSubscription, node *beaconNode) error {
+	client := node.getClient()
+	if client == nil {
+		return errNotConnected
+	}
+
+	ctx, cancel := context.WithCancel(sub.ctx)
+	if err := client.Events(ctx, sub.topics, sub.handler); err != nil {
+		cancel()
+		return err
+	}
+
+	if sub.cancel != nil {
+		sub.cancel()
+	}
+	sub.node = node
+	sub.cancel = cancel
+	return nil
+}
+
+// resubscribeEvents moves the event subscriptions to the active node if it has changed.
+// A subscription which can't be moved stays on its current node until the next attempt.
+func (gc *GoClient) resubscribeEvents() {
+	gc.eventSubsMu.Lock()
+	defer gc.eventSubsMu.Unlock()
+
+	if len(gc.eventSubs) == 0 {
+		return
+	}
+	nodes := gc.rankedNodes()
+	if len(nodes) == 0 {
+		return
+	}
+	active := nodes[0]
+
+	gc.eventSubs = slices.DeleteFunc(gc.eventSubs, func(sub *eventSubscription) bool {
+		return sub.ctx.Err() != nil
+	})
+	for _, sub := range gc.eventSubs {
+		if sub.node == active {
+			continue
+		}
+		if err := gc.subscribe(sub, active); err != nil {
+			gc.log.Warn("could not move event subscription to the active consensus client",
+				fields.Address(redactAddr(active.addr)),
+				zap.Strings("topics", sub.topics),
+				zap.Error(err),
+			)
+			continue
+		}
+		gc.log.Info("moved event subscription to the active consensus client",
+			fields.Address(redactAddr(active.addr)),
+			zap.Strings("topics", sub.topics),
+		)
+	}
+}
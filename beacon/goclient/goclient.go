@@ -2,8 +2,10 @@ package goclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -15,7 +17,6 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jellydator/ttlcache/v3"
-	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"go.uber.org/zap"
 	"tailscale.com/util/singleflight"
@@ -74,6 +75,7 @@ type Client interface {
 	eth2client.NodeClientProvider
 	eth2client.SpecProvider
 	eth2client.GenesisProvider
+	eth2client.ForkScheduleProvider
 
 	eth2client.AttestationDataProvider
 	eth2client.AttestationsSubmitter
@@ -111,13 +113,15 @@ type GoClient struct {
 	log         *zap.Logger
 	ctx         context.Context
 	network     beaconprotocol.Network
-	client      Client
 	nodeVersion string
 	nodeClient  NodeClient
 	gasLimit    uint64
 
+	// nodes are the connected consensus clients in the configured priority order.
+	nodes []*beaconNode
+
 	syncDistanceTolerance phase0.Slot
-	nodeSyncingFn         func(ctx context.Context, opts *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error)
+	nodeSyncingFn         func(ctx context.Context, client Client, opts *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error)
 
 	operatorDataStore operatordatastore.OperatorDataStore
 
@@ -134,18 +138,38 @@ type GoClient struct {
 	// data regardless of the requested committeeIndex.
 	attestationDataCache *ttlcache.Cache[phase0.Slot, *phase0.AttestationData]
 
+	// forkSchedule and genesisValidatorsRoot are fetched once and cached by ForkInfo.
+	forkInfoMu            sync.Mutex
+	forkSchedule          []*phase0.Fork
+	genesisValidatorsRoot *phase0.Root
+
+	// eventSubs are the event subscriptions, which are moved to the active node on failover.
+	eventSubsMu sync.Mutex
+	eventSubs   []*eventSubscription
+
+	// referenceSpec is the spec of the first connected node, which the other nodes must match.
+	referenceMu      sync.Mutex
+	referenceAddr    string
+	referenceVersion string
+	referenceSpec    map[string]any
+
 	commonTimeout time.Duration
 	longTimeout   time.Duration
 }
 
-// New init new client and go-client instance
+// New init new client and go-client instance.
+// BeaconNodeAddr may contain several addresses separated by BeaconNodeAddrSeparator,
+// in which case requests fail over between the nodes and submissions are broadcast to all healthy ones.
 func New(
 	logger *zap.Logger,
 	opt beaconprotocol.Options,
 	operatorDataStore operatordatastore.OperatorDataStore,
 	slotTickerProvider slotticker.Provider,
 ) (*GoClient, error) {
-	logger.Info("consensus client: connecting", fields.Address(opt.BeaconNodeAddr), fields.Network(string(opt.Network.BeaconNetwork)))
+	addrs := parseBeaconNodeAddrs(opt.BeaconNodeAddr)
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no beacon node address provided")
+	}
 
 	commonTimeout := opt.CommonTimeout
 	if commonTimeout == 0 {
@@ -156,27 +180,10 @@ func New(
 		longTimeout = DefaultLongTimeout
 	}
 
-	httpClient, err := eth2clienthttp.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		eth2clienthttp.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
-		eth2clienthttp.WithTimeout(commonTimeout),
-		eth2clienthttp.WithReducedMemoryUsage(true),
-	)
-	if err != nil {
-		logger.Error("Consensus client initialization failed",
-			zap.String("address", opt.BeaconNodeAddr),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to create http client: %w", err)
-	}
-
 	client := &GoClient{
 		log:                   logger,
 		ctx:                   opt.Context,
 		network:               opt.Network,
-		client:                httpClient.(*eth2clienthttp.Service),
 		gasLimit:              opt.GasLimit,
 		syncDistanceTolerance: phase0.Slot(opt.SyncDistanceTolerance),
 		operatorDataStore:     operatorDataStore,
@@ -192,39 +199,110 @@ func New(
 
 	client.nodeSyncingFn = client.nodeSyncing
 
-	nodeVersionResp, err := client.client.NodeVersion(opt.Context, &api.NodeVersionOpts{})
+	// Nodes which are unavailable at startup are kept as unhealthy and reconnected in the background,
+	// as long as at least one node is available.
+	var errs error
+	var connected int
+	for _, addr := range addrs {
+		node := &beaconNode{addr: addr}
+		client.nodes = append(client.nodes, node)
+
+		nodeClient, version, err := client.connect(opt.Context, addr)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", redactAddr(addr), err))
+			continue
+		}
+		if len(addrs) > 1 {
+			if err := client.checkConsistency(opt.Context, addr, nodeClient, version); err != nil {
+				if errors.Is(err, errSpecMismatch) {
+					return nil, fmt.Errorf("%s: %w", redactAddr(addr), err)
+				}
+				errs = errors.Join(errs, fmt.Errorf("%s: %w", redactAddr(addr), err))
+				continue
+			}
+		}
+		node.setClient(nodeClient)
+		node.healthy.Store(true)
+		if connected == 0 {
+			client.nodeVersion = version
+			client.nodeClient = ParseNodeClient(version)
+		}
+		connected++
+	}
+	if connected == 0 {
+		return nil, errs
+	}
+	if errs != nil {
+		logger.Warn("some consensus clients are unavailable, reconnecting to them in the background",
+			zap.Int("available", connected),
+			zap.Int("configured", len(addrs)),
+			zap.Error(errs),
+		)
+		for _, node := range client.nodes {
+			if node.getClient() == nil {
+				go client.reconnectLoop(opt.Context, node)
+			}
+		}
+	}
+
+	go client.registrationSubmitter(slotTickerProvider)
+	// Start automatic expired item deletion for attestationDataCache.
+	go client.attestationDataCache.Start()
+
+	return client, nil
+}
+
+// connect creates a client for the beacon node at the given address and checks that it responds.
+// It returns the client along with the version of the node.
+func (gc *GoClient) connect(ctx context.Context, addr string) (Client, string, error) {
+	gc.log.Info("consensus client: connecting", fields.Address(redactAddr(addr)), fields.Network(string(gc.network.BeaconNetwork)))
+
+	httpClient, err := eth2clienthttp.New(ctx,
+		// WithAddress supplies the address of the beacon node, in host:port format.
+		eth2clienthttp.WithAddress(addr),
+		// LogLevel supplies the level of logging to carry out.
+		eth2clienthttp.WithLogLevel(zerolog.DebugLevel),
+		eth2clienthttp.WithTimeout(gc.commonTimeout),
+		eth2clienthttp.WithReducedMemoryUsage(true),
+	)
 	if err != nil {
-		logger.Error(clResponseErrMsg,
+		gc.log.Error("Consensus client initialization failed",
+			zap.String("address", redactAddr(addr)),
+			zap.Error(err),
+		)
+		return nil, "", fmt.Errorf("failed to create http client: %w", err)
+	}
+	client := httpClient.(*eth2clienthttp.Service)
+
+	nodeVersionResp, err := client.NodeVersion(ctx, &api.NodeVersionOpts{})
+	if err != nil {
+		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "NodeVersion"),
+			fields.Address(redactAddr(addr)),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("failed to get node version: %w", err)
+		return nil, "", fmt.Errorf("failed to get node version: %w", err)
 	}
 	if nodeVersionResp == nil {
-		logger.Error(clNilResponseErrMsg,
+		gc.log.Error(clNilResponseErrMsg,
 			zap.String("api", "NodeVersion"),
+			fields.Address(redactAddr(addr)),
 		)
-		return nil, fmt.Errorf("node version response is nil")
+		return nil, "", fmt.Errorf("node version response is nil")
 	}
-	client.nodeVersion = nodeVersionResp.Data
-	client.nodeClient = ParseNodeClient(nodeVersionResp.Data)
 
-	logger.Info("consensus client connected",
+	gc.log.Info("consensus client connected",
 		fields.Name(httpClient.Name()),
 		fields.Address(httpClient.Address()),
-		zap.String("client", string(client.nodeClient)),
-		zap.String("version", client.nodeVersion),
+		zap.String("client", string(ParseNodeClient(nodeVersionResp.Data))),
+		zap.String("version", nodeVersionResp.Data),
 	)
 
-	go client.registrationSubmitter(slotTickerProvider)
-	// Start automatic expired item deletion for attestationDataCache.
-	go client.attestationDataCache.Start()
-
-	return client, nil
+	return client, nodeVersionResp.Data, nil
 }
 
-func (gc *GoClient) nodeSyncing(ctx context.Context, opts *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error) {
-	return gc.client.NodeSyncing(ctx, opts)
+func (gc *GoClient) nodeSyncing(ctx context.Context, client Client, opts *api.NodeSyncingOpts) (*api.Response[*apiv1.SyncState], error) {
+	return client.NodeSyncing(ctx, opts)
 }
 
 func (gc *GoClient) NodeClient() NodeClient {
@@ -235,48 +313,129 @@ var errSyncing = errors.New("syncing")
 
 // Healthy returns if beacon node is currently healthy: responds to requests, not in the syncing state, not optimistic
 // (for optimistic see https://github.com/ethereum/consensus-specs/blob/dev/sync/optimistic.md#block-production).
+// When several beacon nodes are configured, each of them is checked and ranked separately,
+// and GoClient is healthy as long as at least one of them is healthy.
 func (gc *GoClient) Healthy(ctx context.Context) error {
-	nodeSyncingResp, err := gc.nodeSyncingFn(ctx, &api.NodeSyncingOpts{})
+	nodeErrs := make([]error, len(gc.nodes))
+	var wg sync.WaitGroup
+	for i, node := range gc.nodes {
+		wg.Add(1)
+		go func(i int, node *beaconNode) {
+			defer wg.Done()
+			nodeErrs[i] = gc.checkNode(ctx, node)
+		}(i, node)
+	}
+	wg.Wait()
+
+	var errs error
+	for i, err := range nodeErrs {
+		if err == nil {
+			return nil
+		}
+		if len(gc.nodes) == 1 {
+			return err
+		}
+		errs = errors.Join(errs, fmt.Errorf("%s: %w", redactAddr(gc.nodes[i].addr), err))
+	}
+	return errs
+}
+
+// NodeCheckers returns a health check for each of the configured beacon nodes, named after its position and address.
+// Each check also updates the ranking of its node.
+func (gc *GoClient) NodeCheckers() map[string]NodeChecker {
+	checkers := make(map[string]NodeChecker, len(gc.nodes))
+	for i, node := range gc.nodes {
+		name := fmt.Sprintf("consensus client %d (%s)", i+1, redactAddr(node.addr))
+		checkers[name] = nodeChecker{gc: gc, node: node}
+	}
+	return checkers
+}
+
+// NodeChecker checks the health of a single beacon node.
+type NodeChecker interface {
+	Healthy(ctx context.Context) error
+}
+
+type nodeChecker struct {
+	gc   *GoClient
+	node *beaconNode
+}
+
+func (c nodeChecker) Healthy(ctx context.Context) error {
+	return c.gc.checkNode(ctx, c.node)
+}
+
+var errNotConnected = errors.New("not connected")
+
+// checkNode checks the health of the node and updates its ranking accordingly.
+func (gc *GoClient) checkNode(ctx context.Context, node *beaconNode) error {
+	client := node.getClient()
+	if client == nil {
+		node.healthy.Store(false)
+		return errNotConnected
+	}
+	syncDistance, err := gc.nodeHealthy(ctx, client)
+	node.syncDistance.Store(uint64(syncDistance))
+	if node.healthy.Swap(err == nil) != (err == nil) {
+		gc.resubscribeEvents()
+	}
+	return err
+}
+
+// redactAddr hides the credentials which the address of a beacon node may contain.
+func redactAddr(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return addr
+	}
+	return u.Redacted()
+}
+
+// nodeHealthy checks the health of a single beacon node, returning its sync distance if it responds.
+func (gc *GoClient) nodeHealthy(ctx context.Context, client Client) (phase0.Slot, error) {
+	logger := gc.log.With(fields.Address(client.Address()))
+
+	nodeSyncingResp, err := gc.nodeSyncingFn(ctx, client, &api.NodeSyncingOpts{})
 	if err != nil {
-		gc.log.Error(clResponseErrMsg,
+		logger.Error(clResponseErrMsg,
 			zap.String("api", "NodeSyncing"),
 			zap.Error(err),
 		)
 		// TODO: get rid of global variable, pass metrics to goClient
-		recordBeaconClientStatus(ctx, statusUnknown, gc.client.Address())
-		return fmt.Errorf("failed to obtain node syncing status: %w", err)
+		recordBeaconClientStatus(ctx, statusUnknown, client.Address())
+		return 0, fmt.Errorf("failed to obtain node syncing status: %w", err)
 	}
 	if nodeSyncingResp == nil {
-		gc.log.Error(clNilResponseErrMsg,
+		logger.Error(clNilResponseErrMsg,
 			zap.String("api", "NodeSyncing"),
 		)
-		recordBeaconClientStatus(ctx, statusUnknown, gc.client.Address())
-		return fmt.Errorf("node syncing response is nil")
+		recordBeaconClientStatus(ctx, statusUnknown, client.Address())
+		return 0, fmt.Errorf("node syncing response is nil")
 	}
 	if nodeSyncingResp.Data == nil {
-		gc.log.Error(clNilResponseDataErrMsg,
+		logger.Error(clNilResponseDataErrMsg,
 			zap.String("api", "NodeSyncing"),
 		)
-		recordBeaconClientStatus(ctx, statusUnknown, gc.client.Address())
-		return fmt.Errorf("node syncing data is nil")
+		recordBeaconClientStatus(ctx, statusUnknown, client.Address())
+		return 0, fmt.Errorf("node syncing data is nil")
 	}
 	syncState := nodeSyncingResp.Data
-	recordBeaconClientStatus(ctx, statusSyncing, gc.client.Address())
-	recordSyncDistance(ctx, syncState.SyncDistance, gc.client.Address())
+	recordBeaconClientStatus(ctx, statusSyncing, client.Address())
+	recordSyncDistance(ctx, syncState.SyncDistance, client.Address())
 
 	// TODO: also check if syncState.ElOffline when github.com/attestantio/go-eth2-client supports it
 	if syncState.IsSyncing && syncState.SyncDistance > gc.syncDistanceTolerance {
-		gc.log.Error("Consensus client is not synced")
-		return errSyncing
+		logger.Error("Consensus client is not synced")
+		return syncState.SyncDistance, errSyncing
 	}
 	if syncState.IsOptimistic {
-		gc.log.Error("Consensus client is in optimistic mode")
-		return fmt.Errorf("optimistic")
+		logger.Error("Consensus client is in optimistic mode")
+		return syncState.SyncDistance, fmt.Errorf("optimistic")
 	}
 
-	recordBeaconClientStatus(ctx, statusSynced, gc.client.Address())
+	recordBeaconClientStatus(ctx, statusSynced, client.Address())
 
-	return nil
+	return syncState.SyncDistance, nil
 }
 
 // GetBeaconNetwork returns the beacon network the node is on
@@ -291,16 +450,3 @@ func (gc *GoClient) slotStartTime(slot phase0.Slot) time.Time {
 	startTime := time.Unix(gc.network.MinGenesisTime(), 0).Add(duration)
 	return startTime
 }
-
-func (gc *GoClient) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
-	if err := gc.client.Events(ctx, topics, handler); err != nil {
-		gc.log.Error(clResponseErrMsg,
-			zap.String("api", "Events"),
-			zap.Error(err),
-		)
-
-		return err
-	}
-
-	return nil
-}
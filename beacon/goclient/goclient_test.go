@@ -38,7 +38,7 @@ func TestHealthy(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("sync distance larger than allowed", func(t *testing.T) {
-		client.nodeSyncingFn = func(ctx context.Context, opts *api.NodeSyncingOpts) (*api.Response[*v1.SyncState], error) {
+		client.nodeSyncingFn = func(ctx context.Context, _ Client, opts *api.NodeSyncingOpts) (*api.Response[*v1.SyncState], error) {
 			r := new(api.Response[*v1.SyncState])
 			r.Data = &v1.SyncState{
 				SyncDistance: phase0.Slot(3),
@@ -54,7 +54,7 @@ func TestHealthy(t *testing.T) {
 	})
 
 	t.Run("sync distance within allowed limits", func(t *testing.T) {
-		client.nodeSyncingFn = func(ctx context.Context, opts *api.NodeSyncingOpts) (*api.Response[*v1.SyncState], error) {
+		client.nodeSyncingFn = func(ctx context.Context, _ Client, opts *api.NodeSyncingOpts) (*api.Response[*v1.SyncState], error) {
 			r := new(api.Response[*v1.SyncState])
 			r.Data = &v1.SyncState{
 				SyncDistance: phase0.Slot(3),
@@ -0,0 +1,262 @@
+package goclient
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+)
+
+// BeaconNodeAddrSeparator separates multiple beacon node addresses in beaconprotocol.Options.BeaconNodeAddr.
+// Addresses are listed in priority order: the first healthy node is preferred for requests.
+const BeaconNodeAddrSeparator = ";"
+
+// reconnectInterval is how often GoClient retries to connect to beacon nodes which were unavailable at startup.
+const reconnectInterval = 12 * time.Second
+
+// beaconNode is one of the configured consensus clients.
+type beaconNode struct {
+	addr string
+
+	clientMu sync.RWMutex
+	// client is nil until the node is connected.
+	client Client
+	// healthy is updated on every Healthy check using the sync distance logic,
+	// and is used to rank nodes for requests and submissions.
+	healthy atomic.Bool
+	// syncDistance is the sync distance observed by the last Healthy check, which ranks the healthy nodes.
+	syncDistance atomic.Uint64
+}
+
+// getClient returns the client of the node, or nil if it isn't connected yet.
+func (n *beaconNode) getClient() Client {
+	n.clientMu.RLock()
+	defer n.clientMu.RUnlock()
+
+	return n.client
+}
+
+func (n *beaconNode) setClient(client Client) {
+	n.clientMu.Lock()
+	defer n.clientMu.Unlock()
+
+	n.client = client
+}
+
+// parseBeaconNodeAddrs splits the configured address string into a list of addresses, preserving their order.
+func parseBeaconNodeAddrs(addrs string) []string {
+	var result []string
+	for _, addr := range strings.Split(addrs, BeaconNodeAddrSeparator) {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		result = append(result, addr)
+	}
+	return result
+}
+
+// rankedNodes returns the connected nodes, healthy ones first and then unhealthy ones.
+// Healthy nodes are ordered by their last observed sync distance, and nodes as far behind as each other,
+// as well as unhealthy nodes, by the configured priority. The first node is the active one.
+func (gc *GoClient) rankedNodes() []*beaconNode {
+	healthy := make([]*beaconNode, 0, len(gc.nodes))
+	var unhealthy []*beaconNode
+	for _, node := range gc.nodes {
+		if node.getClient() == nil {
+			continue
+		}
+		if node.healthy.Load() {
+			healthy = append(healthy, node)
+		} else {
+			unhealthy = append(unhealthy, node)
+		}
+	}
+	slices.SortStableFunc(healthy, func(a, b *beaconNode) int {
+		return cmp.Compare(a.syncDistance.Load(), b.syncDistance.Load())
+	})
+	return append(healthy, unhealthy...)
+}
+
+// rankedClients returns the clients of the connected nodes in the order of rankedNodes.
+func (gc *GoClient) rankedClients() []Client {
+	nodes := gc.rankedNodes()
+	clients := make([]Client, 0, len(nodes))
+	for _, node := range nodes {
+		if client := node.getClient(); client != nil {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// healthyClients returns the clients of all healthy nodes.
+// If no node is considered healthy, the clients of all connected nodes are returned as a best effort.
+func (gc *GoClient) healthyClients() []Client {
+	clients := make([]Client, 0, len(gc.nodes))
+	for _, node := range gc.nodes {
+		if client := node.getClient(); client != nil && node.healthy.Load() {
+			clients = append(clients, client)
+		}
+	}
+	if len(clients) == 0 {
+		for _, node := range gc.nodes {
+			if client := node.getClient(); client != nil {
+				clients = append(clients, client)
+			}
+		}
+	}
+	return clients
+}
+
+// reconnectLoop retries to connect to the node until it succeeds or the context is done.
+// The node stays unhealthy, and thus ranked last, until it's connected and passes a health check.
+func (gc *GoClient) reconnectLoop(ctx context.Context, node *beaconNode) {
+	ticker := time.NewTicker(reconnectInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if gc.reconnect(ctx, node) == nil {
+			return
+		}
+	}
+}
+
+// reconnect makes a single attempt to connect to a node which isn't connected yet.
+func (gc *GoClient) reconnect(ctx context.Context, node *beaconNode) error {
+	client, version, err := gc.connect(ctx, node.addr)
+	if err != nil {
+		gc.log.Debug("consensus client is still unavailable", fields.Address(redactAddr(node.addr)), zap.Error(err))
+		return err
+	}
+	if err := gc.checkConsistency(ctx, node.addr, client, version); err != nil {
+		gc.log.Error("consensus client is inconsistent with the others", fields.Address(redactAddr(node.addr)), zap.Error(err))
+		return err
+	}
+	node.setClient(client)
+	gc.log.Info("reconnected to consensus client, it's used once it passes a health check", fields.Address(redactAddr(node.addr)))
+	return nil
+}
+
+// withFailover calls fn with each node's client in the ranked order until one of them succeeds.
+// The errors of all failed attempts are joined together if none succeeds.
+func withFailover[T any](gc *GoClient, ctx context.Context, fn func(client Client) (T, error)) (T, error) {
+	var errs error
+	for _, client := range gc.rankedClients() {
+		result, err := fn(client)
+		if err == nil {
+			return result, nil
+		}
+		if len(gc.nodes) == 1 {
+			return result, err
+		}
+		errs = errors.Join(errs, fmt.Errorf("%s: %w", client.Address(), err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	var zero T
+	return zero, errs
+}
+
+// broadcast calls fn with the clients of all healthy nodes in parallel, so that a submission
+// reaches the network even if some of the nodes fail to propagate it.
+// It succeeds if at least one of the nodes accepts the submission.
+func (gc *GoClient) broadcast(fn func(client Client) error) error {
+	clients := gc.healthyClients()
+	if len(clients) == 1 {
+		return fn(clients[0])
+	}
+
+	var (
+		wg        sync.WaitGroup
+		errsMu    sync.Mutex
+		errs      error
+		succeeded atomic.Bool
+	)
+	for _, client := range clients {
+		wg.Add(1)
+		go func(client Client) {
+			defer wg.Done()
+			if err := fn(client); err != nil {
+				errsMu.Lock()
+				errs = errors.Join(errs, fmt.Errorf("%s: %w", client.Address(), err))
+				errsMu.Unlock()
+				return
+			}
+			succeeded.Store(true)
+		}(client)
+	}
+	wg.Wait()
+
+	if succeeded.Load() {
+		return nil
+	}
+	return errs
+}
+
+// errSpecMismatch is returned when a beacon node reports a spec different from the other nodes,
+// which means it's configured for another network and mustn't be used.
+var errSpecMismatch = errors.New("spec mismatch")
+
+// checkConsistency checks that the node reports the same spec as the first connected node,
+// and warns if it runs a different client version, as its responses may differ in details.
+// The first node checked becomes the reference for the others.
+func (gc *GoClient) checkConsistency(ctx context.Context, addr string, client Client, version string) error {
+	specResp, err := client.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to get spec: %w", err)
+	}
+	if specResp == nil || specResp.Data == nil {
+		return fmt.Errorf("spec response data is nil")
+	}
+
+	gc.referenceMu.Lock()
+	defer gc.referenceMu.Unlock()
+
+	if gc.referenceSpec == nil {
+		gc.referenceAddr = addr
+		gc.referenceVersion = version
+		gc.referenceSpec = specResp.Data
+		return nil
+	}
+
+	// Clients of different implementations report different sets of keys, so only the common ones are compared.
+	var mismatched []string
+	for key, value := range specResp.Data {
+		if referenceValue, ok := gc.referenceSpec[key]; ok && !reflect.DeepEqual(value, referenceValue) {
+			mismatched = append(mismatched, key)
+		}
+	}
+	if len(mismatched) > 0 {
+		slices.Sort(mismatched)
+		return fmt.Errorf("%w with %s: %s", errSpecMismatch, redactAddr(gc.referenceAddr), strings.Join(mismatched, ", "))
+	}
+
+	if version != gc.referenceVersion {
+		gc.log.Warn("consensus clients run different versions",
+			fields.Address(redactAddr(addr)),
+			zap.String("version", version),
+			zap.String("reference_address", redactAddr(gc.referenceAddr)),
+			zap.String("reference_version", gc.referenceVersion),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package goclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/slotticker"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+type mockBeaconNode struct {
+	server            *httptest.Server
+	syncDistance      atomic.Value
+	failData          atomic.Bool
+	dataRequests      atomic.Int64
+	submittedRequests atomic.Int64
+	secondsPerSlot    atomic.Value
+	eventStreams      atomic.Int64
+}
+
+func newMockBeaconNode(t *testing.T) *mockBeaconNode {
+	node := &mockBeaconNode{}
+	node.syncDistance.Store("0")
+	node.secondsPerSlot.Store("12")
+
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.URL.Path {
+		case "/eth/v1/node/version":
+			resp = `{"data": {"version": "Lighthouse/v4.5.0-441fc16/x86_64-linux"}}`
+		case "/eth/v1/node/syncing":
+			syncDistance := node.syncDistance.Load().(string)
+			isSyncing := syncDistance != "0"
+			resp = `{"data": {"head_slot": "4239945", "sync_distance": "` + syncDistance + `", "is_syncing": ` +
+				map[bool]string{true: "true", false: "false"}[isSyncing] + `, "is_optimistic": false, "el_offline": false}}`
+		case "/eth/v1/config/spec":
+			resp = `{"data": {"CONFIG_NAME": "mainnet", "SECONDS_PER_SLOT": "` + node.secondsPerSlot.Load().(string) + `", "SLOTS_PER_EPOCH": "32"}}`
+		case "/eth/v1/events":
+			// The stream is kept open without events until the subscriber disconnects.
+			node.eventStreams.Add(1)
+			defer node.eventStreams.Add(-1)
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		case "/eth/v1/validator/attestation_data":
+			node.dataRequests.Add(1)
+			if node.failData.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			root := "0x1662a3d288b0338436d74083b4ce68908a0ece0661aa236acd95c8a4c3f6e8fc"
+			resp = `{"data": {"slot": "` + r.URL.Query().Get("slot") + `", "index": "0", "beacon_block_root": "` + root + `",
+				"source": {"epoch": "1", "root": "` + root + `"}, "target": {"epoch": "2", "root": "` + root + `"}}}`
+		case "/eth/v1/beacon/pool/attestations":
+			node.submittedRequests.Add(1)
+			w.WriteHeader(http.StatusOK)
+			return
+		default:
+			require.FailNowf(t, "unexpected request", "unexpected request: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(resp)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(node.server.Close)
+
+	return node
+}
+
+func newMultiClient(t *testing.T, nodes ...*mockBeaconNode) *GoClient {
+	addrs := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		addrs = append(addrs, node.server.URL)
+	}
+
+	client, err := New(
+		zap.NewNop(),
+		beacon.Options{
+			Context:               context.Background(),
+			Network:               beacon.NewNetwork(types.MainNetwork),
+			BeaconNodeAddr:        strings.Join(addrs, BeaconNodeAddrSeparator),
+			SyncDistanceTolerance: 2,
+			CommonTimeout:         100 * time.Millisecond,
+			LongTimeout:           500 * time.Millisecond,
+		},
+		operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+		func() slotticker.SlotTicker {
+			return slotticker.New(zap.NewNop(), slotticker.Config{
+				SlotDuration: 12 * time.Second,
+				GenesisTime:  time.Now(),
+			})
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, client.nodes, len(nodes))
+
+	return client
+}
+
+func TestParseBeaconNodeAddrs(t *testing.T) {
+	require.Equal(t, []string{"http://a:5052"}, parseBeaconNodeAddrs("http://a:5052"))
+	require.Equal(t, []string{"http://a:5052", "http://b:5052"}, parseBeaconNodeAddrs(" http://a:5052 ; http://b:5052;"))
+	require.Empty(t, parseBeaconNodeAddrs(" ; "))
+}
+
+func TestGoClient_Failover(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("request fails over to the next node", func(t *testing.T) {
+		node1, node2 := newMockBeaconNode(t), newMockBeaconNode(t)
+		client := newMultiClient(t, node1, node2)
+
+		node1.failData.Store(true)
+
+		data, _, err := client.GetAttestationData(100, 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, data.Slot)
+		require.EqualValues(t, 1, node1.dataRequests.Load())
+		require.EqualValues(t, 1, node2.dataRequests.Load())
+	})
+
+	t.Run("request fails if all nodes fail", func(t *testing.T) {
+		node1, node2 := newMockBeaconNode(t), newMockBeaconNode(t)
+		client := newMultiClient(t, node1, node2)
+
+		node1.failData.Store(true)
+		node2.failData.Store(true)
+
+		_, _, err := client.GetAttestationData(100, 1)
+		require.ErrorContains(t, err, node1.server.URL)
+		require.ErrorContains(t, err, node2.server.URL)
+	})
+
+	t.Run("unhealthy nodes are ranked last", func(t *testing.T) {
+		node1, node2 := newMockBeaconNode(t), newMockBeaconNode(t)
+		client := newMultiClient(t, node1, node2)
+
+		node1.syncDistance.Store("10")
+		require.NoError(t, client.Healthy(ctx))
+		require.Equal(t, node2.server.URL, client.rankedClients()[0].Address())
+
+		data, _, err := client.GetAttestationData(100, 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, data.Slot)
+		require.Zero(t, node1.dataRequests.Load())
+		require.EqualValues(t, 1, node2.dataRequests.Load())
+
+		node2.syncDistance.Store("10")
+		require.ErrorIs(t, client.Healthy(ctx), errSyncing)
+
+		node1.syncDistance.Store("0")
+		require.NoError(t, client.Healthy(ctx))
+		require.Equal(t, node1.server.URL, client.rankedClients()[0].Address())
+	})
+
+	t.Run("healthy nodes are ranked by sync distance", func(t *testing.T) {
+		node1, node2, node3 := newMockBeaconNode(t), newMockBeaconNode(t), newMockBeaconNode(t)
+		client := newMultiClient(t, node1, node2, node3)
+
+		// All nodes are within the tolerance of 2 slots, and nodes as far behind keep their configured order.
+		node1.syncDistance.Store("2")
+		node2.syncDistance.Store("1")
+		node3.syncDistance.Store("1")
+		require.NoError(t, client.Healthy(ctx))
+
+		var ranked []string
+		for _, c := range client.rankedClients() {
+			ranked = append(ranked, c.Address())
+		}
+		require.Equal(t, []string{node2.server.URL, node3.server.URL, node1.server.URL}, ranked)
+	})
+
+	t.Run("submissions are broadcast to all healthy nodes", func(t *testing.T) {
+		node1, node2, node3 := newMockBeaconNode(t), newMockBeaconNode(t), newMockBeaconNode(t)
+		client := newMultiClient(t, node1, node2, node3)
+
+		node3.syncDistance.Store("10")
+		require.NoError(t, client.Healthy(ctx))
+
+		require.NoError(t, client.SubmitAttestations([]*phase0.Attestation{}))
+		require.EqualValues(t, 1, node1.submittedRequests.Load())
+		require.EqualValues(t, 1, node2.submittedRequests.Load())
+		require.Zero(t, node3.submittedRequests.Load())
+	})
+
+	t.Run("event subscription follows the active node", func(t *testing.T) {
+		node1, node2 := newMockBeaconNode(t), newMockBeaconNode(t)
+		client := newMultiClient(t, node1, node2)
+
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		require.NoError(t, client.Events(subCtx, []string{"head"}, func(*apiv1.Event) {}))
+		require.Eventually(t, func() bool {
+			return node1.eventStreams.Load() == 1 && node2.eventStreams.Load() == 0
+		}, 5*time.Second, 50*time.Millisecond)
+
+		node1.syncDistance.Store("10")
+		require.NoError(t, client.Healthy(ctx))
+		require.Eventually(t, func() bool {
+			return node1.eventStreams.Load() == 0 && node2.eventStreams.Load() == 1
+		}, 5*time.Second, 50*time.Millisecond)
+
+		node1.syncDistance.Store("0")
+		require.NoError(t, client.Healthy(ctx))
+		require.Eventually(t, func() bool {
+			return node1.eventStreams.Load() == 1 && node2.eventStreams.Load() == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("nodes with a different spec are rejected", func(t *testing.T) {
+		node1, node2 := newMockBeaconNode(t), newMockBeaconNode(t)
+		node2.secondsPerSlot.Store("6")
+
+		_, err := New(
+			zap.NewNop(),
+			beacon.Options{
+				Context:        ctx,
+				Network:        beacon.NewNetwork(types.MainNetwork),
+				BeaconNodeAddr: node1.server.URL + BeaconNodeAddrSeparator + node2.server.URL,
+				CommonTimeout:  100 * time.Millisecond,
+				LongTimeout:    500 * time.Millisecond,
+			},
+			operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+			func() slotticker.SlotTicker {
+				return slotticker.New(zap.NewNop(), slotticker.Config{
+					SlotDuration: 12 * time.Second,
+					GenesisTime:  time.Now(),
+				})
+			},
+		)
+		require.ErrorIs(t, err, errSpecMismatch)
+		require.ErrorContains(t, err, "SECONDS_PER_SLOT")
+	})
+
+	t.Run("unavailable nodes are kept and reconnected", func(t *testing.T) {
+		node := newMockBeaconNode(t)
+		// The listener of an unstarted server accepts no requests, so connecting to it times out.
+		down := newMockBeaconNode(t)
+		down.server.Close()
+		down.server = httptest.NewUnstartedServer(down.server.Config.Handler)
+		t.Cleanup(down.server.Close)
+		downURL := "http://" + down.server.Listener.Addr().String()
+
+		client, err := New(
+			zap.NewNop(),
+			beacon.Options{
+				Context:        ctx,
+				Network:        beacon.NewNetwork(types.MainNetwork),
+				BeaconNodeAddr: downURL + BeaconNodeAddrSeparator + node.server.URL,
+				CommonTimeout:  100 * time.Millisecond,
+				LongTimeout:    500 * time.Millisecond,
+			},
+			operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+			func() slotticker.SlotTicker {
+				return slotticker.New(zap.NewNop(), slotticker.Config{
+					SlotDuration: 12 * time.Second,
+					GenesisTime:  time.Now(),
+				})
+			},
+		)
+		require.NoError(t, err)
+		require.Len(t, client.nodes, 2)
+		require.Nil(t, client.nodes[0].getClient())
+		require.False(t, client.nodes[0].healthy.Load())
+		require.Len(t, client.rankedClients(), 1)
+
+		checkers := client.NodeCheckers()
+		require.Len(t, checkers, 2)
+		require.ErrorIs(t, checkers["consensus client 1 ("+downURL+")"].Healthy(ctx), errNotConnected)
+		require.NoError(t, checkers["consensus client 2 ("+node.server.URL+")"].Healthy(ctx))
+		require.NoError(t, client.Healthy(ctx))
+
+		down.server.Start()
+		require.NoError(t, client.reconnect(ctx, client.nodes[0]))
+		require.Equal(t, downURL, client.nodes[0].getClient().Address())
+		require.False(t, client.nodes[0].healthy.Load(), "reconnected node must pass a health check first")
+
+		require.NoError(t, client.Healthy(ctx))
+		require.True(t, client.nodes[0].healthy.Load())
+		require.Len(t, client.rankedClients(), 2)
+	})
+}
@@ -28,12 +28,15 @@ const (
 
 // ProposerDuties returns proposer duties for the given epoch.
 func (gc *GoClient) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.ProposerDuty, error) {
-	start := time.Now()
-	resp, err := gc.client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	resp, err := withFailover(gc, ctx, func(client Client) (*api.Response[[]*eth2apiv1.ProposerDuty], error) {
+		start := time.Now()
+		resp, err := client.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		recordRequestDuration(gc.ctx, "ProposerDuties", client.Address(), http.MethodGet, time.Since(start), err)
+		return resp, err
 	})
-	recordRequestDuration(gc.ctx, "ProposerDuties", gc.client.Address(), http.MethodGet, time.Since(start), err)
 
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
@@ -60,14 +63,17 @@ func (gc *GoClient) GetBeaconBlock(slot phase0.Slot, graffitiBytes, randao []byt
 	graffiti := [32]byte{}
 	copy(graffiti[:], graffitiBytes[:])
 
-	reqStart := time.Now()
-	proposalResp, err := gc.client.Proposal(gc.ctx, &api.ProposalOpts{
-		Slot:                   slot,
-		RandaoReveal:           sig,
-		Graffiti:               graffiti,
-		SkipRandaoVerification: false,
+	proposalResp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*api.VersionedProposal], error) {
+		reqStart := time.Now()
+		proposalResp, err := client.Proposal(gc.ctx, &api.ProposalOpts{
+			Slot:                   slot,
+			RandaoReveal:           sig,
+			Graffiti:               graffiti,
+			SkipRandaoVerification: false,
+		})
+		recordRequestDuration(gc.ctx, "Proposal", client.Address(), http.MethodGet, time.Since(reqStart), err)
+		return proposalResp, err
 	})
-	recordRequestDuration(gc.ctx, "Proposal", gc.client.Address(), http.MethodGet, time.Since(reqStart), err)
 
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
@@ -187,9 +193,12 @@ func (gc *GoClient) SubmitBlindedBeaconBlock(block *api.VersionedBlindedProposal
 		Proposal: signedBlock,
 	}
 
-	start := time.Now()
-	err := gc.client.SubmitBlindedProposal(gc.ctx, opts)
-	recordRequestDuration(gc.ctx, "SubmitBlindedProposal", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitBlindedProposal(gc.ctx, opts)
+		recordRequestDuration(gc.ctx, "SubmitBlindedProposal", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitBlindedProposal"),
@@ -243,9 +252,12 @@ func (gc *GoClient) SubmitBeaconBlock(block *api.VersionedProposal, sig phase0.B
 		Proposal: signedBlock,
 	}
 
-	start := time.Now()
-	err := gc.client.SubmitProposal(gc.ctx, opts)
-	recordRequestDuration(gc.ctx, "SubmitProposal", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitProposal(gc.ctx, opts)
+		recordRequestDuration(gc.ctx, "SubmitProposal", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitProposal"),
@@ -267,9 +279,12 @@ func (gc *GoClient) SubmitProposalPreparation(feeRecipients map[phase0.Validator
 			FeeRecipient:   recipient,
 		})
 	}
-	start := time.Now()
-	err := gc.client.SubmitProposalPreparations(gc.ctx, preparations)
-	recordRequestDuration(gc.ctx, "SubmitProposalPreparations", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitProposalPreparations(gc.ctx, preparations)
+		recordRequestDuration(gc.ctx, "SubmitProposalPreparations", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitProposalPreparations"),
@@ -382,10 +397,12 @@ func (gc *GoClient) submitBatchedRegistrations(slot phase0.Slot, registrations [
 			bs = len(registrations)
 		}
 
-		start := time.Now()
-		err := gc.client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs])
-
-		recordRequestDuration(gc.ctx, "SubmitValidatorRegistrations", gc.client.Address(), http.MethodPost, time.Since(start), err)
+		err := gc.broadcast(func(client Client) error {
+			start := time.Now()
+			err := client.SubmitValidatorRegistrations(gc.ctx, registrations[0:bs])
+			recordRequestDuration(gc.ctx, "SubmitValidatorRegistrations", client.Address(), http.MethodPost, time.Since(start), err)
+			return err
+		})
 		if err != nil {
 			gc.log.Error(clResponseErrMsg,
 				zap.String("api", "SubmitValidatorRegistrations"),
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/api"
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
@@ -18,9 +19,12 @@ import (
 )
 
 func (gc *GoClient) computeVoluntaryExitDomain(ctx context.Context) (phase0.Domain, error) {
-	start := time.Now()
-	specResponse, err := gc.client.Spec(gc.ctx, &api.SpecOpts{})
-	recordRequestDuration(gc.ctx, "Spec", gc.client.Address(), http.MethodGet, time.Since(start), err)
+	specResponse, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[map[string]any], error) {
+		start := time.Now()
+		specResponse, err := client.Spec(gc.ctx, &api.SpecOpts{})
+		recordRequestDuration(gc.ctx, "Spec", client.Address(), http.MethodGet, time.Since(start), err)
+		return specResponse, err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "Spec"),
@@ -57,9 +61,12 @@ func (gc *GoClient) computeVoluntaryExitDomain(ctx context.Context) (phase0.Doma
 		CurrentVersion: forkVersion,
 	}
 
-	start = time.Now()
-	genesisResponse, err := gc.client.Genesis(ctx, &api.GenesisOpts{})
-	recordRequestDuration(gc.ctx, "Genesis", gc.client.Address(), http.MethodGet, time.Since(start), err)
+	genesisResponse, err := withFailover(gc, ctx, func(client Client) (*api.Response[*eth2apiv1.Genesis], error) {
+		start := time.Now()
+		genesisResponse, err := client.Genesis(ctx, &api.GenesisOpts{})
+		recordRequestDuration(gc.ctx, "Genesis", client.Address(), http.MethodGet, time.Since(start), err)
+		return genesisResponse, err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "Genesis"),
@@ -112,9 +119,12 @@ func (gc *GoClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (ph
 		return gc.computeVoluntaryExitDomain(gc.ctx)
 	}
 
-	start := time.Now()
-	data, err := gc.client.Domain(gc.ctx, domain, epoch)
-	recordRequestDuration(gc.ctx, "Domain", gc.client.Address(), http.MethodGet, time.Since(start), err)
+	data, err := withFailover(gc, gc.ctx, func(client Client) (phase0.Domain, error) {
+		start := time.Now()
+		data, err := client.Domain(gc.ctx, domain, epoch)
+		recordRequestDuration(gc.ctx, "Domain", client.Address(), http.MethodGet, time.Since(start), err)
+		return data, err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "Domain"),
@@ -246,3 +256,66 @@ func ToBytes4(x []byte) [4]byte {
 	copy(y[:], x)
 	return y
 }
+
+// ForkInfo returns the fork active at the given epoch and the genesis validators root of the chain,
+// which remote signers require to compute signing domains.
+func (gc *GoClient) ForkInfo(epoch phase0.Epoch) (*phase0.Fork, phase0.Root, error) {
+	gc.forkInfoMu.Lock()
+	defer gc.forkInfoMu.Unlock()
+
+	if gc.forkSchedule == nil {
+		resp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[[]*phase0.Fork], error) {
+			start := time.Now()
+			resp, err := client.ForkSchedule(gc.ctx, &api.ForkScheduleOpts{})
+			recordRequestDuration(gc.ctx, "ForkSchedule", client.Address(), http.MethodGet, time.Since(start), err)
+			return resp, err
+		})
+		if err != nil {
+			gc.log.Error(clResponseErrMsg,
+				zap.String("api", "ForkSchedule"),
+				zap.Error(err),
+			)
+			return nil, phase0.Root{}, fmt.Errorf("failed to obtain fork schedule: %w", err)
+		}
+		if resp == nil || len(resp.Data) == 0 {
+			gc.log.Error(clNilResponseDataErrMsg,
+				zap.String("api", "ForkSchedule"),
+			)
+			return nil, phase0.Root{}, fmt.Errorf("fork schedule response data is empty")
+		}
+		gc.forkSchedule = resp.Data
+	}
+
+	if gc.genesisValidatorsRoot == nil {
+		resp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*eth2apiv1.Genesis], error) {
+			start := time.Now()
+			resp, err := client.Genesis(gc.ctx, &api.GenesisOpts{})
+			recordRequestDuration(gc.ctx, "Genesis", client.Address(), http.MethodGet, time.Since(start), err)
+			return resp, err
+		})
+		if err != nil {
+			gc.log.Error(clResponseErrMsg,
+				zap.String("api", "Genesis"),
+				zap.Error(err),
+			)
+			return nil, phase0.Root{}, fmt.Errorf("failed to obtain genesis response: %w", err)
+		}
+		if resp == nil || resp.Data == nil {
+			gc.log.Error(clNilResponseDataErrMsg,
+				zap.String("api", "Genesis"),
+			)
+			return nil, phase0.Root{}, fmt.Errorf("genesis response data is nil")
+		}
+		gc.genesisValidatorsRoot = &resp.Data.GenesisValidatorsRoot
+	}
+
+	// The fork schedule is sorted by epoch, so the last fork which started at or before the epoch is the active one.
+	fork := gc.forkSchedule[0]
+	for _, f := range gc.forkSchedule {
+		if f.Epoch <= epoch {
+			fork = f
+		}
+	}
+
+	return fork, *gc.genesisValidatorsRoot, nil
+}
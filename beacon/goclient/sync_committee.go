@@ -17,12 +17,15 @@ import (
 
 // SyncCommitteeDuties returns sync committee duties for a given epoch
 func (gc *GoClient) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*eth2apiv1.SyncCommitteeDuty, error) {
-	reqStart := time.Now()
-	resp, err := gc.client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
-		Epoch:   epoch,
-		Indices: validatorIndices,
+	resp, err := withFailover(gc, ctx, func(client Client) (*api.Response[[]*eth2apiv1.SyncCommitteeDuty], error) {
+		reqStart := time.Now()
+		resp, err := client.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   epoch,
+			Indices: validatorIndices,
+		})
+		recordRequestDuration(gc.ctx, "SyncCommitteeDuties", client.Address(), http.MethodPost, time.Since(reqStart), err)
+		return resp, err
 	})
-	recordRequestDuration(gc.ctx, "SyncCommitteeDuties", gc.client.Address(), http.MethodPost, time.Since(reqStart), err)
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SyncCommitteeDuties"),
@@ -42,11 +45,14 @@ func (gc *GoClient) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch,
 
 // GetSyncMessageBlockRoot returns beacon block root for sync committee
 func (gc *GoClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec.DataVersion, error) {
-	reqStart := time.Now()
-	resp, err := gc.client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
-		Block: "head",
+	resp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*phase0.Root], error) {
+		reqStart := time.Now()
+		resp, err := client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
+			Block: "head",
+		})
+		recordRequestDuration(gc.ctx, "BeaconBlockRoot", client.Address(), http.MethodGet, time.Since(reqStart), err)
+		return resp, err
 	})
-	recordRequestDuration(gc.ctx, "BeaconBlockRoot", gc.client.Address(), http.MethodGet, time.Since(reqStart), err)
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "BeaconBlockRoot"),
@@ -73,9 +79,12 @@ func (gc *GoClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, spec
 
 // SubmitSyncMessages submits a signed sync committee msg
 func (gc *GoClient) SubmitSyncMessages(msgs []*altair.SyncCommitteeMessage) error {
-	reqStart := time.Now()
-	err := gc.client.SubmitSyncCommitteeMessages(gc.ctx, msgs)
-	recordRequestDuration(gc.ctx, "SubmitSyncCommitteeMessages", gc.client.Address(), http.MethodPost, time.Since(reqStart), err)
+	err := gc.broadcast(func(client Client) error {
+		reqStart := time.Now()
+		err := client.SubmitSyncCommitteeMessages(gc.ctx, msgs)
+		recordRequestDuration(gc.ctx, "SubmitSyncCommitteeMessages", client.Address(), http.MethodPost, time.Since(reqStart), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitSyncCommitteeMessages"),
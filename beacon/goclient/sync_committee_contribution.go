@@ -44,11 +44,14 @@ func (gc *GoClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 
 	gc.waitForOneThirdSlotDuration(slot)
 
-	scDataReqStart := time.Now()
-	beaconBlockRootResp, err := gc.client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
-		Block: fmt.Sprint(slot),
+	beaconBlockRootResp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*phase0.Root], error) {
+		scDataReqStart := time.Now()
+		beaconBlockRootResp, err := client.BeaconBlockRoot(gc.ctx, &api.BeaconBlockRootOpts{
+			Block: fmt.Sprint(slot),
+		})
+		recordRequestDuration(gc.ctx, "BeaconBlockRoot", client.Address(), http.MethodGet, time.Since(scDataReqStart), err)
+		return beaconBlockRootResp, err
 	})
-	recordRequestDuration(gc.ctx, "BeaconBlockRoot", gc.client.Address(), http.MethodGet, time.Since(scDataReqStart), err)
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "BeaconBlockRoot"),
@@ -81,13 +84,16 @@ func (gc *GoClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 	for i := range subnetIDs {
 		index := i
 		g.Go(func() error {
-			start := time.Now()
-			syncCommitteeContrResp, err := gc.client.SyncCommitteeContribution(gc.ctx, &api.SyncCommitteeContributionOpts{
-				Slot:              slot,
-				SubcommitteeIndex: subnetIDs[index],
-				BeaconBlockRoot:   *blockRoot,
+			syncCommitteeContrResp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[*altair.SyncCommitteeContribution], error) {
+				start := time.Now()
+				syncCommitteeContrResp, err := client.SyncCommitteeContribution(gc.ctx, &api.SyncCommitteeContributionOpts{
+					Slot:              slot,
+					SubcommitteeIndex: subnetIDs[index],
+					BeaconBlockRoot:   *blockRoot,
+				})
+				recordRequestDuration(gc.ctx, "SyncCommitteeContribution", client.Address(), http.MethodGet, time.Since(start), err)
+				return syncCommitteeContrResp, err
 			})
-			recordRequestDuration(gc.ctx, "SyncCommitteeContribution", gc.client.Address(), http.MethodGet, time.Since(start), err)
 			if err != nil {
 				gc.log.Error(clResponseErrMsg,
 					zap.String("api", "SyncCommitteeContribution"),
@@ -125,9 +131,12 @@ func (gc *GoClient) GetSyncCommitteeContribution(slot phase0.Slot, selectionProo
 
 // SubmitSignedContributionAndProof broadcasts to the network
 func (gc *GoClient) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
-	start := time.Now()
-	err := gc.client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
-	recordRequestDuration(gc.ctx, "SubmitSyncCommitteeContributions", gc.client.Address(), http.MethodPost, time.Since(start), err)
+	err := gc.broadcast(func(client Client) error {
+		start := time.Now()
+		err := client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
+		recordRequestDuration(gc.ctx, "SubmitSyncCommitteeContributions", client.Address(), http.MethodPost, time.Since(start), err)
+		return err
+	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitSyncCommitteeContributions"),
@@ -11,10 +11,12 @@ import (
 
 // GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 func (gc *GoClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error) {
-	resp, err := gc.client.Validators(gc.ctx, &api.ValidatorsOpts{
-		State:   "head", // TODO maybe need to get the chainId (head) as var
-		PubKeys: validatorPubKeys,
-		Common:  api.CommonOpts{Timeout: gc.longTimeout},
+	resp, err := withFailover(gc, gc.ctx, func(client Client) (*api.Response[map[phase0.ValidatorIndex]*eth2apiv1.Validator], error) {
+		return client.Validators(gc.ctx, &api.ValidatorsOpts{
+			State:   "head", // TODO maybe need to get the chainId (head) as var
+			PubKeys: validatorPubKeys,
+			Common:  api.CommonOpts{Timeout: gc.longTimeout},
+		})
 	})
 	if err != nil {
 		gc.log.Error(clResponseErrMsg,
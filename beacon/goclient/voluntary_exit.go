@@ -6,7 +6,10 @@ import (
 )
 
 func (gc *GoClient) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
-	if err := gc.client.SubmitVoluntaryExit(gc.ctx, voluntaryExit); err != nil {
+	err := gc.broadcast(func(client Client) error {
+		return client.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
+	})
+	if err != nil {
 		gc.log.Error(clResponseErrMsg,
 			zap.String("api", "SubmitVoluntaryExit"),
 			zap.Error(err),
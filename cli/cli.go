@@ -33,4 +33,6 @@ func init() {
 	RootCmd.AddCommand(bootnode.StartBootNodeCmd)
 	RootCmd.AddCommand(operator.StartNodeCmd)
 	RootCmd.AddCommand(operator.GenerateDocCmd)
+	RootCmd.AddCommand(operator.SlashingProtectionCmd)
+	RootCmd.AddCommand(operator.DBCmd)
 }
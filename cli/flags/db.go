@@ -0,0 +1,118 @@
+package flags
+
+import (
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	backupFileFlag   = "file"
+	targetEngineFlag = "target-engine"
+	targetPathFlag   = "target-path"
+
+	inspectOutputFlag = "output"
+	ownersFlag        = "owners"
+	operatorsFlag     = "operators"
+	clustersFlag      = "clusters"
+	subclustersFlag   = "subclusters"
+	pubKeysFlag       = "pubkeys"
+	indicesFlag       = "indices"
+	fromSlotFlag      = "from-slot"
+	toSlotFlag        = "to-slot"
+)
+
+// AddBackupFileFlag adds the database backup file flag to the command
+func AddBackupFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupFileFlag, "", "Path to the database backup file", true)
+}
+
+// GetBackupFileFlagValue gets the database backup file flag from the command
+func GetBackupFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupFileFlag)
+}
+
+// AddTargetEngineFlag adds the target database engine flag to the command
+func AddTargetEngineFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, targetEngineFlag, "pebble", "Storage engine to migrate the database to, either badger or pebble", false)
+}
+
+// GetTargetEngineFlagValue gets the target database engine flag from the command
+func GetTargetEngineFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(targetEngineFlag)
+}
+
+// AddTargetPathFlag adds the target database path flag to the command
+func AddTargetPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, targetPathFlag, "", "Path of the new database to migrate into, which must not exist yet", true)
+}
+
+// GetTargetPathFlagValue gets the target database path flag from the command
+func GetTargetPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(targetPathFlag)
+}
+
+// AddInspectOutputFlag adds the database inspection output format flag to the command
+func AddInspectOutputFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, inspectOutputFlag, "text", "Output format, either text or json", false)
+}
+
+// GetInspectOutputFlagValue gets the database inspection output format flag from the command
+func GetInspectOutputFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(inspectOutputFlag)
+}
+
+// AddSharesFilterFlags adds the flags filtering shares like the validators API to the command
+func AddSharesFilterFlags(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, ownersFlag, "", "Comma-separated owner addresses of the shares to list", false)
+	cliflag.AddPersistentStringFlag(c, operatorsFlag, "", "Comma-separated operator IDs of the shares to list", false)
+	cliflag.AddPersistentStringFlag(c, clustersFlag, "", "Space-separated clusters, each of comma-separated operator IDs, of the shares to list", false)
+	cliflag.AddPersistentStringFlag(c, subclustersFlag, "", "Space-separated subsets of clusters, each of comma-separated operator IDs, of the shares to list", false)
+	cliflag.AddPersistentStringFlag(c, pubKeysFlag, "", "Comma-separated public keys of the shares to list", false)
+	cliflag.AddPersistentStringFlag(c, indicesFlag, "", "Comma-separated validator indices of the shares to list", false)
+}
+
+// GetSharesFilterFlagValues gets the flags filtering shares from the command, named like the validators API parameters
+func GetSharesFilterFlagValues(c *cobra.Command) (url.Values, error) {
+	values := make(url.Values)
+	for _, flag := range []string{ownersFlag, operatorsFlag, clustersFlag, subclustersFlag, pubKeysFlag, indicesFlag} {
+		value, err := c.Flags().GetString(flag)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			values.Set(flag, value)
+		}
+	}
+	return values, nil
+}
+
+// AddJournalPubKeysFlag adds the flag of the validators whose journaled duties to list to the command
+func AddJournalPubKeysFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, pubKeysFlag, "", "Comma-separated public keys of the validators whose duties to list", true)
+}
+
+// GetJournalPubKeysFlagValue gets the flag of the validators whose journaled duties to list from the command
+func GetJournalPubKeysFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(pubKeysFlag)
+}
+
+// AddSlotRangeFlags adds the slot range flags to the command
+func AddSlotRangeFlags(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, fromSlotFlag, 0, "First slot of the records to list", false)
+	cliflag.AddPersistentIntFlag(c, toSlotFlag, 0, "Last slot of the records to list, 0 for no limit", false)
+}
+
+// GetSlotRangeFlagValues gets the slot range flags from the command
+func GetSlotRangeFlagValues(c *cobra.Command) (from, to uint64, err error) {
+	if from, err = c.Flags().GetUint64(fromSlotFlag); err != nil {
+		return 0, 0, err
+	}
+	if to, err = c.Flags().GetUint64(toSlotFlag); err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	interchangeFileFlag       = "file"
+	genesisValidatorsRootFlag = "genesis-validators-root"
+)
+
+// AddInterchangeFileFlag adds the slashing protection interchange file flag to the command
+func AddInterchangeFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, interchangeFileFlag, "", "Path to the EIP-3076 slashing protection interchange JSON file", true)
+}
+
+// GetInterchangeFileFlagValue gets the slashing protection interchange file flag from the command
+func GetInterchangeFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(interchangeFileFlag)
+}
+
+// AddGenesisValidatorsRootFlag adds the genesis validators root flag to the command
+func AddGenesisValidatorsRootFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, genesisValidatorsRootFlag, "", "Hex encoded genesis validators root of the beacon network, required for networks without a known one", false)
+}
+
+// GetGenesisValidatorsRootFlagValue gets the genesis validators root flag from the command
+func GetGenesisValidatorsRootFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(genesisValidatorsRootFlag)
+}
@@ -0,0 +1,405 @@
+package operator
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/api"
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/cli/flags"
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/operator/dutyjournal"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+// DBCmd groups the commands to maintain the node database.
+// The node must be stopped while running them, since they access its database directly.
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Maintain the node database",
+}
+
+var backupDBCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backs up the node database to a file, recording its network, operator key and last processed block",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		filePath, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+		if err != nil {
+			logger.Fatal("could not setup db", zap.Error(err))
+		}
+		defer closeDB(logger, db)
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+		header, err := operatorstorage.NewBackupHeader(nodeStorage)
+		if err != nil {
+			logger.Fatal("could not describe db", zap.Error(err))
+		}
+		encodedHeader, err := json.Marshal(header)
+		if err != nil {
+			logger.Fatal("could not marshal backup header", zap.Error(err))
+		}
+
+		if err := kv.WriteBackupFile(filePath, func(w io.Writer) error { return db.Backup(w, encodedHeader) }); err != nil {
+			logger.Fatal("could not back up db", zap.Error(err))
+		}
+
+		logger.Info("backed up db",
+			zap.String("file", filePath),
+			zap.String("network", header.Config.NetworkName),
+			zap.Stringer("last_processed_block", header.LastProcessedBlock))
+	},
+}
+
+var restoreDBCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores the node database from a backup file into an empty database, if it's of the configured network and operator key",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		filePath, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		// nolint: gosec
+		f, err := os.Open(filePath)
+		if err != nil {
+			logger.Fatal("could not open backup file", zap.Error(err))
+		}
+		defer func() { _ = f.Close() }()
+		r := bufio.NewReader(f)
+
+		engine, encodedHeader, err := kv.ReadBackupHeader(r)
+		if err != nil {
+			logger.Fatal("could not read backup header", zap.Error(err))
+		}
+		var header operatorstorage.BackupHeader
+		if err := json.Unmarshal(encodedHeader, &header); err != nil {
+			logger.Fatal("could not unmarshal backup header", zap.Error(err))
+		}
+
+		operatorPrivKey, operatorPrivKeyText := setupOperatorKey(cmd.Context(), logger)
+		privKeyHash, privKeyLegacyHash, err := operatorKeyStorageHashes(operatorPrivKey, operatorPrivKeyText)
+		if err != nil {
+			logger.Fatal("could not hash private key", zap.Error(err))
+		}
+		currentConfig := &operatorstorage.ConfigLock{
+			NetworkName:      networkConfig.NetworkName(),
+			UsingLocalEvents: len(cfg.LocalEventsPath) != 0,
+		}
+		if err := header.ValidateRestore(currentConfig, privKeyHash, privKeyLegacyHash); err != nil {
+			logger.Fatal("refusing to restore backup", zap.Error(err))
+		}
+
+		// The backup is restored into the engine it was taken from, which may then be migrated.
+		// Migrations aren't run, since the restored data is migrated when the node starts.
+		if configuredEngine := kv.Engine(cfg.DBOptions.Engine); configuredEngine != engine {
+			logger.Warn("backup is restored into its own engine, migrate it to use the configured engine",
+				zap.String("backup_engine", string(engine)),
+				zap.String("configured_engine", string(configuredEngine)))
+		}
+		cfg.DBOptions.Ctx = cmd.Context()
+		cfg.DBOptions.Engine = string(engine)
+		db, err := kv.Open(logger, cfg.DBOptions)
+		if err != nil {
+			logger.Fatal("could not open db", zap.Error(err))
+		}
+		defer closeDB(logger, db)
+
+		// Restore reads the backup from its start.
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			logger.Fatal("could not rewind backup file", zap.Error(err))
+		}
+		if err := db.Restore(bufio.NewReader(f)); err != nil {
+			logger.Fatal("could not restore db", zap.Error(err))
+		}
+
+		logger.Info("restored db",
+			zap.String("file", filePath),
+			zap.String("engine", string(engine)),
+			zap.Time("created_at", header.CreatedAt),
+			zap.Stringer("last_processed_block", header.LastProcessedBlock))
+	},
+}
+
+var migrateDBCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copies the node database into a new database of another storage engine",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+
+		targetEngine, err := flags.GetTargetEngineFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get target engine flag value", zap.Error(err))
+		}
+		targetPath, err := flags.GetTargetPathFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get target path flag value", zap.Error(err))
+		}
+		if _, err := os.Stat(targetPath); !os.IsNotExist(err) {
+			logger.Fatal("target path must not exist", zap.String("path", targetPath), zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		// The source is migrated first, so that the copy is up to date.
+		cfg.DBOptions.Ctx = cmd.Context()
+		src, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+		if err != nil {
+			logger.Fatal("could not setup db", zap.Error(err))
+		}
+		defer closeDB(logger, src)
+
+		targetOptions := cfg.DBOptions
+		targetOptions.Engine = targetEngine
+		targetOptions.Path = targetPath
+		dst, err := kv.Open(logger, targetOptions)
+		if err != nil {
+			logger.Fatal("could not open target db", zap.Error(err))
+		}
+		defer closeDB(logger, dst)
+
+		count, err := kv.Copy(src, dst)
+		if err != nil {
+			logger.Fatal("could not migrate db", zap.Error(err))
+		}
+
+		logger.Info("migrated db, point the db Path and Engine options to it to use it",
+			zap.String("engine", targetEngine),
+			zap.String("path", targetPath),
+			zap.Int("items", count))
+	},
+}
+
+var inspectDBCmd = &cobra.Command{
+	Use:   "inspect [section...]",
+	Short: "Prints the data in the node database without modifying it",
+	Long: "Prints the sections of the node database, or all of them when none is given: " +
+		strings.Join(inspectSections, ", ") + ".\n" +
+		"Shares are filtered like in the validators API, and QBFT participants by slot range.",
+	ValidArgs: inspectSections,
+	Args:      cobra.OnlyValidArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+		// Info logs would be mixed up with the inspection, which is printed to the same output.
+		logger = logger.WithOptions(zap.IncreaseLevel(zapcore.WarnLevel))
+
+		output, err := flags.GetInspectOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
+		if output != "text" && output != "json" {
+			logger.Fatal("unknown output format", zap.String("output", output))
+		}
+		sharesFilters, err := flags.GetSharesFilterFlagValues(cmd)
+		if err != nil {
+			logger.Fatal("failed to get shares filter flag values", zap.Error(err))
+		}
+		fromSlot, toSlot, err := flags.GetSlotRangeFlagValues(cmd)
+		if err != nil {
+			logger.Fatal("failed to get slot range flag values", zap.Error(err))
+		}
+
+		sections := args
+		if len(sections) == 0 {
+			sections = inspectSections
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+
+		db := openReadOnlyDB(cmd, logger)
+		defer closeDB(logger, db)
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		if err != nil {
+			logger.Fatal("failed to create node storage", zap.Error(err))
+		}
+		inspector := &dbInspector{
+			db:            db,
+			nodeStorage:   nodeStorage,
+			signerStorage: ekm.NewSignerStorage(db, networkConfig.Beacon, logger),
+			fromSlot:      phase0.Slot(fromSlot),
+			toSlot:        phase0.Slot(toSlot),
+		}
+		if err := api.BindValues(sharesFilters, &inspector.sharesRequest); err != nil {
+			logger.Fatal("invalid shares filter", zap.Error(err))
+		}
+
+		inspected := make(map[string]any, len(sections))
+		for _, section := range sections {
+			inspected[section], err = inspector.inspect(section)
+			if err != nil {
+				logger.Fatal("could not inspect db", zap.String("section", section), zap.Error(err))
+			}
+		}
+
+		if output == "json" {
+			err = writeInspectionJSON(cmd.OutOrStdout(), sections, inspected)
+		} else {
+			err = writeInspectionText(cmd.OutOrStdout(), sections, inspected)
+		}
+		if err != nil {
+			logger.Fatal("could not write inspection", zap.Error(err))
+		}
+	},
+}
+
+var journalDBCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Prints the journaled duties of validators as JSON",
+	Long: "Prints the duties of the given validators within the slot range, as journaled by a node running with DutyJournal,\n" +
+		"with the timing of each phase, the operators who contributed signatures and the Beacon node response.",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, err := setupGlobal()
+		if err != nil {
+			log.Fatal("could not create logger ", err)
+		}
+		// Info logs would be mixed up with the duties, which are printed to the same output.
+		logger = logger.WithOptions(zap.IncreaseLevel(zapcore.WarnLevel))
+
+		pubKeys, err := flags.GetJournalPubKeysFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get pubkeys flag value", zap.Error(err))
+		}
+		var request struct {
+			PubKeys api.HexSlice `form:"pubkeys"`
+		}
+		if err := api.BindValues(url.Values{"pubkeys": {pubKeys}}, &request); err != nil {
+			logger.Fatal("invalid pubkeys", zap.Error(err))
+		}
+		fromSlot, toSlot, err := flags.GetSlotRangeFlagValues(cmd)
+		if err != nil {
+			logger.Fatal("failed to get slot range flag values", zap.Error(err))
+		}
+
+		networkConfig, err := setupSSVNetwork(logger)
+		if err != nil {
+			logger.Fatal("could not setup network", zap.Error(err))
+		}
+		if toSlot == 0 {
+			toSlot = uint64(networkConfig.Beacon.EstimatedCurrentSlot())
+		}
+		if fromSlot > toSlot {
+			logger.Fatal("from slot must not be after to slot", zap.Uint64("from_slot", fromSlot), zap.Uint64("to_slot", toSlot))
+		}
+
+		db := openReadOnlyDB(cmd, logger)
+		defer closeDB(logger, db)
+
+		journal := dutyjournal.New(logger, db)
+		duties := make([]*dutyjournal.Duty, 0)
+		for _, pubKey := range request.PubKeys {
+			var validatorPK phase0.BLSPubKey
+			if len(pubKey) != len(validatorPK) {
+				logger.Fatal("invalid public key length", zap.Int("length", len(pubKey)))
+			}
+			copy(validatorPK[:], pubKey)
+
+			validatorDuties, err := journal.Duties(validatorPK, phase0.Slot(fromSlot), phase0.Slot(toSlot))
+			if err != nil {
+				logger.Fatal("could not read duty journal", zap.Error(err))
+			}
+			duties = append(duties, validatorDuties...)
+		}
+
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(duties); err != nil {
+			logger.Fatal("could not write duties", zap.Error(err))
+		}
+	},
+}
+
+// openReadOnlyDB opens the node database read-only in its own engine, without migrating it,
+// so that it's read as is.
+func openReadOnlyDB(cmd *cobra.Command, logger *zap.Logger) basedb.Database {
+	engine, err := kv.DetectEngine(cfg.DBOptions.Path)
+	if err != nil {
+		logger.Fatal("could not detect db engine", zap.Error(err))
+	}
+	cfg.DBOptions.Ctx = cmd.Context()
+	cfg.DBOptions.Engine = string(engine)
+	cfg.DBOptions.ReadOnly = true
+	cfg.DBOptions.GCInterval = 0
+	db, err := kv.Open(logger, cfg.DBOptions)
+	if err != nil {
+		logger.Fatal("could not open db", zap.Error(err))
+	}
+	return db
+}
+
+func closeDB(logger *zap.Logger, db basedb.Database) {
+	if err := db.Close(); err != nil {
+		logger.Error("could not close db", zap.Error(err))
+	}
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, DBCmd)
+	flags.AddBackupFileFlag(backupDBCmd)
+	flags.AddBackupFileFlag(restoreDBCmd)
+	flags.AddTargetEngineFlag(migrateDBCmd)
+	flags.AddTargetPathFlag(migrateDBCmd)
+	flags.AddInspectOutputFlag(inspectDBCmd)
+	flags.AddSharesFilterFlags(inspectDBCmd)
+	flags.AddSlotRangeFlags(inspectDBCmd)
+	flags.AddJournalPubKeysFlag(journalDBCmd)
+	flags.AddSlotRangeFlags(journalDBCmd)
+
+	DBCmd.AddCommand(backupDBCmd)
+	DBCmd.AddCommand(restoreDBCmd)
+	DBCmd.AddCommand(migrateDBCmd)
+	DBCmd.AddCommand(inspectDBCmd)
+	DBCmd.AddCommand(journalDBCmd)
+}
@@ -0,0 +1,347 @@
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/api/handlers"
+	"github.com/ssvlabs/ssv/ekm"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// Sections of the database inspection.
+const (
+	inspectNodeSection               = "node"
+	inspectOperatorsSection          = "operators"
+	inspectSharesSection             = "shares"
+	inspectRecipientsSection         = "recipients"
+	inspectParticipantsSection       = "participants"
+	inspectSlashingProtectionSection = "slashing-protection"
+)
+
+// inspectSections are all sections of the database inspection, in the order they're printed.
+var inspectSections = []string{
+	inspectNodeSection,
+	inspectOperatorsSection,
+	inspectSharesSection,
+	inspectRecipientsSection,
+	inspectParticipantsSection,
+	inspectSlashingProtectionSection,
+}
+
+type inspectedNode struct {
+	Config             *operatorstorage.ConfigLock `json:"config"`
+	LastProcessedBlock *big.Int                    `json:"last_processed_block"`
+}
+
+type inspectedOperator struct {
+	ID        spectypes.OperatorID `json:"id"`
+	PublicKey string               `json:"public_key"`
+	Owner     common.Address       `json:"owner"`
+}
+
+type inspectedRecipient struct {
+	Owner        common.Address         `json:"owner"`
+	FeeRecipient string                 `json:"fee_recipient"`
+	Nonce        *registrystorage.Nonce `json:"nonce"`
+	NextNonce    registrystorage.Nonce  `json:"next_nonce"`
+}
+
+type inspectedParticipants struct {
+	Role           string                 `json:"role"`
+	Slot           phase0.Slot            `json:"slot"`
+	DutyExecutorID api.Hex                `json:"duty_executor_id"`
+	Signers        []spectypes.OperatorID `json:"signers"`
+}
+
+type inspectedSlashingProtection struct {
+	Attestations []inspectedAttestation `json:"attestations"`
+	Proposals    []inspectedProposal    `json:"proposals"`
+}
+
+type inspectedAttestation struct {
+	PubKey      api.Hex      `json:"public_key"`
+	Slot        phase0.Slot  `json:"slot"`
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+}
+
+type inspectedProposal struct {
+	PubKey api.Hex     `json:"public_key"`
+	Slot   phase0.Slot `json:"slot"`
+}
+
+// dbInspector reads the sections of the database inspection.
+type dbInspector struct {
+	db            basedb.Database
+	nodeStorage   operatorstorage.Storage
+	signerStorage ekm.Storage
+
+	sharesRequest handlers.ValidatorsRequest
+	fromSlot      phase0.Slot
+	toSlot        phase0.Slot
+}
+
+// inspect returns the given section, ready to be marshalled or written by writeInspectionText.
+func (i *dbInspector) inspect(section string) (any, error) {
+	switch section {
+	case inspectNodeSection:
+		return i.inspectNode()
+	case inspectOperatorsSection:
+		return i.inspectOperators()
+	case inspectSharesSection:
+		return i.inspectShares(), nil
+	case inspectRecipientsSection:
+		return i.inspectRecipients()
+	case inspectParticipantsSection:
+		return i.inspectParticipants()
+	case inspectSlashingProtectionSection:
+		return i.inspectSlashingProtection()
+	default:
+		return nil, fmt.Errorf("unknown section: %s", section)
+	}
+}
+
+func (i *dbInspector) inspectNode() (*inspectedNode, error) {
+	config, _, err := i.nodeStorage.GetConfig(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	lastProcessedBlock, _, err := i.nodeStorage.GetLastProcessedBlock(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last processed block: %w", err)
+	}
+	return &inspectedNode{
+		Config:             config,
+		LastProcessedBlock: lastProcessedBlock,
+	}, nil
+}
+
+func (i *dbInspector) inspectOperators() ([]inspectedOperator, error) {
+	operators, err := i.nodeStorage.ListOperators(nil, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operators: %w", err)
+	}
+	inspected := make([]inspectedOperator, len(operators))
+	for j, od := range operators {
+		inspected[j] = inspectedOperator{
+			ID:        od.ID,
+			PublicKey: string(od.PublicKey),
+			Owner:     od.OwnerAddress,
+		}
+	}
+	sort.Slice(inspected, func(a, b int) bool { return inspected[a].ID < inspected[b].ID })
+	return inspected, nil
+}
+
+func (i *dbInspector) inspectShares() []*handlers.ValidatorJSON {
+	shares := i.nodeStorage.Shares().List(nil, i.sharesRequest.Filters()...)
+	sort.Slice(shares, func(a, b int) bool {
+		return bytes.Compare(shares[a].ValidatorPubKey[:], shares[b].ValidatorPubKey[:]) < 0
+	})
+	inspected := make([]*handlers.ValidatorJSON, len(shares))
+	for j, share := range shares {
+		inspected[j] = handlers.ValidatorFromShare(share)
+	}
+	return inspected
+}
+
+func (i *dbInspector) inspectRecipients() ([]inspectedRecipient, error) {
+	recipients, err := i.nodeStorage.ListRecipients(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipients: %w", err)
+	}
+	inspected := make([]inspectedRecipient, len(recipients))
+	for j, rd := range recipients {
+		nextNonce, err := i.nodeStorage.GetNextNonce(nil, rd.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next nonce: %w", err)
+		}
+		inspected[j] = inspectedRecipient{
+			Owner:        rd.Owner,
+			FeeRecipient: rd.FeeRecipient.String(),
+			Nonce:        rd.Nonce,
+			NextNonce:    nextNonce,
+		}
+	}
+	sort.Slice(inspected, func(a, b int) bool {
+		return bytes.Compare(inspected[a].Owner[:], inspected[b].Owner[:]) < 0
+	})
+	return inspected, nil
+}
+
+func (i *dbInspector) inspectParticipants() ([]inspectedParticipants, error) {
+	toSlot := i.toSlot
+	if toSlot == 0 {
+		toSlot = math.MaxUint64
+	}
+
+	inspected := make([]inspectedParticipants, 0)
+	for _, role := range qbftStorageRoles {
+		entries, err := ibftstorage.New(i.db, role.String()).GetAllParticipantsInRange(i.fromSlot, toSlot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s participants: %w", role, err)
+		}
+		sort.Slice(entries, func(a, b int) bool {
+			if entries[a].Slot != entries[b].Slot {
+				return entries[a].Slot < entries[b].Slot
+			}
+			return bytes.Compare(entries[a].Identifier[:], entries[b].Identifier[:]) < 0
+		})
+		for _, entry := range entries {
+			inspected = append(inspected, inspectedParticipants{
+				Role:           role.String(),
+				Slot:           entry.Slot,
+				DutyExecutorID: entry.Identifier.GetDutyExecutorID(),
+				Signers:        entry.Signers,
+			})
+		}
+	}
+	return inspected, nil
+}
+
+func (i *dbInspector) inspectSlashingProtection() (*inspectedSlashingProtection, error) {
+	attestations, err := i.signerStorage.ListHighestAttestations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list highest attestations: %w", err)
+	}
+	proposals, err := i.signerStorage.ListHighestProposals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list highest proposals: %w", err)
+	}
+
+	inspected := &inspectedSlashingProtection{
+		Attestations: make([]inspectedAttestation, 0, len(attestations)),
+		Proposals:    make([]inspectedProposal, 0, len(proposals)),
+	}
+	for pubKey, att := range attestations {
+		inspected.Attestations = append(inspected.Attestations, inspectedAttestation{
+			PubKey:      api.Hex(pubKey[:]),
+			Slot:        att.Slot,
+			SourceEpoch: att.Source.Epoch,
+			TargetEpoch: att.Target.Epoch,
+		})
+	}
+	for pubKey, slot := range proposals {
+		inspected.Proposals = append(inspected.Proposals, inspectedProposal{
+			PubKey: api.Hex(pubKey[:]),
+			Slot:   slot,
+		})
+	}
+	sort.Slice(inspected.Attestations, func(a, b int) bool {
+		return bytes.Compare(inspected.Attestations[a].PubKey, inspected.Attestations[b].PubKey) < 0
+	})
+	sort.Slice(inspected.Proposals, func(a, b int) bool {
+		return bytes.Compare(inspected.Proposals[a].PubKey, inspected.Proposals[b].PubKey) < 0
+	})
+	return inspected, nil
+}
+
+// writeInspectionJSON writes the inspected sections as a single JSON object keyed by section.
+func writeInspectionJSON(w io.Writer, sections []string, inspected map[string]any) error {
+	out := make(map[string]any, len(sections))
+	for _, section := range sections {
+		out[section] = inspected[section]
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// writeInspectionText writes the inspected sections as human-readable tables.
+func writeInspectionText(w io.Writer, sections []string, inspected map[string]any) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	row := func(values ...any) {
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = fmt.Sprint(v)
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(strs, "\t"))
+	}
+
+	for i, section := range sections {
+		if i > 0 {
+			row()
+		}
+		switch data := inspected[section].(type) {
+		case *inspectedNode:
+			row("# node")
+			if data.Config != nil {
+				row("network", data.Config.NetworkName)
+				row("using local events", data.Config.UsingLocalEvents)
+			} else {
+				row("config", "none")
+			}
+			if data.LastProcessedBlock != nil {
+				row("last processed block", data.LastProcessedBlock)
+			} else {
+				row("last processed block", "none")
+			}
+		case []inspectedOperator:
+			row(fmt.Sprintf("# operators (%d)", len(data)))
+			row("ID", "OWNER", "PUBLIC KEY")
+			for _, op := range data {
+				row(op.ID, op.Owner, op.PublicKey)
+			}
+		case []*handlers.ValidatorJSON:
+			row(fmt.Sprintf("# shares (%d)", len(data)))
+			row("PUBLIC KEY", "INDEX", "STATUS", "OWNER", "COMMITTEE", "LIQUIDATED")
+			for _, v := range data {
+				row(fmt.Sprintf("%x", []byte(v.PubKey)), v.Index, v.Status, fmt.Sprintf("%x", []byte(v.Owner)), joinOperatorIDs(v.Committee), v.Liquidated)
+			}
+		case []inspectedRecipient:
+			row(fmt.Sprintf("# recipients (%d)", len(data)))
+			row("OWNER", "FEE RECIPIENT", "NONCE", "NEXT NONCE")
+			for _, r := range data {
+				nonce := "none"
+				if r.Nonce != nil {
+					nonce = fmt.Sprint(*r.Nonce)
+				}
+				row(r.Owner, r.FeeRecipient, nonce, r.NextNonce)
+			}
+		case []inspectedParticipants:
+			row(fmt.Sprintf("# participants (%d)", len(data)))
+			row("ROLE", "SLOT", "DUTY EXECUTOR", "SIGNERS")
+			for _, p := range data {
+				row(p.Role, p.Slot, fmt.Sprintf("%x", []byte(p.DutyExecutorID)), joinOperatorIDs(p.Signers))
+			}
+		case *inspectedSlashingProtection:
+			row(fmt.Sprintf("# slashing protection (%d attestations, %d proposals)", len(data.Attestations), len(data.Proposals)))
+			row("PUBLIC KEY", "HIGHEST ATTESTATION SLOT", "SOURCE EPOCH", "TARGET EPOCH")
+			for _, att := range data.Attestations {
+				row(fmt.Sprintf("%x", []byte(att.PubKey)), att.Slot, att.SourceEpoch, att.TargetEpoch)
+			}
+			row()
+			row("PUBLIC KEY", "HIGHEST PROPOSAL SLOT")
+			for _, p := range data.Proposals {
+				row(fmt.Sprintf("%x", []byte(p.PubKey)), p.Slot)
+			}
+		default:
+			return fmt.Errorf("unknown section: %s", section)
+		}
+	}
+	return tw.Flush()
+}
+
+func joinOperatorIDs(ids []spectypes.OperatorID) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = fmt.Sprint(id)
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,161 @@
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/api"
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestDBInspector(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+	signerStorage := ekm.NewSignerStorage(db, networkconfig.TestNetwork.Beacon, logger)
+
+	// Populate the database.
+	config := &operatorstorage.ConfigLock{NetworkName: networkconfig.TestNetwork.NetworkName()}
+	require.NoError(t, nodeStorage.SaveConfig(nil, config))
+	require.NoError(t, nodeStorage.SaveLastProcessedBlock(nil, big.NewInt(123)))
+
+	owner := common.HexToAddress("0x1")
+	for _, id := range []spectypes.OperatorID{10, 2} {
+		_, err := nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{ID: id, PublicKey: []byte{byte(id)}, OwnerAddress: owner})
+		require.NoError(t, err)
+	}
+	require.NoError(t, nodeStorage.BumpNonce(nil, owner))
+
+	newShare := func(pubKey byte, index phase0.ValidatorIndex, committee ...spectypes.OperatorID) *types.SSVShare {
+		share := &types.SSVShare{
+			Share: spectypes.Share{ValidatorPubKey: spectypes.ValidatorPK{pubKey}},
+			Metadata: types.Metadata{
+				BeaconMetadata: &beaconprotocol.ValidatorMetadata{Index: index},
+				OwnerAddress:   owner,
+			},
+		}
+		for _, id := range committee {
+			share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: id})
+		}
+		return share
+	}
+	require.NoError(t, nodeStorage.Shares().Save(nil, newShare(1, 100, 1, 2, 3, 4), newShare(2, 200, 2, 3, 4, 5)))
+
+	pubKey := phase0.BLSPubKey{1}
+	require.NoError(t, signerStorage.SaveHighestAttestation(pubKey[:], &phase0.AttestationData{
+		Slot:   64,
+		Source: &phase0.Checkpoint{Epoch: 1},
+		Target: &phase0.Checkpoint{Epoch: 2},
+	}))
+	require.NoError(t, signerStorage.SaveHighestProposal(pubKey[:], 65))
+
+	identifier := convert.NewMsgID(networkconfig.TestNetwork.DomainType, pubKey[:], convert.RoleProposer)
+	for _, slot := range []phase0.Slot{65, 100} {
+		_, err := ibftstorage.New(db, convert.RoleProposer.String()).UpdateParticipants(identifier, slot, []spectypes.OperatorID{1, 2, 3})
+		require.NoError(t, err)
+	}
+
+	// Inspect it.
+	inspector := &dbInspector{
+		db:            db,
+		nodeStorage:   nodeStorage,
+		signerStorage: signerStorage,
+		toSlot:        99,
+	}
+	require.NoError(t, api.BindValues(url.Values{"clusters": {"1,2,3,4"}}, &inspector.sharesRequest))
+
+	inspected := make(map[string]any)
+	for _, section := range inspectSections {
+		inspected[section], err = inspector.inspect(section)
+		require.NoError(t, err)
+	}
+	_, err = inspector.inspect("unknown")
+	require.Error(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeInspectionJSON(&buf, inspectSections, inspected))
+	var decoded struct {
+		Node struct {
+			Config             *operatorstorage.ConfigLock `json:"config"`
+			LastProcessedBlock *big.Int                    `json:"last_processed_block"`
+		} `json:"node"`
+		Operators []struct {
+			ID spectypes.OperatorID `json:"id"`
+		} `json:"operators"`
+		Shares []struct {
+			PubKey api.Hex               `json:"public_key"`
+			Index  phase0.ValidatorIndex `json:"index"`
+		} `json:"shares"`
+		Recipients []struct {
+			Owner     common.Address        `json:"owner"`
+			NextNonce registrystorage.Nonce `json:"next_nonce"`
+		} `json:"recipients"`
+		Participants []struct {
+			Role    string                 `json:"role"`
+			Slot    phase0.Slot            `json:"slot"`
+			Signers []spectypes.OperatorID `json:"signers"`
+		} `json:"participants"`
+		SlashingProtection struct {
+			Attestations []struct {
+				TargetEpoch phase0.Epoch `json:"target_epoch"`
+			} `json:"attestations"`
+			Proposals []struct {
+				Slot phase0.Slot `json:"slot"`
+			} `json:"proposals"`
+		} `json:"slashing-protection"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	require.Equal(t, config, decoded.Node.Config)
+	require.Equal(t, int64(123), decoded.Node.LastProcessedBlock.Int64())
+
+	require.Len(t, decoded.Operators, 2)
+	require.Equal(t, spectypes.OperatorID(2), decoded.Operators[0].ID)
+	require.Equal(t, spectypes.OperatorID(10), decoded.Operators[1].ID)
+
+	require.Len(t, decoded.Shares, 1)
+	require.Equal(t, phase0.ValidatorIndex(100), decoded.Shares[0].Index)
+
+	require.Len(t, decoded.Recipients, 1)
+	require.Equal(t, owner, decoded.Recipients[0].Owner)
+	require.Equal(t, registrystorage.Nonce(1), decoded.Recipients[0].NextNonce)
+
+	require.Len(t, decoded.Participants, 1)
+	require.Equal(t, convert.RoleProposer.String(), decoded.Participants[0].Role)
+	require.Equal(t, phase0.Slot(65), decoded.Participants[0].Slot)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, decoded.Participants[0].Signers)
+
+	require.Len(t, decoded.SlashingProtection.Attestations, 1)
+	require.Equal(t, phase0.Epoch(2), decoded.SlashingProtection.Attestations[0].TargetEpoch)
+	require.Len(t, decoded.SlashingProtection.Proposals, 1)
+	require.Equal(t, phase0.Slot(65), decoded.SlashingProtection.Proposals[0].Slot)
+
+	buf.Reset()
+	require.NoError(t, writeInspectionText(&buf, inspectSections, inspected))
+	require.Contains(t, buf.String(), "# operators (2)")
+	require.Contains(t, buf.String(), "# shares (1)")
+	require.Contains(t, buf.String(), "# slashing protection (1 attestations, 1 proposals)")
+}
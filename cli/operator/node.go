@@ -19,6 +19,7 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"github.com/ssvlabs/ssv/api/handlers"
 	apiserver "github.com/ssvlabs/ssv/api/server"
@@ -48,9 +49,12 @@ import (
 	"github.com/ssvlabs/ssv/networkconfig"
 	"github.com/ssvlabs/ssv/nodeprobe"
 	"github.com/ssvlabs/ssv/observability"
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/operator"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/doppelganger"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/dutyjournal"
 	"github.com/ssvlabs/ssv/operator/keys"
 	"github.com/ssvlabs/ssv/operator/keystore"
 	"github.com/ssvlabs/ssv/operator/slotticker"
@@ -73,24 +77,68 @@ type KeyStore struct {
 }
 
 type config struct {
-	global_config.GlobalConfig `yaml:"global"`
-	DBOptions                  basedb.Options                   `yaml:"db"`
-	SSVOptions                 operator.Options                 `yaml:"ssv"`
-	ExecutionClient            executionclient.ExecutionOptions `yaml:"eth1"` // TODO: execution_client in yaml
-	ConsensusClient            beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
-	P2pNetworkConfig           p2pv1.Config                     `yaml:"p2p"`
-	KeyStore                   KeyStore                         `yaml:"KeyStore"`
-	Graffiti                   string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals." env-default:"ssv.network" `
-	OperatorPrivateKey         string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
-	MetricsAPIPort             int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
-	EnableProfile              bool                             `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
-	NetworkPrivateKey          string                           `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
-	WsAPIPort                  int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
-	WithPing                   bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
-	SSVAPIPort                 int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
-	LocalEventsPath            string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	global_config.GlobalConfig   `yaml:"global"`
+	DBOptions                    basedb.Options                   `yaml:"db"`
+	SSVOptions                   operator.Options                 `yaml:"ssv"`
+	ExecutionClient              executionclient.ExecutionOptions `yaml:"eth1"` // TODO: execution_client in yaml
+	ConsensusClient              beaconprotocol.Options           `yaml:"eth2"` // TODO: consensus_client in yaml
+	P2pNetworkConfig             p2pv1.Config                     `yaml:"p2p"`
+	KeyStore                     KeyStore                         `yaml:"KeyStore"`
+	Graffiti                     string                           `yaml:"Graffiti" env:"GRAFFITI" env-description:"Custom graffiti for block proposals." env-default:"ssv.network" `
+	OperatorPrivateKey           string                           `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
+	MetricsAPIPort               int                              `yaml:"MetricsAPIPort" env:"METRICS_API_PORT" env-description:"Port to listen on for the metrics API."`
+	MetricsValidatorIndex        bool                             `yaml:"MetricsValidatorIndex" env:"METRICS_VALIDATOR_INDEX" env-description:"Label duty metrics with validator indices, adding time series for every validator of the node."`
+	TracesEndpoint               string                           `yaml:"TracesEndpoint" env:"TRACES_ENDPOINT" env-description:"OTLP gRPC endpoint (host:port) of the collector to export traces to. Tracing is disabled if empty."`
+	TracesInsecure               bool                             `yaml:"TracesInsecure" env:"TRACES_INSECURE" env-description:"Connect to the traces collector without TLS."`
+	TracesSampleRatio            float64                          `yaml:"TracesSampleRatio" env:"TRACES_SAMPLE_RATIO" env-default:"1" env-description:"Ratio of duties to trace, from 0 to 1."`
+	EnableProfile                bool                             `yaml:"EnableProfile" env:"ENABLE_PROFILE" env-description:"flag that indicates whether go profiling tools are enabled"`
+	NetworkPrivateKey            string                           `yaml:"NetworkPrivateKey" env:"NETWORK_PRIVATE_KEY" env-description:"private key for network identity"`
+	WsAPIPort                    int                              `yaml:"WebSocketAPIPort" env:"WS_API_PORT" env-description:"Port to listen on for the websocket API."`
+	WithPing                     bool                             `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
+	GRPCAPIPort                  int                              `yaml:"GRPCAPIPort" env:"GRPC_API_PORT" env-description:"Port to listen on for the gRPC exporter API."`
+	GRPCAPITLSCertFile           string                           `yaml:"GRPCAPITLSCertFile" env:"GRPC_API_TLS_CERT_FILE" env-description:"Path to a TLS certificate to serve the gRPC exporter API over TLS"`
+	GRPCAPITLSKeyFile            string                           `yaml:"GRPCAPITLSKeyFile" env:"GRPC_API_TLS_KEY_FILE" env-description:"Path to the private key of the gRPC exporter API's TLS certificate"`
+	SSVAPIPort                   int                              `yaml:"SSVAPIPort" env:"SSV_API_PORT" env-description:"Port to listen on for the SSV API."`
+	SSVAPI                       apiserver.Config                 `yaml:"SSVAPI"`
+	LocalEventsPath              string                           `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+	EnableDoppelgangerProtection bool                             `yaml:"EnableDoppelgangerProtection" env:"ENABLE_DOPPELGANGER_PROTECTION" env-description:"Postpone signing after startup until no other instance with the same operator ID is observed on the network"`
+	DoppelgangerEpochs           uint64                           `yaml:"DoppelgangerEpochs" env:"DOPPELGANGER_EPOCHS" env-default:"2" env-description:"Number of epochs without messages from another instance with the same operator ID before signing is allowed"`
+	DutyJournal                  bool                             `yaml:"DutyJournal" env:"DUTY_JOURNAL" env-description:"Journal the duties of this operator's validators in the database"`
+	DutyJournalRetention         uint64                           `yaml:"DutyJournalRetention" env:"DUTY_JOURNAL_RETENTION" env-default:"1575" env-description:"Number of epochs to keep journaled duties for, or 0 to keep them forever"`
+	RemoteSigner                 RemoteSigner                     `yaml:"RemoteSigner"`
+	RemoteOperatorKey            RemoteOperatorKey                `yaml:"RemoteOperatorKey"`
 }
 
+// RemoteSigner configures a Web3Signer compatible remote signer to hold the validator shares instead of the local key manager.
+type RemoteSigner struct {
+	URL     string        `yaml:"URL" env:"REMOTE_SIGNER_URL" env-description:"URL of a Web3Signer compatible remote signer to sign with instead of the local key manager"`
+	Timeout time.Duration `yaml:"Timeout" env:"REMOTE_SIGNER_TIMEOUT" env-default:"10s" env-description:"Timeout of requests to the remote signer"`
+}
+
+// RemoteOperatorKey configures an external keyholder service to hold the operator private key instead of the node.
+type RemoteOperatorKey struct {
+	URL          string        `yaml:"URL" env:"REMOTE_OPERATOR_KEY_URL" env-description:"URL of the keyholder service holding the operator private key"`
+	Timeout      time.Duration `yaml:"Timeout" env:"REMOTE_OPERATOR_KEY_TIMEOUT" env-default:"5s" env-description:"Timeout of requests to the keyholder service"`
+	MaxBatchSize int           `yaml:"MaxBatchSize" env:"REMOTE_OPERATOR_KEY_MAX_BATCH_SIZE" env-default:"128" env-description:"Maximum number of messages signed by the keyholder service at once"`
+	BatchWindow  time.Duration `yaml:"BatchWindow" env:"REMOTE_OPERATOR_KEY_BATCH_WINDOW" env-default:"2ms" env-description:"How long to wait for more messages to sign before sending a batch to the keyholder service"`
+	CacheSize    int           `yaml:"CacheSize" env:"REMOTE_OPERATOR_KEY_CACHE_SIZE" env-default:"16384" env-description:"Number of signatures and decrypted shares cached from the keyholder service"`
+}
+
+// qbftStorageRoles are the roles which have their own QBFT storage.
+var qbftStorageRoles = []convert.RunnerRole{
+	convert.RoleCommittee,
+	convert.RoleAttester,
+	convert.RoleProposer,
+	convert.RoleSyncCommittee,
+	convert.RoleAggregator,
+	convert.RoleSyncCommitteeContribution,
+	convert.RoleValidatorRegistration,
+	convert.RoleVoluntaryExit,
+}
+
+// syncedStoragePrefix prefixes the QBFT storage of the decided participants synced from peers.
+const syncedStoragePrefix = "synced_"
+
 var cfg config
 
 var globalArgs global_config.Args
@@ -111,10 +159,22 @@ var StartNodeCmd = &cobra.Command{
 
 		logger.Info(fmt.Sprintf("starting %v", commons.GetBuildData()))
 
+		observabilityOptions := []observability.Option{observability.WithMetrics()}
+		if cfg.MetricsValidatorIndex {
+			logger.Warn("duty metrics are labeled with validator indices, which may produce many time series")
+			observabilityOptions = append(observabilityOptions, observability.WithValidatorAttributes())
+		}
+		if cfg.TracesEndpoint != "" {
+			observabilityOptions = append(observabilityOptions, observability.WithTraces(observability.TracesConfig{
+				Endpoint:    cfg.TracesEndpoint,
+				Insecure:    cfg.TracesInsecure,
+				SampleRatio: cfg.TracesSampleRatio,
+			}))
+		}
 		observabilityShutdown, err := observability.Initialize(
 			cmd.Parent().Short,
 			cmd.Parent().Version,
-			observability.WithMetrics())
+			observabilityOptions...)
 		if err != nil {
 			logger.Fatal("could not initialize observability configuration", zap.Error(err))
 		}
@@ -135,38 +195,7 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("could not setup db", zap.Error(err))
 		}
 
-		var operatorPrivKey keys.OperatorPrivateKey
-		var operatorPrivKeyText string
-		if cfg.KeyStore.PrivateKeyFile != "" {
-			// nolint: gosec
-			encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
-			if err != nil {
-				logger.Fatal("could not read PEM file", zap.Error(err))
-			}
-
-			// nolint: gosec
-			keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
-			if err != nil {
-				logger.Fatal("could not read password file", zap.Error(err))
-			}
-
-			decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
-			if err != nil {
-				logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
-			}
-			operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
-			if err != nil {
-				logger.Fatal("could not extract operator private key from file", zap.Error(err))
-			}
-
-			operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
-		} else {
-			operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
-			if err != nil {
-				logger.Fatal("could not decode operator private key", zap.Error(err))
-			}
-			operatorPrivKeyText = cfg.OperatorPrivateKey
-		}
+		operatorPrivKey, operatorPrivKeyText := setupOperatorKey(cmd.Context(), logger)
 		cfg.P2pNetworkConfig.OperatorSigner = operatorPrivKey
 
 		nodeStorage, operatorData := setupOperatorStorage(logger, db, operatorPrivKey, operatorPrivKeyText)
@@ -178,14 +207,11 @@ var StartNodeCmd = &cobra.Command{
 			logger.Fatal("failed to validate config", zap.Error(err))
 		}
 
-		ekmHashedKey, err := operatorPrivKey.EKMHash()
-		if err != nil {
-			logger.Fatal("could not get operator private key hash", zap.Error(err))
-		}
-
-		keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey)
+		err = db.StartPeriodicSnapshots(cfg.DBOptions.SnapshotInterval, cfg.DBOptions.SnapshotDir, cfg.DBOptions.SnapshotRetain, func() ([]byte, error) {
+			return operatorstorage.MarshalBackupHeader(nodeStorage)
+		})
 		if err != nil {
-			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+			logger.Fatal("failed to start db snapshots", zap.Error(err))
 		}
 
 		cfg.P2pNetworkConfig.Ctx = cmd.Context()
@@ -203,6 +229,33 @@ var StartNodeCmd = &cobra.Command{
 
 		consensusClient := setupConsensusClient(logger, operatorDataStore, slotTickerProvider)
 
+		ekmHashedKey, err := operatorPrivKey.EKMHash()
+		if err != nil {
+			logger.Fatal("could not get operator private key hash", zap.Error(err))
+		}
+
+		var keyManager ekm.KeyManager
+		if cfg.RemoteSigner.URL != "" {
+			logger.Info("using remote signer", zap.String("url", cfg.RemoteSigner.URL))
+			keyManager = ekm.NewRemoteKeyManager(logger, cfg.RemoteSigner.URL, cfg.RemoteSigner.Timeout, networkConfig, consensusClient)
+			importLocalShares(logger, db, networkConfig, ekmHashedKey, keyManager.(ekm.SharesImporter))
+		} else {
+			keyManager, err = ekm.NewETHKeyManagerSigner(logger, db, networkConfig, ekmHashedKey)
+			if err != nil {
+				logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
+			}
+		}
+
+		doppelgangerHandler := doppelganger.NoOpHandler()
+		if cfg.EnableDoppelgangerProtection && !cfg.SSVOptions.ValidatorOptions.Exporter {
+			doppelgangerHandler = doppelganger.New(logger, doppelganger.Options{
+				Network:           networkConfig.Beacon,
+				OperatorDataStore: operatorDataStore,
+				ObservationEpochs: cfg.DoppelgangerEpochs,
+			})
+			keyManager = ekm.NewGuardedKeyManager(keyManager, doppelgangerHandler)
+		}
+
 		executionClient, err := executionclient.New(
 			cmd.Context(),
 			cfg.ExecutionClient.Addr,
@@ -264,36 +317,68 @@ var StartNodeCmd = &cobra.Command{
 		cfg.SSVOptions.ValidatorOptions.RecipientsStorage = nodeStorage
 		cfg.SSVOptions.ValidatorOptions.GasLimit = cfg.ConsensusClient.GasLimit
 
-		if cfg.WsAPIPort != 0 {
+		// The lifecycle events of duties are published by the validators and the scheduler,
+		// and received by the duty journal and the SSV API.
+		eventBus := events.NewBus()
+		cfg.SSVOptions.ValidatorOptions.EventBus = eventBus
+
+		if cfg.WsAPIPort != 0 || cfg.GRPCAPIPort != 0 {
+			// The gRPC API streams the decided messages broadcast to the WebSocket API.
 			ws := exporterapi.NewWsServer(cmd.Context(), nil, http.NewServeMux(), cfg.WithPing)
 			cfg.SSVOptions.WS = ws
 			cfg.SSVOptions.WsAPIPort = cfg.WsAPIPort
+			cfg.SSVOptions.GRPCAPIPort = cfg.GRPCAPIPort
+			cfg.SSVOptions.GRPCAPITLSCertFile = cfg.GRPCAPITLSCertFile
+			cfg.SSVOptions.GRPCAPITLSKeyFile = cfg.GRPCAPITLSKeyFile
 			cfg.SSVOptions.ValidatorOptions.NewDecidedHandler = decided.NewStreamPublisher(logger, ws)
 		}
 
 		cfg.SSVOptions.ValidatorOptions.DutyRoles = []spectypes.BeaconRole{spectypes.BNRoleAttester} // TODO could be better to set in other place
 
-		storageRoles := []convert.RunnerRole{
-			convert.RoleCommittee,
-			convert.RoleAttester,
-			convert.RoleProposer,
-			convert.RoleSyncCommittee,
-			convert.RoleAggregator,
-			convert.RoleSyncCommitteeContribution,
-			convert.RoleValidatorRegistration,
-			convert.RoleVoluntaryExit,
-		}
-
 		storageMap := ibftstorage.NewStores()
 
-		for _, storageRole := range storageRoles {
+		for _, storageRole := range qbftStorageRoles {
 			storageMap.Add(storageRole, ibftstorage.New(cfg.SSVOptions.ValidatorOptions.DB, storageRole.String()))
 		}
 
 		cfg.SSVOptions.ValidatorOptions.StorageMap = storageMap
+		cfg.P2pNetworkConfig.QBFTStores = storageMap
+
+		// Decided participants synced from peers are kept apart from those observed by the node,
+		// since they aren't signed, and the exporter serves them marked as synced where none were observed.
+		syncedStorageMap := ibftstorage.NewStores()
+		for _, storageRole := range qbftStorageRoles {
+			syncedStorageMap.Add(storageRole, ibftstorage.New(cfg.SSVOptions.ValidatorOptions.DB, syncedStoragePrefix+storageRole.String()))
+		}
+		cfg.P2pNetworkConfig.SyncedQBFTStores = syncedStorageMap
+		cfg.SSVOptions.SyncedQBFTStores = syncedStorageMap
+
+		if cfg.SSVOptions.ValidatorOptions.ExporterArchive {
+			if !cfg.SSVOptions.ValidatorOptions.Exporter {
+				logger.Fatal("exporter archive requires exporter mode")
+			}
+			archive := ibftstorage.NewArchive(cfg.SSVOptions.ValidatorOptions.DB)
+			if retention := cfg.SSVOptions.ValidatorOptions.ExporterArchiveRetention; retention > 0 {
+				go archive.PruneLoop(cmd.Context(), logger, networkConfig.Beacon, phase0.Epoch(retention))
+			}
+			cfg.SSVOptions.ValidatorOptions.Archive = archive
+		}
+
+		var journal *dutyjournal.Journal
+		if cfg.DutyJournal {
+			journal = dutyjournal.New(logger.Named("duty_journal"), db)
+			go journal.Run(cmd.Context(), eventBus)
+			if cfg.DutyJournalRetention > 0 {
+				go journal.PruneLoop(cmd.Context(), networkConfig.Beacon, phase0.Epoch(cfg.DutyJournalRetention))
+			}
+		}
 		cfg.SSVOptions.ValidatorOptions.Graffiti = []byte(cfg.Graffiti)
 		cfg.SSVOptions.ValidatorOptions.ValidatorStore = nodeStorage.ValidatorStore()
-		cfg.SSVOptions.ValidatorOptions.OperatorSigner = types.NewSsvOperatorSigner(operatorPrivKey, operatorDataStore.GetOperatorID)
+		cfg.SSVOptions.ValidatorOptions.OperatorSigner = doppelganger.GuardOperatorSigner(
+			types.NewSsvOperatorSigner(operatorPrivKey, operatorDataStore.GetOperatorID),
+			doppelgangerHandler,
+		)
+		cfg.SSVOptions.ValidatorOptions.DoppelgangerHandler = doppelgangerHandler
 
 		validatorCtrl := validator.NewController(logger, cfg.SSVOptions.ValidatorOptions)
 		cfg.SSVOptions.ValidatorController = validatorCtrl
@@ -313,10 +398,9 @@ var StartNodeCmd = &cobra.Command{
 			map[string]nodeprobe.Node{
 				"execution client": executionClient,
 
-				// Underlying options.Beacon's value implements nodeprobe.StatusChecker.
-				// However, as it uses spec's specssv.BeaconNode interface, avoiding type assertion requires modifications in spec.
-				// If options.Beacon doesn't implement nodeprobe.StatusChecker due to a mistake, this would panic early.
-				"consensus client": consensusClient,
+				// Each beacon node is probed under its own name, and the consensus client
+				// is healthy as long as any of them is, since they fail over to each other.
+				"consensus client": nodeprobe.NewFailoverGroup(logger, beaconNodeProbes(consensusClient)),
 			},
 		)
 
@@ -381,27 +465,57 @@ var StartNodeCmd = &cobra.Command{
 		}
 
 		if cfg.SSVAPIPort > 0 {
+			listenAddresses := []string{fmt.Sprintf("tcp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.TCPPort), fmt.Sprintf("udp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.UDPPort)}
+			if cfg.P2pNetworkConfig.QUICPort != 0 {
+				listenAddresses = append(listenAddresses, fmt.Sprintf("quic://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.QUICPort))
+			}
 			apiServer := apiserver.New(
 				logger,
 				fmt.Sprintf(":%d", cfg.SSVAPIPort),
+				cfg.SSVAPI,
 				&handlers.Node{
 					// TODO: replace with narrower interface! (instead of accessing the entire PeersIndex)
-					ListenAddresses: []string{fmt.Sprintf("tcp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.TCPPort), fmt.Sprintf("udp://%s:%d", cfg.P2pNetworkConfig.HostAddress, cfg.P2pNetworkConfig.UDPPort)},
+					ListenAddresses: listenAddresses,
 					PeersIndex:      p2pNetwork.(p2pv1.PeersIndexProvider).PeersIndex(),
 					Network:         p2pNetwork.(p2pv1.HostProvider).Host().Network(),
 					TopicIndex:      p2pNetwork.(handlers.TopicIndex),
+					TrafficIndex:    p2pNetwork.(handlers.TrafficIndex),
 					NodeProber:      nodeProber,
+					Doppelganger:    doppelgangerHandler,
 				},
 				&handlers.Validators{
-					Shares: nodeStorage.Shares(),
+					Shares:     nodeStorage.Shares(),
+					Recipients: nodeStorage,
 				},
 				&handlers.Exporter{
-					DomainType: networkConfig.DomainType,
-					QBFTStores: storageMap,
+					DomainType:       networkConfig.DomainType,
+					QBFTStores:       storageMap,
+					SyncedQBFTStores: syncedStorageMap,
+					Shares:           nodeStorage.Shares(),
+					BeaconNetwork:    networkConfig.Beacon,
+					Archive:          cfg.SSVOptions.ValidatorOptions.Archive,
+				},
+				&handlers.Events{
+					Bus:    eventBus,
+					Shares: nodeStorage.Shares(),
+				},
+				&handlers.Operators{
+					Operators: nodeStorage,
+					Shares:    nodeStorage.Shares(),
+				},
+				&handlers.Clusters{
+					Shares:     nodeStorage.Shares(),
+					Recipients: nodeStorage,
+				},
+				&handlers.Journal{
+					Journal: journal,
+				},
+				&handlers.Bans{
+					BanList: p2pNetwork.(handlers.BanList),
 				},
 			)
 			go func() {
-				err := apiServer.Run()
+				err := apiServer.Run(cmd.Context())
 				if err != nil {
 					logger.Fatal("failed to start API server", zap.Error(err))
 				}
@@ -471,8 +585,8 @@ func setupGlobal() (*zap.Logger, error) {
 	return zap.L(), nil
 }
 
-func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.BadgerDB, error) {
-	db, err := kv.New(logger, cfg.DBOptions)
+func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (kv.DB, error) {
+	db, err := kv.Open(logger, cfg.DBOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to open db")
 	}
@@ -480,7 +594,7 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 		if err := db.Close(); err != nil {
 			return errors.Wrap(err, "failed to close db")
 		}
-		db, err = kv.New(logger, cfg.DBOptions)
+		db, err = kv.Open(logger, cfg.DBOptions)
 		return errors.Wrap(err, "failed to reopen db")
 	}
 
@@ -522,30 +636,115 @@ func setupDB(logger *zap.Logger, eth2Network beaconprotocol.Network) (*kv.Badger
 	return db, nil
 }
 
-func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey keys.OperatorPrivateKey, configPrivKeyText string) (operatorstorage.Storage, *registrystorage.OperatorData) {
-	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
-	if err != nil {
-		logger.Fatal("failed to create node storage", zap.Error(err))
-	}
+// setupOperatorKey loads the configured operator key, and its text for the legacy storage hash if it's held locally.
+func setupOperatorKey(ctx context.Context, logger *zap.Logger) (operatorPrivKey keys.OperatorKey, operatorPrivKeyText string) {
+	var err error
+	if cfg.RemoteOperatorKey.URL != "" {
+		logger.Info("using remote operator key", zap.String("url", cfg.RemoteOperatorKey.URL))
+		operatorPrivKey, err = keys.NewRemoteKey(ctx, logger, keys.RemoteKeyOptions{
+			URL:          cfg.RemoteOperatorKey.URL,
+			Timeout:      cfg.RemoteOperatorKey.Timeout,
+			MaxBatchSize: cfg.RemoteOperatorKey.MaxBatchSize,
+			BatchWindow:  cfg.RemoteOperatorKey.BatchWindow,
+			CacheSize:    cfg.RemoteOperatorKey.CacheSize,
+		})
+		if err != nil {
+			logger.Fatal("could not setup remote operator key", zap.Error(err))
+		}
+	} else if cfg.KeyStore.PrivateKeyFile != "" {
+		// nolint: gosec
+		encryptedJSON, err := os.ReadFile(cfg.KeyStore.PrivateKeyFile)
+		if err != nil {
+			logger.Fatal("could not read PEM file", zap.Error(err))
+		}
 
-	storedPrivKeyHash, found, err := nodeStorage.GetPrivateKeyHash()
-	if err != nil {
-		logger.Fatal("could not get hashed private key", zap.Error(err))
+		// nolint: gosec
+		keyStorePassword, err := os.ReadFile(cfg.KeyStore.PasswordFile)
+		if err != nil {
+			logger.Fatal("could not read password file", zap.Error(err))
+		}
+
+		decryptedKeystore, err := keystore.DecryptKeystore(encryptedJSON, string(keyStorePassword))
+		if err != nil {
+			logger.Fatal("could not decrypt operator private key keystore", zap.Error(err))
+		}
+		operatorPrivKey, err = keys.PrivateKeyFromBytes(decryptedKeystore)
+		if err != nil {
+			logger.Fatal("could not extract operator private key from file", zap.Error(err))
+		}
+
+		operatorPrivKeyText = base64.StdEncoding.EncodeToString(decryptedKeystore)
+	} else {
+		operatorPrivKey, err = keys.PrivateKeyFromString(cfg.OperatorPrivateKey)
+		if err != nil {
+			logger.Fatal("could not decode operator private key", zap.Error(err))
+		}
+		operatorPrivKeyText = cfg.OperatorPrivateKey
 	}
+	return operatorPrivKey, operatorPrivKeyText
+}
 
-	configStoragePrivKeyHash, err := configPrivKey.StorageHash()
+// operatorKeyStorageHashes returns the hash of the operator key stored in the database,
+// and its legacy hash if the key is held locally.
+func operatorKeyStorageHashes(operatorPrivKey keys.OperatorKey, operatorPrivKeyText string) (hash, legacyHash string, err error) {
+	hash, err = operatorPrivKey.StorageHash()
 	if err != nil {
-		logger.Fatal("could not hash private key", zap.Error(err))
+		return "", "", err
 	}
 
 	// Backwards compatibility for the old hashing method,
 	// which was hashing the text from the configuration directly,
 	// whereas StorageHash re-encodes with PEM format.
-	cliPrivKeyDecoded, err := base64.StdEncoding.DecodeString(configPrivKeyText)
+	// Remote keys have no text, so they were never hashed that way.
+	if operatorPrivKeyText != "" {
+		decoded, err := base64.StdEncoding.DecodeString(operatorPrivKeyText)
+		if err != nil {
+			return "", "", fmt.Errorf("could not decode private key: %w", err)
+		}
+		legacyHash, err = rsaencryption.HashRsaKey(decoded)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return hash, legacyHash, nil
+}
+
+// importLocalShares imports the shares kept by the local key manager into the remote signer,
+// so that validators which were added before switching to the remote signer keep signing.
+func importLocalShares(logger *zap.Logger, db basedb.Database, networkConfig networkconfig.NetworkConfig, ekmHashedKey string, importer ekm.SharesImporter) {
+	signerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+	if err := signerStorage.SetEncryptionKey(ekmHashedKey); err != nil {
+		logger.Fatal("could not set signer storage encryption key", zap.Error(err))
+	}
+	shareKeys, err := signerStorage.ShareKeys()
+	if err != nil {
+		logger.Fatal("could not read local share keys", zap.Error(err))
+	}
+	if len(shareKeys) == 0 {
+		return
+	}
+
+	imported, err := importer.ImportShares(shareKeys)
+	if err != nil {
+		logger.Fatal("could not import local share keys into remote signer", zap.Int("imported", imported), zap.Error(err))
+	}
+	logger.Info("imported local share keys into remote signer",
+		zap.Int("local", len(shareKeys)),
+		zap.Int("imported", imported))
+}
+
+func setupOperatorStorage(logger *zap.Logger, db basedb.Database, configPrivKey keys.OperatorKey, configPrivKeyText string) (operatorstorage.Storage, *registrystorage.OperatorData) {
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	if err != nil {
+		logger.Fatal("failed to create node storage", zap.Error(err))
+	}
+
+	storedPrivKeyHash, found, err := nodeStorage.GetPrivateKeyHash()
 	if err != nil {
-		logger.Fatal("could not decode private key", zap.Error(err))
+		logger.Fatal("could not get hashed private key", zap.Error(err))
 	}
-	configStoragePrivKeyLegacyHash, err := rsaencryption.HashRsaKey(cliPrivKeyDecoded)
+
+	configStoragePrivKeyHash, configStoragePrivKeyLegacyHash, err := operatorKeyStorageHashes(configPrivKey, configPrivKeyText)
 	if err != nil {
 		logger.Fatal("could not hash private key", zap.Error(err))
 	}
@@ -633,6 +832,7 @@ func setupP2P(logger *zap.Logger, db basedb.Database) network.P2PNetwork {
 		logger.Fatal("failed to setup network private key", zap.Error(err))
 	}
 	cfg.P2pNetworkConfig.NetworkPrivateKey = netPrivKey
+	cfg.P2pNetworkConfig.DB = db
 
 	n, err := p2pv1.New(logger, &cfg.P2pNetworkConfig)
 	if err != nil {
@@ -641,6 +841,15 @@ func setupP2P(logger *zap.Logger, db basedb.Database) network.P2PNetwork {
 	return n
 }
 
+// beaconNodeProbes returns a probe for each of the configured beacon nodes.
+func beaconNodeProbes(consensusClient *goclient.GoClient) map[string]nodeprobe.Node {
+	probes := make(map[string]nodeprobe.Node)
+	for name, checker := range consensusClient.NodeCheckers() {
+		probes[name] = checker
+	}
+	return probes
+}
+
 func setupConsensusClient(
 	logger *zap.Logger,
 	operatorDataStore operatordatastore.OperatorDataStore,
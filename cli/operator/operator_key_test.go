@@ -0,0 +1,117 @@
+package operator
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
+)
+
+// newTestKeyholder serves the given private key the way a keyholder service for remote operator keys does.
+func newTestKeyholder(t *testing.T, privKey *rsa.PrivateKey) *httptest.Server {
+	respond := func(w http.ResponseWriter, body any) {
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/operator/public-key", func(w http.ResponseWriter, r *http.Request) {
+		pubKey, err := rsaencryption.ExtractPublicKey(&privKey.PublicKey)
+		require.NoError(t, err)
+		respond(w, map[string]string{"public_key": pubKey})
+	})
+	mux.HandleFunc("POST /v1/operator/sign", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Digests [][]byte `json:"digests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		signatures := make([][]byte, 0, len(req.Digests))
+		for _, digest := range req.Digests {
+			signature, err := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, digest)
+			require.NoError(t, err)
+			signatures = append(signatures, signature)
+		}
+		respond(w, map[string][][]byte{"signatures": signatures})
+	})
+	mux.HandleFunc("GET /v1/operator/key-hashes", func(w http.ResponseWriter, r *http.Request) {
+		storageHash := sha256.Sum256(rsaencryption.PrivateKeyToByte(privKey))
+		ekmHash := sha256.Sum256(x509.MarshalPKCS1PrivateKey(privKey))
+		respond(w, map[string]string{
+			"storage_hash": hex.EncodeToString(storageHash[:]),
+			"ekm_hash":     hex.EncodeToString(ekmHash[:]),
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOperatorKey_SwitchFromLocalToRemote(t *testing.T) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+	logger := zap.New(zapcore.NewNopCore(), zap.WithFatalHook(zapcore.WriteThenPanic))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	localKeyText := base64.StdEncoding.EncodeToString(rsaencryption.PrivateKeyToByte(rsaKey))
+	localKey, err := keys.PrivateKeyFromString(localKeyText)
+	require.NoError(t, err)
+
+	// Start with the local key, and save a share encrypted by it.
+	require.NotPanics(t, func() { setupOperatorStorage(logger, db, localKey, localKeyText) })
+	localEKMHash, err := localKey.EKMHash()
+	require.NoError(t, err)
+	keyManager, err := ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, localEKMHash)
+	require.NoError(t, err)
+	shareKey := &bls.SecretKey{}
+	shareKey.SetByCSPRNG()
+	require.NoError(t, keyManager.AddShare(shareKey))
+
+	// Switch to the same key held by a keyholder service.
+	remoteKey, err := keys.NewRemoteKey(ctx, logger, keys.RemoteKeyOptions{
+		URL:          newTestKeyholder(t, rsaKey).URL,
+		Timeout:      5 * time.Second,
+		MaxBatchSize: 16,
+		CacheSize:    16,
+	})
+	require.NoError(t, err)
+
+	require.NotPanics(t, func() { setupOperatorStorage(logger, db, remoteKey, "") })
+	remoteEKMHash, err := remoteKey.EKMHash()
+	require.NoError(t, err)
+	keyManager, err = ekm.NewETHKeyManagerSigner(logger, db, networkconfig.TestNetwork, remoteEKMHash)
+	require.NoError(t, err)
+
+	// The share is readable only if the storage is decrypted with the same hash.
+	accounts, err := keyManager.(ekm.StorageProvider).ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, shareKey.GetPublicKey().Serialize(), accounts[0].ValidatorPublicKey())
+}
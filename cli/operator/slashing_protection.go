@@ -0,0 +1,161 @@
+package operator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	global_config "github.com/ssvlabs/ssv/cli/config"
+	"github.com/ssvlabs/ssv/cli/flags"
+	"github.com/ssvlabs/ssv/ekm"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// knownGenesisValidatorsRoots are the genesis validators roots of the beacon networks,
+// used to fill in and verify the metadata of slashing protection interchange files.
+var knownGenesisValidatorsRoots = map[spectypes.BeaconNetwork]string{
+	spectypes.MainNetwork:    "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+	spectypes.HoleskyNetwork: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
+}
+
+// SlashingProtectionCmd groups the commands to move slashing protection data between nodes.
+// The node must be stopped while running them, since they access its database directly.
+var SlashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Export or import the slashing protection data of the shares in the EIP-3076 interchange format",
+}
+
+var exportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the slashing protection data of all shares to an EIP-3076 interchange file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, signerStorage, genesisValidatorsRoot, db := setupSlashingProtection(cmd)
+		defer closeDB(logger, db)
+
+		filePath, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		interchange, err := ekm.ExportSlashingProtection(signerStorage, genesisValidatorsRoot)
+		if err != nil {
+			logger.Fatal("could not export slashing protection data", zap.Error(err))
+		}
+
+		data, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("could not marshal slashing protection data", zap.Error(err))
+		}
+		if err := os.WriteFile(filePath, data, 0600); err != nil {
+			logger.Fatal("could not write slashing protection interchange file", zap.Error(err))
+		}
+
+		logger.Info("exported slashing protection data",
+			zap.String("file", filePath),
+			zap.Int("validators", len(interchange.Data)))
+	},
+}
+
+var importSlashingProtectionCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports the slashing protection data of shares from an EIP-3076 interchange file, never lowering the existing data",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger, signerStorage, genesisValidatorsRoot, db := setupSlashingProtection(cmd)
+		defer closeDB(logger, db)
+
+		filePath, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		// nolint: gosec
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			logger.Fatal("could not read slashing protection interchange file", zap.Error(err))
+		}
+
+		var interchange ekm.Interchange
+		if err := json.Unmarshal(data, &interchange); err != nil {
+			logger.Fatal("could not unmarshal slashing protection interchange file", zap.Error(err))
+		}
+
+		if err := ekm.ImportSlashingProtection(signerStorage, &interchange, genesisValidatorsRoot); err != nil {
+			logger.Fatal("could not import slashing protection data", zap.Error(err))
+		}
+
+		logger.Info("imported slashing protection data",
+			zap.String("file", filePath),
+			zap.Int("validators", len(interchange.Data)))
+	},
+}
+
+func setupSlashingProtection(cmd *cobra.Command) (*zap.Logger, ekm.Storage, phase0.Root, basedb.Database) {
+	logger, err := setupGlobal()
+	if err != nil {
+		log.Fatal("could not create logger ", err)
+	}
+
+	networkConfig, err := setupSSVNetwork(logger)
+	if err != nil {
+		logger.Fatal("could not setup network", zap.Error(err))
+	}
+
+	genesisValidatorsRoot, err := getGenesisValidatorsRoot(cmd, networkConfig)
+	if err != nil {
+		logger.Fatal("could not get genesis validators root", zap.Error(err))
+	}
+
+	cfg.DBOptions.Ctx = cmd.Context()
+	db, err := setupDB(logger, networkConfig.Beacon.GetNetwork())
+	if err != nil {
+		logger.Fatal("could not setup db", zap.Error(err))
+	}
+
+	signerStorage := ekm.NewSignerStorage(db, networkConfig.Beacon, logger)
+
+	return logger, signerStorage, genesisValidatorsRoot, db
+}
+
+// getGenesisValidatorsRoot returns the genesis validators root given by flag,
+// or the known one of the configured beacon network.
+func getGenesisValidatorsRoot(cmd *cobra.Command, networkConfig networkconfig.NetworkConfig) (phase0.Root, error) {
+	rootHex, err := flags.GetGenesisValidatorsRootFlagValue(cmd)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	if rootHex == "" {
+		var ok bool
+		rootHex, ok = knownGenesisValidatorsRoots[networkConfig.Beacon.GetBeaconNetwork()]
+		if !ok {
+			return phase0.Root{}, fmt.Errorf("unknown genesis validators root for beacon network %s, specify it with a flag",
+				networkConfig.Beacon.GetBeaconNetwork())
+		}
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(rootHex, "0x"))
+	if err != nil {
+		return phase0.Root{}, fmt.Errorf("invalid genesis validators root: %w", err)
+	}
+	if len(b) != len(phase0.Root{}) {
+		return phase0.Root{}, fmt.Errorf("invalid genesis validators root length: %d", len(b))
+	}
+	return phase0.Root(b), nil
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, SlashingProtectionCmd)
+	flags.AddInterchangeFileFlag(SlashingProtectionCmd)
+	flags.AddGenesisValidatorsRootFlag(SlashingProtectionCmd)
+
+	SlashingProtectionCmd.AddCommand(exportSlashingProtectionCmd)
+	SlashingProtectionCmd.AddCommand(importSlashingProtectionCmd)
+}
@@ -64,6 +64,8 @@ type KeyManager interface {
 	AddShare(shareKey *bls.SecretKey) error
 	// RemoveShare removes a share key
 	RemoveShare(pubKey string) error
+	// BumpSlashingProtection raises the slashing protection of a share to the current epoch and slot
+	BumpSlashingProtection(pubKey []byte) error
 }
 
 // NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
@@ -0,0 +1,45 @@
+package ekm
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// SigningGuard decides whether beacon objects may be signed at the moment.
+type SigningGuard interface {
+	// CanSign returns an error if signing isn't allowed.
+	CanSign() error
+}
+
+type guardedKeyManager struct {
+	KeyManager
+	guard SigningGuard
+}
+
+// NewGuardedKeyManager returns a KeyManager which refuses to sign beacon objects while the guard doesn't allow it.
+func NewGuardedKeyManager(km KeyManager, guard SigningGuard) KeyManager {
+	return &guardedKeyManager{
+		KeyManager: km,
+		guard:      guard,
+	}
+}
+
+func (km *guardedKeyManager) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	if err := km.guard.CanSign(); err != nil {
+		return nil, [32]byte{}, err
+	}
+	return km.KeyManager.SignBeaconObject(obj, domain, pk, domainType)
+}
+
+func (km *guardedKeyManager) SignBeaconObjectAtSlot(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType, slot phase0.Slot) (spectypes.Signature, [32]byte, error) {
+	if err := km.guard.CanSign(); err != nil {
+		return nil, [32]byte{}, err
+	}
+	if signer, ok := km.KeyManager.(ssvtypes.SlotBeaconSigner); ok {
+		return signer.SignBeaconObjectAtSlot(obj, domain, pk, domainType, slot)
+	}
+	return km.KeyManager.SignBeaconObject(obj, domain, pk, domainType)
+}
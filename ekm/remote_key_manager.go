@@ -0,0 +1,567 @@
+package ekm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	apiv1capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	apiv1deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+)
+
+const (
+	// DefaultRemoteSignerTimeout is the default timeout of requests to the remote signer.
+	DefaultRemoteSignerTimeout = 10 * time.Second
+
+	remoteSignerSignPath      = "/api/v1/eth2/sign/"
+	remoteSignerKeystoresPath = "/eth/v1/keystores"
+)
+
+// ForkInfoProvider provides the fork information the remote signer needs to compute signing domains.
+type ForkInfoProvider interface {
+	ForkInfo(epoch phase0.Epoch) (*phase0.Fork, phase0.Root, error)
+}
+
+// remoteKeyManager is a KeyManager which keeps share keys in a remote signer
+// implementing the Web3Signer signing API and the Ethereum keymanager API.
+// Slashing protection is enforced by the remote signer, which refuses to sign slashable objects.
+type remoteKeyManager struct {
+	logger           *zap.Logger
+	client           *http.Client
+	signerURL        string
+	network          networkconfig.NetworkConfig
+	forkInfoProvider ForkInfoProvider
+}
+
+// NewRemoteKeyManager returns a KeyManager which delegates signing to the remote signer at signerURL.
+func NewRemoteKeyManager(
+	logger *zap.Logger,
+	signerURL string,
+	timeout time.Duration,
+	network networkconfig.NetworkConfig,
+	forkInfoProvider ForkInfoProvider,
+) KeyManager {
+	return &remoteKeyManager{
+		logger:           logger.Named("RemoteKeyManager"),
+		client:           &http.Client{Timeout: timeout},
+		signerURL:        strings.TrimSuffix(signerURL, "/"),
+		network:          network,
+		forkInfoProvider: forkInfoProvider,
+	}
+}
+
+type remoteForkInfo struct {
+	Fork                  *phase0.Fork `json:"fork"`
+	GenesisValidatorsRoot phase0.Root  `json:"genesis_validators_root"`
+}
+
+type remoteBeaconBlock struct {
+	Version     string                    `json:"version"`
+	BlockHeader *phase0.BeaconBlockHeader `json:"block_header"`
+}
+
+type remoteAggregationSlot struct {
+	Slot phase0.Slot `json:"slot,string"`
+}
+
+type remoteRandaoReveal struct {
+	Epoch phase0.Epoch `json:"epoch,string"`
+}
+
+type remoteSyncCommitteeMessage struct {
+	BeaconBlockRoot phase0.Root `json:"beacon_block_root"`
+	Slot            phase0.Slot `json:"slot,string"`
+}
+
+type remoteSyncAggregatorSelectionData struct {
+	Slot              phase0.Slot `json:"slot,string"`
+	SubcommitteeIndex uint64      `json:"subcommittee_index,string"`
+}
+
+// remoteSignRequest is the body of a Web3Signer signing request.
+// Exactly one of the object fields is set, according to Type.
+type remoteSignRequest struct {
+	Type        string          `json:"type"`
+	ForkInfo    *remoteForkInfo `json:"fork_info,omitempty"`
+	SigningRoot phase0.Root     `json:"signingRoot"`
+
+	Attestation                 *phase0.AttestationData            `json:"attestation,omitempty"`
+	BeaconBlock                 *remoteBeaconBlock                 `json:"beacon_block,omitempty"`
+	VoluntaryExit               *phase0.VoluntaryExit              `json:"voluntary_exit,omitempty"`
+	AggregateAndProof           *phase0.AggregateAndProof          `json:"aggregate_and_proof,omitempty"`
+	AggregationSlot             *remoteAggregationSlot             `json:"aggregation_slot,omitempty"`
+	RandaoReveal                *remoteRandaoReveal                `json:"randao_reveal,omitempty"`
+	SyncCommitteeMessage        *remoteSyncCommitteeMessage        `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *remoteSyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof       `json:"contribution_and_proof,omitempty"`
+	ValidatorRegistration       *eth2apiv1.ValidatorRegistration   `json:"validator_registration,omitempty"`
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+func (km *remoteKeyManager) SignBeaconObject(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType) (spectypes.Signature, [32]byte, error) {
+	if domainType == spectypes.DomainSyncCommittee {
+		return nil, [32]byte{}, fmt.Errorf("sync committee messages must be signed with their slot")
+	}
+	return km.SignBeaconObjectAtSlot(obj, domain, pk, domainType, 0)
+}
+
+// SignBeaconObjectAtSlot signs the object with the remote signer. The slot of the duty is only used
+// for sync committee messages, whose fork the remote signer determines by their slot.
+func (km *remoteKeyManager) SignBeaconObjectAtSlot(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType, slot phase0.Slot) (spectypes.Signature, [32]byte, error) {
+	root, err := spectypes.ComputeETHSigningRoot(obj, domain)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("could not compute signing root: %w", err)
+	}
+
+	req, epoch, err := km.signRequest(obj, domainType, slot)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	req.SigningRoot = root
+
+	// The builder domain doesn't depend on the fork, so fork info isn't needed for validator registrations.
+	if domainType != spectypes.DomainApplicationBuilder {
+		fork, genesisValidatorsRoot, err := km.forkInfoProvider.ForkInfo(epoch)
+		if err != nil {
+			return nil, [32]byte{}, fmt.Errorf("could not get fork info: %w", err)
+		}
+		req.ForkInfo = &remoteForkInfo{
+			Fork:                  fork,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+		}
+	}
+
+	var resp remoteSignResponse
+	if err := km.request(http.MethodPost, remoteSignerSignPath+"0x"+hex.EncodeToString(pk), req, &resp); err != nil {
+		return nil, [32]byte{}, fmt.Errorf("remote signer failed to sign %s: %w", req.Type, err)
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(resp.Signature, "0x"))
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("could not decode signature: %w", err)
+	}
+	if len(sig) != phase0.SignatureLength {
+		return nil, [32]byte{}, fmt.Errorf("unexpected signature length: %d", len(sig))
+	}
+
+	return sig, root, nil
+}
+
+// signRequest builds the signing request of the object, without the signing root and fork info,
+// and returns the epoch of the object which determines the fork. The slot of sync committee messages is given,
+// since their object is only the block root.
+func (km *remoteKeyManager) signRequest(obj ssz.HashRoot, domainType phase0.DomainType, slot phase0.Slot) (*remoteSignRequest, phase0.Epoch, error) {
+	beaconNetwork := km.network.Beacon
+
+	switch domainType {
+	case spectypes.DomainAttester:
+		data, ok := obj.(*phase0.AttestationData)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to AttestationData")
+		}
+		return &remoteSignRequest{Type: "ATTESTATION", Attestation: data}, data.Target.Epoch, nil
+	case spectypes.DomainProposer:
+		block, err := remoteBlock(obj)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &remoteSignRequest{Type: "BLOCK_V2", BeaconBlock: block}, beaconNetwork.EstimatedEpochAtSlot(block.BlockHeader.Slot), nil
+	case spectypes.DomainVoluntaryExit:
+		data, ok := obj.(*phase0.VoluntaryExit)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to VoluntaryExit")
+		}
+		return &remoteSignRequest{Type: "VOLUNTARY_EXIT", VoluntaryExit: data}, data.Epoch, nil
+	case spectypes.DomainAggregateAndProof:
+		data, ok := obj.(*phase0.AggregateAndProof)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to AggregateAndProof")
+		}
+		return &remoteSignRequest{Type: "AGGREGATE_AND_PROOF", AggregateAndProof: data}, beaconNetwork.EstimatedEpochAtSlot(data.Aggregate.Data.Slot), nil
+	case spectypes.DomainSelectionProof:
+		data, ok := obj.(spectypes.SSZUint64)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to SSZUint64")
+		}
+		slot := phase0.Slot(data)
+		return &remoteSignRequest{Type: "AGGREGATION_SLOT", AggregationSlot: &remoteAggregationSlot{Slot: slot}}, beaconNetwork.EstimatedEpochAtSlot(slot), nil
+	case spectypes.DomainRandao:
+		data, ok := obj.(spectypes.SSZUint64)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to SSZUint64")
+		}
+		epoch := phase0.Epoch(data)
+		return &remoteSignRequest{Type: "RANDAO_REVEAL", RandaoReveal: &remoteRandaoReveal{Epoch: epoch}}, epoch, nil
+	case spectypes.DomainSyncCommittee:
+		data, ok := obj.(spectypes.SSZBytes)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to SSZBytes")
+		}
+		if len(data) != len(phase0.Root{}) {
+			return nil, 0, fmt.Errorf("unexpected sync committee block root length: %d", len(data))
+		}
+		return &remoteSignRequest{
+			Type: "SYNC_COMMITTEE_MESSAGE",
+			SyncCommitteeMessage: &remoteSyncCommitteeMessage{
+				BeaconBlockRoot: phase0.Root(data),
+				Slot:            slot,
+			},
+		}, beaconNetwork.EstimatedEpochAtSlot(slot), nil
+	case spectypes.DomainSyncCommitteeSelectionProof:
+		data, ok := obj.(*altair.SyncAggregatorSelectionData)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to SyncAggregatorSelectionData")
+		}
+		return &remoteSignRequest{
+			Type: "SYNC_COMMITTEE_SELECTION_PROOF",
+			SyncAggregatorSelectionData: &remoteSyncAggregatorSelectionData{
+				Slot:              data.Slot,
+				SubcommitteeIndex: data.SubcommitteeIndex,
+			},
+		}, beaconNetwork.EstimatedEpochAtSlot(data.Slot), nil
+	case spectypes.DomainContributionAndProof:
+		data, ok := obj.(*altair.ContributionAndProof)
+		if !ok {
+			return nil, 0, fmt.Errorf("could not cast obj to ContributionAndProof")
+		}
+		return &remoteSignRequest{
+			Type:                 "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF",
+			ContributionAndProof: data,
+		}, beaconNetwork.EstimatedEpochAtSlot(data.Contribution.Slot), nil
+	case spectypes.DomainApplicationBuilder:
+		data, ok := obj.(*eth2apiv1.ValidatorRegistration)
+		if !ok {
+			return nil, 0, fmt.Errorf("obj type is unknown: %T", obj)
+		}
+		return &remoteSignRequest{Type: "VALIDATOR_REGISTRATION", ValidatorRegistration: data}, 0, nil
+	default:
+		return nil, 0, fmt.Errorf("domain unknown")
+	}
+}
+
+// remoteBlock returns the header of the block, which is what the remote signer expects for signing blocks.
+// The header is the same for full and blinded blocks.
+func remoteBlock(obj ssz.HashRoot) (*remoteBeaconBlock, error) {
+	var (
+		version spec.DataVersion
+		header  *phase0.BeaconBlockHeader
+		body    ssz.HashRoot
+	)
+	switch v := obj.(type) {
+	case *capella.BeaconBlock:
+		version, header, body = spec.DataVersionCapella, blockHeader(v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot), v.Body
+	case *deneb.BeaconBlock:
+		version, header, body = spec.DataVersionDeneb, blockHeader(v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot), v.Body
+	case *apiv1capella.BlindedBeaconBlock:
+		version, header, body = spec.DataVersionCapella, blockHeader(v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot), v.Body
+	case *apiv1deneb.BlindedBeaconBlock:
+		version, header, body = spec.DataVersionDeneb, blockHeader(v.Slot, v.ProposerIndex, v.ParentRoot, v.StateRoot), v.Body
+	default:
+		return nil, fmt.Errorf("obj type is unknown: %T", obj)
+	}
+
+	bodyRoot, err := body.HashTreeRoot()
+	if err != nil {
+		return nil, fmt.Errorf("could not compute block body root: %w", err)
+	}
+	header.BodyRoot = bodyRoot
+
+	return &remoteBeaconBlock{
+		Version:     strings.ToUpper(version.String()),
+		BlockHeader: header,
+	}, nil
+}
+
+func blockHeader(slot phase0.Slot, proposerIndex phase0.ValidatorIndex, parentRoot, stateRoot phase0.Root) *phase0.BeaconBlockHeader {
+	return &phase0.BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    parentRoot,
+		StateRoot:     stateRoot,
+	}
+}
+
+// IsAttestationSlashable always returns nil, because the remote signer refuses to sign slashable attestations.
+func (km *remoteKeyManager) IsAttestationSlashable(pk spectypes.ShareValidatorPK, data *phase0.AttestationData) error {
+	return nil
+}
+
+// IsBeaconBlockSlashable always returns nil, because the remote signer refuses to sign slashable blocks.
+func (km *remoteKeyManager) IsBeaconBlockSlashable(pk []byte, slot phase0.Slot) error {
+	return nil
+}
+
+type remoteImportKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+
+type remoteDeleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+type remoteKeystoresResponse struct {
+	Data []struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+type remoteListKeystoresResponse struct {
+	Data []struct {
+		ValidatingPubkey string `json:"validating_pubkey"`
+	} `json:"data"`
+}
+
+// remoteImportBatchSize is the maximum number of share keys imported into the remote signer in one request.
+const remoteImportBatchSize = 100
+
+// SharesImporter is implemented by key managers which hold share keys apart from the node,
+// and so must be given the share keys which the node already has.
+type SharesImporter interface {
+	// ImportShares imports the share keys which the key manager doesn't hold yet and returns how many were imported.
+	ImportShares(shareKeys []*bls.SecretKey) (int, error)
+}
+
+// AddShare imports the share key into the remote signer as an EIP-2335 keystore,
+// along with slashing protection data which prevents signing anything before the current epoch.
+func (km *remoteKeyManager) AddShare(shareKey *bls.SecretKey) error {
+	return km.importShares([]*bls.SecretKey{shareKey})
+}
+
+// ImportShares imports the share keys which the remote signer doesn't hold yet, the same way as AddShare.
+// It's used when switching to the remote signer, since AddShare is called only for newly added validators.
+func (km *remoteKeyManager) ImportShares(shareKeys []*bls.SecretKey) (int, error) {
+	var resp remoteListKeystoresResponse
+	if err := km.request(http.MethodGet, remoteSignerKeystoresPath, nil, &resp); err != nil {
+		return 0, fmt.Errorf("could not list share keys of remote signer: %w", err)
+	}
+	existing := make(map[string]struct{}, len(resp.Data))
+	for _, keystore := range resp.Data {
+		existing[strings.ToLower(keystore.ValidatingPubkey)] = struct{}{}
+	}
+
+	var missing []*bls.SecretKey
+	for _, shareKey := range shareKeys {
+		if _, ok := existing["0x"+shareKey.GetPublicKey().SerializeToHexStr()]; !ok {
+			missing = append(missing, shareKey)
+		}
+	}
+
+	for start := 0; start < len(missing); start += remoteImportBatchSize {
+		if err := km.importShares(missing[start:min(start+remoteImportBatchSize, len(missing))]); err != nil {
+			return start, err
+		}
+	}
+	return len(missing), nil
+}
+
+func (km *remoteKeyManager) importShares(shareKeys []*bls.SecretKey) error {
+	req := remoteImportKeystoresRequest{
+		Keystores: make([]string, 0, len(shareKeys)),
+		Passwords: make([]string, 0, len(shareKeys)),
+	}
+	pubKeys := make([]string, 0, len(shareKeys))
+	for _, shareKey := range shareKeys {
+		pubKey := "0x" + shareKey.GetPublicKey().SerializeToHexStr()
+
+		password, err := randomPassword()
+		if err != nil {
+			return err
+		}
+		crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(shareKey.Serialize(), password)
+		if err != nil {
+			return fmt.Errorf("could not encrypt share key: %w", err)
+		}
+		keystore, err := json.Marshal(map[string]any{
+			"crypto":  crypto,
+			"pubkey":  strings.TrimPrefix(pubKey, "0x"),
+			"path":    "",
+			"uuid":    uuid.New().String(),
+			"version": 4,
+		})
+		if err != nil {
+			return fmt.Errorf("could not marshal keystore: %w", err)
+		}
+
+		req.Keystores = append(req.Keystores, string(keystore))
+		req.Passwords = append(req.Passwords, password)
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	slashingProtection, err := km.minimalSlashingProtection(pubKeys)
+	if err != nil {
+		return err
+	}
+	req.SlashingProtection = slashingProtection
+
+	var resp remoteKeystoresResponse
+	if err := km.request(http.MethodPost, remoteSignerKeystoresPath, req, &resp); err != nil {
+		return fmt.Errorf("could not import share key into remote signer: %w", err)
+	}
+	if len(resp.Data) != len(pubKeys) {
+		return fmt.Errorf("unexpected number of import statuses: %d", len(resp.Data))
+	}
+
+	for i, status := range resp.Data {
+		switch status.Status {
+		case "imported", "duplicate":
+			km.logger.Debug("imported share key into remote signer", zap.String("pubkey", pubKeys[i]), zap.String("status", status.Status))
+		default:
+			return fmt.Errorf("remote signer failed to import share key %s (%s): %s", pubKeys[i], status.Status, status.Message)
+		}
+	}
+	return nil
+}
+
+// RemoveShare deletes the share key from the remote signer.
+func (km *remoteKeyManager) RemoveShare(pubKey string) error {
+	req := remoteDeleteKeystoresRequest{
+		Pubkeys: []string{"0x" + strings.TrimPrefix(pubKey, "0x")},
+	}
+	var resp remoteKeystoresResponse
+	if err := km.request(http.MethodDelete, remoteSignerKeystoresPath, req, &resp); err != nil {
+		return fmt.Errorf("could not delete share key from remote signer: %w", err)
+	}
+	if len(resp.Data) != 1 {
+		return fmt.Errorf("unexpected number of delete statuses: %d", len(resp.Data))
+	}
+
+	switch status := resp.Data[0]; status.Status {
+	case "deleted", "not_found", "not_active":
+		return nil
+	default:
+		return fmt.Errorf("remote signer failed to delete share key (%s): %s", status.Status, status.Message)
+	}
+}
+
+// BumpSlashingProtection is a no-op, because the remote signer keeps the full
+// slashing protection history of the share and there's no API to raise it.
+func (km *remoteKeyManager) BumpSlashingProtection(pubKey []byte) error {
+	return nil
+}
+
+// minimalSlashingProtection returns EIP-3076 interchange data for the shares which prevents
+// signing attestations and blocks before the current epoch and slot, the same way BumpSlashingProtection does locally.
+func (km *remoteKeyManager) minimalSlashingProtection(pubKeys []string) (string, error) {
+	currentSlot := km.network.Beacon.EstimatedCurrentSlot()
+	currentEpoch := km.network.Beacon.EstimatedEpochAtSlot(currentSlot)
+
+	_, genesisValidatorsRoot, err := km.forkInfoProvider.ForkInfo(currentEpoch)
+	if err != nil {
+		return "", fmt.Errorf("could not get fork info: %w", err)
+	}
+
+	highestTarget := currentEpoch + minSPAttestationEpochGap
+	highestSource := highestTarget
+	if highestSource > 0 {
+		highestSource--
+	}
+	interchange := Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    "0x" + hex.EncodeToString(genesisValidatorsRoot[:]),
+		},
+	}
+	for _, pubKey := range pubKeys {
+		interchange.Data = append(interchange.Data, InterchangeData{
+			Pubkey: pubKey,
+			SignedBlocks: []InterchangeBlock{{
+				Slot: strconv.FormatUint(uint64(currentSlot+minSPProposalSlotGap), 10),
+			}},
+			SignedAttestations: []InterchangeAttestation{{
+				SourceEpoch: strconv.FormatUint(uint64(highestSource), 10),
+				TargetEpoch: strconv.FormatUint(uint64(highestTarget), 10),
+			}},
+		})
+	}
+
+	b, err := json.Marshal(interchange)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal slashing protection data: %w", err)
+	}
+	return string(b), nil
+}
+
+func (km *remoteKeyManager) request(method, path string, body, result any) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("could not marshal request: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), km.client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, km.signerURL+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := km.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("signing refused by slashing protection: %s", respBody)
+	case http.StatusNotFound:
+		return fmt.Errorf("key not found: %s", respBody)
+	default:
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("could not unmarshal response: %w", err)
+	}
+	return nil
+}
+
+func randomPassword() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not generate keystore password: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,340 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/networkconfig"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/utils/threshold"
+)
+
+type testForkInfoProvider struct{}
+
+func (testForkInfoProvider) ForkInfo(epoch phase0.Epoch) (*phase0.Fork, phase0.Root, error) {
+	return &phase0.Fork{
+		PreviousVersion: phase0.Version{0x1},
+		CurrentVersion:  phase0.Version{0x2},
+	}, phase0.Root{0x3}, nil
+}
+
+// testRemoteSigner is a stand-in for a Web3Signer instance with a minimal slashing protection.
+type testRemoteSigner struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu             sync.Mutex
+	keys           map[string]*bls.SecretKey
+	highestTarget  map[string]uint64
+	highestSlot    map[string]uint64
+	signedRequests []remoteSignRequest
+	importRequests int
+}
+
+func newTestRemoteSigner(t *testing.T) *testRemoteSigner {
+	s := &testRemoteSigner{
+		t:             t,
+		keys:          make(map[string]*bls.SecretKey),
+		highestTarget: make(map[string]uint64),
+		highestSlot:   make(map[string]uint64),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+remoteSignerKeystoresPath, s.listKeystores)
+	mux.HandleFunc("POST "+remoteSignerKeystoresPath, s.importKeystores)
+	mux.HandleFunc("DELETE "+remoteSignerKeystoresPath, s.deleteKeystores)
+	mux.HandleFunc("POST "+remoteSignerSignPath+"{pubkey}", s.sign)
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *testRemoteSigner) importKeystores(w http.ResponseWriter, r *http.Request) {
+	var req remoteImportKeystoresRequest
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+	require.Len(s.t, req.Passwords, len(req.Keystores))
+
+	var interchange Interchange
+	require.NoError(s.t, json.Unmarshal([]byte(req.SlashingProtection), &interchange))
+	require.Len(s.t, interchange.Data, len(req.Keystores))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.importRequests++
+	statuses := make([]map[string]string, 0, len(req.Keystores))
+	for i := range req.Keystores {
+		var keystore struct {
+			Crypto map[string]any `json:"crypto"`
+			Pubkey string         `json:"pubkey"`
+		}
+		require.NoError(s.t, json.Unmarshal([]byte(req.Keystores[i]), &keystore))
+		secret, err := keystorev4.New().Decrypt(keystore.Crypto, req.Passwords[i])
+		require.NoError(s.t, err)
+		sk := &bls.SecretKey{}
+		require.NoError(s.t, sk.Deserialize(secret))
+		require.Equal(s.t, keystore.Pubkey, sk.GetPublicKey().SerializeToHexStr())
+
+		pubKey := "0x" + keystore.Pubkey
+		status := "imported"
+		if _, ok := s.keys[pubKey]; ok {
+			status = "duplicate"
+		}
+		s.keys[pubKey] = sk
+		statuses = append(statuses, map[string]string{"status": status})
+	}
+	for _, data := range interchange.Data {
+		for _, att := range data.SignedAttestations {
+			target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+			require.NoError(s.t, err)
+			s.highestTarget[data.Pubkey] = max(s.highestTarget[data.Pubkey], target)
+		}
+		for _, block := range data.SignedBlocks {
+			slot, err := strconv.ParseUint(block.Slot, 10, 64)
+			require.NoError(s.t, err)
+			s.highestSlot[data.Pubkey] = max(s.highestSlot[data.Pubkey], slot)
+		}
+	}
+
+	s.respond(w, http.StatusOK, map[string]any{"data": statuses})
+}
+
+func (s *testRemoteSigner) listKeystores(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keystores := make([]map[string]string, 0, len(s.keys))
+	for pubKey := range s.keys {
+		keystores = append(keystores, map[string]string{"validating_pubkey": pubKey})
+	}
+	s.respond(w, http.StatusOK, map[string]any{"data": keystores})
+}
+
+func (s *testRemoteSigner) deleteKeystores(w http.ResponseWriter, r *http.Request) {
+	var req remoteDeleteKeystoresRequest
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+	require.Len(s.t, req.Pubkeys, 1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := "not_found"
+	if _, ok := s.keys[req.Pubkeys[0]]; ok {
+		status = "deleted"
+		delete(s.keys, req.Pubkeys[0])
+	}
+	s.respond(w, http.StatusOK, map[string]any{"data": []map[string]string{{"status": status}}})
+}
+
+func (s *testRemoteSigner) sign(w http.ResponseWriter, r *http.Request) {
+	var req remoteSignRequest
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pubKey := r.PathValue("pubkey")
+	sk, ok := s.keys[pubKey]
+	if !ok {
+		s.respond(w, http.StatusNotFound, map[string]string{"error": "key not found"})
+		return
+	}
+	if req.Type != "VALIDATOR_REGISTRATION" {
+		require.NotNil(s.t, req.ForkInfo)
+	}
+
+	switch req.Type {
+	case "ATTESTATION":
+		target := uint64(req.Attestation.Target.Epoch)
+		if target <= s.highestTarget[pubKey] {
+			s.respond(w, http.StatusPreconditionFailed, map[string]string{"error": "slashable attestation"})
+			return
+		}
+		s.highestTarget[pubKey] = target
+	case "BLOCK_V2":
+		slot := uint64(req.BeaconBlock.BlockHeader.Slot)
+		if slot <= s.highestSlot[pubKey] {
+			s.respond(w, http.StatusPreconditionFailed, map[string]string{"error": "slashable block"})
+			return
+		}
+		s.highestSlot[pubKey] = slot
+	}
+	s.signedRequests = append(s.signedRequests, req)
+
+	// Copy the root, because cgo doesn't accept pointers into structs with Go pointers.
+	root := make([]byte, len(req.SigningRoot))
+	copy(root, req.SigningRoot[:])
+	sig := sk.SignByte(root)
+	s.respond(w, http.StatusOK, remoteSignResponse{Signature: "0x" + hex.EncodeToString(sig.Serialize())})
+}
+
+func (s *testRemoteSigner) respond(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(s.t, json.NewEncoder(w).Encode(body))
+}
+
+func TestRemoteKeyManager(t *testing.T) {
+	threshold.Init()
+
+	network := networkconfig.TestNetwork
+	signer := newTestRemoteSigner(t)
+	km := NewRemoteKeyManager(zap.NewNop(), signer.server.URL, DefaultRemoteSignerTimeout, network, testForkInfoProvider{})
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	pk := sk.GetPublicKey().Serialize()
+
+	currentEpoch := network.Beacon.EstimatedCurrentEpoch()
+	currentSlot := network.Beacon.EstimatedCurrentSlot()
+	domain := phase0.Domain{0x1}
+
+	attestation := func(target phase0.Epoch) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Slot:            network.Beacon.FirstSlotAtEpoch(target),
+			BeaconBlockRoot: phase0.Root{0x1},
+			Source:          &phase0.Checkpoint{Epoch: target - 1},
+			Target:          &phase0.Checkpoint{Epoch: target},
+		}
+	}
+
+	t.Run("signing fails for unknown share", func(t *testing.T) {
+		_, _, err := km.SignBeaconObject(attestation(currentEpoch+1), domain, pk, spectypes.DomainAttester)
+		require.ErrorContains(t, err, "key not found")
+	})
+
+	t.Run("share is imported", func(t *testing.T) {
+		require.NoError(t, km.AddShare(sk))
+		require.NoError(t, km.AddShare(sk))
+	})
+
+	t.Run("attestation is signed", func(t *testing.T) {
+		data := attestation(currentEpoch + 1)
+		sig, root, err := km.SignBeaconObject(data, domain, pk, spectypes.DomainAttester)
+		require.NoError(t, err)
+
+		expectedRoot, err := spectypes.ComputeETHSigningRoot(data, domain)
+		require.NoError(t, err)
+		require.EqualValues(t, expectedRoot, root)
+
+		blsSig := &bls.Sign{}
+		require.NoError(t, blsSig.Deserialize(sig))
+		require.True(t, blsSig.VerifyByte(sk.GetPublicKey(), root[:]))
+	})
+
+	t.Run("slashable attestation is refused", func(t *testing.T) {
+		_, _, err := km.SignBeaconObject(attestation(currentEpoch+1), domain, pk, spectypes.DomainAttester)
+		require.ErrorContains(t, err, "slashing protection")
+	})
+
+	t.Run("attestation before the share was added is refused", func(t *testing.T) {
+		_, _, err := km.SignBeaconObject(attestation(currentEpoch), domain, pk, spectypes.DomainAttester)
+		require.ErrorContains(t, err, "slashing protection")
+	})
+
+	t.Run("block is signed with its header", func(t *testing.T) {
+		block := *testingutils.TestingBlockContentsDeneb.Block
+		block.Slot = currentSlot + 1
+
+		sig, root, err := km.SignBeaconObject(&block, domain, pk, spectypes.DomainProposer)
+		require.NoError(t, err)
+
+		blsSig := &bls.Sign{}
+		require.NoError(t, blsSig.Deserialize(sig))
+		require.True(t, blsSig.VerifyByte(sk.GetPublicKey(), root[:]))
+
+		req := signer.signedRequests[len(signer.signedRequests)-1]
+		require.Equal(t, "BLOCK_V2", req.Type)
+		require.Equal(t, "DENEB", req.BeaconBlock.Version)
+		bodyRoot, err := block.Body.HashTreeRoot()
+		require.NoError(t, err)
+		require.EqualValues(t, bodyRoot, req.BeaconBlock.BlockHeader.BodyRoot)
+
+		// The header has the same root as the block, so the remote signer can verify the signing root.
+		headerRoot, err := spectypes.ComputeETHSigningRoot(req.BeaconBlock.BlockHeader, domain)
+		require.NoError(t, err)
+		require.EqualValues(t, root, headerRoot)
+	})
+
+	t.Run("randao reveal is signed", func(t *testing.T) {
+		_, _, err := km.SignBeaconObject(spectypes.SSZUint64(currentEpoch), domain, pk, spectypes.DomainRandao)
+		require.NoError(t, err)
+
+		req := signer.signedRequests[len(signer.signedRequests)-1]
+		require.Equal(t, "RANDAO_REVEAL", req.Type)
+		require.Equal(t, currentEpoch, req.RandaoReveal.Epoch)
+	})
+
+	t.Run("sync committee message is signed at the duty's slot", func(t *testing.T) {
+		root := phase0.Root{0x2}
+		blockRoot := spectypes.SSZBytes(root[:])
+		_, _, err := km.SignBeaconObject(blockRoot, domain, pk, spectypes.DomainSyncCommittee)
+		require.ErrorContains(t, err, "slot")
+
+		dutySlot := network.Beacon.FirstSlotAtEpoch(currentEpoch + 2)
+		_, _, err = km.(ssvtypes.SlotBeaconSigner).SignBeaconObjectAtSlot(blockRoot, domain, pk, spectypes.DomainSyncCommittee, dutySlot)
+		require.NoError(t, err)
+
+		req := signer.signedRequests[len(signer.signedRequests)-1]
+		require.Equal(t, "SYNC_COMMITTEE_MESSAGE", req.Type)
+		require.Equal(t, dutySlot, req.SyncCommitteeMessage.Slot)
+		require.Equal(t, root, req.SyncCommitteeMessage.BeaconBlockRoot)
+	})
+
+	t.Run("share is removed", func(t *testing.T) {
+		require.NoError(t, km.RemoveShare(strings.TrimPrefix(sk.GetPublicKey().SerializeToHexStr(), "0x")))
+		require.NoError(t, km.RemoveShare(sk.GetPublicKey().SerializeToHexStr()))
+
+		_, _, err := km.SignBeaconObject(attestation(currentEpoch+2), domain, pk, spectypes.DomainAttester)
+		require.ErrorContains(t, err, "key not found")
+	})
+
+	t.Run("local shares missing in the remote signer are imported", func(t *testing.T) {
+		db, err := getBaseStorage(zap.NewNop())
+		require.NoError(t, err)
+		localKM, err := NewETHKeyManagerSigner(zap.NewNop(), db, network, "")
+		require.NoError(t, err)
+
+		localKeys := make([]*bls.SecretKey, 3)
+		for i := range localKeys {
+			localKeys[i] = &bls.SecretKey{}
+			localKeys[i].SetByCSPRNG()
+			require.NoError(t, localKM.AddShare(localKeys[i]))
+		}
+		shareKeys, err := NewSignerStorage(db, network.Beacon, zap.NewNop()).ShareKeys()
+		require.NoError(t, err)
+		require.ElementsMatch(t, localKeys, shareKeys)
+
+		require.NoError(t, km.AddShare(localKeys[0]))
+		importRequests := signer.importRequests
+
+		imported, err := km.(SharesImporter).ImportShares(shareKeys)
+		require.NoError(t, err)
+		require.Equal(t, 2, imported)
+		require.Equal(t, importRequests+1, signer.importRequests)
+		for _, shareKey := range localKeys {
+			_, _, err := km.SignBeaconObject(attestation(currentEpoch+2), domain, shareKey.GetPublicKey().Serialize(), spectypes.DomainAttester)
+			require.NoError(t, err)
+		}
+
+		imported, err = km.(SharesImporter).ImportShares(shareKeys)
+		require.NoError(t, err)
+		require.Zero(t, imported)
+		require.Equal(t, importRequests+1, signer.importRequests)
+	})
+}
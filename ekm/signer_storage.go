@@ -13,6 +13,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"github.com/ssvlabs/eth2-key-manager/core"
 	"github.com/ssvlabs/eth2-key-manager/encryptor"
@@ -44,8 +45,11 @@ type Storage interface {
 
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
+	ListHighestAttestations() (map[phase0.BLSPubKey]*phase0.AttestationData, error)
+	ListHighestProposals() (map[phase0.BLSPubKey]phase0.Slot, error)
 	SetEncryptionKey(newKey string) error
 	ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, error)
+	ShareKeys() ([]*bls.SecretKey, error)
 	SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error
 
 	BeaconNetwork() beacon.BeaconNetwork
@@ -168,6 +172,37 @@ func (s *storage) ListAccountsTxn(r basedb.Reader) ([]core.ValidatorAccount, err
 	return ret, err
 }
 
+// ShareKeys returns the share keys of all accounts, which are needed to move them to another key manager.
+func (s *storage) ShareKeys() ([]*bls.SecretKey, error) {
+	accounts, err := s.ListAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	shareKeys := make([]*bls.SecretKey, 0, len(accounts))
+	for _, account := range accounts {
+		// Accounts expose their keys only through their JSON encoding.
+		data, err := json.Marshal(account)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal account")
+		}
+		var encoded struct {
+			ValidationKey struct {
+				PrivKey string `json:"privKey"`
+			} `json:"validationKey"`
+		}
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal account")
+		}
+		shareKey := &bls.SecretKey{}
+		if err := shareKey.SetHexString(encoded.ValidationKey.PrivKey); err != nil {
+			return nil, errors.Wrap(err, "failed to decode share key")
+		}
+		shareKeys = append(shareKeys, shareKey)
+	}
+	return shareKeys, nil
+}
+
 func (s *storage) SaveAccountTxn(rw basedb.ReadWriter, account core.ValidatorAccount) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -299,6 +334,26 @@ func (s *storage) RemoveHighestAttestation(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestAttPrefix), pubKey)
 }
 
+// ListHighestAttestations returns the highest attestation of every share public key which has one.
+func (s *storage) ListHighestAttestations() (map[phase0.BLSPubKey]*phase0.AttestationData, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := make(map[phase0.BLSPubKey]*phase0.AttestationData)
+	err := s.db.GetAll(s.objPrefix(highestAttPrefix), func(i int, obj basedb.Obj) error {
+		if len(obj.Key) != len(phase0.BLSPubKey{}) {
+			return fmt.Errorf("unexpected highest attestation key length: %d", len(obj.Key))
+		}
+		att := &phase0.AttestationData{}
+		if err := att.UnmarshalSSZ(obj.Value); err != nil {
+			return errors.Wrap(err, "could not unmarshal attestation data")
+		}
+		ret[phase0.BLSPubKey(obj.Key)] = att
+		return nil
+	})
+	return ret, err
+}
+
 func (s *storage) SaveHighestProposal(pubKey []byte, slot phase0.Slot) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -349,6 +404,25 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
 
+// ListHighestProposals returns the highest proposal slot of every share public key which has one.
+func (s *storage) ListHighestProposals() (map[phase0.BLSPubKey]phase0.Slot, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := make(map[phase0.BLSPubKey]phase0.Slot)
+	err := s.db.GetAll(s.objPrefix(highestProposalPrefix), func(i int, obj basedb.Obj) error {
+		if len(obj.Key) != len(phase0.BLSPubKey{}) {
+			return fmt.Errorf("unexpected highest proposal key length: %d", len(obj.Key))
+		}
+		if len(obj.Value) != 8 {
+			return fmt.Errorf("unexpected highest proposal value length: %d", len(obj.Value))
+		}
+		ret[phase0.BLSPubKey(obj.Key)] = phase0.Slot(ssz.UnmarshallUint64(obj.Value))
+		return nil
+	})
+	return ret, err
+}
+
 func (s *storage) decryptData(objectValue []byte) ([]byte, error) {
 	if len(s.encryptionKey) == 0 {
 		return objectValue, nil
@@ -0,0 +1,241 @@
+package ekm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// InterchangeFormatVersion is the version of the EIP-3076 slashing protection interchange format.
+const InterchangeFormatVersion = "5"
+
+// Interchange is the EIP-3076 slashing protection interchange format.
+// See https://eips.ethereum.org/EIPS/eip-3076
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type InterchangeData struct {
+	Pubkey             string                   `json:"pubkey"`
+	SignedBlocks       []InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeAttestation `json:"signed_attestations"`
+}
+
+type InterchangeBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// ExportSlashingProtection returns the slashing protection data of all shares in the EIP-3076 interchange format.
+// Only the highest attestation and proposal are stored per share, so they're exported
+// as a single attestation and block without signing roots, which is the minimal form allowed by the format.
+func ExportSlashingProtection(s Storage, genesisValidatorsRoot phase0.Root) (*Interchange, error) {
+	attestations, err := s.ListHighestAttestations()
+	if err != nil {
+		return nil, fmt.Errorf("could not list highest attestations: %w", err)
+	}
+	proposals, err := s.ListHighestProposals()
+	if err != nil {
+		return nil, fmt.Errorf("could not list highest proposals: %w", err)
+	}
+
+	data := make(map[phase0.BLSPubKey]*InterchangeData)
+	entry := func(pubKey phase0.BLSPubKey) *InterchangeData {
+		if d, ok := data[pubKey]; ok {
+			return d
+		}
+		d := &InterchangeData{
+			Pubkey:             "0x" + hex.EncodeToString(pubKey[:]),
+			SignedBlocks:       []InterchangeBlock{},
+			SignedAttestations: []InterchangeAttestation{},
+		}
+		data[pubKey] = d
+		return d
+	}
+	for pubKey, att := range attestations {
+		d := entry(pubKey)
+		d.SignedAttestations = append(d.SignedAttestations, InterchangeAttestation{
+			SourceEpoch: strconv.FormatUint(uint64(att.Source.Epoch), 10),
+			TargetEpoch: strconv.FormatUint(uint64(att.Target.Epoch), 10),
+		})
+	}
+	for pubKey, slot := range proposals {
+		d := entry(pubKey)
+		d.SignedBlocks = append(d.SignedBlocks, InterchangeBlock{
+			Slot: strconv.FormatUint(uint64(slot), 10),
+		})
+	}
+
+	pubKeys := make([]phase0.BLSPubKey, 0, len(data))
+	for pubKey := range data {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    "0x" + hex.EncodeToString(genesisValidatorsRoot[:]),
+		},
+		Data: make([]InterchangeData, 0, len(pubKeys)),
+	}
+	for _, pubKey := range pubKeys {
+		interchange.Data = append(interchange.Data, *data[pubKey])
+	}
+	return interchange, nil
+}
+
+// ImportSlashingProtection merges the given EIP-3076 interchange data into the storage.
+// The merge is conservative: the stored highest attestation and proposal of a share are
+// only ever raised, never lowered, so that importing can't make a slashable signature possible.
+// Shares which don't exist yet are imported as well, so their protection applies once they're added.
+func ImportSlashingProtection(s Storage, interchange *Interchange, genesisValidatorsRoot phase0.Root) error {
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q, expected %q",
+			interchange.Metadata.InterchangeFormatVersion, InterchangeFormatVersion)
+	}
+	root, err := parseHex(interchange.Metadata.GenesisValidatorsRoot, len(phase0.Root{}))
+	if err != nil {
+		return fmt.Errorf("invalid genesis validators root: %w", err)
+	}
+	if !bytes.Equal(root, genesisValidatorsRoot[:]) {
+		return fmt.Errorf("genesis validators root mismatch: interchange has %s, expected %#x",
+			interchange.Metadata.GenesisValidatorsRoot, genesisValidatorsRoot)
+	}
+
+	// Validate all the data before storing anything.
+	type importedData struct {
+		pubKey       []byte
+		attestation  *phase0.AttestationData
+		proposalSlot phase0.Slot
+	}
+	imported := make([]importedData, 0, len(interchange.Data))
+	for _, d := range interchange.Data {
+		pubKey, err := parseHex(d.Pubkey, len(phase0.BLSPubKey{}))
+		if err != nil {
+			return fmt.Errorf("invalid pubkey %q: %w", d.Pubkey, err)
+		}
+		entry := importedData{pubKey: pubKey}
+
+		for _, block := range d.SignedBlocks {
+			slot, err := strconv.ParseUint(block.Slot, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid block slot for pubkey %s: %w", d.Pubkey, err)
+			}
+			entry.proposalSlot = max(entry.proposalSlot, phase0.Slot(slot))
+		}
+
+		for _, att := range d.SignedAttestations {
+			source, err := strconv.ParseUint(att.SourceEpoch, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid attestation source epoch for pubkey %s: %w", d.Pubkey, err)
+			}
+			target, err := strconv.ParseUint(att.TargetEpoch, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid attestation target epoch for pubkey %s: %w", d.Pubkey, err)
+			}
+			if source > target {
+				return fmt.Errorf("attestation source epoch %d is higher than target epoch %d for pubkey %s", source, target, d.Pubkey)
+			}
+			if entry.attestation == nil {
+				entry.attestation = minimalAttestationData(phase0.Epoch(source), phase0.Epoch(target))
+				continue
+			}
+			entry.attestation.Source.Epoch = max(entry.attestation.Source.Epoch, phase0.Epoch(source))
+			entry.attestation.Target.Epoch = max(entry.attestation.Target.Epoch, phase0.Epoch(target))
+		}
+
+		imported = append(imported, entry)
+	}
+
+	for _, entry := range imported {
+		if entry.attestation != nil {
+			if err := mergeHighestAttestation(s, entry.pubKey, entry.attestation); err != nil {
+				return err
+			}
+		}
+		if entry.proposalSlot != 0 {
+			if err := mergeHighestProposal(s, entry.pubKey, entry.proposalSlot); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func mergeHighestAttestation(s Storage, pubKey []byte, imported *phase0.AttestationData) error {
+	stored, found, err := s.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not retrieve highest attestation: %w", err)
+	}
+	if found && stored != nil {
+		if stored.Source.Epoch >= imported.Source.Epoch && stored.Target.Epoch >= imported.Target.Epoch {
+			return nil
+		}
+		imported.Source.Epoch = max(imported.Source.Epoch, stored.Source.Epoch)
+		imported.Target.Epoch = max(imported.Target.Epoch, stored.Target.Epoch)
+	}
+
+	if err := s.SaveHighestAttestation(pubKey, imported); err != nil {
+		return fmt.Errorf("could not save highest attestation: %w", err)
+	}
+	return nil
+}
+
+func mergeHighestProposal(s Storage, pubKey []byte, imported phase0.Slot) error {
+	stored, found, err := s.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return fmt.Errorf("could not retrieve highest proposal: %w", err)
+	}
+	if found && stored >= imported {
+		return nil
+	}
+
+	if err := s.SaveHighestProposal(pubKey, imported); err != nil {
+		return fmt.Errorf("could not save highest proposal: %w", err)
+	}
+	return nil
+}
+
+// minimalAttestationData returns attestation data with only the source and target epochs set,
+// which is all the slashing protection relies on.
+func minimalAttestationData(source, target phase0.Epoch) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Source: &phase0.Checkpoint{
+			Epoch: source,
+		},
+		Target: &phase0.Checkpoint{
+			Epoch: target,
+		},
+	}
+}
+
+func parseHex(s string, length int) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != length {
+		return nil, fmt.Errorf("expected %d bytes, got %d", length, len(b))
+	}
+	return b, nil
+}
@@ -0,0 +1,121 @@
+package ekm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	genesisValidatorsRoot := phase0.Root{0x1, 0x2, 0x3}
+	pk1 := _byteArray("a6d8d61ab2fb1d7e62e7c3a37b9e1c6b2a9c0e8c9ab33d5a86d7e5f41b33a1c5fb1a8ee0d7c1d1e4a1ee5f2a0b9c3d4e")
+	pk2 := _byteArray("b1d2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8")
+
+	t.Run("export and import round trip", func(t *testing.T) {
+		source, done := newStorageForTest(t)
+		defer done()
+
+		require.NoError(t, source.SaveHighestAttestation(pk1, minimalAttestationData(10, 11)))
+		require.NoError(t, source.SaveHighestProposal(pk1, 400))
+		require.NoError(t, source.SaveHighestProposal(pk2, 500))
+
+		interchange, err := ExportSlashingProtection(source, genesisValidatorsRoot)
+		require.NoError(t, err)
+		require.Equal(t, InterchangeFormatVersion, interchange.Metadata.InterchangeFormatVersion)
+		require.Len(t, interchange.Data, 2)
+
+		// Round trip through JSON, as done by the CLI.
+		b, err := json.Marshal(interchange)
+		require.NoError(t, err)
+		var decoded Interchange
+		require.NoError(t, json.Unmarshal(b, &decoded))
+
+		target, done := newStorageForTest(t)
+		defer done()
+
+		require.NoError(t, ImportSlashingProtection(target, &decoded, genesisValidatorsRoot))
+
+		att, found, err := target.RetrieveHighestAttestation(pk1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 10, att.Source.Epoch)
+		require.EqualValues(t, 11, att.Target.Epoch)
+
+		slot, found, err := target.RetrieveHighestProposal(pk1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 400, slot)
+
+		slot, found, err = target.RetrieveHighestProposal(pk2)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 500, slot)
+
+		_, found, err = target.RetrieveHighestAttestation(pk2)
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("import never lowers stored data", func(t *testing.T) {
+		s, done := newStorageForTest(t)
+		defer done()
+
+		require.NoError(t, s.SaveHighestAttestation(pk1, minimalAttestationData(20, 25)))
+		require.NoError(t, s.SaveHighestProposal(pk1, 1000))
+
+		interchange := &Interchange{
+			Metadata: InterchangeMetadata{
+				InterchangeFormatVersion: InterchangeFormatVersion,
+				GenesisValidatorsRoot:    genesisValidatorsRoot.String(),
+			},
+			Data: []InterchangeData{{
+				Pubkey:       phase0.BLSPubKey(pk1).String(),
+				SignedBlocks: []InterchangeBlock{{Slot: "900"}, {Slot: "950"}},
+				SignedAttestations: []InterchangeAttestation{
+					{SourceEpoch: "5", TargetEpoch: "30"},
+					{SourceEpoch: "12", TargetEpoch: "13"},
+				},
+			}},
+		}
+		require.NoError(t, ImportSlashingProtection(s, interchange, genesisValidatorsRoot))
+
+		att, found, err := s.RetrieveHighestAttestation(pk1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 20, att.Source.Epoch)
+		require.EqualValues(t, 30, att.Target.Epoch)
+
+		slot, found, err := s.RetrieveHighestProposal(pk1)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 1000, slot)
+	})
+
+	t.Run("invalid interchange is rejected", func(t *testing.T) {
+		s, done := newStorageForTest(t)
+		defer done()
+
+		interchange, err := ExportSlashingProtection(s, genesisValidatorsRoot)
+		require.NoError(t, err)
+		require.Empty(t, interchange.Data)
+
+		require.ErrorContains(t, ImportSlashingProtection(s, interchange, phase0.Root{0x9}), "genesis validators root mismatch")
+
+		interchange.Metadata.InterchangeFormatVersion = "4"
+		require.ErrorContains(t, ImportSlashingProtection(s, interchange, genesisValidatorsRoot), "unsupported interchange format version")
+
+		interchange.Metadata.InterchangeFormatVersion = InterchangeFormatVersion
+		interchange.Data = []InterchangeData{
+			{Pubkey: phase0.BLSPubKey(pk1).String(), SignedBlocks: []InterchangeBlock{{Slot: "100"}}},
+			{Pubkey: "0x1234"},
+		}
+		require.ErrorContains(t, ImportSlashingProtection(s, interchange, genesisValidatorsRoot), "invalid pubkey")
+
+		// Nothing is stored if any of the data is invalid.
+		_, found, err := s.RetrieveHighestProposal(pk1)
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
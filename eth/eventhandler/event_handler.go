@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/ssvlabs/ssv/ekm"
@@ -69,6 +70,10 @@ type EventHandler struct {
 	keyManager        ekm.KeyManager
 	beacon            beaconprotocol.BeaconNode
 
+	// decryptedShares holds the node's share keys decrypted ahead of handling the events of a block,
+	// keyed by their ciphertexts. Each is removed once its share is loaded, and all are dropped after the block.
+	decryptedShares map[string][]byte
+
 	fullNode bool
 	logger   *zap.Logger
 }
@@ -165,6 +170,9 @@ func (eh *EventHandler) processBlockEvents(ctx context.Context, block executionc
 		return nil, ErrInferiorBlock
 	}
 
+	eh.decryptedShares = eh.decryptOwnShares(block.Logs)
+	defer func() { eh.decryptedShares = nil }()
+
 	var tasks []Task
 	for _, log := range block.Logs {
 		task, err := eh.processEvent(ctx, txn, log)
@@ -187,6 +195,66 @@ func (eh *EventHandler) processBlockEvents(ctx context.Context, block executionc
 	return tasks, nil
 }
 
+// decryptOwnShares decrypts the node's shares of the validators added in the block at once,
+// if the operator key supports it, so that handling the events doesn't make a round trip per share.
+// Failures are left to be reported when the events are handled.
+func (eh *EventHandler) decryptOwnShares(logs []ethtypes.Log) map[string][]byte {
+	batchDecrypter, ok := eh.operatorDecrypter.(keys.BatchDecrypter)
+	if !ok {
+		return nil
+	}
+
+	selfOperatorID := eh.operatorDataStore.GetOperatorID()
+	var encryptedKeys [][]byte
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		abiEvent, err := eh.eventParser.EventByID(log.Topics[0])
+		if err != nil || abiEvent.Name != ValidatorAdded {
+			continue
+		}
+		event, err := eh.eventParser.ParseValidatorAdded(log)
+		if err != nil {
+			continue
+		}
+		i := slices.Index(event.OperatorIds, selfOperatorID)
+		if i < 0 {
+			continue
+		}
+		pubKeysOffset := phase0.PublicKeyLength*len(event.OperatorIds) + phase0.SignatureLength
+		if len(event.Shares) != encryptedKeyLength*len(event.OperatorIds)+pubKeysOffset {
+			continue
+		}
+		offset := pubKeysOffset + i*encryptedKeyLength
+		encryptedKeys = append(encryptedKeys, event.Shares[offset:offset+encryptedKeyLength])
+	}
+	if len(encryptedKeys) == 0 {
+		return nil
+	}
+
+	plaintexts, err := batchDecrypter.DecryptBatch(encryptedKeys)
+	if err != nil {
+		eh.logger.Debug("could not decrypt shares in batch", zap.Int("shares", len(encryptedKeys)), zap.Error(err))
+		return nil
+	}
+	decrypted := make(map[string][]byte, len(encryptedKeys))
+	for i, encryptedKey := range encryptedKeys {
+		decrypted[string(encryptedKey)] = plaintexts[i]
+	}
+	return decrypted
+}
+
+// decryptShare returns the share key decrypted ahead by decryptOwnShares, removing it,
+// or decrypts the share key if it wasn't.
+func (eh *EventHandler) decryptShare(encryptedKey []byte) ([]byte, error) {
+	if plaintext, ok := eh.decryptedShares[string(encryptedKey)]; ok {
+		delete(eh.decryptedShares, string(encryptedKey))
+		return plaintext, nil
+	}
+	return eh.operatorDecrypter.Decrypt(encryptedKey)
+}
+
 func (eh *EventHandler) processEvent(ctx context.Context, txn basedb.Txn, event ethtypes.Log) (Task, error) {
 	abiEvent, err := eh.eventParser.EventByID(event.Topics[0])
 	if err != nil {
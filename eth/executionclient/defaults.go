@@ -5,10 +5,11 @@ import (
 )
 
 const (
-	DefaultConnectionTimeout           = 10 * time.Second
-	DefaultReconnectionInitialInterval = 1 * time.Second
-	DefaultReconnectionMaxInterval     = 64 * time.Second
-	DefaultFollowDistance              = 8
+	DefaultConnectionTimeout              = 10 * time.Second
+	DefaultReconnectionInitialInterval    = 1 * time.Second
+	DefaultReconnectionMaxInterval        = 64 * time.Second
+	DefaultFollowDistance                 = 8
+	DefaultPreferredEndpointCheckInterval = 1 * time.Minute
 	// TODO ALAN: revert
 	DefaultHistoricalLogsBatchSize = 500
 	defaultLogBuf                  = 8 * 1024
@@ -6,6 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -31,10 +34,14 @@ var (
 
 const elResponseErrMsg = "Execution client returned an error"
 
+// NodeAddrSeparator separates multiple execution client endpoints in ExecutionOptions.Addr.
+// Endpoints are listed in priority order: the first healthy endpoint is preferred.
+const NodeAddrSeparator = ";"
+
 // ExecutionClient represents a client for interacting with Ethereum execution client.
 type ExecutionClient struct {
 	// mandatory
-	nodeAddr        string
+	nodeAddrs       []string // in priority order
 	contractAddress ethcommon.Address
 
 	// optional
@@ -46,55 +53,110 @@ type ExecutionClient struct {
 	reconnectionInitialInterval time.Duration
 	reconnectionMaxInterval     time.Duration
 	logBatchSize                uint64
+	// preferredEndpointCheckInterval is how often the endpoints with a higher priority
+	// than the one in use are checked to switch back to them once they recover.
+	preferredEndpointCheckInterval time.Duration
 
 	syncDistanceTolerance uint64
-	syncProgressFn        func(context.Context) (*ethereum.SyncProgress, error)
+	syncProgressFn        func(ctx context.Context, client *ethclient.Client, nodeAddr string) (*ethereum.SyncProgress, error)
 
 	// variables
-	client *ethclient.Client
-	closed chan struct{}
+	connectMu sync.Mutex // serializes connecting and switching between endpoints
+	clientMu  sync.RWMutex
+	client    *ethclient.Client
+	nodeAddr  string // the endpoint client is connected to
+	closed    chan struct{}
 }
 
 // New creates a new instance of ExecutionClient.
+// nodeAddr may contain several endpoints separated by NodeAddrSeparator, in which case
+// the client switches to the next healthy endpoint whenever the current one fails.
 func New(ctx context.Context, nodeAddr string, contractAddr ethcommon.Address, opts ...Option) (*ExecutionClient, error) {
+	nodeAddrs := parseNodeAddrs(nodeAddr)
+	if len(nodeAddrs) == 0 {
+		return nil, fmt.Errorf("no execution client address provided")
+	}
+
 	client := &ExecutionClient{
-		nodeAddr:                    nodeAddr,
-		contractAddress:             contractAddr,
-		logger:                      zap.NewNop(),
-		followDistance:              DefaultFollowDistance,
-		connectionTimeout:           DefaultConnectionTimeout,
-		reconnectionInitialInterval: DefaultReconnectionInitialInterval,
-		reconnectionMaxInterval:     DefaultReconnectionMaxInterval,
-		logBatchSize:                DefaultHistoricalLogsBatchSize, // TODO Make batch of logs adaptive depending on "websocket: read limit"
-		closed:                      make(chan struct{}),
+		nodeAddrs:                      nodeAddrs,
+		contractAddress:                contractAddr,
+		logger:                         zap.NewNop(),
+		followDistance:                 DefaultFollowDistance,
+		connectionTimeout:              DefaultConnectionTimeout,
+		reconnectionInitialInterval:    DefaultReconnectionInitialInterval,
+		reconnectionMaxInterval:        DefaultReconnectionMaxInterval,
+		logBatchSize:                   DefaultHistoricalLogsBatchSize, // TODO Make batch of logs adaptive depending on "websocket: read limit"
+		preferredEndpointCheckInterval: DefaultPreferredEndpointCheckInterval,
+		closed:                         make(chan struct{}),
 	}
 	for _, opt := range opts {
 		opt(client)
 	}
-	err := client.connect(ctx)
+
+	client.syncProgressFn = client.syncProgress
+
+	err := client.connect(ctx, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to execution client: %w", err)
 	}
 
-	client.syncProgressFn = client.syncProgress
+	if len(nodeAddrs) > 1 {
+		go client.preferredEndpointLoop(ctx)
+	}
 
 	return client, nil
 }
 
-func (ec *ExecutionClient) syncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	return ec.client.SyncProgress(ctx)
+// parseNodeAddrs splits the configured address string into a list of endpoints, preserving their order.
+func parseNodeAddrs(addrs string) []string {
+	var result []string
+	for _, addr := range strings.Split(addrs, NodeAddrSeparator) {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		result = append(result, addr)
+	}
+	return result
+}
+
+func (ec *ExecutionClient) syncProgress(ctx context.Context, client *ethclient.Client, _ string) (*ethereum.SyncProgress, error) {
+	return client.SyncProgress(ctx)
+}
+
+// currentClient returns the client of the endpoint currently in use along with its address.
+func (ec *ExecutionClient) currentClient() (*ethclient.Client, string) {
+	ec.clientMu.RLock()
+	defer ec.clientMu.RUnlock()
+
+	return ec.client, ec.nodeAddr
+}
+
+// setClient replaces the client in use and closes the previous one.
+func (ec *ExecutionClient) setClient(client *ethclient.Client, nodeAddr string) {
+	ec.clientMu.Lock()
+	prev := ec.client
+	ec.client = client
+	ec.nodeAddr = nodeAddr
+	ec.clientMu.Unlock()
+
+	if prev != nil {
+		prev.Close()
+	}
 }
 
 // Close shuts down ExecutionClient.
 func (ec *ExecutionClient) Close() error {
 	close(ec.closed)
-	ec.client.Close()
+	client, _ := ec.currentClient()
+	client.Close()
 	return nil
 }
 
 // FetchHistoricalLogs retrieves historical logs emitted by the contract starting from fromBlock.
 func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock uint64) (logs <-chan BlockLogs, errors <-chan error, err error) {
-	currentBlock, err := ec.client.BlockNumber(ctx)
+	client, _ := ec.currentClient()
+	currentBlock, err := client.BlockNumber(ctx)
 	if err != nil {
 		ec.logger.Error(elResponseErrMsg,
 			zap.String("method", "eth_blockNumber"),
@@ -115,6 +177,7 @@ func (ec *ExecutionClient) FetchHistoricalLogs(ctx context.Context, fromBlock ui
 
 // Calls FilterLogs multiple times and batches results to avoid fetching enormous amount of events
 func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, endBlock uint64) (<-chan BlockLogs, <-chan error) {
+	client, _ := ec.currentClient()
 	logs := make(chan BlockLogs, defaultLogBuf)
 	errors := make(chan error, 1)
 
@@ -134,7 +197,7 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 			}
 
 			start := time.Now()
-			results, err := ec.client.FilterLogs(ctx, ethereum.FilterQuery{
+			results, err := client.FilterLogs(ctx, ethereum.FilterQuery{
 				Addresses: []ethcommon.Address{ec.contractAddress},
 				FromBlock: new(big.Int).SetUint64(fromBlock),
 				ToBlock:   new(big.Int).SetUint64(toBlock),
@@ -194,6 +257,8 @@ func (ec *ExecutionClient) fetchLogsInBatches(ctx context.Context, startBlock, e
 }
 
 // StreamLogs subscribes to events emitted by the contract.
+// If the endpoint fails, streaming resumes on the next available endpoint from the first block
+// which hasn't been streamed yet, so no BlockLogs are skipped or duplicated.
 func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-chan BlockLogs {
 	logs := make(chan BlockLogs)
 
@@ -207,7 +272,10 @@ func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-c
 			case <-ec.closed:
 				return
 			default:
-				lastBlock, err := ec.streamLogsToChan(ctx, logs, fromBlock)
+				// The endpoint is saved before streaming, because by the time streaming fails
+				// a parallel failover may have switched to another endpoint already.
+				client, nodeAddr := ec.currentClient()
+				nextBlock, err := ec.streamLogsToChan(ctx, client, nodeAddr, logs, fromBlock)
 				if errors.Is(err, ErrClosed) || errors.Is(err, context.Canceled) {
 					// Closed gracefully.
 					return
@@ -219,18 +287,27 @@ func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-c
 					err = errors.New("streamLogsToChan halted without an error")
 				}
 
+				if _, currentAddr := ec.currentClient(); currentAddr != nodeAddr {
+					// The client has switched endpoints, which ended the stream, so it's resumed on the new one.
+					ec.logger.Info("execution client endpoint switched, resuming registry events stream",
+						zap.String("from", nodeAddr),
+						zap.String("to", currentAddr))
+					fromBlock = nextBlock
+					continue
+				}
+
 				tries++
-				if tries > 2 {
+				if tries > 2*len(ec.nodeAddrs) {
 					ec.logger.Fatal("failed to stream registry events", zap.Error(err))
 				}
-				if lastBlock > fromBlock {
+				if nextBlock > fromBlock {
 					// Successfully streamed some logs, reset tries.
 					tries = 0
 				}
 
-				ec.logger.Error("failed to stream registry events, reconnecting", zap.Error(err))
-				ec.reconnect(ctx)
-				fromBlock = lastBlock + 1
+				ec.logger.Error("failed to stream registry events, reconnecting", fields.Address(nodeAddr), zap.Error(err))
+				ec.reconnect(ctx, nodeAddr)
+				fromBlock = nextBlock
 			}
 		}
 	}()
@@ -241,31 +318,52 @@ func (ec *ExecutionClient) StreamLogs(ctx context.Context, fromBlock uint64) <-c
 var errSyncing = fmt.Errorf("syncing")
 
 // Healthy returns if execution client is currently healthy: responds to requests and not in the syncing state.
+// If the endpoint in use isn't healthy and other endpoints are configured, it switches to the healthy endpoint
+// with the highest priority, and is considered healthy if such endpoint is found.
 func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 	if ec.isClosed() {
 		return ErrClosed
 	}
 
+	client, nodeAddr := ec.currentClient()
+	err := ec.nodeHealthy(ctx, client, nodeAddr)
+	if err == nil || len(ec.nodeAddrs) == 1 {
+		return err
+	}
+
+	ec.logger.Warn("execution client is not healthy, switching to another endpoint",
+		fields.Address(nodeAddr),
+		zap.Error(err))
+
+	if switchErr := ec.switchEndpoint(ctx, nodeAddr); switchErr != nil {
+		return errors.Join(err, switchErr)
+	}
+	return nil
+}
+
+// nodeHealthy checks the health of a single endpoint.
+func (ec *ExecutionClient) nodeHealthy(ctx context.Context, client *ethclient.Client, nodeAddr string) error {
 	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
 	defer cancel()
 
 	start := time.Now()
-	sp, err := ec.syncProgressFn(ctx)
+	sp, err := ec.syncProgressFn(ctx, client, nodeAddr)
 	if err != nil {
-		recordExecutionClientStatus(ctx, statusFailure, ec.nodeAddr)
+		recordExecutionClientStatus(ctx, statusFailure, nodeAddr)
 		ec.logger.Error(elResponseErrMsg,
 			zap.String("method", "eth_syncing"),
+			fields.Address(nodeAddr),
 			zap.Error(err))
 		return err
 	}
-	recordRequestDuration(ctx, ec.nodeAddr, time.Since(start))
+	recordRequestDuration(ctx, nodeAddr, time.Since(start))
 
 	if sp != nil {
-		recordExecutionClientStatus(ctx, statusSyncing, ec.nodeAddr)
+		recordExecutionClientStatus(ctx, statusSyncing, nodeAddr)
 
 		syncDistance := max(sp.HighestBlock, sp.CurrentBlock) - sp.CurrentBlock
 
-		observability.RecordUint64Value(ctx, syncDistance, syncDistanceGauge.Record, metric.WithAttributes(semconv.ServerAddress(ec.nodeAddr)))
+		observability.RecordUint64Value(ctx, syncDistance, syncDistanceGauge.Record, metric.WithAttributes(semconv.ServerAddress(nodeAddr)))
 
 		// block out of sync distance tolerance
 		if syncDistance > ec.syncDistanceTolerance {
@@ -273,15 +371,16 @@ func (ec *ExecutionClient) Healthy(ctx context.Context) error {
 		}
 	}
 
-	recordExecutionClientStatus(ctx, statusReady, ec.nodeAddr)
+	recordExecutionClientStatus(ctx, statusReady, nodeAddr)
 
-	syncDistanceGauge.Record(ctx, 0, metric.WithAttributes(semconv.ServerAddress(ec.nodeAddr)))
+	syncDistanceGauge.Record(ctx, 0, metric.WithAttributes(semconv.ServerAddress(nodeAddr)))
 
 	return nil
 }
 
 func (ec *ExecutionClient) BlockByNumber(ctx context.Context, blockNumber *big.Int) (*ethtypes.Block, error) {
-	b, err := ec.client.BlockByNumber(ctx, blockNumber)
+	client, _ := ec.currentClient()
+	b, err := client.BlockByNumber(ctx, blockNumber)
 	if err != nil {
 		ec.logger.Error(elResponseErrMsg,
 			zap.String("method", "eth_getBlockByNumber"),
@@ -302,15 +401,16 @@ func (ec *ExecutionClient) isClosed() bool {
 }
 
 // streamLogsToChan streams ongoing logs from the given block to the given channel.
-// streamLogsToChan *always* returns the last block it fetched, even if it errored.
+// streamLogsToChan *always* returns the first block which hasn't been streamed yet, even if it errored.
 // TODO: consider handling "websocket: read limit exceeded" error and reducing batch size (syncSmartContractsEvents has code for this)
-func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- BlockLogs, fromBlock uint64) (lastBlock uint64, err error) {
+func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, client *ethclient.Client, nodeAddr string, logs chan<- BlockLogs, fromBlock uint64) (nextBlock uint64, err error) {
 	heads := make(chan *ethtypes.Header)
 
-	sub, err := ec.client.SubscribeNewHead(ctx, heads)
+	sub, err := client.SubscribeNewHead(ctx, heads)
 	if err != nil {
 		ec.logger.Error(elResponseErrMsg,
 			zap.String("operation", "SubscribeNewHead"),
+			fields.Address(nodeAddr),
 			zap.Error(err))
 		return fromBlock, fmt.Errorf("subscribe heads: %w", err)
 	}
@@ -341,50 +441,175 @@ func (ec *ExecutionClient) streamLogsToChan(ctx context.Context, logs chan<- Blo
 			logStream, fetchErrors := ec.fetchLogsInBatches(ctx, fromBlock, toBlock)
 			for block := range logStream {
 				logs <- block
-				lastBlock = block.BlockNumber
+				fromBlock = block.BlockNumber + 1
 			}
 			if err := <-fetchErrors; err != nil {
-				// If we get an error while fetching, we return the last block we fetched.
-				return lastBlock, fmt.Errorf("fetch logs: %w", err)
+				// If we get an error while fetching, we return the block following the last one we streamed.
+				return fromBlock, fmt.Errorf("fetch logs: %w", err)
 			}
 			fromBlock = toBlock + 1
-			observability.RecordUint64Value(ctx, fromBlock, lastProcessedBlockGauge.Record, metric.WithAttributes(semconv.ServerAddress(ec.nodeAddr)))
+			observability.RecordUint64Value(ctx, fromBlock, lastProcessedBlockGauge.Record, metric.WithAttributes(semconv.ServerAddress(nodeAddr)))
+		}
+	}
+}
+
+// connect connects to the healthy endpoint with the highest priority. If none of the endpoints is healthy
+// and requireHealthy is false, it connects to the endpoint with the highest priority that can be dialed.
+// Endpoints listed in skip aren't considered. If only one endpoint is configured, it's connected without a health check.
+func (ec *ExecutionClient) connect(ctx context.Context, requireHealthy bool, skip ...string) error {
+	ec.connectMu.Lock()
+	defer ec.connectMu.Unlock()
+
+	var (
+		fallbackClient *ethclient.Client
+		fallbackAddr   string
+		errs           error
+	)
+	for _, nodeAddr := range ec.nodeAddrs {
+		if slices.Contains(skip, nodeAddr) {
+			continue
+		}
+
+		client, err := ec.dial(ctx, nodeAddr)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", nodeAddr, err))
+			continue
+		}
+		if len(ec.nodeAddrs) == 1 {
+			ec.setClient(client, nodeAddr)
+			return nil
+		}
+
+		if err := ec.nodeHealthy(ctx, client, nodeAddr); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", nodeAddr, err))
+			if fallbackClient == nil && !requireHealthy {
+				fallbackClient, fallbackAddr = client, nodeAddr
+			} else {
+				client.Close()
+			}
+			continue
+		}
+
+		if fallbackClient != nil {
+			fallbackClient.Close()
 		}
+		ec.setClient(client, nodeAddr)
+		return nil
+	}
+
+	if fallbackClient != nil {
+		ec.logger.Warn("no healthy execution client endpoint found, using an unhealthy one",
+			fields.Address(fallbackAddr),
+			zap.Error(errs))
+		ec.setClient(fallbackClient, fallbackAddr)
+		return nil
 	}
+	if errs == nil {
+		errs = fmt.Errorf("no execution client endpoint to connect to")
+	}
+	return errs
 }
 
-// connect connects to Ethereum execution client.
-// It must not be called twice in parallel.
-func (ec *ExecutionClient) connect(ctx context.Context) error {
-	logger := ec.logger.With(fields.Address(ec.nodeAddr))
+// dial connects to the given endpoint.
+func (ec *ExecutionClient) dial(ctx context.Context, nodeAddr string) (*ethclient.Client, error) {
+	logger := ec.logger.With(fields.Address(nodeAddr))
 
 	ctx, cancel := context.WithTimeout(ctx, ec.connectionTimeout)
 	defer cancel()
 
 	start := time.Now()
-	var err error
-	ec.client, err = ethclient.DialContext(ctx, ec.nodeAddr)
+	client, err := ethclient.DialContext(ctx, nodeAddr)
 	if err != nil {
-		ec.logger.Error(elResponseErrMsg,
+		logger.Error(elResponseErrMsg,
 			zap.String("operation", "DialContext"),
 			zap.Error(err))
-		return err
+		return nil, err
 	}
 
 	logger.Info("connected to execution client", zap.Duration("took", time.Since(start)))
+	return client, nil
+}
+
+// switchEndpoint switches from the given failed endpoint to the healthy endpoint with the highest priority.
+// It does nothing if the client has already switched away from the failed endpoint.
+func (ec *ExecutionClient) switchEndpoint(ctx context.Context, failedAddr string) error {
+	if _, nodeAddr := ec.currentClient(); nodeAddr != failedAddr {
+		return nil
+	}
+
+	if err := ec.connect(ctx, true, failedAddr); err != nil {
+		return fmt.Errorf("no healthy execution client endpoint to switch to: %w", err)
+	}
+
+	_, nodeAddr := ec.currentClient()
+	ec.logger.Info("switched execution client endpoint",
+		zap.String("from", failedAddr),
+		zap.String("to", nodeAddr))
 	return nil
 }
 
-// reconnect tries to reconnect multiple times with an exponent interval.
+// preferredEndpointLoop periodically switches back to an endpoint with a higher priority
+// than the one in use once it's healthy again.
+func (ec *ExecutionClient) preferredEndpointLoop(ctx context.Context) {
+	ticker := time.NewTicker(ec.preferredEndpointCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ec.closed:
+			return
+		case <-ticker.C:
+			ec.switchToPreferredEndpoint(ctx)
+		}
+	}
+}
+
+// switchToPreferredEndpoint switches to the healthy endpoint with the highest priority,
+// if its priority is higher than the priority of the endpoint in use.
+func (ec *ExecutionClient) switchToPreferredEndpoint(ctx context.Context) {
+	ec.connectMu.Lock()
+	defer ec.connectMu.Unlock()
+
+	_, currentAddr := ec.currentClient()
+	for _, nodeAddr := range ec.nodeAddrs {
+		if nodeAddr == currentAddr || ec.isClosed() {
+			return
+		}
+
+		client, err := ec.dial(ctx, nodeAddr)
+		if err != nil {
+			continue
+		}
+		if err := ec.nodeHealthy(ctx, client, nodeAddr); err != nil {
+			client.Close()
+			continue
+		}
+
+		ec.setClient(client, nodeAddr)
+		ec.logger.Info("switched back to preferred execution client endpoint",
+			zap.String("from", currentAddr),
+			zap.String("to", nodeAddr))
+		return
+	}
+}
+
+// reconnect tries to reconnect multiple times with an exponent interval,
+// switching to another endpoint if the failed one is unavailable.
+// It does nothing if the client has already switched away from the failed endpoint.
 // It panics when reconnecting limit is reached.
-// It must not be called twice in parallel.
-func (ec *ExecutionClient) reconnect(ctx context.Context) {
-	logger := ec.logger.With(fields.Address(ec.nodeAddr))
+func (ec *ExecutionClient) reconnect(ctx context.Context, failedAddr string) {
+	if _, nodeAddr := ec.currentClient(); nodeAddr != failedAddr {
+		return
+	}
+
+	logger := ec.logger.With(fields.Address(failedAddr))
 
 	start := time.Now()
 	tasks.ExecWithInterval(func(lastTick time.Duration) (stop bool, cont bool) {
 		logger.Info("reconnecting")
-		if err := ec.connect(ctx); err != nil {
+		if err := ec.connect(ctx, false); err != nil {
 			if ec.isClosed() {
 				return true, false
 			}
@@ -399,9 +624,11 @@ func (ec *ExecutionClient) reconnect(ctx context.Context) {
 		return true, false
 	}, ec.reconnectionInitialInterval, ec.reconnectionMaxInterval+(ec.reconnectionInitialInterval))
 
-	logger.Info("reconnected to execution client", zap.Duration("took", time.Since(start)))
+	_, nodeAddr := ec.currentClient()
+	logger.Info("reconnected to execution client", zap.String("to", nodeAddr), zap.Duration("took", time.Since(start)))
 }
 
 func (ec *ExecutionClient) Filterer() (*contract.ContractFilterer, error) {
-	return contract.NewContractFilterer(ec.contractAddress, ec.client)
+	client, _ := ec.currentClient()
+	return contract.NewContractFilterer(ec.contractAddress, client)
 }
@@ -3,8 +3,11 @@ package executionclient
 import (
 	"context"
 	"math/big"
+	"net"
+	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -16,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/simulated"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -644,7 +648,7 @@ func TestSyncProgress(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("out of sync", func(t *testing.T) {
-		client.syncProgressFn = func(context.Context) (*ethereum.SyncProgress, error) {
+		client.syncProgressFn = func(context.Context, *ethclient.Client, string) (*ethereum.SyncProgress, error) {
 			p := new(ethereum.SyncProgress)
 			p.CurrentBlock = 5
 			p.HighestBlock = 6
@@ -659,7 +663,7 @@ func TestSyncProgress(t *testing.T) {
 		client, err := New(ctx, addr, contractAddr, WithSyncDistanceTolerance(2))
 		require.NoError(t, err)
 
-		client.syncProgressFn = func(context.Context) (*ethereum.SyncProgress, error) {
+		client.syncProgressFn = func(context.Context, *ethclient.Client, string) (*ethereum.SyncProgress, error) {
 			p := new(ethereum.SyncProgress)
 			p.CurrentBlock = 5
 			p.HighestBlock = 7
@@ -674,3 +678,174 @@ func TestSyncProgress(t *testing.T) {
 func httpToWebSocketURL(url string) string {
 	return "ws:" + strings.TrimPrefix(url, "http:")
 }
+
+func TestFailover(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	const testTimeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sim := simTestBackend(testAddr)
+
+	// Expose the same simulated chain on two endpoints.
+	rpcServer, _ := sim.Node().RPCHandler()
+	defer rpcServer.Stop()
+	// Track hijacked websocket connections of the first endpoint to be able to take it down.
+	var (
+		hijackedMu sync.Mutex
+		hijacked   []net.Conn
+	)
+	httpsrv1 := httptest.NewUnstartedServer(rpcServer.WebsocketHandler([]string{"*"}))
+	httpsrv1.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateHijacked {
+			hijackedMu.Lock()
+			hijacked = append(hijacked, conn)
+			hijackedMu.Unlock()
+		}
+	}
+	httpsrv1.Start()
+	defer httpsrv1.Close()
+	httpsrv2 := httptest.NewServer(rpcServer.WebsocketHandler([]string{"*"}))
+	defer httpsrv2.Close()
+	addr1 := httpToWebSocketURL(httpsrv1.URL)
+	addr2 := httpToWebSocketURL(httpsrv2.URL)
+
+	parsed, _ := abi.JSON(strings.NewReader(callableAbi))
+	auth, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	contractAddr, _, contract, err := bind.DeployContract(auth, parsed, ethcommon.FromHex(callableBin), sim.Client())
+	require.NoError(t, err)
+	sim.Commit()
+
+	t.Run("unhealthy endpoint is switched", func(t *testing.T) {
+		client, err := New(ctx, addr1+NodeAddrSeparator+addr2, contractAddr, WithLogger(logger))
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, nodeAddr := client.currentClient()
+		require.Equal(t, addr1, nodeAddr)
+
+		client.syncProgressFn = func(ctx context.Context, c *ethclient.Client, nodeAddr string) (*ethereum.SyncProgress, error) {
+			if nodeAddr == addr1 {
+				return &ethereum.SyncProgress{CurrentBlock: 5, HighestBlock: 10}, nil
+			}
+			return nil, nil
+		}
+
+		require.NoError(t, client.Healthy(ctx))
+		_, nodeAddr = client.currentClient()
+		require.Equal(t, addr2, nodeAddr)
+
+		client.syncProgressFn = func(ctx context.Context, c *ethclient.Client, nodeAddr string) (*ethereum.SyncProgress, error) {
+			return &ethereum.SyncProgress{CurrentBlock: 5, HighestBlock: 10}, nil
+		}
+		require.ErrorIs(t, client.Healthy(ctx), errSyncing)
+	})
+
+	t.Run("recovered preferred endpoint is switched back to", func(t *testing.T) {
+		client, err := New(ctx, addr1+NodeAddrSeparator+addr2, contractAddr, WithLogger(logger))
+		require.NoError(t, err)
+		defer client.Close()
+
+		var addr1Syncing atomic.Bool
+		addr1Syncing.Store(true)
+		client.syncProgressFn = func(ctx context.Context, c *ethclient.Client, nodeAddr string) (*ethereum.SyncProgress, error) {
+			if nodeAddr == addr1 && addr1Syncing.Load() {
+				return &ethereum.SyncProgress{CurrentBlock: 5, HighestBlock: 10}, nil
+			}
+			return nil, nil
+		}
+
+		require.NoError(t, client.Healthy(ctx))
+		_, nodeAddr := client.currentClient()
+		require.Equal(t, addr2, nodeAddr)
+
+		// The preferred endpoint isn't switched back to while it's unhealthy.
+		client.switchToPreferredEndpoint(ctx)
+		_, nodeAddr = client.currentClient()
+		require.Equal(t, addr2, nodeAddr)
+
+		addr1Syncing.Store(false)
+		client.switchToPreferredEndpoint(ctx)
+		_, nodeAddr = client.currentClient()
+		require.Equal(t, addr1, nodeAddr)
+	})
+
+	t.Run("logs stream resumes on another endpoint", func(t *testing.T) {
+		const followDistance = 2
+		client, err := New(
+			ctx,
+			addr1+NodeAddrSeparator+addr2,
+			contractAddr,
+			WithLogger(logger),
+			WithFollowDistance(followDistance),
+			WithReconnectionInitialInterval(10*time.Millisecond),
+		)
+		require.NoError(t, err)
+		defer client.Close()
+
+		fromBlock, err := sim.Client().BlockNumber(ctx)
+		require.NoError(t, err)
+		logs := client.StreamLogs(ctx, fromBlock+1)
+
+		var (
+			streamedLogsCount atomic.Int64
+			logBlocksMu       sync.Mutex
+			logBlocks         []uint64
+		)
+		go func() {
+			for block := range logs {
+				logBlocksMu.Lock()
+				for _, log := range block.Logs {
+					logBlocks = append(logBlocks, log.BlockNumber)
+				}
+				logBlocksMu.Unlock()
+				streamedLogsCount.Add(int64(len(block.Logs)))
+			}
+		}()
+
+		commitBlocks := func(n int) {
+			for i := 0; i < n; i++ {
+				_, err := contract.Transact(auth, "Call")
+				require.NoError(t, err)
+				sim.Commit()
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+		waitForLogs := func(count int64) {
+			for {
+				select {
+				case <-ctx.Done():
+					require.Failf(t, "timed out", "streamedLogsCount: %d, expected: %d", streamedLogsCount.Load(), count)
+				case <-time.After(5 * time.Millisecond):
+					if streamedLogsCount.Load() == count {
+						return
+					}
+				}
+			}
+		}
+
+		commitBlocks(10)
+		waitForLogs(10 - followDistance)
+
+		// Take the first endpoint down.
+		httpsrv1.Close()
+		hijackedMu.Lock()
+		for _, conn := range hijacked {
+			_ = conn.Close() // Some connections may already be closed by the client.
+		}
+		hijackedMu.Unlock()
+
+		commitBlocks(10)
+		waitForLogs(20 - followDistance)
+
+		_, nodeAddr := client.currentClient()
+		require.Equal(t, addr2, nodeAddr)
+
+		// Every block has exactly one log, so logs must come from consecutive blocks.
+		logBlocksMu.Lock()
+		defer logBlocksMu.Unlock()
+		for i := 1; i < len(logBlocks); i++ {
+			require.Equal(t, logBlocks[i-1]+1, logBlocks[i])
+		}
+	})
+}
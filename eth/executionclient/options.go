@@ -58,3 +58,11 @@ func WithSyncDistanceTolerance(count uint64) Option {
 		s.syncDistanceTolerance = count
 	}
 }
+
+// WithPreferredEndpointCheckInterval sets how often the client checks whether an endpoint
+// with a higher priority than the one in use has recovered, to switch back to it.
+func WithPreferredEndpointCheckInterval(interval time.Duration) Option {
+	return func(s *ExecutionClient) {
+		s.preferredEndpointCheckInterval = interval
+	}
+}
@@ -22,6 +22,11 @@ type broadcasted interface {
 	Send([]byte)
 }
 
+// subscriber is a broadcasted which receives only the messages it accepts
+type subscriber interface {
+	Accepts(msg *Message) bool
+}
+
 type broadcaster struct {
 	mut         sync.Mutex
 	connections map[string]broadcasted
@@ -71,8 +76,11 @@ func (b *broadcaster) Broadcast(msg Message) error {
 		conns = append(conns, c)
 	}
 	b.mut.Unlock()
-	// send to all connections
+	// send to all connections which subscribed to the message
 	for _, c := range conns {
+		if s, ok := c.(subscriber); ok && !s.Accepts(&msg) {
+			continue
+		}
 		c.Send(data)
 	}
 
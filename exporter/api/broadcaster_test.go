@@ -20,6 +20,39 @@ func TestConn_Send_FullQueue(t *testing.T) {
 	}
 }
 
+func TestConn_Send_SlowConsumer(t *testing.T) {
+	c := newConn(context.Background(), nil, "test", 0, false).(*conn)
+
+	for i := 0; i < chanSize; i++ {
+		c.Send([]byte(fmt.Sprintf("test-%d", i)))
+	}
+	require.False(t, c.slow.Load())
+	require.NoError(t, c.ctx.Err())
+
+	c.Send([]byte("overflow"))
+	require.True(t, c.slow.Load())
+	require.Error(t, c.ctx.Err())
+	require.Nil(t, c.ReadNext())
+}
+
+func TestBroadcaster_Subscriptions(t *testing.T) {
+	b := newBroadcaster()
+	c1 := newConn(context.Background(), nil, "1", 0, false).(*conn)
+	c2 := newConn(context.Background(), nil, "2", 0, false).(*conn)
+	require.True(t, b.Register(c1))
+	require.True(t, b.Register(c2))
+
+	pk1, pk2 := testPubKey(1), testPubKey(2)
+	f, err := newStreamFilter(nil, &Subscription{PublicKeys: []string{pk1}})
+	require.NoError(t, err)
+	c2.Subscribe(f)
+
+	require.NoError(t, b.Broadcast(Message{Type: TypeDecided, Filter: MessageFilter{PublicKey: pk1, Role: "ATTESTER"}}))
+	require.NoError(t, b.Broadcast(Message{Type: TypeDecided, Filter: MessageFilter{PublicKey: pk2, Role: "ATTESTER"}}))
+	require.Len(t, c1.send, 2)
+	require.Len(t, c2.send, 1)
+}
+
 func TestBroadcaster(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	b := newBroadcaster()
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -21,9 +22,11 @@ var (
 	// pingInterval period to send ping messages. Must be less than pingTimeout.
 	pingInterval = (pingTimeout * 8) / 10
 
-	// maxMessageSize max msg size allowed from peer.
-	maxMessageSize = int64(1024)
+	// maxMessageSize max msg size allowed from peer, which fits subscriptions of hundreds of validators.
+	maxMessageSize = int64(64 * 1024)
 
+	// chanSize is the number of messages buffered for a connection,
+	// which is disconnected as a slow consumer once it's full.
 	chanSize = 256
 
 	newline = []byte{'\n'}
@@ -43,6 +46,8 @@ type Conn interface {
 	ID() string
 	ReadNext() []byte
 	Send(msg []byte)
+	Accepts(msg *Message) bool
+	Subscribe(filter *streamFilter)
 	WriteLoop(logger *zap.Logger)
 	ReadLoop(logger *zap.Logger)
 	Close() error
@@ -50,9 +55,10 @@ type Conn interface {
 }
 
 type conn struct {
-	ctx context.Context
-	id  string
-	ws  *websocket.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+	id     string
+	ws     *websocket.Conn
 
 	writeTimeout time.Duration
 
@@ -62,11 +68,18 @@ type conn struct {
 	writeLock sync.Locker
 
 	withPing bool
+
+	// filter is the subscription of the connection, nil if it receives all messages.
+	filter atomic.Pointer[streamFilter]
+	// slow is set once the connection didn't keep up with its messages.
+	slow atomic.Bool
 }
 
 func newConn(ctx context.Context, ws *websocket.Conn, id string, writeTimeout time.Duration, withPing bool) Conn {
+	ctx, cancel := context.WithCancel(ctx)
 	return &conn{
 		ctx:          ctx,
+		cancel:       cancel,
 		id:           id,
 		ws:           ws,
 		writeTimeout: writeTimeout,
@@ -92,18 +105,41 @@ func (c *conn) Close() error {
 	return c.ws.Close()
 }
 
-// ReadNext reads the next message
+// ReadNext reads the next message, or returns nil once the connection is done
 func (c *conn) ReadNext() []byte {
-	return <-c.read
+	select {
+	case <-c.ctx.Done():
+		return nil
+	case msg := <-c.read:
+		return msg
+	}
 }
 
-// Send sends the given message
+// Send sends the given message, or disconnects the connection if its buffer is full
 func (c *conn) Send(msg []byte) {
-	if len(c.send) >= chanSize {
-		// don't send on full channel
-		return
+	select {
+	case c.send <- msg:
+	default:
+		// consumers which don't keep up are disconnected rather than silently missing messages,
+		// so they can reconnect and resume from the last slot they received.
+		if c.slow.CompareAndSwap(false, true) {
+			c.cancel()
+		}
+	}
+}
+
+// Accepts returns whether the given message matches the subscription of the connection
+func (c *conn) Accepts(msg *Message) bool {
+	filter := c.filter.Load()
+	if filter == nil {
+		return true
 	}
-	c.send <- msg
+	return filter.match(msg.Filter.PublicKey, msg.Filter.Role)
+}
+
+// Subscribe sets the subscription of the connection
+func (c *conn) Subscribe(filter *streamFilter) {
+	c.filter.Store(filter)
 }
 
 // WriteLoop a loop to activate writes on the socket
@@ -127,14 +163,19 @@ func (c *conn) WriteLoop(logger *zap.Logger) {
 	for {
 		select {
 		case <-ctx.Done():
+			closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+			if c.slow.Load() {
+				logger.Warn("disconnecting slow consumer")
+				closeMsg = websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "slow consumer")
+			}
 			c.writeLock.Lock()
 			logger.Debug("context done, sending close message")
-			err := c.ws.WriteControl(websocket.CloseMessage, []byte{}, time.Now().Add(c.writeTimeout))
+			err := c.ws.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(c.writeTimeout))
 			c.writeLock.Unlock()
 			if err != nil {
-				logger.Error("could not send close message", zap.Error(err))
-				return
+				logger.Debug("could not send close message", zap.Error(err))
 			}
+			return
 		case message := <-c.send:
 			c.writeLock.Lock()
 			n, err := c.sendMsg(message)
@@ -151,6 +192,7 @@ func (c *conn) WriteLoop(logger *zap.Logger) {
 // ReadLoop is a loop to read messages from the socket
 func (c *conn) ReadLoop(logger *zap.Logger) {
 	defer func() {
+		c.cancel()
 		_ = c.ws.Close()
 	}()
 	c.ws.SetReadLimit(maxMessageSize)
@@ -181,7 +223,14 @@ func (c *conn) ReadLoop(logger *zap.Logger) {
 		}
 		if mt == websocket.TextMessage {
 			msg = bytes.TrimSpace(bytes.Replace(msg, newline, space, -1))
-			c.read <- msg
+			if len(msg) == 0 {
+				continue
+			}
+			select {
+			case c.read <- msg:
+			case <-c.ctx.Done():
+				return
+			}
 		}
 	}
 }
@@ -0,0 +1,500 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: exporter/api/exporterpb/exporter.proto
+
+package exporterpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Role is the role of a duty.
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED                 Role = 0
+	Role_ROLE_ATTESTER                    Role = 1
+	Role_ROLE_AGGREGATOR                  Role = 2
+	Role_ROLE_PROPOSER                    Role = 3
+	Role_ROLE_SYNC_COMMITTEE              Role = 4
+	Role_ROLE_SYNC_COMMITTEE_CONTRIBUTION Role = 5
+	Role_ROLE_VALIDATOR_REGISTRATION      Role = 6
+	Role_ROLE_VOLUNTARY_EXIT              Role = 7
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_ATTESTER",
+		2: "ROLE_AGGREGATOR",
+		3: "ROLE_PROPOSER",
+		4: "ROLE_SYNC_COMMITTEE",
+		5: "ROLE_SYNC_COMMITTEE_CONTRIBUTION",
+		6: "ROLE_VALIDATOR_REGISTRATION",
+		7: "ROLE_VOLUNTARY_EXIT",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED":                 0,
+		"ROLE_ATTESTER":                    1,
+		"ROLE_AGGREGATOR":                  2,
+		"ROLE_PROPOSER":                    3,
+		"ROLE_SYNC_COMMITTEE":              4,
+		"ROLE_SYNC_COMMITTEE_CONTRIBUTION": 5,
+		"ROLE_VALIDATOR_REGISTRATION":      6,
+		"ROLE_VOLUNTARY_EXIT":              7,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_exporter_api_exporterpb_exporter_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_exporter_api_exporterpb_exporter_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_exporter_api_exporterpb_exporter_proto_rawDescGZIP(), []int{0}
+}
+
+// Decided is a decided duty of a validator.
+type Decided struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Slot uint64 `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	Role Role   `protobuf:"varint,2,opt,name=role,proto3,enum=ssv.exporter.v1.Role" json:"role,omitempty"`
+	// public_key is the public key of the validator.
+	PublicKey []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// signers are the operators whose signatures decided the duty.
+	Signers []uint64 `protobuf:"varint,4,rep,packed,name=signers,proto3" json:"signers,omitempty"`
+	// identifier is the message ID of the duty's QBFT instance.
+	Identifier []byte `protobuf:"bytes,5,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	// synced is set when the participants were synced from peers rather than observed by the exporter.
+	Synced bool `protobuf:"varint,6,opt,name=synced,proto3" json:"synced,omitempty"`
+}
+
+func (x *Decided) Reset() {
+	*x = Decided{}
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Decided) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Decided) ProtoMessage() {}
+
+func (x *Decided) ProtoReflect() protoreflect.Message {
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Decided.ProtoReflect.Descriptor instead.
+func (*Decided) Descriptor() ([]byte, []int) {
+	return file_exporter_api_exporterpb_exporter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Decided) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *Decided) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Decided) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *Decided) GetSigners() []uint64 {
+	if x != nil {
+		return x.Signers
+	}
+	return nil
+}
+
+func (x *Decided) GetIdentifier() []byte {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *Decided) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
+type GetDecidedsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Role      Role   `protobuf:"varint,2,opt,name=role,proto3,enum=ssv.exporter.v1.Role" json:"role,omitempty"`
+	// from and to are the first and last slots of the range.
+	From uint64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   uint64 `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *GetDecidedsRequest) Reset() {
+	*x = GetDecidedsRequest{}
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecidedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecidedsRequest) ProtoMessage() {}
+
+func (x *GetDecidedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecidedsRequest.ProtoReflect.Descriptor instead.
+func (*GetDecidedsRequest) Descriptor() ([]byte, []int) {
+	return file_exporter_api_exporterpb_exporter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDecidedsRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *GetDecidedsRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *GetDecidedsRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetDecidedsRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type GetDecidedsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decideds []*Decided `protobuf:"bytes,1,rep,name=decideds,proto3" json:"decideds,omitempty"`
+}
+
+func (x *GetDecidedsResponse) Reset() {
+	*x = GetDecidedsResponse{}
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecidedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecidedsResponse) ProtoMessage() {}
+
+func (x *GetDecidedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecidedsResponse.ProtoReflect.Descriptor instead.
+func (*GetDecidedsResponse) Descriptor() ([]byte, []int) {
+	return file_exporter_api_exporterpb_exporter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetDecidedsResponse) GetDecideds() []*Decided {
+	if x != nil {
+		return x.Decideds
+	}
+	return nil
+}
+
+// StreamDecidedsRequest filters the streamed duties,
+// matching those which match any of the values of every given filter.
+type StreamDecidedsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKeys [][]byte `protobuf:"bytes,1,rep,name=public_keys,json=publicKeys,proto3" json:"public_keys,omitempty"`
+	// committees are committee IDs, matching the duties of the committees' validators.
+	Committees [][]byte `protobuf:"bytes,2,rep,name=committees,proto3" json:"committees,omitempty"`
+	Roles      []Role   `protobuf:"varint,3,rep,packed,name=roles,proto3,enum=ssv.exporter.v1.Role" json:"roles,omitempty"`
+	// last_slot, if not zero, resumes the stream with the stored duties decided after it.
+	LastSlot uint64 `protobuf:"varint,4,opt,name=last_slot,json=lastSlot,proto3" json:"last_slot,omitempty"`
+}
+
+func (x *StreamDecidedsRequest) Reset() {
+	*x = StreamDecidedsRequest{}
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDecidedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDecidedsRequest) ProtoMessage() {}
+
+func (x *StreamDecidedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_exporter_api_exporterpb_exporter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDecidedsRequest.ProtoReflect.Descriptor instead.
+func (*StreamDecidedsRequest) Descriptor() ([]byte, []int) {
+	return file_exporter_api_exporterpb_exporter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamDecidedsRequest) GetPublicKeys() [][]byte {
+	if x != nil {
+		return x.PublicKeys
+	}
+	return nil
+}
+
+func (x *StreamDecidedsRequest) GetCommittees() [][]byte {
+	if x != nil {
+		return x.Committees
+	}
+	return nil
+}
+
+func (x *StreamDecidedsRequest) GetRoles() []Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *StreamDecidedsRequest) GetLastSlot() uint64 {
+	if x != nil {
+		return x.LastSlot
+	}
+	return 0
+}
+
+var File_exporter_api_exporterpb_exporter_proto protoreflect.FileDescriptor
+
+var file_exporter_api_exporterpb_exporter_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x70, 0x62, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73, 0x73, 0x76, 0x2e, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0xb9, 0x01, 0x0a, 0x07, 0x44, 0x65,
+	0x63, 0x69, 0x64, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x73, 0x73, 0x76, 0x2e, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73,
+	0x79, 0x6e, 0x63, 0x65, 0x64, 0x22, 0x82, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44, 0x65, 0x63,
+	0x69, 0x64, 0x65, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x73, 0x73, 0x76, 0x2e,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65,
+	0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x34, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x73, 0x76, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x52, 0x08, 0x64,
+	0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x65, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x15, 0x2e, 0x73, 0x73, 0x76, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x2a, 0xd0, 0x01, 0x0a,
+	0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x52,
+	0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x13,
+	0x0a, 0x0f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x4f,
+	0x52, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50,
+	0x4f, 0x53, 0x45, 0x52, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x53,
+	0x59, 0x4e, 0x43, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x45, 0x10, 0x04, 0x12,
+	0x24, 0x0a, 0x20, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x5f, 0x43, 0x4f, 0x4d,
+	0x4d, 0x49, 0x54, 0x54, 0x45, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x49, 0x42, 0x55, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x05, 0x12, 0x1f, 0x0a, 0x1b, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x52, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x06, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x56,
+	0x4f, 0x4c, 0x55, 0x4e, 0x54, 0x41, 0x52, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x54, 0x10, 0x07, 0x32,
+	0xba, 0x01, 0x0a, 0x08, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x12, 0x58, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x12, 0x23, 0x2e, 0x73, 0x73,
+	0x76, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x24, 0x2e, 0x73, 0x73, 0x76, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x12, 0x26, 0x2e, 0x73, 0x73, 0x76, 0x2e, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x73, 0x73, 0x76, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x64, 0x65, 0x64, 0x30, 0x01, 0x42, 0x30, 0x5a, 0x2e,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x73, 0x76, 0x6c, 0x61,
+	0x62, 0x73, 0x2f, 0x73, 0x73, 0x76, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x72, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_exporter_api_exporterpb_exporter_proto_rawDescOnce sync.Once
+	file_exporter_api_exporterpb_exporter_proto_rawDescData = file_exporter_api_exporterpb_exporter_proto_rawDesc
+)
+
+func file_exporter_api_exporterpb_exporter_proto_rawDescGZIP() []byte {
+	file_exporter_api_exporterpb_exporter_proto_rawDescOnce.Do(func() {
+		file_exporter_api_exporterpb_exporter_proto_rawDescData = protoimpl.X.CompressGZIP(file_exporter_api_exporterpb_exporter_proto_rawDescData)
+	})
+	return file_exporter_api_exporterpb_exporter_proto_rawDescData
+}
+
+var file_exporter_api_exporterpb_exporter_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_exporter_api_exporterpb_exporter_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_exporter_api_exporterpb_exporter_proto_goTypes = []any{
+	(Role)(0),                     // 0: ssv.exporter.v1.Role
+	(*Decided)(nil),               // 1: ssv.exporter.v1.Decided
+	(*GetDecidedsRequest)(nil),    // 2: ssv.exporter.v1.GetDecidedsRequest
+	(*GetDecidedsResponse)(nil),   // 3: ssv.exporter.v1.GetDecidedsResponse
+	(*StreamDecidedsRequest)(nil), // 4: ssv.exporter.v1.StreamDecidedsRequest
+}
+var file_exporter_api_exporterpb_exporter_proto_depIdxs = []int32{
+	0, // 0: ssv.exporter.v1.Decided.role:type_name -> ssv.exporter.v1.Role
+	0, // 1: ssv.exporter.v1.GetDecidedsRequest.role:type_name -> ssv.exporter.v1.Role
+	1, // 2: ssv.exporter.v1.GetDecidedsResponse.decideds:type_name -> ssv.exporter.v1.Decided
+	0, // 3: ssv.exporter.v1.StreamDecidedsRequest.roles:type_name -> ssv.exporter.v1.Role
+	2, // 4: ssv.exporter.v1.Exporter.GetDecideds:input_type -> ssv.exporter.v1.GetDecidedsRequest
+	4, // 5: ssv.exporter.v1.Exporter.StreamDecideds:input_type -> ssv.exporter.v1.StreamDecidedsRequest
+	3, // 6: ssv.exporter.v1.Exporter.GetDecideds:output_type -> ssv.exporter.v1.GetDecidedsResponse
+	1, // 7: ssv.exporter.v1.Exporter.StreamDecideds:output_type -> ssv.exporter.v1.Decided
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_exporter_api_exporterpb_exporter_proto_init() }
+func file_exporter_api_exporterpb_exporter_proto_init() {
+	if File_exporter_api_exporterpb_exporter_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_exporter_api_exporterpb_exporter_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_exporter_api_exporterpb_exporter_proto_goTypes,
+		DependencyIndexes: file_exporter_api_exporterpb_exporter_proto_depIdxs,
+		EnumInfos:         file_exporter_api_exporterpb_exporter_proto_enumTypes,
+		MessageInfos:      file_exporter_api_exporterpb_exporter_proto_msgTypes,
+	}.Build()
+	File_exporter_api_exporterpb_exporter_proto = out.File
+	file_exporter_api_exporterpb_exporter_proto_rawDesc = nil
+	file_exporter_api_exporterpb_exporter_proto_goTypes = nil
+	file_exporter_api_exporterpb_exporter_proto_depIdxs = nil
+}
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: exporter/api/exporterpb/exporter.proto
+
+package exporterpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Exporter_GetDecideds_FullMethodName    = "/ssv.exporter.v1.Exporter/GetDecideds"
+	Exporter_StreamDecideds_FullMethodName = "/ssv.exporter.v1.Exporter/StreamDecideds"
+)
+
+// ExporterClient is the client API for Exporter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExporterClient interface {
+	// GetDecideds returns the decided duties of a validator in a range of slots.
+	GetDecideds(ctx context.Context, in *GetDecidedsRequest, opts ...grpc.CallOption) (*GetDecidedsResponse, error)
+	// StreamDecideds streams duties as they're decided, optionally resuming after the last slot received.
+	StreamDecideds(ctx context.Context, in *StreamDecidedsRequest, opts ...grpc.CallOption) (Exporter_StreamDecidedsClient, error)
+}
+
+type exporterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExporterClient(cc grpc.ClientConnInterface) ExporterClient {
+	return &exporterClient{cc}
+}
+
+func (c *exporterClient) GetDecideds(ctx context.Context, in *GetDecidedsRequest, opts ...grpc.CallOption) (*GetDecidedsResponse, error) {
+	out := new(GetDecidedsResponse)
+	err := c.cc.Invoke(ctx, Exporter_GetDecideds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exporterClient) StreamDecideds(ctx context.Context, in *StreamDecidedsRequest, opts ...grpc.CallOption) (Exporter_StreamDecidedsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Exporter_ServiceDesc.Streams[0], Exporter_StreamDecideds_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &exporterStreamDecidedsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Exporter_StreamDecidedsClient interface {
+	Recv() (*Decided, error)
+	grpc.ClientStream
+}
+
+type exporterStreamDecidedsClient struct {
+	grpc.ClientStream
+}
+
+func (x *exporterStreamDecidedsClient) Recv() (*Decided, error) {
+	m := new(Decided)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExporterServer is the server API for Exporter service.
+// All implementations must embed UnimplementedExporterServer
+// for forward compatibility
+type ExporterServer interface {
+	// GetDecideds returns the decided duties of a validator in a range of slots.
+	GetDecideds(context.Context, *GetDecidedsRequest) (*GetDecidedsResponse, error)
+	// StreamDecideds streams duties as they're decided, optionally resuming after the last slot received.
+	StreamDecideds(*StreamDecidedsRequest, Exporter_StreamDecidedsServer) error
+	mustEmbedUnimplementedExporterServer()
+}
+
+// UnimplementedExporterServer must be embedded to have forward compatible implementations.
+type UnimplementedExporterServer struct {
+}
+
+func (UnimplementedExporterServer) GetDecideds(context.Context, *GetDecidedsRequest) (*GetDecidedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDecideds not implemented")
+}
+func (UnimplementedExporterServer) StreamDecideds(*StreamDecidedsRequest, Exporter_StreamDecidedsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDecideds not implemented")
+}
+func (UnimplementedExporterServer) mustEmbedUnimplementedExporterServer() {}
+
+// UnsafeExporterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExporterServer will
+// result in compilation errors.
+type UnsafeExporterServer interface {
+	mustEmbedUnimplementedExporterServer()
+}
+
+func RegisterExporterServer(s grpc.ServiceRegistrar, srv ExporterServer) {
+	s.RegisterService(&Exporter_ServiceDesc, srv)
+}
+
+func _Exporter_GetDecideds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecidedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExporterServer).GetDecideds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Exporter_GetDecideds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExporterServer).GetDecideds(ctx, req.(*GetDecidedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Exporter_StreamDecideds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamDecidedsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExporterServer).StreamDecideds(m, &exporterStreamDecidedsServer{stream})
+}
+
+type Exporter_StreamDecidedsServer interface {
+	Send(*Decided) error
+	grpc.ServerStream
+}
+
+type exporterStreamDecidedsServer struct {
+	grpc.ServerStream
+}
+
+func (x *exporterStreamDecidedsServer) Send(m *Decided) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Exporter_ServiceDesc is the grpc.ServiceDesc for Exporter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Exporter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ssv.exporter.v1.Exporter",
+	HandlerType: (*ExporterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDecideds",
+			Handler:    _Exporter_GetDecideds_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamDecideds",
+			Handler:       _Exporter_StreamDecideds_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "exporter/api/exporterpb/exporter.proto",
+}
@@ -0,0 +1,4 @@
+// Package exporterpb generates the protobuf messages and gRPC service of the exporter API.
+package exporterpb
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative exporter/api/exporterpb/exporter.proto
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/ssvlabs/ssv/exporter/api/exporterpb"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/logging/fields"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+// GRPCServer serves the exporter API over gRPC,
+// sharing the queries and the stream of decided messages of the WebSocket API.
+type GRPCServer struct {
+	exporterpb.UnimplementedExporterServer
+
+	// ctx is done when the server stops, ending the streams.
+	ctx    context.Context
+	logger *zap.Logger
+	feed   *event.Feed
+	domain spectypes.DomainType
+	stream *StreamStorage
+}
+
+// NewGRPCServer creates a server querying the given storage and streaming the decided messages sent on the given feed.
+func NewGRPCServer(logger *zap.Logger, feed *event.Feed, domain spectypes.DomainType, stream *StreamStorage) *GRPCServer {
+	return &GRPCServer{
+		ctx:    context.Background(),
+		logger: logger.Named(logging.NameGRPCServer),
+		feed:   feed,
+		domain: domain,
+		stream: stream,
+	}
+}
+
+// Start serves the gRPC API on the given address until the context is done,
+// over TLS when a certificate and key are given.
+// Once the context is done, it stops accepting calls and waits for the ones in progress, ending streams.
+func (s *GRPCServer) Start(ctx context.Context, addr, tlsCertFile, tlsKeyFile string) error {
+	var opts []grpc.ServerOption
+	if tlsCertFile != "" || tlsKeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(tlsCertFile, tlsKeyFile)
+		if err != nil {
+			return fmt.Errorf("could not load TLS certificate: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer(opts...)
+	exporterpb.RegisterExporterServer(server, s)
+
+	// Streams only end when their client disconnects, so they're ended for the server to stop.
+	serverCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.ctx = serverCtx
+	go func() {
+		<-serverCtx.Done()
+		server.GracefulStop()
+	}()
+
+	s.logger.Info("starting", fields.Address(addr), zap.Bool("tls", len(opts) > 0))
+	err = server.Serve(listener)
+	if err != nil {
+		s.logger.Warn("could not start", zap.Error(err))
+	}
+	return err
+}
+
+func (s *GRPCServer) GetDecideds(ctx context.Context, req *exporterpb.GetDecidedsRequest) (*exporterpb.GetDecidedsResponse, error) {
+	if len(req.PublicKey) != len(phase0.BLSPubKey{}) {
+		return nil, status.Error(codes.InvalidArgument, "invalid public key")
+	}
+	if req.Role == exporterpb.Role_ROLE_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+	if req.From > req.To {
+		return nil, status.Error(codes.InvalidArgument, "from is after to")
+	}
+
+	entries, err := queryParticipants(s.logger, s.stream.QBFTStores, s.stream.SyncedQBFTStores, s.domain, req.PublicKey, roleName(req.Role), req.From, req.To)
+	if err != nil {
+		return nil, queryStatus(err)
+	}
+	data, err := ParticipantsAPIData(entries...)
+	if err != nil {
+		// There are no decided duties in the range.
+		return &exporterpb.GetDecidedsResponse{}, nil
+	}
+	return &exporterpb.GetDecidedsResponse{Decideds: decidedsFromAPI(data.([]*ParticipantsAPI))}, nil
+}
+
+func (s *GRPCServer) StreamDecideds(req *exporterpb.StreamDecidedsRequest, stream exporterpb.Exporter_StreamDecidedsServer) error {
+	var subscription Subscription
+	for _, pubKey := range req.PublicKeys {
+		subscription.PublicKeys = append(subscription.PublicKeys, hex.EncodeToString(pubKey))
+	}
+	for _, committee := range req.Committees {
+		subscription.Committees = append(subscription.Committees, hex.EncodeToString(committee))
+	}
+	for _, role := range req.Roles {
+		subscription.Roles = append(subscription.Roles, roleName(role))
+	}
+	var shares registrystorage.Shares
+	if s.stream != nil {
+		shares = s.stream.Shares
+	}
+	filter, err := newStreamFilter(shares, &subscription)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var from, to phase0.Slot
+	if req.LastSlot != 0 {
+		if from, to, err = resumeRange(s.stream, filter, phase0.Slot(req.LastSlot)); errors.Is(err, errResumeUnfiltered) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		} else if err != nil {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+	}
+
+	// Subscribing before backfilling may send messages decided meanwhile twice, rather than miss them.
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	stopServerCancel := context.AfterFunc(s.ctx, cancel)
+	defer stopServerCancel()
+	msgs, slow := s.subscribe(ctx, cancel, filter)
+
+	if req.LastSlot != 0 {
+		backfilled, err := backfill(s.stream, filter, from, to)
+		if err != nil {
+			s.logger.Warn("could not backfill stream", zap.Error(err))
+			return status.Error(codes.Internal, "could not get stored decided messages")
+		}
+		for _, msg := range backfilled {
+			if err := sendDecideds(stream, msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if slow() {
+				return status.Error(codes.ResourceExhausted, "slow consumer")
+			}
+			return nil
+		case msg := <-msgs:
+			if err := sendDecideds(stream, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribe relays the messages of the feed which match the filter, without blocking the feed.
+// Once the buffer of a consumer which doesn't keep up is full, the context is cancelled and slow returns true.
+func (s *GRPCServer) subscribe(ctx context.Context, cancel context.CancelFunc, filter *streamFilter) (msgs <-chan Message, slow func() bool) {
+	in := make(chan Message, chanSize)
+	out := make(chan Message, chanSize)
+	sub := s.feed.Subscribe(in)
+
+	var full bool
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-in:
+				if full || !filter.match(msg.Filter.PublicKey, msg.Filter.Role) {
+					continue
+				}
+				select {
+				case out <- msg:
+				default:
+					full = true
+					cancel()
+				}
+			}
+		}
+	}()
+	return out, func() bool {
+		<-done
+		return full
+	}
+}
+
+func sendDecideds(stream exporterpb.Exporter_StreamDecidedsServer, msg Message) error {
+	data, ok := msg.Data.([]*ParticipantsAPI)
+	if !ok {
+		return nil
+	}
+	for _, decided := range decidedsFromAPI(data) {
+		if err := stream.Send(decided); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decidedsFromAPI(data []*ParticipantsAPI) []*exporterpb.Decided {
+	decideds := make([]*exporterpb.Decided, 0, len(data))
+	for _, p := range data {
+		pubKey, _ := hex.DecodeString(p.ValidatorPK)
+		decideds = append(decideds, &exporterpb.Decided{
+			Slot:       uint64(p.Slot),
+			Role:       exporterpb.Role(exporterpb.Role_value["ROLE_"+p.Role]),
+			PublicKey:  pubKey,
+			Signers:    p.Signers,
+			Identifier: p.Identifier,
+			Synced:     p.Synced,
+		})
+	}
+	return decideds
+}
+
+// roleName returns the name of the role in the WebSocket API, such as ATTESTER.
+func roleName(role exporterpb.Role) string {
+	return strings.TrimPrefix(role.String(), "ROLE_")
+}
+
+// queryStatus returns the gRPC status of a failed query.
+func queryStatus(err error) error {
+	var qErr *queryError
+	if !errors.As(err, &qErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	switch qErr.kind {
+	case queryInvalid:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case queryUnavailable:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ssvlabs/ssv/exporter/api/exporterpb"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	qbftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	protocolstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestGRPCServer(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	domain := networkconfig.TestNetwork.DomainType
+	stores := qbftstorage.NewStoresFromRoles(db, convert.RoleAttester, convert.RoleProposer)
+	pk1, pk2 := testPubKey(1), testPubKey(2)
+	pk1Raw, _ := hex.DecodeString(pk1)
+	saveParticipants := func(pubKey string, role convert.RunnerRole, slot phase0.Slot) convert.MessageID {
+		b, err := hex.DecodeString(pubKey)
+		require.NoError(t, err)
+		msgID := convert.NewMsgID(domain, b, role)
+		_, err = stores.Get(role).UpdateParticipants(msgID, slot, []spectypes.OperatorID{1, 2, 3})
+		require.NoError(t, err)
+		return msgID
+	}
+	saveParticipants(pk1, convert.RoleAttester, 10)
+	saveParticipants(pk1, convert.RoleAttester, 12)
+	saveParticipants(pk2, convert.RoleAttester, 11)
+
+	feed := new(event.Feed)
+	s := NewGRPCServer(logger, feed, domain, &StreamStorage{
+		DomainType:  domain,
+		QBFTStores:  stores,
+		CurrentSlot: func() phase0.Slot { return 20 },
+	})
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	exporterpb.RegisterExporterServer(server, s)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := exporterpb.NewExporterClient(conn)
+
+	t.Run("get decideds", func(t *testing.T) {
+		res, err := client.GetDecideds(context.Background(), &exporterpb.GetDecidedsRequest{
+			PublicKey: pk1Raw,
+			Role:      exporterpb.Role_ROLE_ATTESTER,
+			From:      0,
+			To:        20,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Decideds, 2)
+		require.Equal(t, uint64(10), res.Decideds[0].Slot)
+		require.Equal(t, uint64(12), res.Decideds[1].Slot)
+		require.Equal(t, exporterpb.Role_ROLE_ATTESTER, res.Decideds[0].Role)
+		require.Equal(t, pk1Raw, res.Decideds[0].PublicKey)
+		require.Equal(t, []uint64{1, 2, 3}, res.Decideds[0].Signers)
+
+		res, err = client.GetDecideds(context.Background(), &exporterpb.GetDecidedsRequest{
+			PublicKey: pk1Raw,
+			Role:      exporterpb.Role_ROLE_PROPOSER,
+			From:      0,
+			To:        20,
+		})
+		require.NoError(t, err)
+		require.Empty(t, res.Decideds)
+	})
+
+	t.Run("get decideds errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			req  *exporterpb.GetDecidedsRequest
+			code codes.Code
+		}{
+			{&exporterpb.GetDecidedsRequest{PublicKey: []byte{1}, Role: exporterpb.Role_ROLE_ATTESTER}, codes.InvalidArgument},
+			{&exporterpb.GetDecidedsRequest{PublicKey: pk1Raw}, codes.InvalidArgument},
+			{&exporterpb.GetDecidedsRequest{PublicKey: pk1Raw, Role: exporterpb.Role_ROLE_ATTESTER, From: 2, To: 1}, codes.InvalidArgument},
+			{&exporterpb.GetDecidedsRequest{PublicKey: pk1Raw, Role: exporterpb.Role_ROLE_AGGREGATOR}, codes.FailedPrecondition},
+		} {
+			_, err := client.GetDecideds(context.Background(), tc.req)
+			require.Equal(t, tc.code, status.Code(err), err)
+		}
+
+		// Errors other than failed queries are internal errors.
+		require.Equal(t, codes.Internal, status.Code(queryStatus(errors.New("unexpected"))))
+	})
+
+	t.Run("stream decideds", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream, err := client.StreamDecideds(ctx, &exporterpb.StreamDecidedsRequest{
+			PublicKeys: [][]byte{pk1Raw},
+			LastSlot:   10,
+		})
+		require.NoError(t, err)
+
+		// The stream resumes with the stored duties after the last slot.
+		decided, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, uint64(12), decided.Slot)
+
+		msgID := saveParticipants(pk2, convert.RoleAttester, 13)
+		feed.Send(NewParticipantsAPIMsg(protocolstorage.ParticipantsRangeEntry{Slot: 13, Signers: []spectypes.OperatorID{1, 2, 3}, Identifier: msgID}))
+		msgID = saveParticipants(pk1, convert.RoleAttester, 14)
+		feed.Send(NewParticipantsAPIMsg(protocolstorage.ParticipantsRangeEntry{Slot: 14, Signers: []spectypes.OperatorID{1, 2, 3}, Identifier: msgID}))
+
+		decided, err = stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, uint64(14), decided.Slot)
+		require.Equal(t, pk1Raw, decided.PublicKey)
+	})
+
+	t.Run("stream decideds errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			req  *exporterpb.StreamDecidedsRequest
+			code codes.Code
+		}{
+			{&exporterpb.StreamDecidedsRequest{PublicKeys: [][]byte{{1}}}, codes.InvalidArgument},
+			{&exporterpb.StreamDecidedsRequest{Roles: []exporterpb.Role{exporterpb.Role_ROLE_UNSPECIFIED}}, codes.InvalidArgument},
+			{&exporterpb.StreamDecidedsRequest{Committees: [][]byte{make([]byte, 32)}}, codes.InvalidArgument},
+		} {
+			stream, err := client.StreamDecideds(context.Background(), tc.req)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, tc.code, status.Code(err), err)
+		}
+
+		s.stream.CurrentSlot = func() phase0.Slot { return 10 + maxResumeSlots + 1 }
+		defer func() { s.stream.CurrentSlot = func() phase0.Slot { return 20 } }()
+		stream, err := client.StreamDecideds(context.Background(), &exporterpb.StreamDecidedsRequest{PublicKeys: [][]byte{pk1Raw}, LastSlot: 10})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.OutOfRange, status.Code(err), err)
+
+		// Streams of all validators can't be resumed.
+		stream, err = client.StreamDecideds(context.Background(), &exporterpb.StreamDecidedsRequest{LastSlot: 10})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err), err)
+	})
+}
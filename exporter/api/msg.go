@@ -8,6 +8,7 @@ import (
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 
+	"github.com/ssvlabs/ssv/ibft/storage"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
 )
 
@@ -29,6 +30,8 @@ type ParticipantsAPI struct {
 	Role        string
 	Message     specqbft.Message
 	FullData    *spectypes.ValidatorConsensusData
+	// Synced is set when the participants were synced from peers rather than observed by this node.
+	Synced bool `json:",omitempty"`
 }
 
 // NewParticipantsAPIMsg creates a new message in a new format from the given message.
@@ -85,6 +88,7 @@ func ParticipantsAPIData(msgs ...qbftstorage.ParticipantsRangeEntry) (interface{
 					Slot:   msg.Slot,
 				},
 			},
+			Synced: msg.Synced,
 		}
 
 		apiMsgs = append(apiMsgs, apiMsg)
@@ -93,6 +97,69 @@ func ParticipantsAPIData(msgs ...qbftstorage.ParticipantsRangeEntry) (interface{
 	return apiMsgs, nil
 }
 
+// DutyAPI is an archived duty of a validator.
+type DutyAPI struct {
+	Role              string                          `json:"role"`
+	Slot              phase0.Slot                     `json:"slot"`
+	PublicKey         string                          `json:"public_key"`
+	Index             phase0.ValidatorIndex           `json:"index"`
+	BeaconRoot        phase0.Root                     `json:"beacon_root"`
+	Signature         phase0.BLSSignature             `json:"signature"`
+	PartialSignatures map[spectypes.OperatorID]string `json:"partial_signatures"`
+	// Decided is the decided QBFT message of the duty, if it was observed.
+	Decided *DecidedAPI `json:"decided,omitempty"`
+}
+
+// DecidedAPI is a decided QBFT message, both encoded and with its main fields decoded.
+type DecidedAPI struct {
+	Signers  []spectypes.OperatorID `json:"signers"`
+	Round    specqbft.Round         `json:"round"`
+	Root     phase0.Root            `json:"root"`
+	FullData string                 `json:"full_data"`
+	// Message is the SSZ-encoded SignedSSVMessage.
+	Message string `json:"message"`
+}
+
+// DutiesAPIData creates the API representation of archived duties.
+func DutiesAPIData(duties ...*storage.ArchivedDuty) ([]*DutyAPI, error) {
+	apiDuties := make([]*DutyAPI, 0, len(duties))
+	for _, duty := range duties {
+		apiDuty := &DutyAPI{
+			Role:              duty.Role.ToBeaconRole(),
+			Slot:              duty.Slot,
+			PublicKey:         hex.EncodeToString(duty.PubKey[:]),
+			Index:             duty.Index,
+			BeaconRoot:        duty.BeaconRoot,
+			Signature:         duty.Signature,
+			PartialSignatures: make(map[spectypes.OperatorID]string, len(duty.PartialSignatures)),
+		}
+		for signer, signature := range duty.PartialSignatures {
+			apiDuty.PartialSignatures[signer] = hex.EncodeToString(signature)
+		}
+
+		if duty.Decided != nil {
+			qbftMsg, err := specqbft.DecodeMessage(duty.Decided.SSVMessage.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not decode decided qbft message")
+			}
+			encoded, err := duty.Decided.Encode()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not encode decided message")
+			}
+			apiDuty.Decided = &DecidedAPI{
+				Signers:  duty.Decided.OperatorIDs,
+				Round:    qbftMsg.Round,
+				Root:     qbftMsg.Root,
+				FullData: hex.EncodeToString(duty.Decided.FullData),
+				Message:  hex.EncodeToString(encoded),
+			}
+		}
+
+		apiDuties = append(apiDuties, apiDuty)
+	}
+	return apiDuties, nil
+}
+
 // MessageFilter is a criteria for query in request messages and projection in responses
 type MessageFilter struct {
 	// From is the starting index of the desired data
@@ -119,4 +186,8 @@ const (
 	TypeError MessageType = "error"
 	// TypeParticipants is an enum for participants type messages
 	TypeParticipants MessageType = "participants"
+	// TypeDuties is an enum for archived duty type messages
+	TypeDuties MessageType = "duties"
+	// TypeSubscribe is an enum for stream subscription messages
+	TypeSubscribe MessageType = "subscribe"
 )
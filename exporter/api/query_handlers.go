@@ -2,7 +2,9 @@ package api
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -12,6 +14,7 @@ import (
 	"github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/protocol/v2/message"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
 	"github.com/ssvlabs/ssv/utils/casts"
 )
 
@@ -48,7 +51,8 @@ func HandleUnknownQuery(logger *zap.Logger, nm *NetworkMessage) {
 }
 
 // HandleParticipantsQuery handles TypeParticipants queries.
-func HandleParticipantsQuery(logger *zap.Logger, qbftStorage *storage.QBFTStores, nm *NetworkMessage, domain spectypes.DomainType) {
+// The participants synced from peers are included where none were observed, and may be nil.
+func HandleParticipantsQuery(logger *zap.Logger, qbftStorage, syncedStorage *storage.QBFTStores, nm *NetworkMessage, domain spectypes.DomainType) {
 	logger.Debug("handles query request",
 		zap.Uint64("from", nm.Msg.Filter.From),
 		zap.Uint64("to", nm.Msg.Filter.To),
@@ -66,31 +70,113 @@ func HandleParticipantsQuery(logger *zap.Logger, qbftStorage *storage.QBFTStores
 		return
 	}
 
-	beaconRole, err := message.BeaconRoleFromString(nm.Msg.Filter.Role)
+	participantsList, err := queryParticipants(logger, qbftStorage, syncedStorage, domain, pkRaw, nm.Msg.Filter.Role, nm.Msg.Filter.From, nm.Msg.Filter.To)
+	var qErr *queryError
+	if errors.As(err, &qErr) {
+		res.Data = qErr.data
+	} else if err != nil {
+		res.Data = []string{"internal error - could not get participants messages"}
+	} else {
+		data, err := ParticipantsAPIData(participantsList...)
+		if err != nil {
+			res.Data = []string{err.Error()}
+		} else {
+			res.Data = data
+		}
+	}
+	nm.Msg = res
+}
+
+// queryErrorKind is the reason a query failed.
+type queryErrorKind int
+
+const (
+	// queryInvalid is a query with invalid arguments.
+	queryInvalid queryErrorKind = iota
+	// queryUnavailable is a query of data which this node doesn't store.
+	queryUnavailable
+	// queryInternal is a query which failed on this node.
+	queryInternal
+)
+
+// queryError is a failed query, with the data the WebSocket API responds with.
+type queryError struct {
+	kind queryErrorKind
+	data []string
+}
+
+func (e *queryError) Error() string {
+	return strings.Join(e.data, ": ")
+}
+
+// queryParticipants returns the participants of the decided duties of a validator in a range of slots,
+// including those synced from peers in the slots which weren't observed, or a *queryError.
+func queryParticipants(logger *zap.Logger, qbftStorage, syncedStorage *storage.QBFTStores, domain spectypes.DomainType, pubKey []byte, role string, from, to uint64) ([]qbftstorage.ParticipantsRangeEntry, error) {
+	beaconRole, err := message.BeaconRoleFromString(role)
 	if err != nil {
 		logger.Warn("failed to parse role", zap.Error(err))
-		res.Data = []string{"role doesn't exist"}
-		nm.Msg = res
-		return
+		return nil, &queryError{kind: queryInvalid, data: []string{"role doesn't exist"}}
 	}
 	runnerRole := casts.BeaconRoleToConvertRole(beaconRole)
 	roleStorage := qbftStorage.Get(runnerRole)
 	if roleStorage == nil {
 		logger.Warn("role storage doesn't exist", fields.ExporterRole(runnerRole))
-		res.Data = []string{"internal error - role storage doesn't exist", beaconRole.String()}
+		return nil, &queryError{kind: queryUnavailable, data: []string{"internal error - role storage doesn't exist", beaconRole.String()}}
+	}
+
+	msgID := convert.NewMsgID(domain, pubKey, runnerRole)
+	participantsList, err := storage.ParticipantsInRange(roleStorage, syncedStorage.Get(runnerRole), msgID, phase0.Slot(from), phase0.Slot(to))
+	if err != nil {
+		logger.Warn("failed to get participants", zap.Error(err))
+		return nil, &queryError{kind: queryInternal, data: []string{"internal error - could not get participants messages"}}
+	}
+	return participantsList, nil
+}
+
+// HandleDutiesQuery handles TypeDuties queries.
+func HandleDutiesQuery(logger *zap.Logger, archive *storage.Archive, nm *NetworkMessage) {
+	logger.Debug("handles duties query request",
+		zap.Uint64("from", nm.Msg.Filter.From),
+		zap.Uint64("to", nm.Msg.Filter.To),
+		zap.String("publicKey", nm.Msg.Filter.PublicKey),
+		zap.String("role", nm.Msg.Filter.Role))
+	res := Message{
+		Type:   nm.Msg.Type,
+		Filter: nm.Msg.Filter,
+	}
+	if archive == nil {
+		res.Data = []string{"bad request - archive is not enabled"}
+		nm.Msg = res
+		return
+	}
+
+	var pubKey spectypes.ValidatorPK
+	pkRaw, err := hex.DecodeString(nm.Msg.Filter.PublicKey)
+	if err != nil || len(pkRaw) != len(pubKey) {
+		logger.Warn("failed to decode validator public key", zap.Error(err))
+		res.Data = []string{"bad request - could not read validator key"}
+		nm.Msg = res
+		return
+	}
+	copy(pubKey[:], pkRaw)
+
+	beaconRole, err := message.BeaconRoleFromString(nm.Msg.Filter.Role)
+	if err != nil {
+		logger.Warn("failed to parse role", zap.Error(err))
+		res.Data = []string{"role doesn't exist"}
 		nm.Msg = res
 		return
 	}
+	runnerRole := casts.BeaconRoleToConvertRole(beaconRole)
 
-	msgID := convert.NewMsgID(domain, pkRaw, runnerRole)
 	from := phase0.Slot(nm.Msg.Filter.From)
 	to := phase0.Slot(nm.Msg.Filter.To)
-	participantsList, err := roleStorage.GetParticipantsInRange(msgID, from, to)
+	duties, err := archive.GetDutiesInRange(runnerRole, pubKey, from, to)
 	if err != nil {
-		logger.Warn("failed to get participants", zap.Error(err))
-		res.Data = []string{"internal error - could not get participants messages"}
+		logger.Warn("failed to get archived duties", zap.Error(err))
+		res.Data = []string{"internal error - could not get archived duties"}
 	} else {
-		data, err := ParticipantsAPIData(participantsList...)
+		data, err := DutiesAPIData(duties...)
 		if err != nil {
 			res.Data = []string{err.Error()}
 		} else {
@@ -2,6 +2,7 @@ package api
 
 import (
 	"crypto/rsa"
+	"encoding/hex"
 	"math"
 	"testing"
 
@@ -132,7 +133,7 @@ func TestHandleDecidedQuery(t *testing.T) {
 
 		t.Run("valid range", func(t *testing.T) {
 			nm := newParticipantsAPIMsg(pk.SerializeToHexStr(), spectypes.BNRoleAttester, 0, 250)
-			HandleParticipantsQuery(l, ibftStorage, nm, networkConfig.DomainType)
+			HandleParticipantsQuery(l, ibftStorage, nil, nm, networkConfig.DomainType)
 			require.NotNil(t, nm.Msg.Data)
 			msgs, ok := nm.Msg.Data.([]*ParticipantsAPI)
 
@@ -142,7 +143,7 @@ func TestHandleDecidedQuery(t *testing.T) {
 
 		t.Run("invalid range", func(t *testing.T) {
 			nm := newParticipantsAPIMsg(pk.SerializeToHexStr(), spectypes.BNRoleAttester, 400, 404)
-			HandleParticipantsQuery(l, ibftStorage, nm, networkConfig.DomainType)
+			HandleParticipantsQuery(l, ibftStorage, nil, nm, networkConfig.DomainType)
 			require.NotNil(t, nm.Msg.Data)
 			data, ok := nm.Msg.Data.([]string)
 			require.True(t, ok)
@@ -151,7 +152,7 @@ func TestHandleDecidedQuery(t *testing.T) {
 
 		t.Run("non-existing validator", func(t *testing.T) {
 			nm := newParticipantsAPIMsg("xxx", spectypes.BNRoleAttester, 400, 404)
-			HandleParticipantsQuery(l, ibftStorage, nm, networkConfig.DomainType)
+			HandleParticipantsQuery(l, ibftStorage, nil, nm, networkConfig.DomainType)
 			require.NotNil(t, nm.Msg.Data)
 			errs, ok := nm.Msg.Data.([]string)
 			require.True(t, ok)
@@ -160,7 +161,7 @@ func TestHandleDecidedQuery(t *testing.T) {
 
 		t.Run("non-existing role", func(t *testing.T) {
 			nm := newParticipantsAPIMsg(pk.SerializeToHexStr(), math.MaxUint64, 0, 250)
-			HandleParticipantsQuery(l, ibftStorage, nm, networkConfig.DomainType)
+			HandleParticipantsQuery(l, ibftStorage, nil, nm, networkConfig.DomainType)
 			require.NotNil(t, nm.Msg.Data)
 			errs, ok := nm.Msg.Data.([]string)
 			require.True(t, ok)
@@ -169,7 +170,7 @@ func TestHandleDecidedQuery(t *testing.T) {
 
 		t.Run("non-existing storage", func(t *testing.T) {
 			nm := newParticipantsAPIMsg(pk.SerializeToHexStr(), spectypes.BNRoleSyncCommitteeContribution, 0, 250)
-			HandleParticipantsQuery(l, ibftStorage, nm, networkConfig.DomainType)
+			HandleParticipantsQuery(l, ibftStorage, nil, nm, networkConfig.DomainType)
 			require.NotNil(t, nm.Msg.Data)
 			errs, ok := nm.Msg.Data.([]string)
 			require.True(t, ok)
@@ -178,6 +179,89 @@ func TestHandleDecidedQuery(t *testing.T) {
 	}
 }
 
+func TestHandleDutiesQuery(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	db, l, done := newDBAndLoggerForTest(logger)
+	defer done()
+
+	archive := qbftstorage.NewArchive(db)
+
+	pubKey := spectypes.ValidatorPK{1, 2, 3}
+	identifier := convert.NewMsgID(networkconfig.TestNetwork.DomainType, pubKey[:], convert.RoleProposer)
+	qbftMsg := &specqbft.Message{
+		MsgType:    specqbft.CommitMsgType,
+		Height:     10,
+		Round:      2,
+		Identifier: identifier[:],
+		Root:       [32]byte{0x1, 0x2, 0x3},
+	}
+	data, err := qbftMsg.Encode()
+	require.NoError(t, err)
+	decided := &spectypes.SignedSSVMessage{
+		OperatorIDs: []spectypes.OperatorID{1, 2, 3},
+		Signatures:  [][]byte{{1}, {2}, {3}},
+		SSVMessage: &spectypes.SSVMessage{
+			MsgType: spectypes.SSVConsensusMsgType,
+			MsgID:   spectypes.MessageID(identifier),
+			Data:    data,
+		},
+		FullData: []byte{0x4, 0x5},
+	}
+	require.NoError(t, archive.SaveDecided(10, decided))
+	for _, slot := range []phase0.Slot{10, 11} {
+		require.NoError(t, archive.SaveDuty(&qbftstorage.ArchivedDuty{
+			Role:              convert.RoleProposer,
+			Slot:              slot,
+			PubKey:            pubKey,
+			DecidedIdentifier: identifier[:],
+			BeaconRoot:        phase0.Root{0x6},
+			PartialSignatures: map[spectypes.OperatorID]spectypes.Signature{1: {0x7}},
+		}))
+	}
+
+	newDutiesAPIMsg := func(pk string, from, to uint64) *NetworkMessage {
+		nm := newParticipantsAPIMsg(pk, spectypes.BNRoleProposer, from, to)
+		nm.Msg.Type = TypeDuties
+		return nm
+	}
+
+	t.Run("valid range", func(t *testing.T) {
+		nm := newDutiesAPIMsg(hex.EncodeToString(pubKey[:]), 0, 20)
+		HandleDutiesQuery(l, archive, nm)
+		duties, ok := nm.Msg.Data.([]*DutyAPI)
+		require.True(t, ok, "expected []*DutyAPI, got %+v", nm.Msg.Data)
+		require.Len(t, duties, 2)
+
+		require.Equal(t, spectypes.BNRoleProposer.String(), duties[0].Role)
+		require.Equal(t, phase0.Root{0x6}, duties[0].BeaconRoot)
+		require.Equal(t, map[spectypes.OperatorID]string{1: "07"}, duties[0].PartialSignatures)
+		require.Equal(t, []spectypes.OperatorID{1, 2, 3}, duties[0].Decided.Signers)
+		require.Equal(t, specqbft.Round(2), duties[0].Decided.Round)
+		require.Equal(t, "0405", duties[0].Decided.FullData)
+
+		encoded, err := hex.DecodeString(duties[0].Decided.Message)
+		require.NoError(t, err)
+		decoded := &spectypes.SignedSSVMessage{}
+		require.NoError(t, decoded.Decode(encoded))
+		require.Equal(t, decided, decoded)
+
+		require.Nil(t, duties[1].Decided)
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		nm := newDutiesAPIMsg("0102", 0, 20)
+		HandleDutiesQuery(l, archive, nm)
+		require.Equal(t, []string{"bad request - could not read validator key"}, nm.Msg.Data)
+	})
+
+	t.Run("archive disabled", func(t *testing.T) {
+		nm := newDutiesAPIMsg(hex.EncodeToString(pubKey[:]), 0, 20)
+		HandleDutiesQuery(l, nil, nm)
+		require.Equal(t, []string{"bad request - archive is not enabled"}, nm.Msg.Data)
+	})
+}
+
 func newParticipantsAPIMsg(pk string, role spectypes.BeaconRole, from, to uint64) *NetworkMessage {
 	return &NetworkMessage{
 		Msg: Message{
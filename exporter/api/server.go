@@ -2,16 +2,19 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging"
 	"github.com/ssvlabs/ssv/logging/fields"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
 	"github.com/ssvlabs/ssv/utils/tasks"
 )
 
@@ -24,6 +27,7 @@ type WebSocketServer interface {
 	Start(logger *zap.Logger, addr string) error
 	BroadcastFeed() *event.Feed
 	UseQueryHandler(handler QueryMessageHandler)
+	UseStreamStorage(stream *StreamStorage)
 }
 
 // wsServer is an implementation of WebSocketServer
@@ -31,6 +35,8 @@ type wsServer struct {
 	ctx context.Context
 
 	handler QueryMessageHandler
+	// stream is used to filter stream subscriptions by committees and to resume them
+	stream *StreamStorage
 
 	broadcaster Broadcaster
 
@@ -57,6 +63,10 @@ func (ws *wsServer) UseQueryHandler(handler QueryMessageHandler) {
 	ws.handler = handler
 }
 
+func (ws *wsServer) UseStreamStorage(stream *StreamStorage) {
+	ws.stream = stream
+}
+
 // Start starts the websocket server and the broadcaster
 func (ws *wsServer) Start(logger *zap.Logger, addr string) error {
 	logger = logger.Named(logging.NameWSServer)
@@ -173,6 +183,72 @@ func (ws *wsServer) handleStream(logger *zap.Logger, wsc *websocket.Conn) {
 	defer ws.broadcaster.Deregister(c)
 
 	go c.ReadLoop(logger)
+	go ws.handleSubscriptions(logger, c)
 
 	c.WriteLoop(logger)
 }
+
+// handleSubscriptions applies the subscriptions sent on a stream connection,
+// and sends back either the applied subscription or an error.
+func (ws *wsServer) handleSubscriptions(logger *zap.Logger, c Conn) {
+	for {
+		raw := c.ReadNext()
+		if raw == nil {
+			return
+		}
+		response, err := ws.subscribe(c, raw)
+		if err != nil {
+			logger.Debug("could not subscribe", zap.Error(err))
+			response = []any{Message{Type: TypeError, Data: []string{err.Error()}}}
+		}
+		for _, msg := range response {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				logger.Error("could not marshal msg", zap.Error(err))
+				continue
+			}
+			c.Send(data)
+		}
+	}
+}
+
+// subscribe applies the given subscription message to the connection,
+// and returns the messages to send back: the subscription, followed by the backfilled messages if it resumes the stream.
+func (ws *wsServer) subscribe(c Conn, raw []byte) ([]any, error) {
+	var msg SubscriptionMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, errors.New("could not parse subscription message")
+	}
+	if msg.Type != TypeSubscribe {
+		return nil, errors.Errorf("bad request - unknown message type '%s'", msg.Type)
+	}
+
+	var shares registrystorage.Shares
+	if ws.stream != nil {
+		shares = ws.stream.Shares
+	}
+	filter, err := newStreamFilter(shares, &msg.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Filter.LastSlot == nil {
+		c.Subscribe(filter)
+		return []any{msg}, nil
+	}
+
+	from, to, err := resumeRange(ws.stream, filter, *msg.Filter.LastSlot)
+	if err != nil {
+		return nil, err
+	}
+	// Subscribing before backfilling may send messages decided meanwhile twice, rather than miss them.
+	c.Subscribe(filter)
+	backfilled, err := backfill(ws.stream, filter, from, to)
+	if err != nil {
+		return nil, err
+	}
+	response := []any{msg}
+	for _, m := range backfilled {
+		response = append(response, m)
+	}
+	return response, nil
+}
@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
 
 	"github.com/stretchr/testify/require"
@@ -117,6 +119,50 @@ func TestHandleStream(t *testing.T) {
 	}
 }
 
+func TestHandleStream_Subscribe(t *testing.T) {
+	logger := zaptest.NewLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mux := http.NewServeMux()
+	ws := NewWsServer(ctx, nil, mux, false).(*wsServer)
+	addr := fmt.Sprintf("localhost:%d", getRandomPort(8001, 14000))
+	go func() {
+		require.NoError(t, ws.Start(logger, addr))
+	}()
+
+	var c *websocket.Conn
+	require.Eventually(t, func() bool {
+		var err error
+		c, _, err = websocket.DefaultDialer.Dial("ws://"+addr+"/stream", nil)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	defer c.Close()
+
+	pk1, pk2 := testPubKey(1), testPubKey(2)
+	require.NoError(t, c.WriteJSON(SubscriptionMessage{
+		Type:   TypeSubscribe,
+		Filter: Subscription{PublicKeys: []string{pk1}, Roles: []string{"ATTESTER"}},
+	}))
+	var ack SubscriptionMessage
+	require.NoError(t, c.ReadJSON(&ack))
+	require.Equal(t, TypeSubscribe, ack.Type)
+	require.Equal(t, []string{pk1}, ack.Filter.PublicKeys)
+
+	ws.out.Send(Message{Type: TypeDecided, Filter: MessageFilter{PublicKey: pk2, Role: "ATTESTER", From: 1, To: 1}})
+	ws.out.Send(Message{Type: TypeDecided, Filter: MessageFilter{PublicKey: pk1, Role: "PROPOSER", From: 2, To: 2}})
+	ws.out.Send(Message{Type: TypeDecided, Filter: MessageFilter{PublicKey: pk1, Role: "ATTESTER", From: 3, To: 3}})
+	var msg Message
+	require.NoError(t, c.ReadJSON(&msg))
+	require.Equal(t, pk1, msg.Filter.PublicKey)
+	require.Equal(t, uint64(3), msg.Filter.From)
+
+	// Streams can't be resumed without storage.
+	lastSlot := phase0.Slot(1)
+	require.NoError(t, c.WriteJSON(SubscriptionMessage{Type: TypeSubscribe, Filter: Subscription{LastSlot: &lastSlot}}))
+	require.NoError(t, c.ReadJSON(&msg))
+	require.Equal(t, TypeError, msg.Type)
+}
+
 func newTestMessage() Message {
 	return Message{
 		Type:   TypeValidator,
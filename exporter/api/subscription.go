@@ -0,0 +1,240 @@
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+const (
+	// maxResumeSlots is the number of slots (32 epochs) a stream can be resumed from.
+	// Consumers who were away for longer should fetch the missing data with queries.
+	maxResumeSlots = 32 * 32
+	// maxResumeSlotReads bounds the validators × roles × slots a resumed stream reads,
+	// since the participants of every slot in the range are read for every validator and role.
+	maxResumeSlotReads = 100_000
+	// maxBackfillEntries is the number of entries a backfilled message holds at most.
+	maxBackfillEntries = 256
+)
+
+var errResumeUnfiltered = errors.New("streams can only be resumed with a public key or committee filter")
+
+// SubscriptionMessage is sent by stream consumers to choose the messages they receive,
+// and is sent back once the subscription is applied.
+type SubscriptionMessage struct {
+	Type   MessageType  `json:"type"`
+	Filter Subscription `json:"filter"`
+}
+
+// Subscription filters the decided messages of a stream,
+// matching those which match any of the values of every given filter.
+type Subscription struct {
+	// PublicKeys are hex encoded validator public keys.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Committees are hex encoded committee IDs, matching the validators of the committees.
+	Committees []string `json:"committees,omitempty"`
+	// Roles are runner roles, such as ATTESTER or PROPOSER.
+	Roles []string `json:"roles,omitempty"`
+	// LastSlot is the last slot the consumer received, which resumes the stream
+	// with the messages decided after it, up to maxResumeSlots ago.
+	LastSlot *phase0.Slot `json:"lastSlot,omitempty"`
+}
+
+// StreamStorage provides streams with the validators to match committees with,
+// and the stored participants to resume from.
+type StreamStorage struct {
+	DomainType spectypes.DomainType
+	Shares     registrystorage.Shares
+	QBFTStores *storage.QBFTStores
+	// SyncedQBFTStores store the participants synced from peers, which are served where none were observed. Optional.
+	SyncedQBFTStores *storage.QBFTStores
+	CurrentSlot      func() phase0.Slot
+}
+
+// streamFilter matches stream messages with a subscription.
+type streamFilter struct {
+	shares     registrystorage.Shares
+	pubKeys    map[string]struct{}
+	committees map[spectypes.CommitteeID]struct{}
+	roles      []string
+}
+
+func newStreamFilter(shares registrystorage.Shares, subscription *Subscription) (*streamFilter, error) {
+	f := &streamFilter{
+		shares:     shares,
+		pubKeys:    make(map[string]struct{}),
+		committees: make(map[spectypes.CommitteeID]struct{}),
+	}
+	for _, pk := range subscription.PublicKeys {
+		b, err := hex.DecodeString(pk)
+		if err != nil || len(b) != len(phase0.BLSPubKey{}) {
+			return nil, fmt.Errorf("invalid public key %q", pk)
+		}
+		f.pubKeys[hex.EncodeToString(b)] = struct{}{}
+	}
+	if len(subscription.Committees) > 0 && shares == nil {
+		return nil, errors.New("committees can't be filtered by this node")
+	}
+	for _, committee := range subscription.Committees {
+		b, err := hex.DecodeString(committee)
+		if err != nil || len(b) != len(spectypes.CommitteeID{}) {
+			return nil, fmt.Errorf("invalid committee %q", committee)
+		}
+		f.committees[spectypes.CommitteeID(b)] = struct{}{}
+	}
+	for _, role := range subscription.Roles {
+		if !slices.ContainsFunc(runnerRoles, func(r convert.RunnerRole) bool { return r.String() == role }) {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+		f.roles = append(f.roles, role)
+	}
+	return f, nil
+}
+
+var runnerRoles = []convert.RunnerRole{
+	convert.RoleAttester,
+	convert.RoleAggregator,
+	convert.RoleProposer,
+	convert.RoleSyncCommitteeContribution,
+	convert.RoleSyncCommittee,
+	convert.RoleValidatorRegistration,
+	convert.RoleVoluntaryExit,
+	convert.RoleCommittee,
+}
+
+// match returns whether a message of the given validator and role matches the filter.
+func (f *streamFilter) match(pubKey string, role string) bool {
+	if len(f.roles) > 0 && !slices.Contains(f.roles, role) {
+		return false
+	}
+	if len(f.pubKeys) == 0 && len(f.committees) == 0 {
+		return true
+	}
+	if _, ok := f.pubKeys[pubKey]; ok {
+		return true
+	}
+	if len(f.committees) > 0 {
+		b, err := hex.DecodeString(pubKey)
+		if err != nil {
+			return false
+		}
+		if share, ok := f.shares.Get(nil, b); ok {
+			_, ok := f.committees[share.CommitteeID()]
+			return ok
+		}
+	}
+	return false
+}
+
+// validators returns the public keys of the validators the filter matches, either given or in the given committees.
+func (f *streamFilter) validators() [][]byte {
+	var pubKeys [][]byte
+	for pk := range f.pubKeys {
+		b, _ := hex.DecodeString(pk)
+		pubKeys = append(pubKeys, b)
+	}
+	if len(f.committees) > 0 {
+		for _, share := range f.shares.List(nil, func(share *types.SSVShare) bool {
+			_, ok := f.committees[share.CommitteeID()]
+			return ok
+		}) {
+			if _, ok := f.pubKeys[hex.EncodeToString(share.ValidatorPubKey[:])]; !ok {
+				pubKeys = append(pubKeys, share.ValidatorPubKey[:])
+			}
+		}
+	}
+	slices.SortFunc(pubKeys, bytes.Compare)
+	return pubKeys
+}
+
+// backfillRoles returns the roles the filter matches whose participants are stored per validator.
+func (f *streamFilter) backfillRoles() []convert.RunnerRole {
+	var roles []convert.RunnerRole
+	for _, role := range runnerRoles {
+		// Committee consensus is stored per validator under the roles of its duties.
+		if role == convert.RoleCommittee || len(f.roles) > 0 && !slices.Contains(f.roles, role.String()) {
+			continue
+		}
+		roles = append(roles, role)
+	}
+	return roles
+}
+
+// resumeRange returns the range of slots to backfill for a stream with the given filter resumed after the given last slot,
+// which is empty if the consumer is up to date. Only the streams of given validators or committees can be resumed,
+// and only if the slots of their participants to read are within maxResumeSlotReads.
+func resumeRange(stream *StreamStorage, f *streamFilter, lastSlot phase0.Slot) (from, to phase0.Slot, err error) {
+	if stream == nil || stream.QBFTStores == nil || stream.CurrentSlot == nil {
+		return 0, 0, errors.New("streams can't be resumed by this node")
+	}
+	if len(f.pubKeys) == 0 && len(f.committees) == 0 {
+		return 0, 0, errResumeUnfiltered
+	}
+	from, to = lastSlot+1, stream.CurrentSlot()
+	if from > to {
+		return from, to, nil
+	}
+	if to-from >= maxResumeSlots {
+		return 0, 0, fmt.Errorf("lastSlot %d is too old, streams can be resumed up to %d slots ago", lastSlot, maxResumeSlots)
+	}
+	if reads := len(f.validators()) * len(f.backfillRoles()) * int(to-from+1); reads > maxResumeSlotReads {
+		return 0, 0, fmt.Errorf("resuming covers %d slot reads, exceeding the limit of %d; filter fewer validators or roles, or resume from a later slot", reads, maxResumeSlotReads)
+	}
+	return from, to, nil
+}
+
+// backfill returns the stored decided messages of the validators and roles of the filter in the given range of slots,
+// including those synced from peers in the slots which weren't observed, in messages per role of up to maxBackfillEntries entries.
+func backfill(stream *StreamStorage, f *streamFilter, from, to phase0.Slot) ([]Message, error) {
+	if from > to {
+		return nil, nil
+	}
+
+	validators := f.validators()
+	var msgs []Message
+	for _, role := range f.backfillRoles() {
+		store := stream.QBFTStores.Get(role)
+		if store == nil {
+			continue
+		}
+		var entries []qbftstorage.ParticipantsRangeEntry
+		for _, pubKey := range validators {
+			msgID := convert.NewMsgID(stream.DomainType, pubKey, role)
+			validatorEntries, err := storage.ParticipantsInRange(store, stream.SyncedQBFTStores.Get(role), msgID, from, to)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get %s participants", role.String())
+			}
+			entries = append(entries, validatorEntries...)
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Slot < entries[j].Slot })
+
+		for start := 0; start < len(entries); start += maxBackfillEntries {
+			data, err := ParticipantsAPIData(entries[start:min(start+maxBackfillEntries, len(entries))]...)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, Message{
+				Type: TypeDecided,
+				Filter: MessageFilter{
+					From: uint64(from),
+					To:   uint64(to),
+					Role: role.String(),
+				},
+				Data: data,
+			})
+		}
+	}
+	return msgs, nil
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	qbftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestStreamFilter(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	pk1, pk2, pk3 := testPubKey(1), testPubKey(2), testPubKey(3)
+	committee1 := saveTestShare(t, shares, pk1, 1, 2, 3, 4)
+	saveTestShare(t, shares, pk2, 1, 2, 3, 4)
+	saveTestShare(t, shares, pk3, 1, 2, 3, 5)
+
+	newFilter := func(subscription Subscription) *streamFilter {
+		f, err := newStreamFilter(shares, &subscription)
+		require.NoError(t, err)
+		return f
+	}
+
+	t.Run("no filters", func(t *testing.T) {
+		f := newFilter(Subscription{})
+		require.True(t, f.match(pk1, "ATTESTER"))
+		require.True(t, f.match("", "ATTESTER"))
+	})
+
+	t.Run("public keys", func(t *testing.T) {
+		f := newFilter(Subscription{PublicKeys: []string{pk1}})
+		require.True(t, f.match(pk1, "ATTESTER"))
+		require.False(t, f.match(pk2, "ATTESTER"))
+	})
+
+	t.Run("committees", func(t *testing.T) {
+		f := newFilter(Subscription{Committees: []string{hex.EncodeToString(committee1[:])}})
+		require.True(t, f.match(pk1, "ATTESTER"))
+		require.True(t, f.match(pk2, "PROPOSER"))
+		require.False(t, f.match(pk3, "ATTESTER"))
+		require.False(t, f.match(testPubKey(4), "ATTESTER"))
+	})
+
+	t.Run("roles", func(t *testing.T) {
+		f := newFilter(Subscription{PublicKeys: []string{pk1, pk3}, Roles: []string{"PROPOSER"}})
+		require.True(t, f.match(pk3, "PROPOSER"))
+		require.False(t, f.match(pk1, "ATTESTER"))
+		require.False(t, f.match(pk2, "PROPOSER"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, subscription := range []Subscription{
+			{PublicKeys: []string{"xxx"}},
+			{PublicKeys: []string{"0102"}},
+			{Committees: []string{"0102"}},
+			{Roles: []string{"FOO"}},
+		} {
+			_, err := newStreamFilter(shares, &subscription)
+			require.Error(t, err)
+		}
+		_, err := newStreamFilter(nil, &Subscription{Committees: []string{hex.EncodeToString(committee1[:])}})
+		require.Error(t, err)
+	})
+}
+
+func TestBackfill(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	shares, _, err := registrystorage.NewSharesStorage(logger, db, []byte("test"))
+	require.NoError(t, err)
+	pk1, pk2, pk3 := testPubKey(1), testPubKey(2), testPubKey(3)
+	committee := saveTestShare(t, shares, pk1, 1, 2, 3, 4)
+	saveTestShare(t, shares, pk2, 1, 2, 3, 4)
+
+	domain := networkconfig.TestNetwork.DomainType
+	stores := qbftstorage.NewStoresFromRoles(db, convert.RoleAttester, convert.RoleProposer)
+	saveParticipants := func(pubKey string, role convert.RunnerRole, slot phase0.Slot) {
+		b, err := hex.DecodeString(pubKey)
+		require.NoError(t, err)
+		_, err = stores.Get(role).UpdateParticipants(convert.NewMsgID(domain, b, role), slot, []spectypes.OperatorID{1, 2, 3})
+		require.NoError(t, err)
+	}
+	saveParticipants(pk1, convert.RoleAttester, 10)
+	saveParticipants(pk1, convert.RoleAttester, 12)
+	saveParticipants(pk2, convert.RoleAttester, 11)
+	saveParticipants(pk2, convert.RoleProposer, 13)
+	saveParticipants(pk3, convert.RoleAttester, 12)
+
+	stream := &StreamStorage{
+		DomainType:  domain,
+		Shares:      shares,
+		QBFTStores:  stores,
+		CurrentSlot: func() phase0.Slot { return 2000 },
+	}
+	newFilter := func(subscription Subscription) *streamFilter {
+		f, err := newStreamFilter(shares, &subscription)
+		require.NoError(t, err)
+		return f
+	}
+	slots := func(msg Message) (slots []phase0.Slot) {
+		for _, p := range msg.Data.([]*ParticipantsAPI) {
+			slots = append(slots, p.Slot)
+		}
+		return slots
+	}
+
+	t.Run("public keys", func(t *testing.T) {
+		f := newFilter(Subscription{PublicKeys: []string{pk1, pk2}})
+		from, to, err := resumeRange(stream, f, 1000)
+		require.NoError(t, err)
+		require.Equal(t, phase0.Slot(1001), from)
+		require.Equal(t, phase0.Slot(2000), to)
+
+		msgs, err := backfill(stream, f, 11, 20)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		require.Equal(t, "ATTESTER", msgs[0].Filter.Role)
+		require.Equal(t, []phase0.Slot{11, 12}, slots(msgs[0]))
+		require.Equal(t, "PROPOSER", msgs[1].Filter.Role)
+		require.Equal(t, []phase0.Slot{13}, slots(msgs[1]))
+	})
+
+	t.Run("committees", func(t *testing.T) {
+		f := newFilter(Subscription{Committees: []string{hex.EncodeToString(committee[:])}, Roles: []string{"ATTESTER"}})
+		msgs, err := backfill(stream, f, 10, 20)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, []phase0.Slot{10, 11, 12}, slots(msgs[0]))
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		f := newFilter(Subscription{PublicKeys: []string{pk2}, Roles: []string{"ATTESTER"}})
+		msgs, err := backfill(stream, f, 10, 20)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, []phase0.Slot{11}, slots(msgs[0]))
+	})
+
+	t.Run("synced", func(t *testing.T) {
+		synced := qbftstorage.NewStores()
+		synced.Add(convert.RoleAttester, qbftstorage.New(db, "synced_"+convert.RoleAttester.String()))
+		b, err := hex.DecodeString(pk2)
+		require.NoError(t, err)
+		msgID := convert.NewMsgID(domain, b, convert.RoleAttester)
+		_, err = synced.Get(convert.RoleAttester).UpdateParticipants(msgID, 14, []spectypes.OperatorID{2, 3, 4})
+		require.NoError(t, err)
+
+		stream := *stream
+		stream.SyncedQBFTStores = synced
+		msgs, err := backfill(&stream, newFilter(Subscription{PublicKeys: []string{pk2}, Roles: []string{"ATTESTER"}}), 10, 20)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		data := msgs[0].Data.([]*ParticipantsAPI)
+		require.Len(t, data, 2)
+		require.False(t, data[0].Synced)
+		require.Equal(t, phase0.Slot(14), data[1].Slot)
+		require.True(t, data[1].Synced)
+	})
+
+	t.Run("messages are capped", func(t *testing.T) {
+		pk := testPubKey(4)
+		for slot := phase0.Slot(100); slot < 100+maxBackfillEntries+1; slot++ {
+			saveParticipants(pk, convert.RoleAttester, slot)
+		}
+		msgs, err := backfill(stream, newFilter(Subscription{PublicKeys: []string{pk}}), 100, 1000)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		require.Len(t, slots(msgs[0]), maxBackfillEntries)
+		require.Equal(t, []phase0.Slot{100 + maxBackfillEntries}, slots(msgs[1]))
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		f := newFilter(Subscription{PublicKeys: []string{pk1}})
+		from, to, err := resumeRange(stream, f, 2000)
+		require.NoError(t, err)
+		msgs, err := backfill(stream, f, from, to)
+		require.NoError(t, err)
+		require.Empty(t, msgs)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		f := newFilter(Subscription{PublicKeys: []string{pk1}})
+		_, _, err := resumeRange(stream, f, 2000-maxResumeSlots-1)
+		require.Error(t, err)
+		_, _, err = resumeRange(nil, f, 1000)
+		require.Error(t, err)
+
+		// Streams of all validators can't be resumed.
+		_, _, err = resumeRange(stream, newFilter(Subscription{Roles: []string{"ATTESTER"}}), 1000)
+		require.ErrorIs(t, err, errResumeUnfiltered)
+
+		// Nor those reading too many slots.
+		var pubKeys []string
+		for i := byte(10); i < 30; i++ {
+			pubKeys = append(pubKeys, testPubKey(i))
+		}
+		_, _, err = resumeRange(stream, newFilter(Subscription{PublicKeys: pubKeys}), 2000-maxResumeSlots)
+		require.ErrorContains(t, err, "slot reads")
+	})
+}
+
+func testPubKey(b byte) string {
+	return hex.EncodeToString([]byte{b, 47: 0})
+}
+
+func saveTestShare(t *testing.T, shares registrystorage.Shares, pubKey string, operators ...spectypes.OperatorID) spectypes.CommitteeID {
+	b, err := hex.DecodeString(pubKey)
+	require.NoError(t, err)
+	share := &types.SSVShare{}
+	share.ValidatorPubKey = spectypes.ValidatorPK(b)
+	for _, operator := range operators {
+		share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: operator})
+	}
+	require.NoError(t, shares.Save(nil, share))
+	return share.CommitteeID()
+}
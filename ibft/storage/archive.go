@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/slotprune"
+)
+
+var (
+	archivePrefix        = []byte("exporter_archive/")
+	archiveDutyPrefix    = []byte("exporter_archive/duty/")
+	archiveDecidedPrefix = []byte("exporter_archive/decided/")
+)
+
+// ArchivedDuty is the archived record of a duty decided by a validator's committee.
+type ArchivedDuty struct {
+	Role   convert.RunnerRole    `json:"role"`
+	Slot   phase0.Slot           `json:"slot"`
+	PubKey spectypes.ValidatorPK `json:"pub_key"`
+	Index  phase0.ValidatorIndex `json:"index"`
+	// DecidedIdentifier is the identifier of the QBFT instance which decided the duty,
+	// which is shared by the duties of all validators of a committee.
+	DecidedIdentifier []byte `json:"decided_identifier"`
+	// BeaconRoot is the signing root of the beacon object signed in post-consensus.
+	BeaconRoot phase0.Root `json:"beacon_root"`
+	// Signature is the validator signature reconstructed from the partial signatures.
+	Signature         phase0.BLSSignature                          `json:"signature"`
+	PartialSignatures map[spectypes.OperatorID]spectypes.Signature `json:"partial_signatures"`
+
+	// Decided is the decided QBFT message, if it was observed.
+	// It is stored once per QBFT instance and attached when the duty is read.
+	Decided *spectypes.SignedSSVMessage `json:"-"`
+}
+
+// Archive keeps the decided messages and signatures of duties observed by an exporter.
+// Records are keyed by slot first, so that they can be pruned by slot.
+type Archive struct {
+	db     basedb.Database
+	pruner *slotprune.Pruner
+}
+
+// NewArchive creates a new archive.
+func NewArchive(db basedb.Database) *Archive {
+	return &Archive{
+		db:     db,
+		pruner: slotprune.New(db, "exporter archive", archivePrefix, archiveDutyPrefix, archiveDecidedPrefix),
+	}
+}
+
+// SaveDecided saves the decided message of the QBFT instance of a slot.
+func (a *Archive) SaveDecided(slot phase0.Slot, msg *spectypes.SignedSSVMessage) error {
+	encoded, err := msg.Encode()
+	if err != nil {
+		return fmt.Errorf("encode decided message: %w", err)
+	}
+	identifier := msg.SSVMessage.MsgID
+	return a.db.Set(archiveDecidedPrefix, archiveDecidedKey(slot, identifier[:]), encoded)
+}
+
+// GetDecided returns the decided message of the QBFT instance of a slot.
+func (a *Archive) GetDecided(identifier []byte, slot phase0.Slot) (*spectypes.SignedSSVMessage, bool, error) {
+	obj, found, err := a.db.Get(archiveDecidedPrefix, archiveDecidedKey(slot, identifier))
+	if err != nil || !found {
+		return nil, found, err
+	}
+	msg := &spectypes.SignedSSVMessage{}
+	if err := msg.Decode(obj.Value); err != nil {
+		return nil, false, fmt.Errorf("decode decided message: %w", err)
+	}
+	return msg, true, nil
+}
+
+// SaveDuty saves a duty, replacing any previous record of its beacon object.
+func (a *Archive) SaveDuty(duty *ArchivedDuty) error {
+	encoded, err := json.Marshal(duty)
+	if err != nil {
+		return fmt.Errorf("encode duty: %w", err)
+	}
+	key := append(archiveDutyKey(duty.Slot, duty.Role, duty.PubKey), duty.BeaconRoot[:]...)
+	return a.db.Set(archiveDutyPrefix, key, encoded)
+}
+
+// GetDutiesInRange returns the duties of a validator and role within the given slot range,
+// with their decided messages attached.
+func (a *Archive) GetDutiesInRange(role convert.RunnerRole, pubKey spectypes.ValidatorPK, from, to phase0.Slot) ([]*ArchivedDuty, error) {
+	duties := make([]*ArchivedDuty, 0)
+
+	for slot := from; slot <= to; slot++ {
+		// A duty may have several beacon objects, such as the contributions of each sync subcommittee.
+		prefix := slices.Concat(archiveDutyPrefix, archiveDutyKey(slot, role, pubKey))
+		err := a.db.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+			duty := &ArchivedDuty{}
+			if err := json.Unmarshal(obj.Value, duty); err != nil {
+				return fmt.Errorf("decode duty: %w", err)
+			}
+
+			var err error
+			duty.Decided, _, err = a.GetDecided(duty.DecidedIdentifier, slot)
+			if err != nil {
+				return fmt.Errorf("get decided: %w", err)
+			}
+
+			duties = append(duties, duty)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return duties, nil
+}
+
+// Prune deletes the records of slots before the given slot and returns how many were deleted.
+// Pruning continues from the slot the previous pruning stopped at,
+// and scans the entire archive only the first time.
+func (a *Archive) Prune(before phase0.Slot) (int, error) {
+	return a.pruner.Prune(before)
+}
+
+// PruneLoop prunes duties older than the retention period once every epoch, until the context is done.
+func (a *Archive) PruneLoop(ctx context.Context, logger *zap.Logger, beaconNetwork beacon.BeaconNetwork, retention phase0.Epoch) {
+	interval := beaconNetwork.SlotDurationSec() * time.Duration(beaconNetwork.SlotsPerEpoch()) // #nosec G115
+	a.pruner.PruneLoop(ctx, logger, interval, func() phase0.Slot {
+		currentEpoch := beaconNetwork.EstimatedCurrentEpoch()
+		if currentEpoch <= retention {
+			return 0
+		}
+		return beaconNetwork.FirstSlotAtEpoch(currentEpoch - retention)
+	})
+}
+
+func archiveDutyKey(slot phase0.Slot, role convert.RunnerRole, pubKey spectypes.ValidatorPK) []byte {
+	key := slotprune.SlotKey(slot)
+	key = binary.BigEndian.AppendUint32(key, uint32(role)) // #nosec G115
+	return append(key, pubKey[:]...)
+}
+
+func archiveDecidedKey(slot phase0.Slot, identifier []byte) []byte {
+	return append(slotprune.SlotKey(slot), identifier...)
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestArchive(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	archive := NewArchive(db)
+
+	pubKey := spectypes.ValidatorPK{1, 2, 3}
+	identifier := spectypes.MessageID{4, 5, 6}
+	newDecided := func(signers ...spectypes.OperatorID) *spectypes.SignedSSVMessage {
+		msg := &spectypes.SignedSSVMessage{
+			OperatorIDs: signers,
+			SSVMessage: &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   identifier,
+				Data:    []byte{1},
+			},
+			FullData: []byte{2},
+		}
+		for range signers {
+			msg.Signatures = append(msg.Signatures, make([]byte, 256))
+		}
+		return msg
+	}
+	newDuty := func(role convert.RunnerRole, slot phase0.Slot, root byte) *ArchivedDuty {
+		return &ArchivedDuty{
+			Role:              role,
+			Slot:              slot,
+			PubKey:            pubKey,
+			Index:             7,
+			DecidedIdentifier: identifier[:],
+			BeaconRoot:        phase0.Root{root},
+			Signature:         phase0.BLSSignature{root},
+			PartialSignatures: map[spectypes.OperatorID]spectypes.Signature{1: {1}, 2: {2}, 3: {3}},
+		}
+	}
+
+	// Slot 10 has a decided message and a duty with two beacon objects,
+	// slot 11 has a duty whose decided message wasn't observed.
+	decided := newDecided(1, 2, 3)
+	require.NoError(t, archive.SaveDecided(10, decided))
+	require.NoError(t, archive.SaveDuty(newDuty(convert.RoleSyncCommitteeContribution, 10, 1)))
+	require.NoError(t, archive.SaveDuty(newDuty(convert.RoleSyncCommitteeContribution, 10, 2)))
+	require.NoError(t, archive.SaveDuty(newDuty(convert.RoleSyncCommitteeContribution, 11, 1)))
+	require.NoError(t, archive.SaveDuty(newDuty(convert.RoleAggregator, 11, 1)))
+	require.NoError(t, archive.SaveDecided(12, newDecided(1, 2, 3, 4)))
+	require.NoError(t, archive.SaveDuty(newDuty(convert.RoleSyncCommitteeContribution, 12, 1)))
+
+	duties, err := archive.GetDutiesInRange(convert.RoleSyncCommitteeContribution, pubKey, 10, 11)
+	require.NoError(t, err)
+	require.Len(t, duties, 3)
+	require.Equal(t, phase0.Root{1}, duties[0].BeaconRoot)
+	require.Equal(t, phase0.Root{2}, duties[1].BeaconRoot)
+	require.Equal(t, newDuty(convert.RoleSyncCommitteeContribution, 10, 1).PartialSignatures, duties[0].PartialSignatures)
+	require.Equal(t, decided, duties[0].Decided)
+	require.Equal(t, phase0.Slot(11), duties[2].Slot)
+	require.Nil(t, duties[2].Decided)
+
+	duties, err = archive.GetDutiesInRange(convert.RoleAggregator, pubKey, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+
+	// The first pruning scans the archive, later ones continue from where the previous stopped.
+	deleted, err := archive.Prune(11)
+	require.NoError(t, err)
+	require.Equal(t, 3, deleted)
+
+	deleted, err = archive.Prune(11)
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+
+	deleted, err = archive.Prune(12)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	duties, err = archive.GetDutiesInRange(convert.RoleSyncCommitteeContribution, pubKey, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Equal(t, phase0.Slot(12), duties[0].Slot)
+	require.NotNil(t, duties[0].Decided)
+
+	_, found, err := archive.GetDecided(identifier[:], 10)
+	require.NoError(t, err)
+	require.False(t, found)
+}
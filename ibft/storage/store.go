@@ -19,9 +19,10 @@ import (
 )
 
 const (
-	highestInstanceKey = "highest_instance"
-	instanceKey        = "instance"
-	participantsKey    = "participants"
+	highestInstanceKey     = "highest_instance"
+	instanceKey            = "instance"
+	participantsKey        = "participants"
+	highestParticipantsKey = "highest_participants"
 )
 
 var (
@@ -91,6 +92,10 @@ func (i *ibftStorage) UpdateParticipants(identifier convert.MessageID, slot phas
 		return false, fmt.Errorf("save participants: %w", err)
 	}
 
+	if err := i.saveHighestParticipantsSlot(txn, identifier, slot); err != nil {
+		return false, fmt.Errorf("save highest participants slot: %w", err)
+	}
+
 	if err := txn.Commit(); err != nil {
 		return false, fmt.Errorf("commit transaction: %w", err)
 	}
@@ -121,6 +126,94 @@ func (i *ibftStorage) GetParticipantsInRange(identifier convert.MessageID, from,
 	return participantsRange, nil
 }
 
+func (i *ibftStorage) GetAllParticipantsInRange(from, to phase0.Slot) ([]qbftstorage.ParticipantsRangeEntry, error) {
+	participantsRange := make([]qbftstorage.ParticipantsRangeEntry, 0)
+
+	// Participants are keyed by identifier, participantsKey and slot, following the store prefix.
+	keySize := len(convert.MessageID{}) + len(participantsKey) + 8
+	err := i.db.GetAll(i.prefix, func(_ int, obj basedb.Obj) error {
+		if len(obj.Key) != keySize {
+			return nil
+		}
+		identifier := convert.MessageIDFromBytes(obj.Key)
+		key := obj.Key[len(identifier):]
+		if string(key[:len(participantsKey)]) != participantsKey {
+			return nil
+		}
+		slot := phase0.Slot(binary.LittleEndian.Uint64(key[len(participantsKey):]))
+		if slot < from || slot > to {
+			return nil
+		}
+		participantsRange = append(participantsRange, qbftstorage.ParticipantsRangeEntry{
+			Slot:       slot,
+			Signers:    decodeOperators(obj.Value),
+			Identifier: identifier,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	return participantsRange, nil
+}
+
+func (i *ibftStorage) GetHighestParticipants(identifier convert.MessageID) (*qbftstorage.ParticipantsRangeEntry, error) {
+	slot, found, err := i.getHighestParticipantsSlot(nil, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get highest participants slot: %w", err)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	participants, err := i.GetParticipants(identifier, slot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants: %w", err)
+	}
+	return &qbftstorage.ParticipantsRangeEntry{
+		Slot:       slot,
+		Signers:    participants,
+		Identifier: identifier,
+	}, nil
+}
+
+func (i *ibftStorage) getHighestParticipantsSlot(txn basedb.ReadWriter, identifier convert.MessageID) (phase0.Slot, bool, error) {
+	val, found, err := i.get(txn, highestParticipantsKey, identifier[:])
+	if err != nil {
+		return 0, false, err
+	}
+	if found {
+		return phase0.Slot(binary.LittleEndian.Uint64(val)), true, nil
+	}
+
+	// Participants saved before their highest slot was tracked are scanned for.
+	var slot phase0.Slot
+	keySize := len(participantsKey) + 8
+	err = i.db.Using(txn).GetAll(slices.Concat(i.prefix, identifier[:]), func(_ int, obj basedb.Obj) error {
+		if len(obj.Key) != keySize || string(obj.Key[:len(participantsKey)]) != participantsKey {
+			return nil
+		}
+		if s := phase0.Slot(binary.LittleEndian.Uint64(obj.Key[len(participantsKey):])); !found || s > slot {
+			slot = s
+			found = true
+		}
+		return nil
+	})
+	return slot, found, err
+}
+
+func (i *ibftStorage) saveHighestParticipantsSlot(txn basedb.ReadWriter, identifier convert.MessageID, slot phase0.Slot) error {
+	highest, found, err := i.getHighestParticipantsSlot(txn, identifier)
+	if err != nil {
+		return err
+	}
+	if found && highest > slot {
+		return nil
+	}
+	return i.save(txn, uInt64ToByteSlice(uint64(slot)), highestParticipantsKey, identifier[:])
+}
+
 func (i *ibftStorage) GetParticipants(identifier convert.MessageID, slot phase0.Slot) ([]spectypes.OperatorID, error) {
 	return i.getParticipants(nil, identifier, slot)
 }
@@ -4,9 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/logging"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
 )
 
 func TestEncodeDecodeOperators(t *testing.T) {
@@ -101,3 +107,74 @@ func Test_mergeQuorums(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAllParticipantsInRange(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	// The role names are prefixes of each other, which must not mix up their stores.
+	syncCommittee := New(db, convert.RoleSyncCommittee.String())
+	contribution := New(db, convert.RoleSyncCommitteeContribution.String())
+
+	id1 := convert.NewMsgID(spectypes.DomainType{1}, []byte{1}, convert.RoleSyncCommittee)
+	id2 := convert.NewMsgID(spectypes.DomainType{1}, []byte{2}, convert.RoleSyncCommittee)
+	for slot := phase0.Slot(1); slot <= 3; slot++ {
+		_, err := syncCommittee.UpdateParticipants(id1, slot, []spectypes.OperatorID{1, 2, 3})
+		require.NoError(t, err)
+	}
+	_, err = syncCommittee.UpdateParticipants(id2, 2, []spectypes.OperatorID{2, 3, 4})
+	require.NoError(t, err)
+	_, err = contribution.UpdateParticipants(
+		convert.NewMsgID(spectypes.DomainType{1}, []byte{1}, convert.RoleSyncCommitteeContribution),
+		2,
+		[]spectypes.OperatorID{1, 2, 4},
+	)
+	require.NoError(t, err)
+
+	entries, err := syncCommittee.GetAllParticipantsInRange(2, 3)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []qbftstorage.ParticipantsRangeEntry{
+		{Slot: 2, Signers: []spectypes.OperatorID{1, 2, 3}, Identifier: id1},
+		{Slot: 3, Signers: []spectypes.OperatorID{1, 2, 3}, Identifier: id1},
+		{Slot: 2, Signers: []spectypes.OperatorID{2, 3, 4}, Identifier: id2},
+	}, entries)
+
+	entries, err = contribution.GetAllParticipantsInRange(0, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 4}, entries[0].Signers)
+}
+
+func TestGetHighestParticipants(t *testing.T) {
+	db, err := kv.NewInMemory(logging.TestLogger(t), basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := New(db, convert.RoleAttester.String()).(*ibftStorage)
+	id := convert.NewMsgID(spectypes.DomainType{1}, []byte{1}, convert.RoleAttester)
+
+	highest, err := store.GetHighestParticipants(id)
+	require.NoError(t, err)
+	require.Nil(t, highest)
+
+	// Participants saved without tracking their highest slot are scanned for.
+	require.NoError(t, store.saveParticipants(nil, id, 7, []spectypes.OperatorID{1, 2, 3}))
+	require.NoError(t, store.saveParticipants(nil, id, 5, []spectypes.OperatorID{1, 2, 4}))
+	highest, err = store.GetHighestParticipants(id)
+	require.NoError(t, err)
+	require.Equal(t, &qbftstorage.ParticipantsRangeEntry{Slot: 7, Signers: []spectypes.OperatorID{1, 2, 3}, Identifier: id}, highest)
+
+	// Backfilling a lower slot doesn't lower the highest slot.
+	_, err = store.UpdateParticipants(id, 6, []spectypes.OperatorID{2, 3, 4})
+	require.NoError(t, err)
+	highest, err = store.GetHighestParticipants(id)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(7), highest.Slot)
+
+	_, err = store.UpdateParticipants(id, 9, []spectypes.OperatorID{1, 3, 4})
+	require.NoError(t, err)
+	highest, err = store.GetHighestParticipants(id)
+	require.NoError(t, err)
+	require.Equal(t, &qbftstorage.ParticipantsRangeEntry{Slot: 9, Signers: []spectypes.OperatorID{1, 3, 4}, Identifier: id}, highest)
+}
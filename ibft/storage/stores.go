@@ -1,6 +1,11 @@
 package storage
 
 import (
+	"cmp"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
 	"github.com/ssvlabs/ssv/utils/hashmap"
 
 	"github.com/ssvlabs/ssv/exporter/convert"
@@ -27,8 +32,11 @@ func NewStoresFromRoles(db basedb.Database, roles ...convert.RunnerRole) *QBFTSt
 	return stores
 }
 
-// Get store from sync map by role type
+// Get store from sync map by role type, or nil if there is none
 func (qs *QBFTStores) Get(role convert.RunnerRole) qbftstorage.QBFTStore {
+	if qs == nil {
+		return nil
+	}
 	s, ok := qs.m.Get(role)
 	if !ok {
 		return nil
@@ -50,3 +58,52 @@ func (qs *QBFTStores) Each(f func(role convert.RunnerRole, store qbftstorage.QBF
 	})
 	return err
 }
+
+// ParticipantsInRange returns the participants of the identifier within the slot range which the node observed,
+// and those synced from peers in the slots it didn't observe, marked as synced. The synced store may be nil.
+func ParticipantsInRange(observed, synced qbftstorage.QBFTStore, identifier convert.MessageID, from, to phase0.Slot) ([]qbftstorage.ParticipantsRangeEntry, error) {
+	entries, err := observed.GetParticipantsInRange(identifier, from, to)
+	if err != nil || synced == nil {
+		return entries, err
+	}
+	syncedEntries, err := synced.GetParticipantsInRange(identifier, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(syncedEntries) == 0 {
+		return entries, nil
+	}
+
+	observedSlots := make(map[phase0.Slot]struct{}, len(entries))
+	for _, entry := range entries {
+		observedSlots[entry.Slot] = struct{}{}
+	}
+	for _, entry := range syncedEntries {
+		if _, ok := observedSlots[entry.Slot]; !ok {
+			entry.Synced = true
+			entries = append(entries, entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b qbftstorage.ParticipantsRangeEntry) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+	return entries, nil
+}
+
+// HighestParticipants returns the participants of the highest slot of the identifier which the node observed
+// or synced from peers, or nil if there are none. The synced store may be nil.
+func HighestParticipants(observed, synced qbftstorage.QBFTStore, identifier convert.MessageID) (*qbftstorage.ParticipantsRangeEntry, error) {
+	highest, err := observed.GetHighestParticipants(identifier)
+	if err != nil || synced == nil {
+		return highest, err
+	}
+	highestSynced, err := synced.GetHighestParticipants(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if highestSynced != nil && (highest == nil || highestSynced.Slot > highest.Slot) {
+		highestSynced.Synced = true
+		return highestSynced, nil
+	}
+	return highest, nil
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/network/simulator"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+)
+
+func operatorIDs(from, to spectypes.OperatorID) []spectypes.OperatorID {
+	var ids []spectypes.OperatorID
+	for id := from; id <= to; id++ {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestCommitteeDecides(t *testing.T) {
+	for _, keySet := range []*spectestingutils.TestKeySet{
+		spectestingutils.Testing4SharesSet(),
+		spectestingutils.Testing7SharesSet(),
+		spectestingutils.Testing10SharesSet(),
+		spectestingutils.Testing13SharesSet(),
+	} {
+		t.Run(fmt.Sprintf("%d operators", keySet.ShareCount), func(t *testing.T) {
+			c := newCluster(t, keySet, simulator.Config{Seed: 1, Jitter: 20 * time.Millisecond})
+			slot := c.executeAttesterDuty()
+			c.requireDecided(slot, operatorIDs(1, spectypes.OperatorID(keySet.ShareCount)), 20*time.Second)
+
+			for id, operator := range c.operators {
+				require.Zero(t, operator.network.ValidationStats().Rejected, "operator %d rejected messages", id)
+			}
+		})
+	}
+}
+
+func TestCommitteeDecidesWithSilentOperators(t *testing.T) {
+	// 2 of 7 operators may fail.
+	c := newCluster(t, spectestingutils.Testing7SharesSet(), simulator.Config{Seed: 2})
+	c.sim.SetByzantine(6, simulator.Silent)
+	c.sim.SetByzantine(7, simulator.Silent)
+
+	slot := c.executeAttesterDuty()
+	c.requireDecided(slot, operatorIDs(1, 5), 20*time.Second)
+}
+
+func TestCommitteeDecidesWithDrops(t *testing.T) {
+	c := newCluster(t, spectestingutils.Testing10SharesSet(), simulator.Config{Seed: 3, DropRate: 0.05})
+
+	slot := c.executeAttesterDuty()
+	c.requireDecided(slot, operatorIDs(1, 10), 30*time.Second)
+	require.NotZero(t, c.sim.Stats().Dropped)
+}
+
+func TestCommitteeRejectsTamperedMessages(t *testing.T) {
+	c := newCluster(t, spectestingutils.Testing4SharesSet(), simulator.Config{Seed: 4})
+	// Operator 4 alters its messages after signing them, so that their signatures don't match.
+	c.sim.SetByzantine(4, func(_ spectypes.OperatorID, msg *spectypes.SignedSSVMessage) *spectypes.SignedSSVMessage {
+		tampered := *msg
+		tampered.Signatures = [][]byte{append([]byte(nil), msg.Signatures[0]...)}
+		tampered.Signatures[0][0] ^= 0xff
+		return &tampered
+	})
+
+	slot := c.executeAttesterDuty()
+	c.requireDecided(slot, operatorIDs(1, 3), 20*time.Second)
+
+	for _, id := range operatorIDs(1, 3) {
+		require.NotZero(t, c.operators[id].network.ValidationStats().Rejected, "operator %d accepted tampered messages", id)
+	}
+	require.Zero(t, c.operators[1].network.Reported(protocolp2p.ValidationRejectHigh))
+}
+
+func TestCommitteeDecidesAfterPartitionHeals(t *testing.T) {
+	c := newCluster(t, spectestingutils.Testing4SharesSet(), simulator.Config{Seed: 5})
+	// Neither side has a quorum.
+	c.sim.Partition([]spectypes.OperatorID{1, 2})
+
+	slot := c.executeAttesterDuty()
+	// The partition outlasts the first rounds, which time out on the virtual clock.
+	c.sim.Advance(10 * time.Second)
+	require.Empty(t, c.attesters(slot, operatorIDs(1, 4)))
+
+	c.sim.Heal()
+	c.requireDecided(slot, operatorIDs(1, 4), 30*time.Second)
+}
@@ -1,88 +1,299 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
 	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/logging"
-	"github.com/ssvlabs/ssv/network"
-	p2pv1 "github.com/ssvlabs/ssv/network/p2p"
+	"github.com/ssvlabs/ssv/message/signatureverifier"
+	"github.com/ssvlabs/ssv/message/validation"
+	"github.com/ssvlabs/ssv/network/simulator"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/duties/dutystore"
+	"github.com/ssvlabs/ssv/operator/keys"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/operator/validator"
+	"github.com/ssvlabs/ssv/operator/validators"
 	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
 )
 
-const (
-	maxSupportedCommittee = 10
-	maxSupportedQuorum    = 7
-)
+func TestMain(m *testing.M) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
 
-var sharedData *SharedData
+// testValidatorIndex is the index of the validator which the committees in the tests operate.
+const testValidatorIndex = phase0.ValidatorIndex(1)
 
-type SharedData struct {
-	Nodes map[spectypes.OperatorID]network.P2PNetwork
+// recordingBeacon records the attestations which a node submits.
+type recordingBeacon struct {
+	beaconprotocol.BeaconNode
+
+	lock         sync.Mutex
+	attestations []*phase0.Attestation
 }
 
-func GetSharedData(t *testing.T) SharedData { //singleton B-)
-	require.NotNil(t, sharedData, "shared data hadn't set up, try to run test with -test.main flag")
+func (b *recordingBeacon) SubmitAttestations(attestations []*phase0.Attestation) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
 
-	return *sharedData
+	b.attestations = append(b.attestations, attestations...)
+	return nil
 }
 
-func TestMain(m *testing.M) {
-	ctx := context.Background()
-	if err := logging.SetGlobalLogger("debug", "capital", "console", nil); err != nil {
-		panic(err)
+// attestation returns the attestation which the node submitted for the slot, if any.
+func (b *recordingBeacon) attestation(slot phase0.Slot) *phase0.Attestation {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, attestation := range b.attestations {
+		if attestation.Data.Slot == slot {
+			return attestation
+		}
+	}
+	return nil
+}
+
+// operatorNode is an operator which runs a real validator controller on top of a simulated node.
+type operatorNode struct {
+	id         spectypes.OperatorID
+	network    *simulator.Node
+	beacon     *recordingBeacon
+	controller validator.Controller
+}
+
+// cluster is a committee of operators on a simulated network, which operates a single validator.
+type cluster struct {
+	t           *testing.T
+	logger      *zap.Logger
+	ctx         context.Context
+	sim         *simulator.Simulator
+	keySet      *spectestingutils.TestKeySet
+	committeeID spectypes.CommitteeID
+	operators   map[spectypes.OperatorID]*operatorNode
+}
+
+// newCluster starts an operator for each of the key set's shares, on a network with the given configuration.
+func newCluster(t *testing.T, keySet *spectestingutils.TestKeySet, cfg simulator.Config) *cluster {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// 13 nodes are too chatty for debug logs, which logging.TestLogger would enable.
+	logger := zap.L().Named(t.Name())
+	cfg.Logger = logger
+	if cfg.Start.IsZero() {
+		// Message validation and round timers follow the virtual clock, so it has to agree with the duties' slots.
+		// Starting at the beginning of a slot lets duties start right away.
+		beaconNetwork := networkconfig.TestNetwork.Beacon
+		cfg.Start = beaconNetwork.GetSlotStartTime(beaconNetwork.EstimatedCurrentSlot() + 1)
 	}
 
-	logger := zap.L().Named("integration-tests")
-
-	shares := []*ssvtypes.SSVShare{
-		{
-			Share: *spectestingutils.TestingShare(spectestingutils.Testing4SharesSet(), spectestingutils.TestingValidatorIndex),
-			Metadata: ssvtypes.Metadata{
-				BeaconMetadata: &beaconprotocol.ValidatorMetadata{
-					Status: eth2apiv1.ValidatorStateActiveOngoing,
-					Index:  spectestingutils.TestingShare(spectestingutils.Testing4SharesSet(), spectestingutils.TestingValidatorIndex).ValidatorIndex,
-				},
-				Liquidated: false,
+	c := &cluster{
+		t:         t,
+		logger:    logger,
+		ctx:       ctx,
+		keySet:    keySet,
+		operators: make(map[spectypes.OperatorID]*operatorNode),
+	}
+
+	// The topics of the validator's messages don't depend on the operator's share.
+	cfg.Validators = validatorLookup(func(pubKey []byte) (*ssvtypes.SSVShare, bool) {
+		share := c.share(keySet.Committee()[0].Signer)
+		return share, bytes.Equal(share.ValidatorPubKey[:], pubKey)
+	})
+	c.sim = simulator.New(cfg)
+
+	for _, member := range keySet.Committee() {
+		c.startOperator(member.Signer)
+	}
+	return c
+}
+
+func (c *cluster) startOperator(id spectypes.OperatorID) {
+	t := c.t
+	logger := c.logger.Named(fmt.Sprintf("operator-%d", id))
+
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	var privateKey keys.OperatorPrivateKey
+	for operatorID, rsaKey := range c.keySet.OperatorKeys {
+		operatorKey, err := keys.PrivateKeyFromBytes(rsaencryption.PrivateKeyToByte(rsaKey))
+		require.NoError(t, err)
+		publicKey, err := operatorKey.Public().Base64()
+		require.NoError(t, err)
+		_, err = nodeStorage.SaveOperatorData(nil, &registrystorage.OperatorData{ID: operatorID, PublicKey: publicKey})
+		require.NoError(t, err)
+		if operatorID == id {
+			privateKey = operatorKey
+		}
+	}
+
+	share := c.share(id)
+	require.NoError(t, nodeStorage.Shares().Save(nil, share))
+	c.committeeID = share.CommitteeID()
+
+	operatorData, found, err := nodeStorage.GetOperatorData(nil, id)
+	require.NoError(t, err)
+	require.True(t, found)
+	operatorDataStore := operatordatastore.New(operatorData)
+
+	storageMap := ibftstorage.NewStoresFromRoles(db, convert.RoleCommittee, convert.RoleProposer, convert.RoleAggregator)
+
+	network := c.sim.AddNode(id)
+	messageValidator := validation.New(
+		networkconfig.TestNetwork,
+		nodeStorage.ValidatorStore(),
+		dutystore.New(),
+		signatureverifier.NewSignatureVerifier(nodeStorage),
+		validation.WithClock(c.sim.Clock().Now),
+		validation.WithLogger(logger),
+	)
+	network.UseMessageValidator(messageValidator)
+
+	beacon := &recordingBeacon{BeaconNode: NewTestingBeaconNodeWrapped()}
+	controller := validator.NewController(logger, validator.ControllerOptions{
+		Context:                    c.ctx,
+		DB:                         db,
+		NetworkConfig:              networkconfig.TestNetwork,
+		Network:                    network,
+		Beacon:                     beacon,
+		BeaconSigner:               spectestingutils.NewTestingKeyManager(),
+		OperatorSigner:             ssvtypes.NewSsvOperatorSigner(privateKey, operatorDataStore.GetOperatorID),
+		OperatorDataStore:          operatorDataStore,
+		RegistryStorage:            nodeStorage,
+		RecipientsStorage:          nodeStorage,
+		StorageMap:                 storageMap,
+		ValidatorStore:             nodeStorage.ValidatorStore(),
+		ValidatorsMap:              validators.New(c.ctx),
+		MessageValidator:           messageValidator,
+		RoundTimerClock:            c.sim.Clock(),
+		SignatureCollectionTimeout: 5 * time.Second,
+		WorkersCount:               4,
+		QueueBufferSize:            1024,
+	})
+	controller.StartNetworkHandlers()
+	controller.StartValidators()
+
+	c.operators[id] = &operatorNode{
+		id:         id,
+		network:    network,
+		beacon:     beacon,
+		controller: controller,
+	}
+}
+
+// share returns the validator's share of the given operator.
+func (c *cluster) share(id spectypes.OperatorID) *ssvtypes.SSVShare {
+	share := spectestingutils.TestingShare(c.keySet, testValidatorIndex)
+	share.SharePubKey = c.keySet.Shares[id].GetPublicKey().Serialize()
+	share.DomainType = networkconfig.TestNetwork.DomainType
+	return &ssvtypes.SSVShare{
+		Share: *share,
+		Metadata: ssvtypes.Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Status: eth2apiv1.ValidatorStateActiveOngoing,
+				Index:  testValidatorIndex,
 			},
 		},
 	}
+}
 
-	ln, err := p2pv1.CreateAndStartLocalNet(ctx, logger, p2pv1.LocalNetOptions{
-		Nodes:        maxSupportedCommittee,
-		MinConnected: maxSupportedQuorum,
-		UseDiscv5:    false,
-		Shares:       shares,
-	})
-	if err != nil {
-		logger.Fatal("error creating and start local net", zap.Error(err))
-		return
+// executeAttesterDuty makes every operator attest with the validator, and returns the slot.
+// Like the duty scheduler, it executes the duty early in the slot, since the rounds of the consensus
+// time out relative to its start. So unless the current slot has just begun, it advances to the next one.
+func (c *cluster) executeAttesterDuty() phase0.Slot {
+	beaconNetwork := networkconfig.TestNetwork.Beacon
+	now := c.sim.Clock().Now()
+	slot := beaconNetwork.EstimatedSlotAtTime(now.Unix())
+	if now.Sub(beaconNetwork.GetSlotStartTime(slot)) > time.Second {
+		slot++
+		c.sim.Advance(beaconNetwork.GetSlotStartTime(slot).Sub(now))
 	}
 
-	nodes := map[spectypes.OperatorID]network.P2PNetwork{}
-	for i := 0; i < len(ln.Nodes); i++ {
-		nodes[spectypes.OperatorID(i+1)] = ln.Nodes[i]
+	duty := &spectypes.CommitteeDuty{
+		Slot: slot,
+		ValidatorDuties: []*spectypes.ValidatorDuty{{
+			Type:                    spectypes.BNRoleAttester,
+			PubKey:                  phase0.BLSPubKey(c.share(1).ValidatorPubKey),
+			Slot:                    slot,
+			ValidatorIndex:          testValidatorIndex,
+			CommitteeIndex:          spectestingutils.TestingCommitteeIndex,
+			CommitteesAtSlot:        spectestingutils.TestingCommitteesAtSlot,
+			CommitteeLength:         spectestingutils.TestingCommitteeLenght,
+			ValidatorCommitteeIndex: spectestingutils.TestingValidatorCommitteeIndex,
+		}},
+	}
+	for _, operator := range c.operators {
+		go operator.controller.ExecuteCommitteeDuty(c.ctx, c.logger, c.committeeID, duty)
 	}
+	return slot
+}
 
-	sharedData = &SharedData{
-		Nodes: nodes,
+// attesters returns which of the given operators submitted an attestation for the slot.
+func (c *cluster) attesters(slot phase0.Slot, operators []spectypes.OperatorID) []spectypes.OperatorID {
+	var attesters []spectypes.OperatorID
+	for _, id := range operators {
+		if c.operators[id].beacon.attestation(slot) != nil {
+			attesters = append(attesters, id)
+		}
 	}
+	return attesters
+}
+
+// requireDecided runs the network until a quorum of the given operators attests in the slot,
+// and requires all the operators which attested to have decided on the same attestation.
+// Operators outside the quorum may not attest at all, if they missed the messages of the decision.
+func (c *cluster) requireDecided(slot phase0.Slot, operators []spectypes.OperatorID, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(c.ctx, timeout)
+	defer cancel()
 
-	// exitCode required here only for linter
-	exitCode := m.Run()
+	quorum := int(c.keySet.Threshold) // #nosec G115
+	require.NoError(c.t, c.sim.RunUntil(ctx, func() bool {
+		return len(c.attesters(slot, operators)) >= quorum
+	}))
 
-	//teardown
-	for i := 0; i < len(ln.Nodes); i++ {
-		_ = ln.Nodes[i].Close()
+	all := maps.Keys(c.operators)
+	slices.Sort(all)
+	attesters := c.attesters(slot, all)
+	decided := c.operators[attesters[0]].beacon.attestation(slot)
+	for _, id := range attesters[1:] {
+		require.Equal(c.t, decided, c.operators[id].beacon.attestation(slot), "operator %d attested differently", id)
 	}
+}
+
+// validatorLookup adapts a function to simulator.ValidatorProvider.
+type validatorLookup func(pubKey []byte) (*ssvtypes.SSVShare, bool)
 
-	os.Exit(exitCode)
+func (f validatorLookup) Validator(pubKey []byte) (*ssvtypes.SSVShare, bool) {
+	return f(pubKey)
 }
@@ -12,10 +12,13 @@ const (
 	NameSignerStorage    = "SignerStorage"
 	NameValidator        = "Validator"
 	NameWSServer         = "WSServer"
+	NameGRPCServer       = "GRPCServer"
 	NameConnHandler      = "ConnHandler"
 
 	NameBadgerDBLog       = "BadgerDBLog"
 	NameBadgerDBReporting = "BadgerDBReporting"
+	NamePebbleDBLog       = "PebbleDBLog"
+	NamePebbleDBReporting = "PebbleDBReporting"
 	NameCreateThreshold   = "CreateThreshold"
 	NameDiscoveryV5Logger = "DiscoveryV5Logger"
 	NameExportKeys        = "ExportKeys"
@@ -47,6 +47,8 @@ type messageValidator struct {
 
 	selfPID    peer.ID
 	selfAccept bool
+
+	now func() time.Time
 }
 
 // New returns a new MessageValidator with the given network configuration and options.
@@ -65,6 +67,7 @@ func New(
 		validatorStore:      validatorStore,
 		dutyStore:           dutyStore,
 		signatureVerifier:   signatureVerifier,
+		now:                 time.Now,
 	}
 
 	for _, opt := range opts {
@@ -94,7 +97,7 @@ func (mv *messageValidator) Validate(ctx context.Context, peerID peer.ID, pmsg *
 
 	recordMessage(ctx)
 
-	decodedMessage, err := mv.handlePubsubMessage(pmsg, time.Now())
+	decodedMessage, err := mv.handlePubsubMessage(pmsg, mv.now())
 	if err != nil {
 		return mv.handleValidationError(ctx, peerID, decodedMessage, err)
 	}
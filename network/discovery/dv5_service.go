@@ -420,7 +420,7 @@ func (dvs *DiscV5Service) PublishENR(logger *zap.Logger) {
 
 func (dvs *DiscV5Service) createLocalNode(logger *zap.Logger, discOpts *Options, ipAddr net.IP) (*enode.LocalNode, error) {
 	opts := discOpts.DiscV5Opts
-	localNode, err := createLocalNode(opts.NetworkKey, opts.StoragePath, ipAddr, opts.Port, opts.TCPPort)
+	localNode, err := createLocalNode(opts.NetworkKey, opts.StoragePath, ipAddr, opts.Port, opts.TCPPort, opts.QUICPort)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create local node")
 	}
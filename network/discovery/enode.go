@@ -16,7 +16,7 @@ import (
 )
 
 // createLocalNode create a new enode.LocalNode instance
-func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.IP, udpPort, tcpPort uint16) (*enode.LocalNode, error) {
+func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.IP, udpPort, tcpPort, quicPort uint16) (*enode.LocalNode, error) {
 	db, err := enode.OpenDB(storagePath)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not open node's peer database")
@@ -26,6 +26,9 @@ func createLocalNode(privKey *ecdsa.PrivateKey, storagePath string, ipAddr net.I
 	localNode.Set(enr.IP(ipAddr))
 	localNode.Set(enr.UDP(udpPort))
 	localNode.Set(enr.TCP(tcpPort))
+	if quicPort != 0 {
+		localNode.Set(enr.WithEntry(quic, quicPort))
+	}
 	localNode.SetFallbackIP(ipAddr)
 	localNode.SetFallbackUDP(int(udpPort))
 	localNode.Set(enr.WithEntry("ssv", true))
@@ -66,6 +69,14 @@ func ToPeer(node *enode.Node) (*peer.AddrInfo, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create peer info")
 	}
+	var quicPort uint16
+	if err := node.Record().Load(enr.WithEntry(quic, &quicPort)); err == nil && quicPort != 0 {
+		quicAddr, err := commons.BuildMultiAddress(node.IP().String(), "udp", uint(quicPort), "")
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create QUIC multiaddr")
+		}
+		pi.Addrs = append(pi.Addrs, quicAddr.Encapsulate(ma.StringCast("/quic-v1")))
+	}
 	return pi, nil
 }
 
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/ssvlabs/ssv/network/commons"
 	"github.com/stretchr/testify/require"
 )
@@ -29,6 +31,20 @@ func Test_ToPeer(t *testing.T) {
 	require.Equal(t, 1, len(ai.Addrs))
 }
 
+func Test_ToPeerWithQUIC(t *testing.T) {
+	node := localNodeMock(t)
+	node.Set(enr.WithEntry(quic, uint16(13002)))
+
+	ai, err := ToPeer(node.Node())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ai.Addrs))
+	port, err := ai.Addrs[1].ValueForProtocol(ma.P_UDP)
+	require.NoError(t, err)
+	require.Equal(t, "13002", port)
+	_, err = ai.Addrs[1].ValueForProtocol(ma.P_QUIC_V1)
+	require.NoError(t, err)
+}
+
 func Test_ParseENR(t *testing.T) {
 	nodes, err := ParseENR(nil, true,
 		"enr:-Km4QH9oua5xsG_0IN3oxiv5PBb10QXMkMvDeg2IrSSDlRxtONu9hShTmAZm2LjjADQOxGzBxd8VzXYFukmJULzcwrkBh2"+
@@ -47,7 +63,7 @@ func localNodeMock(t *testing.T) *enode.LocalNode {
 	require.NoError(t, err)
 	ip, err := commons.IPAddr()
 	require.NoError(t, err)
-	node, err := createLocalNode(pk, "", ip, 12000, 13000)
+	node, err := createLocalNode(pk, "", ip, 12000, 13000, 0)
 	require.NoError(t, err)
 	return node
 }
@@ -17,7 +17,8 @@ import (
 const (
 	// udp4 = "udp4"
 	// udp6 = "udp6"
-	tcp = "tcp"
+	tcp  = "tcp"
+	quic = "quic"
 )
 
 // CheckPeerLimit enables listener to check peers limit
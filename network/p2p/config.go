@@ -11,11 +11,13 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	libp2ptcp "github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
 	"github.com/ssvlabs/ssv/message/validation"
 	"github.com/ssvlabs/ssv/network"
 	"github.com/ssvlabs/ssv/network/commons"
@@ -23,6 +25,7 @@ import (
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
 	"github.com/ssvlabs/ssv/operator/keys"
 	"github.com/ssvlabs/ssv/operator/storage"
+	"github.com/ssvlabs/ssv/storage/basedb"
 	uc "github.com/ssvlabs/ssv/utils/commons"
 )
 
@@ -40,6 +43,7 @@ type Config struct {
 
 	TCPPort     uint16 `yaml:"TcpPort" env:"TCP_PORT" env-default:"13001" env-description:"TCP port for p2p transport"`
 	UDPPort     uint16 `yaml:"UdpPort" env:"UDP_PORT" env-default:"12001" env-description:"UDP port for discovery"`
+	QUICPort    uint16 `yaml:"QuicPort" env:"QUIC_PORT" env-default:"0" env-description:"UDP port for QUIC p2p transport, alongside TCP. Must differ from UdpPort. 0 disables QUIC"`
 	HostAddress string `yaml:"HostAddress" env:"HOST_ADDRESS" env-description:"External ip node is exposed for discovery"`
 	HostDNS     string `yaml:"HostDNS" env:"HOST_DNS" env-description:"External DNS node is exposed for discovery"`
 
@@ -86,6 +90,24 @@ type Config struct {
 	// FullNode determines whether the network should sync decided history from peers.
 	// If false, SyncDecidedByRange becomes a no-op.
 	FullNode bool
+	// QBFTStores serve the decided participants the node observed to peers. Optional.
+	QBFTStores *ibftstorage.QBFTStores
+	// SyncedQBFTStores store the decided participants synced from peers, apart from those the node observed
+	// since they aren't signed, and serve them marked as synced in the slots without observed ones. Optional.
+	SyncedQBFTStores *ibftstorage.QBFTStores
+
+	DecidedSyncSlots uint64 `yaml:"DecidedSyncSlots" env:"P2P_DECIDED_SYNC_SLOTS" env-default:"7200" env-description:"How many slots back a full node backfills decided participants missed while offline from peers"`
+
+	// DB persists the reputation of peers and the ban list across restarts. Optional.
+	DB basedb.Database
+
+	PeerReputationTTL time.Duration `yaml:"PeerReputationTTL" env:"P2P_PEER_REPUTATION_TTL" env-default:"24h" env-description:"How long the scores of peers are remembered across restarts since they were last seen"`
+
+	// NATTraversal lets nodes behind NAT accept connections through circuit relays,
+	// and upgrade them to direct connections by hole punching.
+	NATTraversal bool `yaml:"NATTraversal" env:"P2P_NAT_TRAVERSAL" env-default:"false" env-description:"Flag to reserve circuit relays and hole punch through them when the node isn't reachable from the internet"`
+	// RelayService lets nodes which are reachable from the internet relay connections for nodes behind NAT.
+	RelayService bool `yaml:"RelayService" env:"P2P_RELAY_SERVICE" env-default:"false" env-description:"Flag to relay connections for peers behind NAT when the node is reachable from the internet"`
 
 	DisableIPRateLimit bool `yaml:"DisableIPRateLimit" env:"DISABLE_IP_RATE_LIMIT" default:"false" env-description:"Flag to turn on/off IP rate limiting"`
 
@@ -115,6 +137,12 @@ func (c *Config) Libp2pOptions(logger *zap.Logger) ([]libp2p.Option, error) {
 		libp2p.Transport(libp2ptcp.NewTCPTransport),
 		libp2p.UserAgent(c.UserAgent),
 	}
+	if c.QUICPort != 0 {
+		if c.QUICPort == c.UDPPort {
+			return nil, errors.New("QUIC port must differ from the discovery UDP port")
+		}
+		opts = append(opts, libp2p.Transport(libp2pquic.NewTransport))
+	}
 
 	opts, err = c.configureAddrs(logger, opts)
 	if err != nil {
@@ -125,6 +153,13 @@ func (c *Config) Libp2pOptions(logger *zap.Logger) ([]libp2p.Option, error) {
 
 	opts = commons.AddOptions(opts)
 
+	if c.NATTraversal {
+		opts = append(opts, libp2p.EnableRelay(), libp2p.EnableAutoNATv2())
+	}
+	if c.RelayService {
+		opts = append(opts, libp2p.EnableRelayService())
+	}
+
 	return opts, nil
 }
 
@@ -135,6 +170,13 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 		return opts, errors.Wrap(err, "could not build multi address for zero address")
 	}
 	addrs = append(addrs, maZero)
+	if c.QUICPort != 0 {
+		maZero, err := buildQUICMultiAddress("0.0.0.0", c.QUICPort)
+		if err != nil {
+			return opts, errors.Wrap(err, "could not build QUIC multi address for zero address")
+		}
+		addrs = append(addrs, maZero)
+	}
 	ipAddr, err := commons.IPAddr()
 	if err != nil {
 		return opts, errors.Wrap(err, "could not get ip addr")
@@ -146,6 +188,13 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 			return opts, errors.Wrap(err, "could not build multi address for zero address")
 		}
 		addrs = append(addrs, maIP)
+		if c.QUICPort != 0 {
+			maIP, err := buildQUICMultiAddress(ipAddr.String(), c.QUICPort)
+			if err != nil {
+				return opts, errors.Wrap(err, "could not build QUIC multi address")
+			}
+			addrs = append(addrs, maIP)
+		}
 	}
 	opts = append(opts, libp2p.ListenAddrs(addrs...))
 
@@ -158,6 +207,14 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 			} else {
 				addrs = append(addrs, external)
 			}
+			if c.QUICPort != 0 {
+				external, err := buildQUICMultiAddress(c.HostAddress, c.QUICPort)
+				if err != nil {
+					logger.Error("unable to create external QUIC multiaddress", zap.Error(err))
+				} else {
+					addrs = append(addrs, external)
+				}
+			}
 			return addrs
 		}))
 	}
@@ -170,6 +227,14 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 			} else {
 				addrs = append(addrs, external)
 			}
+			if c.QUICPort != 0 {
+				external, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/udp/%d/quic-v1", c.HostDNS, c.QUICPort))
+				if err != nil {
+					logger.Warn("unable to create external QUIC multiaddress", zap.Error(err))
+				} else {
+					addrs = append(addrs, external)
+				}
+			}
 			return addrs
 		}))
 	}
@@ -177,6 +242,14 @@ func (c *Config) configureAddrs(logger *zap.Logger, opts []libp2p.Option) ([]lib
 	return opts, nil
 }
 
+func buildQUICMultiAddress(ipAddr string, port uint16) (ma.Multiaddr, error) {
+	udp, err := commons.BuildMultiAddress(ipAddr, "udp", uint(port), "")
+	if err != nil {
+		return nil, err
+	}
+	return udp.Encapsulate(ma.StringCast("/quic-v1")), nil
+}
+
 // TransformBootnodes converts bootnodes string and convert it to slice
 func (c *Config) TransformBootnodes() []string {
 
@@ -0,0 +1,60 @@
+package p2pv1
+
+import (
+	crand "crypto/rand"
+	"strconv"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/commons"
+	nettesting "github.com/ssvlabs/ssv/network/testing"
+)
+
+func TestLibp2pOptionsQUIC(t *testing.T) {
+	logger := logging.TestLogger(t)
+	ports := make(nettesting.UDPPortsRandomizer)
+
+	newConfig := func() *Config {
+		sk, _, err := crypto.GenerateSecp256k1Key(crand.Reader)
+		require.NoError(t, err)
+		networkKey, err := commons.ECDSAPrivFromInterface(sk)
+		require.NoError(t, err)
+		return &Config{
+			Discovery:         localDiscvery,
+			TCPPort:           nettesting.RandomTCPPort(14001, 14999),
+			UDPPort:           ports.Next(15001, 15999),
+			QUICPort:          ports.Next(15001, 15999),
+			NetworkPrivateKey: networkKey,
+		}
+	}
+
+	invalid := newConfig()
+	invalid.QUICPort = invalid.UDPPort
+	_, err := invalid.Libp2pOptions(logger)
+	require.Error(t, err)
+
+	cfg := newConfig()
+	opts, err := cfg.Libp2pOptions(logger)
+	require.NoError(t, err)
+	h, err := libp2p.New(opts...)
+	require.NoError(t, err)
+	defer h.Close()
+
+	var quicPorts []string
+	for _, addr := range h.Addrs() {
+		if _, err := addr.ValueForProtocol(ma.P_QUIC_V1); err == nil {
+			port, err := addr.ValueForProtocol(ma.P_UDP)
+			require.NoError(t, err)
+			quicPorts = append(quicPorts, port)
+		}
+	}
+	require.NotEmpty(t, quicPorts)
+	for _, port := range quicPorts {
+		require.Equal(t, strconv.Itoa(int(cfg.QUICPort)), port)
+	}
+}
@@ -49,6 +49,23 @@ var (
 			metricName("peers.per_version"),
 			metric.WithUnit("{peer}"),
 			metric.WithDescription("number of connected peers per node version")))
+
+	syncedParticipantsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("sync.participants.saved"),
+			metric.WithUnit("{participants}"),
+			metric.WithDescription("number of decided participants synced from peers and saved")))
+
+	reachabilityGauge = observability.NewMetric(
+		meter.Int64Gauge(
+			metricName("reachability"),
+			metric.WithDescription("reachability of the node from the internet as detected by AutoNAT, 1 for the current status and 0 for the others")))
+
+	holePunchesCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("hole_punches"),
+			metric.WithUnit("{hole_punch}"),
+			metric.WithDescription("number of hole punching attempts through circuit relays")))
 )
 
 func metricName(name string) string {
@@ -153,3 +170,13 @@ func recordPeerIdentities(ctx context.Context, host host.Host, index peers.Index
 		}
 	}
 }
+
+func recordReachability(ctx context.Context, reachability network.Reachability) {
+	for _, status := range []network.Reachability{network.ReachabilityUnknown, network.ReachabilityPublic, network.ReachabilityPrivate} {
+		var value int64
+		if status == reachability {
+			value = 1
+		}
+		reachabilityGauge.Record(ctx, value, metric.WithAttributes(attribute.String("ssv.p2p.reachability", status.String())))
+	}
+}
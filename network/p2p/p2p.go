@@ -53,6 +53,7 @@ const (
 	peersReportingInterval             = 60 * time.Second
 	peerIdentitiesReportingInterval    = 5 * time.Minute
 	topicsReportingInterval            = 90 * time.Second
+	reputationSavingInterval           = 5 * time.Minute
 	maximumIrrelevantPeersToDisconnect = 3
 )
 
@@ -76,8 +77,10 @@ type p2pNetwork struct {
 	cfg             *Config
 
 	host         host.Host
+	hostReady    chan struct{}
 	streamCtrl   streams.StreamController
 	idx          peers.Index
+	reputation   *peers.Reputation
 	disc         discovery.Service
 	topicsCtrl   topics.Controller
 	msgRouter    network.MessageRouter
@@ -120,6 +123,7 @@ func New(logger *zap.Logger, cfg *Config) (*p2pNetwork, error) {
 		msgValidator:            cfg.MessageValidator,
 		state:                   stateClosed,
 		activeCommittees:        hashmap.New[string, validatorStatus](),
+		hostReady:               make(chan struct{}),
 		nodeStorage:             cfg.NodeStorage,
 		operatorPKHashToPKCache: hashmap.New[string, []byte](),
 		operatorSigner:          cfg.OperatorSigner,
@@ -192,6 +196,7 @@ func (n *p2pNetwork) Close() error {
 	if err := n.disc.Close(); err != nil {
 		n.interfaceLogger.Warn("could not close discovery", zap.Error(err))
 	}
+	n.saveReputation(n.interfaceLogger)()
 	if err := n.idx.Close(); err != nil {
 		n.interfaceLogger.Warn("could not close index", zap.Error(err))
 	}
@@ -262,10 +267,18 @@ func (n *p2pNetwork) Start(logger *zap.Logger) error {
 
 	async.Interval(n.ctx, topicsReportingInterval, recordPeerCountPerTopic(n.ctx, logger, n.topicsCtrl, 2))
 
+	async.Interval(n.ctx, reputationSavingInterval, n.saveReputation(logger))
+
+	go n.watchReachability(logger)
+
 	if err := n.subscribeToSubnets(logger); err != nil {
 		return err
 	}
 
+	if n.cfg.FullNode && n.cfg.QBFTStores != nil && n.cfg.SyncedQBFTStores != nil && n.nodeStorage != nil {
+		go n.backfillDecided(logger)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,86 @@
+package p2pv1
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	circuitproto "github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/proto"
+	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// natTraversalOptions returns the options which let a node behind NAT reserve slots on circuit relays,
+// announce the relayed addresses and hole punch through them.
+func (n *p2pNetwork) natTraversalOptions() []libp2p.Option {
+	return []libp2p.Option{
+		libp2p.EnableAutoRelayWithPeerSource(n.relayCandidates, autorelay.WithMinCandidates(1)),
+		libp2p.EnableHolePunching(holepunch.WithTracer(holePunchTracer{ctx: n.ctx})),
+	}
+}
+
+// relayCandidates offers the connected peers which serve circuit relay as relays.
+func (n *p2pNetwork) relayCandidates(ctx context.Context, num int) <-chan peer.AddrInfo {
+	candidates := make(chan peer.AddrInfo, num)
+	defer close(candidates)
+
+	// The host starts looking for relays while it's being created.
+	select {
+	case <-n.hostReady:
+	case <-ctx.Done():
+		return candidates
+	}
+
+	for _, id := range n.host.Network().Peers() {
+		if len(candidates) == num {
+			break
+		}
+		protocols, err := n.host.Peerstore().SupportsProtocols(id, circuitproto.ProtoIDv2Hop)
+		if err != nil || len(protocols) == 0 {
+			continue
+		}
+		candidates <- n.host.Peerstore().PeerInfo(id)
+	}
+	return candidates
+}
+
+// watchReachability records the reachability of the node from the internet, as detected by AutoNAT.
+func (n *p2pNetwork) watchReachability(logger *zap.Logger) {
+	sub, err := n.host.EventBus().Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		logger.Warn("could not subscribe to reachability changes", zap.Error(err))
+		return
+	}
+	defer sub.Close()
+
+	recordReachability(n.ctx, network.ReachabilityUnknown)
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case e, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+			reachability := e.(event.EvtLocalReachabilityChanged).Reachability
+			logger.Info("reachability changed", zap.String("reachability", reachability.String()))
+			recordReachability(n.ctx, reachability)
+		}
+	}
+}
+
+// holePunchTracer counts the hole punching attempts and their outcomes.
+type holePunchTracer struct {
+	ctx context.Context
+}
+
+func (t holePunchTracer) Trace(evt *holepunch.Event) {
+	if end, ok := evt.Evt.(*holepunch.EndHolePunchEvt); ok {
+		holePunchesCounter.Add(t.ctx, 1, metric.WithAttributes(attribute.Bool("ssv.p2p.hole_punch.success", end.Success)))
+	}
+}
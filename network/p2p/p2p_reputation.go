@@ -0,0 +1,71 @@
+package p2pv1
+
+import (
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/network/peers"
+)
+
+// saveReputation returns a function that persists the scores of peers, so that they outlive restarts,
+// and deletes the bans which expired.
+func (n *p2pNetwork) saveReputation(logger *zap.Logger) func() {
+	return func() {
+		if err := n.idx.SaveReputation(); err != nil {
+			logger.Warn("could not save peers reputation", zap.Error(err))
+		}
+		deleted, err := n.reputation.PruneBans()
+		if err != nil {
+			logger.Warn("could not delete expired bans", zap.Error(err))
+		} else if deleted > 0 {
+			logger.Debug("deleted expired bans", zap.Int("count", deleted))
+		}
+	}
+}
+
+// Bans returns the peers and IP addresses which are banned.
+func (n *p2pNetwork) Bans() []*peers.Ban {
+	return n.reputation.Bans()
+}
+
+// Ban bans a peer or an IP address from connecting to this node, and disconnects from it.
+func (n *p2pNetwork) Ban(ban *peers.Ban) error {
+	if err := n.reputation.Ban(ban); err != nil {
+		return err
+	}
+	n.interfaceLogger.Info("banned peer",
+		zap.String("target", ban.Target()),
+		zap.String("reason", ban.Reason),
+		zap.Timep("expires_at", ban.ExpiresAt))
+
+	net := n.host.Network()
+	for _, conn := range net.Conns() {
+		if ban.PeerID != "" && conn.RemotePeer() != ban.PeerID {
+			continue
+		}
+		if ban.IP != nil {
+			ip, err := manet.ToIP(conn.RemoteMultiaddr())
+			if err != nil || !ip.Equal(ban.IP) {
+				continue
+			}
+		}
+		if err := net.ClosePeer(conn.RemotePeer()); err != nil {
+			n.interfaceLogger.Warn("could not disconnect from banned peer", fields.PeerID(conn.RemotePeer()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Unban lifts the ban of a peer ID or an IP address, and returns whether there was one.
+func (n *p2pNetwork) Unban(target string) (bool, error) {
+	found, err := n.reputation.Unban(target)
+	if err != nil {
+		return false, errors.Wrap(err, "could not unban")
+	}
+	if found {
+		n.interfaceLogger.Info("unbanned peer", zap.String("target", target))
+	}
+	return found, nil
+}
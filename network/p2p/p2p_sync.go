@@ -0,0 +1,528 @@
+package p2pv1
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+const (
+	// highestDecidedProtocol serves the highest decided participants of a QBFT identifier.
+	highestDecidedProtocol = "/ssv/sync/decided/highest/0.0.1"
+	// decidedHistoryProtocol serves the decided participants of a QBFT identifier within a slot range,
+	// and is only served by full nodes.
+	decidedHistoryProtocol = "/ssv/sync/decided/history/0.0.1"
+
+	// syncPeers is the number of peers asked for the highest decided participants, and for each page of decided history.
+	syncPeers = 3
+	// syncAgreement is the number of peers which must report the same participants of a slot for them to be saved,
+	// since participants aren't signed and a single peer could report any quorum of the committee.
+	syncAgreement = 2
+	// historyPageEpochs bounds the slots scanned for a page of decided history, in epochs per entry,
+	// so that sparse ranges can't make a peer scan its storage unboundedly.
+	historyPageEpochs = 1
+	// backfillDelay is how long after starting a full node waits for peers before backfilling.
+	backfillDelay = time.Minute
+	// backfillWorkers is the number of identifiers backfilled concurrently.
+	backfillWorkers = 4
+	// backfillRequestRate is the number of sync requests per second a backfill makes at most.
+	backfillRequestRate = 20
+	// backfillMaxRequests is the number of sync requests a backfill makes at most, after which it stops.
+	backfillMaxRequests = 50_000
+)
+
+// backfillRoles are the roles whose decided participants are stored per validator.
+// Committee consensus is stored per committee, so it can't be backfilled by validator.
+var backfillRoles = []convert.RunnerRole{
+	convert.RoleAttester,
+	convert.RoleAggregator,
+	convert.RoleProposer,
+	convert.RoleSyncCommittee,
+	convert.RoleSyncCommitteeContribution,
+}
+
+var errBackfillStopped = errors.New("backfill stopped")
+
+// syncRequestFunc sends a sync request to a peer.
+type syncRequestFunc func(logger *zap.Logger, peerID peer.ID, protocolID protocol.ID, req *protocolp2p.SyncRequest) (*protocolp2p.SyncResponse, error)
+
+var _ protocolp2p.Syncer = (*p2pNetwork)(nil)
+
+// setupSyncHandlers serves the decided participants of the QBFT stores to peers,
+// including those synced from other peers, which are marked as such.
+func (n *p2pNetwork) setupSyncHandlers(logger *zap.Logger) {
+	if n.cfg.QBFTStores == nil {
+		return
+	}
+	n.host.SetStreamHandler(highestDecidedProtocol, n.handleSyncStream(logger, protocolp2p.LastDecidedProtocol, n.serveHighestDecided))
+	if n.cfg.FullNode {
+		n.host.SetStreamHandler(decidedHistoryProtocol, n.handleSyncStream(logger, protocolp2p.DecidedHistoryProtocol, n.serveDecidedHistory))
+	}
+	logger.Debug("sync handlers are ready", zap.Bool("full_node", n.cfg.FullNode))
+}
+
+func (n *p2pNetwork) handleSyncStream(
+	logger *zap.Logger,
+	syncProtocol protocolp2p.SyncProtocol,
+	serve func(observed, synced qbftstorage.QBFTStore, req *protocolp2p.SyncRequest, res *protocolp2p.SyncResponse) error,
+) libp2pnetwork.StreamHandler {
+	return func(stream libp2pnetwork.Stream) {
+		logger := logger.With(fields.PeerID(stream.Conn().RemotePeer()), zap.String("protocol", string(stream.Protocol())))
+
+		data, respond, done, err := n.streamCtrl.HandleStream(logger, stream)
+		defer done()
+		if err != nil {
+			logger.Debug("could not handle sync stream", zap.Error(err))
+			return
+		}
+
+		res := &protocolp2p.SyncResponse{Protocol: syncProtocol}
+		req := &protocolp2p.SyncRequest{}
+		if err := json.Unmarshal(data, req); err != nil {
+			res.StatusCode, res.Error = protocolp2p.StatusBadRequest, "could not decode request"
+		} else if err := n.validateSyncRequest(syncProtocol, req); err != nil {
+			res.Identifier = req.Identifier
+			res.StatusCode, res.Error = protocolp2p.StatusBadRequest, err.Error()
+		} else {
+			res.Identifier = req.Identifier
+			role := convert.MessageIDFromBytes(req.Identifier).GetRoleType()
+			store := n.cfg.QBFTStores.Get(role)
+			if store == nil {
+				res.StatusCode, res.Error = protocolp2p.StatusNotFound, "unknown role"
+			} else if err := serve(store, n.cfg.SyncedQBFTStores.Get(role), req, res); err != nil {
+				logger.Debug("could not serve sync request", zap.Error(err))
+				res.StatusCode, res.Error = protocolp2p.StatusInternalError, "could not read storage"
+			}
+		}
+
+		encoded, err := json.Marshal(res)
+		if err != nil {
+			logger.Debug("could not encode sync response", zap.Error(err))
+			return
+		}
+		if err := respond(encoded); err != nil {
+			logger.Debug("could not respond to sync request", zap.Error(err))
+		}
+	}
+}
+
+func (n *p2pNetwork) validateSyncRequest(syncProtocol protocolp2p.SyncProtocol, req *protocolp2p.SyncRequest) error {
+	if req.Protocol != syncProtocol {
+		return fmt.Errorf("unexpected protocol %d", req.Protocol)
+	}
+	if len(req.Identifier) != len(convert.MessageID{}) {
+		return fmt.Errorf("invalid identifier length %d", len(req.Identifier))
+	}
+	domain := n.cfg.Network.DomainType
+	if string(convert.MessageIDFromBytes(req.Identifier).GetDomain()) != string(domain[:]) {
+		return fmt.Errorf("unexpected domain")
+	}
+	if syncProtocol == protocolp2p.DecidedHistoryProtocol {
+		if len(req.Params) != 2 || req.Params[0] > req.Params[1] {
+			return fmt.Errorf("invalid slot range")
+		}
+	}
+	return nil
+}
+
+func (n *p2pNetwork) serveHighestDecided(observed, synced qbftstorage.QBFTStore, req *protocolp2p.SyncRequest, res *protocolp2p.SyncResponse) error {
+	highest, err := ibftstorage.HighestParticipants(observed, synced, convert.MessageIDFromBytes(req.Identifier))
+	if err != nil {
+		return err
+	}
+	if highest == nil {
+		res.StatusCode = protocolp2p.StatusNotFound
+		return nil
+	}
+	res.Data = []protocolp2p.DecidedParticipants{{Slot: highest.Slot, Signers: highest.Signers, Synced: highest.Synced}}
+	return nil
+}
+
+// serveDecidedHistory responds with a page of the requested range, of at most MaxBatchResponse entries,
+// and the range the page covers so that the requester continues after it.
+func (n *p2pNetwork) serveDecidedHistory(observed, synced qbftstorage.QBFTStore, req *protocolp2p.SyncRequest, res *protocolp2p.SyncResponse) error {
+	from, to := req.Params[0], req.Params[1]
+	maxEntries := n.cfg.MaxBatchResponse
+	if maxEntries == 0 {
+		maxEntries = 1
+	}
+	if maxSlots := phase0.Slot(maxEntries * historyPageEpochs * n.cfg.Network.SlotsPerEpoch()); to-from >= maxSlots {
+		to = from + maxSlots - 1
+	}
+
+	entries, err := ibftstorage.ParticipantsInRange(observed, synced, convert.MessageIDFromBytes(req.Identifier), from, to)
+	if err != nil {
+		return err
+	}
+	if uint64(len(entries)) > maxEntries {
+		entries = entries[:maxEntries]
+		to = entries[len(entries)-1].Slot
+	}
+
+	res.Params = []phase0.Slot{from, to}
+	res.Data = make([]protocolp2p.DecidedParticipants, 0, len(entries))
+	for _, entry := range entries {
+		res.Data = append(res.Data, protocolp2p.DecidedParticipants{Slot: entry.Slot, Signers: entry.Signers, Synced: entry.Synced})
+	}
+	return nil
+}
+
+// SyncHighestDecided returns the highest decided participants of the given identifier among a few random peers.
+func (n *p2pNetwork) SyncHighestDecided(logger *zap.Logger, identifier convert.MessageID) (*protocolp2p.DecidedParticipants, error) {
+	return n.syncHighestDecided(logger, identifier, n.syncRequest)
+}
+
+func (n *p2pNetwork) syncHighestDecided(logger *zap.Logger, identifier convert.MessageID, request syncRequestFunc) (*protocolp2p.DecidedParticipants, error) {
+	peers := n.syncPeers(highestDecidedProtocol)
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no peers serve %s", highestDecidedProtocol)
+	}
+	if len(peers) > syncPeers {
+		peers = peers[:syncPeers]
+	}
+
+	var highest *protocolp2p.DecidedParticipants
+	for _, peerID := range peers {
+		res, err := request(logger, peerID, highestDecidedProtocol, &protocolp2p.SyncRequest{
+			Protocol:   protocolp2p.LastDecidedProtocol,
+			Identifier: identifier[:],
+		})
+		if errors.Is(err, errBackfillStopped) {
+			return nil, err
+		}
+		if err != nil {
+			logger.Debug("could not sync highest decided", fields.PeerID(peerID), zap.Error(err))
+			continue
+		}
+		if len(res.Data) != 1 {
+			continue
+		}
+		if err := n.validateParticipants(identifier, res.Data[0]); err != nil {
+			logger.Debug("peer sent invalid highest decided", fields.PeerID(peerID), zap.Error(err))
+			continue
+		}
+		if res.Data[0].Slot > n.cfg.Network.Beacon.EstimatedCurrentSlot() {
+			logger.Debug("peer sent highest decided of a future slot", fields.PeerID(peerID), fields.Slot(res.Data[0].Slot))
+			continue
+		}
+		if highest == nil || res.Data[0].Slot > highest.Slot {
+			highest = &res.Data[0]
+		}
+	}
+	return highest, nil
+}
+
+// SyncDecidedByRange pages through the decided history of the given identifier, asking a few peers serving it for each page,
+// and saves the participants of the slots which at least syncAgreement of them report identically.
+// Peers which fail are replaced by the next ones. The participants are saved to the synced stores,
+// apart from those the node observed itself, since they're only as trustworthy as the peers which agreed on them.
+func (n *p2pNetwork) SyncDecidedByRange(logger *zap.Logger, identifier convert.MessageID, from, to phase0.Slot) (int, error) {
+	return n.syncDecidedByRange(logger, identifier, from, to, n.syncRequest)
+}
+
+func (n *p2pNetwork) syncDecidedByRange(logger *zap.Logger, identifier convert.MessageID, from, to phase0.Slot, request syncRequestFunc) (int, error) {
+	if !n.cfg.FullNode || n.cfg.SyncedQBFTStores == nil {
+		return 0, nil
+	}
+	store := n.cfg.SyncedQBFTStores.Get(identifier.GetRoleType())
+	if store == nil {
+		return 0, fmt.Errorf("no synced storage for role %s", identifier.GetRoleType())
+	}
+	if from > to {
+		return 0, nil
+	}
+
+	peers := n.syncPeers(decidedHistoryProtocol)
+	var saved int
+	defer func() {
+		syncedParticipantsCounter.Add(n.ctx, int64(saved))
+	}()
+	for from <= to {
+		// Peers are asked in turn until enough of them respond, and those which fail are dropped.
+		var pages []*protocolp2p.SyncResponse
+		for i := 0; i < len(peers) && len(pages) < syncPeers; {
+			res, err := request(logger, peers[i], decidedHistoryProtocol, &protocolp2p.SyncRequest{
+				Protocol:   protocolp2p.DecidedHistoryProtocol,
+				Identifier: identifier[:],
+				Params:     []phase0.Slot{from, to},
+			})
+			if errors.Is(err, errBackfillStopped) {
+				return saved, err
+			}
+			if err == nil && (len(res.Params) != 2 || res.Params[0] != from || res.Params[1] < from || res.Params[1] > to) {
+				err = fmt.Errorf("unexpected range %v", res.Params)
+			}
+			if err != nil {
+				logger.Debug("could not sync decided history", fields.PeerID(peers[i]), zap.Error(err))
+				peers = slices.Delete(peers, i, i+1)
+				continue
+			}
+			pages = append(pages, res)
+			i++
+		}
+		if len(pages) < syncAgreement {
+			return saved, fmt.Errorf("could not sync slots %d-%d from enough peers: %d responded, %d must agree", from, to, len(pages), syncAgreement)
+		}
+
+		// Only the slots which all pages cover can be agreed on.
+		end := to
+		for _, res := range pages {
+			end = min(end, res.Params[1])
+		}
+
+		for _, participants := range n.agreedParticipants(logger, identifier, pages, from, end) {
+			updated, err := store.UpdateParticipants(identifier, participants.Slot, participants.Signers)
+			if err != nil {
+				return saved, fmt.Errorf("could not save participants: %w", err)
+			}
+			if updated {
+				saved++
+			}
+		}
+		from = end + 1
+	}
+	return saved, nil
+}
+
+// agreedParticipants returns the valid participants within the slot range which at least syncAgreement pages report, ordered by slot.
+func (n *p2pNetwork) agreedParticipants(
+	logger *zap.Logger,
+	identifier convert.MessageID,
+	pages []*protocolp2p.SyncResponse,
+	from, to phase0.Slot,
+) []protocolp2p.DecidedParticipants {
+	type reported struct {
+		participants protocolp2p.DecidedParticipants
+		peers        int
+	}
+	reports := make(map[string]*reported)
+	for _, res := range pages {
+		seen := make(map[phase0.Slot]struct{})
+		for _, participants := range res.Data {
+			if participants.Slot < from || participants.Slot > to {
+				continue
+			}
+			// A peer reporting a slot more than once doesn't count more than once.
+			if _, ok := seen[participants.Slot]; ok {
+				continue
+			}
+			seen[participants.Slot] = struct{}{}
+			if err := n.validateParticipants(identifier, participants); err != nil {
+				logger.Debug("peer sent invalid decided participants", fields.Slot(participants.Slot), zap.Error(err))
+				continue
+			}
+			key := fmt.Sprint(participants.Slot, participants.Signers)
+			if r, ok := reports[key]; ok {
+				r.peers++
+			} else {
+				reports[key] = &reported{participants: participants, peers: 1}
+			}
+		}
+	}
+
+	var agreed []protocolp2p.DecidedParticipants
+	for _, r := range reports {
+		if r.peers >= syncAgreement {
+			agreed = append(agreed, r.participants)
+		}
+	}
+	slices.SortFunc(agreed, func(a, b protocolp2p.DecidedParticipants) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+	return agreed
+}
+
+// validateParticipants checks that the participants are a quorum of the committee of the identifier's validator,
+// since they aren't signed by the peer reporting them.
+func (n *p2pNetwork) validateParticipants(identifier convert.MessageID, participants protocolp2p.DecidedParticipants) error {
+	if n.nodeStorage == nil {
+		return fmt.Errorf("no validators to validate against")
+	}
+	share, ok := n.nodeStorage.ValidatorStore().Validator(identifier.GetDutyExecutorID())
+	if !ok {
+		return fmt.Errorf("unknown validator")
+	}
+	if !share.HasQuorum(uint64(len(participants.Signers))) {
+		return fmt.Errorf("signers are not a quorum")
+	}
+	committee := share.OperatorIDs()
+	var previous spectypes.OperatorID
+	for _, signer := range participants.Signers {
+		if signer <= previous {
+			return fmt.Errorf("signers are not sorted and unique")
+		}
+		previous = signer
+		if !slices.Contains(committee, signer) {
+			return fmt.Errorf("signer %d is not in the committee", signer)
+		}
+	}
+	return nil
+}
+
+func (n *p2pNetwork) syncRequest(logger *zap.Logger, peerID peer.ID, protocolID protocol.ID, req *protocolp2p.SyncRequest) (*protocolp2p.SyncResponse, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode request: %w", err)
+	}
+	raw, err := n.streamCtrl.Request(logger, peerID, protocolID, data)
+	if err != nil {
+		return nil, err
+	}
+	res := &protocolp2p.SyncResponse{}
+	if err := json.Unmarshal(raw, res); err != nil {
+		return nil, fmt.Errorf("could not decode response: %w", err)
+	}
+	if res.Protocol != req.Protocol || string(res.Identifier) != string(req.Identifier) {
+		return nil, fmt.Errorf("response doesn't match the request")
+	}
+	switch res.StatusCode {
+	case protocolp2p.StatusSuccess:
+		return res, nil
+	case protocolp2p.StatusNotFound:
+		return res, nil
+	default:
+		return nil, fmt.Errorf("status %d: %s", res.StatusCode, res.Error)
+	}
+}
+
+// syncPeers returns the connected peers which serve the given protocol, in random order.
+func (n *p2pNetwork) syncPeers(protocolID protocol.ID) []peer.ID {
+	var peers []peer.ID
+	for _, peerID := range n.host.Network().Peers() {
+		supported, err := n.host.Peerstore().SupportsProtocols(peerID, protocolID)
+		if err == nil && len(supported) > 0 {
+			peers = append(peers, peerID)
+		}
+	}
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	return peers
+}
+
+// backfillDecided syncs the decided participants which this full node missed while it was offline,
+// for every validator and the roles stored per validator, from the highest slot it has up to the highest slot
+// its peers have, going back at most DecidedSyncSlots.
+// Its requests are rate limited, and it stops once it made backfillMaxRequests of them.
+func (n *p2pNetwork) backfillDecided(logger *zap.Logger) {
+	select {
+	case <-n.ctx.Done():
+		return
+	case <-time.After(backfillDelay):
+	}
+
+	currentSlot := n.cfg.Network.Beacon.EstimatedCurrentSlot()
+	var oldestSlot phase0.Slot
+	if slots := phase0.Slot(n.cfg.DecidedSyncSlots); currentSlot > slots {
+		oldestSlot = currentSlot - slots
+	}
+	shares := n.nodeStorage.Shares().List(nil, registrystorage.ByNotLiquidated())
+	logger.Info("backfilling decided participants", zap.Int("validators", len(shares)), fields.Slot(oldestSlot))
+
+	ctx, cancel := context.WithCancel(n.ctx)
+	defer cancel()
+	limiter := rate.NewLimiter(backfillRequestRate, 1)
+	var requests atomic.Int64
+	request := func(logger *zap.Logger, peerID peer.ID, protocolID protocol.ID, req *protocolp2p.SyncRequest) (*protocolp2p.SyncResponse, error) {
+		if requests.Add(1) > backfillMaxRequests {
+			cancel()
+			return nil, errBackfillStopped
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, errBackfillStopped
+		}
+		return n.syncRequest(logger, peerID, protocolID, req)
+	}
+
+	identifiers := make(chan convert.MessageID)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var total int
+	for i := 0; i < backfillWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for identifier := range identifiers {
+				saved, err := n.backfillIdentifier(logger, identifier, oldestSlot, request)
+				if err != nil && !errors.Is(err, errBackfillStopped) {
+					logger.Debug("could not backfill decided participants", fields.MessageID(spectypes.MessageID(identifier)), zap.Error(err))
+				}
+				mu.Lock()
+				total += saved
+				mu.Unlock()
+			}
+		}()
+	}
+
+	start := time.Now()
+loop:
+	for _, share := range shares {
+		for _, role := range backfillRoles {
+			if n.cfg.QBFTStores.Get(role) == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				break loop
+			case identifiers <- convert.NewMsgID(n.cfg.Network.DomainType, share.ValidatorPubKey[:], role):
+			}
+		}
+	}
+	close(identifiers)
+	wg.Wait()
+
+	if requests.Load() > backfillMaxRequests {
+		logger.Warn("stopped backfilling decided participants after too many requests", zap.Int("max_requests", backfillMaxRequests))
+	}
+	logger.Info("backfilled decided participants", zap.Int("saved", total), fields.Took(time.Since(start)))
+}
+
+func (n *p2pNetwork) backfillIdentifier(logger *zap.Logger, identifier convert.MessageID, oldestSlot phase0.Slot, request syncRequestFunc) (int, error) {
+	remote, err := n.syncHighestDecided(logger, identifier, request)
+	if err != nil || remote == nil {
+		return 0, err
+	}
+	from := oldestSlot
+	for _, stores := range []*ibftstorage.QBFTStores{n.cfg.QBFTStores, n.cfg.SyncedQBFTStores} {
+		if stores == nil {
+			continue
+		}
+		store := stores.Get(identifier.GetRoleType())
+		if store == nil {
+			continue
+		}
+		local, err := store.GetHighestParticipants(identifier)
+		if err != nil {
+			return 0, err
+		}
+		if local != nil && local.Slot >= from {
+			from = local.Slot + 1
+		}
+	}
+	if from > remote.Slot {
+		return 0, nil
+	}
+	return n.syncDecidedByRange(logger, identifier, from, remote.Slot, request)
+}
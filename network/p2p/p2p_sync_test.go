@@ -0,0 +1,175 @@
+package p2pv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	ibftstorage "github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/streams"
+	"github.com/ssvlabs/ssv/networkconfig"
+	operatorstorage "github.com/ssvlabs/ssv/operator/storage"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestSyncDecided(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := logging.TestLogger(t)
+
+	share := &types.SSVShare{
+		Share: spectypes.Share{
+			ValidatorPubKey: spectypes.ValidatorPK{1, 2, 3},
+			Committee: []*spectypes.ShareMember{
+				{Signer: 1}, {Signer: 2}, {Signer: 3}, {Signer: 4},
+			},
+		},
+	}
+	identifier := convert.NewMsgID(networkconfig.TestNetwork.DomainType, share.ValidatorPubKey[:], convert.RoleAttester)
+
+	newNetwork := func(fullNode bool) (*p2pNetwork, *ibftstorage.QBFTStores, *ibftstorage.QBFTStores) {
+		db, err := kv.NewInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		nodeStorage, err := operatorstorage.NewNodeStorage(logger, db)
+		require.NoError(t, err)
+		require.NoError(t, nodeStorage.Shares().Save(nil, share))
+
+		h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = h.Close() })
+
+		stores := ibftstorage.NewStoresFromRoles(db, convert.RoleAttester)
+		syncedStores := ibftstorage.NewStores()
+		syncedStores.Add(convert.RoleAttester, ibftstorage.New(db, "synced_"+convert.RoleAttester.String()))
+		n := &p2pNetwork{
+			ctx:  ctx,
+			host: h,
+			cfg: &Config{
+				Network:          networkconfig.TestNetwork,
+				FullNode:         fullNode,
+				MaxBatchResponse: 2,
+				QBFTStores:       stores,
+				SyncedQBFTStores: syncedStores,
+			},
+			streamCtrl:  streams.NewStreamController(ctx, h, time.Second, time.Second),
+			nodeStorage: nodeStorage,
+		}
+		n.setupSyncHandlers(logger)
+		return n, stores, syncedStores
+	}
+	connect := func(from, to host.Host) {
+		require.NoError(t, from.Connect(ctx, peer.AddrInfo{ID: to.ID(), Addrs: to.Addrs()}))
+	}
+	save := func(stores *ibftstorage.QBFTStores, participants map[phase0.Slot][]spectypes.OperatorID) {
+		for slot, signers := range participants {
+			_, err := stores.Get(convert.RoleAttester).UpdateParticipants(identifier, slot, signers)
+			require.NoError(t, err)
+		}
+	}
+
+	serverA, serverAStores, _ := newNetwork(true)
+	serverB, serverBStores, _ := newNetwork(true)
+	liar, liarStores, _ := newNetwork(true)
+	client, clientStores, clientSyncedStores := newNetwork(true)
+
+	// The participants of slots 11 and 12 aren't a quorum of the committee, so they're not synced.
+	participants := map[phase0.Slot][]spectypes.OperatorID{
+		10: {1, 2, 3},
+		11: {1, 2},
+		12: {1, 2, 5},
+		13: {2, 3, 4},
+		14: {1, 2, 3, 4},
+		15: {1, 3, 4},
+		20: {1, 2, 4},
+	}
+	save(serverAStores, participants)
+	save(serverBStores, participants)
+	// Participants which no other peer reports aren't synced.
+	save(liarStores, map[phase0.Slot][]spectypes.OperatorID{
+		10: {1, 2, 3},
+		13: {1, 2, 4},
+		16: {1, 2, 3},
+	})
+
+	connect(client.host, serverA.host)
+	require.Eventually(t, func() bool {
+		return len(client.syncPeers(decidedHistoryProtocol)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	highest, err := client.SyncHighestDecided(logger, identifier)
+	require.NoError(t, err)
+	require.Equal(t, phase0.Slot(20), highest.Slot)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 4}, highest.Signers)
+
+	// A single peer can't be agreed with.
+	saved, err := client.SyncDecidedByRange(logger, identifier, 0, 20)
+	require.Error(t, err)
+	require.Zero(t, saved)
+
+	connect(client.host, serverB.host)
+	connect(client.host, liar.host)
+	require.Eventually(t, func() bool {
+		return len(client.syncPeers(decidedHistoryProtocol)) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Paged by 2 participants at a time.
+	saved, err = client.SyncDecidedByRange(logger, identifier, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, 5, saved)
+
+	entries, err := clientSyncedStores.Get(convert.RoleAttester).GetParticipantsInRange(identifier, 0, 20)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	for _, entry := range entries {
+		require.Equal(t, participants[entry.Slot], entry.Signers)
+	}
+
+	// Synced participants are kept apart from the observed ones.
+	entries, err = clientStores.Get(convert.RoleAttester).GetParticipantsInRange(identifier, 0, 20)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// Synced participants are served to peers, marked as synced.
+	res := &protocolp2p.SyncResponse{}
+	err = client.serveDecidedHistory(clientStores.Get(convert.RoleAttester), clientSyncedStores.Get(convert.RoleAttester), &protocolp2p.SyncRequest{
+		Identifier: identifier[:],
+		Params:     []phase0.Slot{0, 20},
+	}, res)
+	require.NoError(t, err)
+	require.Equal(t, []protocolp2p.DecidedParticipants{
+		{Slot: 10, Signers: participants[10], Synced: true},
+		{Slot: 13, Signers: participants[13], Synced: true},
+	}, res.Data)
+
+	// Syncing again saves nothing new.
+	saved, err = client.SyncDecidedByRange(logger, identifier, 0, 20)
+	require.NoError(t, err)
+	require.Zero(t, saved)
+
+	// Nodes which aren't full nodes neither sync nor serve decided history.
+	partialClient, _, _ := newNetwork(false)
+	connect(partialClient.host, serverA.host)
+	saved, err = partialClient.SyncDecidedByRange(logger, identifier, 0, 20)
+	require.NoError(t, err)
+	require.Zero(t, saved)
+
+	connect(client.host, partialClient.host)
+	require.Eventually(t, func() bool {
+		return len(client.syncPeers(highestDecidedProtocol)) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, client.syncPeers(decidedHistoryProtocol), 3)
+}
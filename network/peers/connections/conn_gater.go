@@ -1,6 +1,7 @@
 package connections
 
 import (
+	"net"
 	"runtime"
 	"time"
 
@@ -28,6 +29,12 @@ const (
 
 type BadPeerF func(logger *zap.Logger, peerID peer.ID) bool
 
+// BanList tells which peers and IP addresses are banned
+type BanList interface {
+	IsPeerBanned(id peer.ID) bool
+	IsIPBanned(ip net.IP) bool
+}
+
 // connGater implements ConnectionGater interface:
 // https://github.com/libp2p/go-libp2p/core/blob/master/connmgr/gater.go
 type connGater struct {
@@ -36,16 +43,19 @@ type connGater struct {
 	atLimit   func() bool
 	ipLimiter *leakybucket.Collector
 	isBadPeer BadPeerF
+	bans      BanList
 }
 
-// NewConnectionGater creates a new instance of ConnectionGater
-func NewConnectionGater(logger *zap.Logger, disable bool, atLimit func() bool, isBadPeerF BadPeerF) connmgr.ConnectionGater {
+// NewConnectionGater creates a new instance of ConnectionGater.
+// disable only turns off the IP rate limit, banned peers and IP addresses are always rejected.
+func NewConnectionGater(logger *zap.Logger, disable bool, atLimit func() bool, isBadPeerF BadPeerF, bans BanList) connmgr.ConnectionGater {
 	return &connGater{
 		logger:    logger,
 		disable:   disable,
 		atLimit:   atLimit,
 		ipLimiter: leakybucket.NewCollector(ipLimitRate, ipLimitBurst, ipLimitPeriod, true),
 		isBadPeer: isBadPeerF,
+		bans:      bans,
 	}
 }
 
@@ -53,6 +63,10 @@ func NewConnectionGater(logger *zap.Logger, disable bool, atLimit func() bool, i
 // to the addresses of that peer being available/resolved. Blocking connections
 // at this stage is typical for blacklisting scenarios
 func (n *connGater) InterceptPeerDial(id peer.ID) bool {
+	if n.bans.IsPeerBanned(id) {
+		n.logger.Debug("preventing outbound connection due to banned peer", fields.PeerID(id))
+		return false
+	}
 	return true
 }
 
@@ -64,6 +78,10 @@ func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
 		n.logger.Debug("preventing outbound connection due to bad peer", fields.PeerID(id))
 		return false
 	}
+	if n.isBannedAddr(multiaddr) {
+		n.logger.Debug("preventing outbound connection due to banned IP", fields.PeerID(id), zap.String("addr", multiaddr.String()))
+		return false
+	}
 	return true
 }
 
@@ -72,10 +90,14 @@ func (n *connGater) InterceptAddrDial(id peer.ID, multiaddr ma.Multiaddr) bool {
 // accept already secure and/or multiplexed connections (e.g. possibly QUIC)
 // MUST call this method regardless, for correctness/consistency.
 func (n *connGater) InterceptAccept(multiaddrs libp2pnetwork.ConnMultiaddrs) bool {
+	remoteAddr := multiaddrs.RemoteMultiaddr()
+	if n.isBannedAddr(remoteAddr) {
+		n.logger.Debug("connection rejected due to banned IP", zap.String("remote_addr", remoteAddr.String()))
+		return false
+	}
 	if n.disable {
 		return true
 	}
-	remoteAddr := multiaddrs.RemoteMultiaddr()
 	if !n.validateDial(remoteAddr) {
 		// Yield this goroutine to allow others to run in-between connection attempts.
 		runtime.Gosched()
@@ -103,6 +125,14 @@ func (n *connGater) InterceptUpgraded(conn libp2pnetwork.Conn) (bool, control.Di
 	return true, 0
 }
 
+func (n *connGater) isBannedAddr(addr multiaddr.Multiaddr) bool {
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return n.bans.IsIPBanned(ip)
+}
+
 func (n *connGater) validateDial(addr multiaddr.Multiaddr) bool {
 	ip, err := manet.ToIP(addr)
 	if err != nil {
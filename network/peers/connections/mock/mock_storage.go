@@ -106,6 +106,11 @@ func (m NodeStorage) GetRecipientDataMany(txn basedb.Reader, owners []common.Add
 	panic("implement me")
 }
 
+func (m NodeStorage) ListRecipients(txn basedb.Reader) ([]registrystorage.RecipientData, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m NodeStorage) SaveRecipientData(txn basedb.ReadWriter, recipientData *registrystorage.RecipientData) (*registrystorage.RecipientData, error) {
 	//TODO implement me
 	panic("implement me")
@@ -2,6 +2,7 @@ package peers
 
 import (
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/ssvlabs/ssv/network/topics/params"
@@ -12,15 +13,25 @@ type gossipScoreIndex struct {
 	score map[peer.ID]float64
 	mutex sync.RWMutex
 
+	// restored are the scores persisted before a restart, which apply until
+	// pubsub scores the peer again or they expire.
+	restored map[peer.ID]restoredScore
+
 	graylistThreshold float64
 }
 
+type restoredScore struct {
+	score     float64
+	expiresAt time.Time
+}
+
 func NewGossipScoreIndex() *gossipScoreIndex {
 
 	graylistThreshold := params.PeerScoreThresholds().GraylistThreshold
 
 	return &gossipScoreIndex{
 		score:             make(map[peer.ID]float64),
+		restored:          make(map[peer.ID]restoredScore),
 		graylistThreshold: graylistThreshold,
 	}
 }
@@ -32,6 +43,9 @@ func (g *gossipScoreIndex) GetGossipScore(peerID peer.ID) (float64, bool) {
 	if score, exists := g.score[peerID]; exists {
 		return score, true
 	}
+	if restored, exists := g.restored[peerID]; exists && time.Now().Before(restored.expiresAt) {
+		return restored.score, true
+	}
 	return 0.0, false
 }
 
@@ -43,7 +57,29 @@ func (g *gossipScoreIndex) SetScores(peerScores map[peer.ID]float64) {
 	// Copy the map
 	for peerID, score := range peerScores {
 		g.score[peerID] = score
+		delete(g.restored, peerID)
+	}
+}
+
+func (g *gossipScoreIndex) Scores() map[peer.ID]float64 {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	scores := make(map[peer.ID]float64, len(g.score))
+	for peerID, score := range g.score {
+		scores[peerID] = score
+	}
+	return scores
+}
+
+func (g *gossipScoreIndex) RestoreScore(peerID peer.ID, score float64, expiresAt time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if _, exists := g.score[peerID]; exists {
+		return
 	}
+	g.restored[peerID] = restoredScore{score: score, expiresAt: expiresAt}
 }
 
 func (g *gossipScoreIndex) clear() {
@@ -2,6 +2,7 @@ package peers
 
 import (
 	"io"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/network"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
@@ -41,7 +42,7 @@ type ConnectionIndex interface {
 	// AtLimit checks if the node has reached peers limit
 	AtLimit(dir libp2pnetwork.Direction) bool
 
-	// IsBad returns whether the given peer is bad
+	// IsBad returns whether the given peer is bad or banned
 	IsBad(logger *zap.Logger, id peer.ID) bool
 }
 
@@ -120,6 +121,19 @@ type GossipScoreIndex interface {
 	GetGossipScore(peerID peer.ID) (float64, bool)
 	// HasBadGossipScore returns true if the peer has a bad Gossip score
 	HasBadGossipScore(peerID peer.ID) (bool, float64)
+	// Scores returns the current Gossip scores of peers
+	Scores() map[peer.ID]float64
+	// RestoreScore restores a Gossip score persisted before a restart, which applies
+	// until the peer is scored again or the given expiry
+	RestoreScore(peerID peer.ID, score float64, expiresAt time.Time)
+}
+
+// ReputationIndex persists the scores of peers across restarts
+type ReputationIndex interface {
+	// SaveReputation persists the current scores of peers
+	SaveReputation() error
+	// RestoreReputation restores the persisted scores of peers
+	RestoreReputation() error
 }
 
 // Index is a facade interface of this package
@@ -131,4 +145,5 @@ type Index interface {
 	SubnetsIndex
 	io.Closer
 	GossipScoreIndex
+	ReputationIndex
 }
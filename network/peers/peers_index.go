@@ -25,7 +25,7 @@ type peersIndex struct {
 	netKeyProvider NetworkKeyProvider
 	network        libp2pnetwork.Network
 
-	scoreIdx ScoreIndex
+	scoreIdx *scoresIndex
 	SubnetsIndex
 	PeerInfoIndex
 
@@ -35,11 +35,13 @@ type peersIndex struct {
 	maxPeers MaxPeersProvider
 
 	gossipScoreIndex GossipScoreIndex
+
+	reputation *Reputation
 }
 
 // NewPeersIndex creates a new Index
 func NewPeersIndex(logger *zap.Logger, network libp2pnetwork.Network, self *records.NodeInfo, maxPeers MaxPeersProvider,
-	netKeyProvider NetworkKeyProvider, subnetsCount int, pruneTTL time.Duration, gossipScoreIndex GossipScoreIndex) *peersIndex {
+	netKeyProvider NetworkKeyProvider, subnetsCount int, pruneTTL time.Duration, gossipScoreIndex GossipScoreIndex, reputation *Reputation) *peersIndex {
 
 	return &peersIndex{
 		network:          network,
@@ -51,15 +53,21 @@ func NewPeersIndex(logger *zap.Logger, network libp2pnetwork.Network, self *reco
 		maxPeers:         maxPeers,
 		netKeyProvider:   netKeyProvider,
 		gossipScoreIndex: gossipScoreIndex,
+		reputation:       reputation,
 	}
 }
 
 // IsBad returns whether the given peer is bad.
 // a peer is considered to be bad if one of the following applies:
+// - banned
 // - bad gossip score
 // - pruned (that was not expired)
 // - bad score
 func (pi *peersIndex) IsBad(logger *zap.Logger, id peer.ID) bool {
+	if pi.reputation != nil && pi.reputation.IsPeerBanned(id) {
+		return true
+	}
+
 	if isBad, _ := pi.HasBadGossipScore(id); isBad {
 		return true
 	}
@@ -198,3 +206,56 @@ func (pi *peersIndex) GetGossipScore(peerID peer.ID) (float64, bool) {
 func (pi *peersIndex) HasBadGossipScore(peerID peer.ID) (bool, float64) {
 	return pi.gossipScoreIndex.HasBadGossipScore(peerID)
 }
+
+func (pi *peersIndex) Scores() map[peer.ID]float64 {
+	return pi.gossipScoreIndex.Scores()
+}
+
+func (pi *peersIndex) RestoreScore(peerID peer.ID, score float64, expiresAt time.Time) {
+	pi.gossipScoreIndex.RestoreScore(peerID, score, expiresAt)
+}
+
+// SaveReputation persists the current Gossip scores and scores of peers
+func (pi *peersIndex) SaveReputation() error {
+	if pi.reputation == nil {
+		return nil
+	}
+
+	reputations := make(map[peer.ID]*PeerReputation)
+	for id, score := range pi.gossipScoreIndex.Scores() {
+		gossipScore := score
+		reputations[id] = &PeerReputation{GossipScore: &gossipScore}
+	}
+	for id, scores := range pi.scoreIdx.all() {
+		if reputations[id] == nil {
+			reputations[id] = &PeerReputation{}
+		}
+		reputations[id].Scores = scores
+	}
+	return pi.reputation.SaveScores(reputations)
+}
+
+// RestoreReputation restores the Gossip scores and scores of peers persisted before a restart
+func (pi *peersIndex) RestoreReputation() error {
+	if pi.reputation == nil {
+		return nil
+	}
+
+	reputations, err := pi.reputation.LoadScores()
+	if err != nil {
+		return err
+	}
+	for id, reputation := range reputations {
+		if reputation.GossipScore != nil {
+			pi.gossipScoreIndex.RestoreScore(id, *reputation.GossipScore, reputation.ExpiresAt)
+		}
+		scores := make([]*NodeScore, len(reputation.Scores))
+		for i := range reputation.Scores {
+			scores[i] = &reputation.Scores[i]
+		}
+		if err := pi.scoreIdx.Score(id, scores...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
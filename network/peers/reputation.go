@@ -0,0 +1,258 @@
+package peers
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var (
+	banPrefix   = []byte("p2p/reputation/ban/")
+	scorePrefix = []byte("p2p/reputation/score/")
+)
+
+// Ban forbids connections with a peer or with an IP address until it expires.
+type Ban struct {
+	PeerID peer.ID `json:"peer_id,omitempty"`
+	IP     net.IP  `json:"ip,omitempty"`
+	Reason string  `json:"reason,omitempty"`
+	// BannedAt is when the ban was created.
+	BannedAt time.Time `json:"banned_at"`
+	// ExpiresAt is when the ban is lifted, nil if never.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Target returns the peer ID or the IP address which the ban applies to.
+func (b *Ban) Target() string {
+	if b.PeerID != "" {
+		return b.PeerID.String()
+	}
+	return b.IP.String()
+}
+
+func (b *Ban) expired(now time.Time) bool {
+	return b.ExpiresAt != nil && !now.Before(*b.ExpiresAt)
+}
+
+// PeerReputation is the persisted reputation of a peer.
+type PeerReputation struct {
+	// GossipScore is the last pubsub score of the peer, nil if it had none.
+	GossipScore *float64    `json:"gossip_score,omitempty"`
+	Scores      []NodeScore `json:"scores,omitempty"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+}
+
+// Reputation persists the bans and the scores of peers in the node DB,
+// so that they outlive restarts of the node.
+// Without a DB, bans only last until the node restarts.
+type Reputation struct {
+	logger *zap.Logger
+	db     basedb.Database
+	ttl    time.Duration
+
+	lock *sync.RWMutex
+	bans map[string]*Ban
+}
+
+// NewReputation creates a new Reputation and loads the bans which haven't expired from the DB.
+// Scores are remembered for ttl since they were last saved.
+func NewReputation(logger *zap.Logger, db basedb.Database, ttl time.Duration) (*Reputation, error) {
+	r := &Reputation{
+		logger: logger,
+		db:     db,
+		ttl:    ttl,
+		lock:   &sync.RWMutex{},
+		bans:   make(map[string]*Ban),
+	}
+	if db == nil {
+		return r, nil
+	}
+
+	now := time.Now()
+	var expired [][]byte
+	err := db.GetAll(banPrefix, func(i int, obj basedb.Obj) error {
+		ban := &Ban{}
+		if err := json.Unmarshal(obj.Value, ban); err != nil {
+			return errors.Wrap(err, "could not decode ban")
+		}
+		if ban.expired(now) {
+			expired = append(expired, obj.Key)
+			return nil
+		}
+		r.bans[ban.Target()] = ban
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load bans")
+	}
+	for _, key := range expired {
+		if err := db.Delete(banPrefix, key); err != nil {
+			return nil, errors.Wrap(err, "could not delete expired ban")
+		}
+	}
+	return r, nil
+}
+
+// Ban bans the peer or the IP address of the given ban, replacing any previous ban of it.
+func (r *Reputation) Ban(ban *Ban) error {
+	if (ban.PeerID == "") == (ban.IP == nil) {
+		return errors.New("ban must have either a peer ID or an IP address")
+	}
+	if ban.BannedAt.IsZero() {
+		ban.BannedAt = time.Now()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.db != nil {
+		raw, err := json.Marshal(ban)
+		if err != nil {
+			return errors.Wrap(err, "could not encode ban")
+		}
+		if err := r.db.Set(banPrefix, []byte(ban.Target()), raw); err != nil {
+			return errors.Wrap(err, "could not save ban")
+		}
+	}
+	r.bans[ban.Target()] = ban
+	return nil
+}
+
+// Unban lifts the ban of the given peer ID or IP address, and returns whether there was one.
+func (r *Reputation) Unban(target string) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.bans[target]; !ok {
+		return false, nil
+	}
+	if r.db != nil {
+		if err := r.db.Delete(banPrefix, []byte(target)); err != nil {
+			return false, errors.Wrap(err, "could not delete ban")
+		}
+	}
+	delete(r.bans, target)
+	return true, nil
+}
+
+// Bans returns the bans which haven't expired.
+func (r *Reputation) Bans() []*Ban {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	now := time.Now()
+	bans := make([]*Ban, 0, len(r.bans))
+	for _, ban := range r.bans {
+		if !ban.expired(now) {
+			bans = append(bans, ban)
+		}
+	}
+	return bans
+}
+
+// PruneBans deletes the bans which expired, and returns how many were deleted.
+func (r *Reputation) PruneBans() (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	var deleted int
+	for target, ban := range r.bans {
+		if !ban.expired(now) {
+			continue
+		}
+		if r.db != nil {
+			if err := r.db.Delete(banPrefix, []byte(target)); err != nil {
+				return deleted, errors.Wrap(err, "could not delete expired ban")
+			}
+		}
+		delete(r.bans, target)
+		deleted++
+	}
+	return deleted, nil
+}
+
+// IsPeerBanned returns whether the given peer is banned.
+func (r *Reputation) IsPeerBanned(id peer.ID) bool {
+	return r.banned(id.String())
+}
+
+// IsIPBanned returns whether the given IP address is banned.
+func (r *Reputation) IsIPBanned(ip net.IP) bool {
+	return r.banned(ip.String())
+}
+
+func (r *Reputation) banned(target string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	ban, ok := r.bans[target]
+	return ok && !ban.expired(time.Now())
+}
+
+// SaveScores persists the given reputations of peers until the TTL passes, replacing their previous ones.
+func (r *Reputation) SaveScores(reputations map[peer.ID]*PeerReputation) error {
+	if r.db == nil || len(reputations) == 0 {
+		return nil
+	}
+
+	expiresAt := time.Now().Add(r.ttl)
+	ids := make([]peer.ID, 0, len(reputations))
+	for id, reputation := range reputations {
+		reputation.ExpiresAt = expiresAt
+		ids = append(ids, id)
+	}
+	err := r.db.SetMany(scorePrefix, len(ids), func(i int) (basedb.Obj, error) {
+		raw, err := json.Marshal(reputations[ids[i]])
+		if err != nil {
+			return basedb.Obj{}, errors.Wrap(err, "could not encode peer reputation")
+		}
+		return basedb.Obj{Key: []byte(ids[i]), Value: raw}, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not save peer reputations")
+	}
+	return nil
+}
+
+// LoadScores returns the persisted reputations of peers which haven't expired yet, and deletes the expired ones.
+func (r *Reputation) LoadScores() (map[peer.ID]*PeerReputation, error) {
+	reputations := make(map[peer.ID]*PeerReputation)
+	if r.db == nil {
+		return reputations, nil
+	}
+
+	now := time.Now()
+	var expired [][]byte
+	err := r.db.GetAll(scorePrefix, func(i int, obj basedb.Obj) error {
+		reputation := &PeerReputation{}
+		if err := json.Unmarshal(obj.Value, reputation); err != nil {
+			return errors.Wrap(err, "could not decode peer reputation")
+		}
+		if !now.Before(reputation.ExpiresAt) {
+			expired = append(expired, obj.Key)
+			return nil
+		}
+		reputations[peer.ID(obj.Key)] = reputation
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load peer reputations")
+	}
+	for _, key := range expired {
+		if err := r.db.Delete(scorePrefix, key); err != nil {
+			return nil, errors.Wrap(err, "could not delete expired peer reputation")
+		}
+	}
+	if len(expired) > 0 {
+		r.logger.Debug("deleted expired peer reputations", zap.Int("count", len(expired)))
+	}
+	return reputations, nil
+}
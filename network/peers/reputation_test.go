@@ -0,0 +1,126 @@
+package peers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/network/records"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestReputation(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	reputation, err := NewReputation(logger, db, time.Hour)
+	require.NoError(t, err)
+
+	bannedPeer, otherPeer := test.RandPeerIDFatal(t), test.RandPeerIDFatal(t)
+	bannedIP := net.ParseIP("10.0.0.1")
+	past := time.Now().Add(-time.Minute)
+
+	require.Error(t, reputation.Ban(&Ban{}))
+	require.Error(t, reputation.Ban(&Ban{PeerID: bannedPeer, IP: bannedIP}))
+	require.NoError(t, reputation.Ban(&Ban{PeerID: bannedPeer, Reason: "spam"}))
+	require.NoError(t, reputation.Ban(&Ban{IP: bannedIP}))
+	require.NoError(t, reputation.Ban(&Ban{PeerID: otherPeer, ExpiresAt: &past}))
+
+	require.True(t, reputation.IsPeerBanned(bannedPeer))
+	require.False(t, reputation.IsPeerBanned(otherPeer))
+	require.True(t, reputation.IsIPBanned(bannedIP))
+	require.True(t, reputation.IsIPBanned(bannedIP.To16()))
+	require.False(t, reputation.IsIPBanned(net.ParseIP("10.0.0.2")))
+	require.Len(t, reputation.Bans(), 2)
+
+	// Expired bans are deleted by pruning.
+	deleted, err := reputation.PruneBans()
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	deleted, err = reputation.PruneBans()
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	gossipScore := -5000.0
+	require.NoError(t, reputation.SaveScores(map[peer.ID]*PeerReputation{
+		bannedPeer: {GossipScore: &gossipScore},
+		otherPeer:  {Scores: []NodeScore{{Name: "validation", Value: 1}}},
+	}))
+
+	// Bans and scores are loaded after a restart, while the expired ones are deleted.
+	reputation, err = NewReputation(logger, db, time.Hour)
+	require.NoError(t, err)
+	require.True(t, reputation.IsPeerBanned(bannedPeer))
+	require.True(t, reputation.IsIPBanned(bannedIP))
+	require.Len(t, reputation.Bans(), 2)
+
+	reputations, err := reputation.LoadScores()
+	require.NoError(t, err)
+	require.Len(t, reputations, 2)
+	require.Equal(t, gossipScore, *reputations[bannedPeer].GossipScore)
+	require.Equal(t, []NodeScore{{Name: "validation", Value: 1}}, reputations[otherPeer].Scores)
+
+	found, err := reputation.Unban(bannedIP.String())
+	require.NoError(t, err)
+	require.True(t, found)
+	found, err = reputation.Unban(bannedIP.String())
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Scores which are saved again expire the TTL after.
+	reputation, err = NewReputation(logger, db, -time.Hour)
+	require.NoError(t, err)
+	require.False(t, reputation.IsIPBanned(bannedIP))
+	require.NoError(t, reputation.SaveScores(map[peer.ID]*PeerReputation{bannedPeer: {GossipScore: &gossipScore}}))
+	reputations, err = reputation.LoadScores()
+	require.NoError(t, err)
+	require.Len(t, reputations, 1)
+	require.Contains(t, reputations, otherPeer)
+}
+
+func TestRestoreReputation(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	badPeer, goodPeer := test.RandPeerIDFatal(t), test.RandPeerIDFatal(t)
+	newIndex := func() *peersIndex {
+		reputation, err := NewReputation(logger, db, time.Hour)
+		require.NoError(t, err)
+		return NewPeersIndex(logger, nil, records.NewNodeInfo("0x00000000"), nil, nil, 0, 0, NewGossipScoreIndex(), reputation)
+	}
+
+	index := newIndex()
+	index.SetScores(map[peer.ID]float64{badPeer: -20000, goodPeer: 10})
+	require.NoError(t, index.Score(goodPeer, &NodeScore{Name: "validation", Value: 3}))
+	require.NoError(t, index.SaveReputation())
+
+	// After a restart, the bad peer is still bad until pubsub scores it again.
+	index = newIndex()
+	isBad, _ := index.HasBadGossipScore(badPeer)
+	require.False(t, isBad)
+	require.NoError(t, index.RestoreReputation())
+	isBad, score := index.HasBadGossipScore(badPeer)
+	require.True(t, isBad)
+	require.Equal(t, -20000.0, score)
+	isBad, _ = index.HasBadGossipScore(goodPeer)
+	require.False(t, isBad)
+	require.Equal(t, map[peer.ID][]NodeScore{goodPeer: {{Name: "validation", Value: 3}}}, index.scoreIdx.all())
+
+	index.SetScores(map[peer.ID]float64{badPeer: 0})
+	isBad, _ = index.HasBadGossipScore(badPeer)
+	require.False(t, isBad)
+
+	// Banned peers are bad regardless of their scores.
+	require.NoError(t, index.reputation.Ban(&Ban{PeerID: goodPeer}))
+	require.True(t, index.IsBad(logger, goodPeer))
+}
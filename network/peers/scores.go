@@ -13,7 +13,7 @@ type scoresIndex struct {
 	lock   *sync.RWMutex
 }
 
-func newScoreIndex() ScoreIndex {
+func newScoreIndex() *scoresIndex {
 	return &scoresIndex{
 		scores: map[peer.ID][]*NodeScore{},
 		lock:   &sync.RWMutex{},
@@ -64,6 +64,20 @@ wantedScoresLoop:
 	return scores, nil
 }
 
+// all returns the scores of all peers
+func (s *scoresIndex) all() map[peer.ID][]NodeScore {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	all := make(map[peer.ID][]NodeScore, len(s.scores))
+	for id, peerScores := range s.scores {
+		for _, score := range peerScores {
+			all[id] = append(all[id], *score)
+		}
+	}
+	return all
+}
+
 // GetTopScores accepts a map of scores and returns the best n peers
 func GetTopScores(peerScores map[peer.ID]PeerScore, n int) map[peer.ID]PeerScore {
 	pl := make(peerScoresList, len(peerScores))
@@ -0,0 +1,83 @@
+package simulator
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a virtual clock, which only moves when the simulation advances it.
+// It implements roundtimer.Clock, so that the round timers of nodes follow it.
+type Clock struct {
+	lock   sync.RWMutex
+	now    time.Time
+	timers []*clockTimer
+}
+
+// clockTimer is a pending timer of a Clock.
+type clockTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+// NewClock creates a virtual clock which starts at the given time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.now
+}
+
+// After returns a channel which receives the virtual time once the clock has advanced by the given duration.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	timer := &clockTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.c <- c.now
+		return timer.c
+	}
+	c.timers = append(c.timers, timer)
+	return timer.c
+}
+
+// nextTimer returns the time of the earliest pending timer, if there is any.
+func (c *Clock) nextTimer() (time.Time, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var next time.Time
+	for _, timer := range c.timers {
+		if next.IsZero() || timer.at.Before(next) {
+			next = timer.at
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// advanceTo moves the clock forward to the given time, unless it's already past it,
+// and fires the timers which are due by then.
+func (c *Clock) advanceTo(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if t.After(c.now) {
+		c.now = t
+	}
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- c.now
+	}
+	clear(c.timers[len(pending):])
+	c.timers = pending
+}
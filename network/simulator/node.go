@@ -0,0 +1,379 @@
+package simulator
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pspb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/message/validation"
+	"github.com/ssvlabs/ssv/network"
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/network/records"
+	protocolp2p "github.com/ssvlabs/ssv/protocol/v2/p2p"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+)
+
+// ValidationStats counts the validation results of the messages delivered to a node.
+type ValidationStats struct {
+	Accepted int
+	Ignored  int
+	Rejected int
+}
+
+// Node is a simulated SSV node, which implements network.P2PNetwork on top of the simulated network.
+type Node struct {
+	sim        *Simulator
+	logger     *zap.Logger
+	operatorID spectypes.OperatorID
+	peerID     peer.ID
+
+	lock         sync.RWMutex
+	ctx          context.Context
+	cancel       context.CancelFunc
+	router       network.MessageRouter
+	validator    validation.MessageValidator
+	topics       map[string]struct{}
+	fixedSubnets records.Subnets
+	seen         map[[32]byte]struct{}
+	validation   ValidationStats
+	reported     map[protocolp2p.MsgValidationResult]int
+}
+
+var _ network.P2PNetwork = (*Node)(nil)
+
+func newNode(sim *Simulator, operatorID spectypes.OperatorID) *Node {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Node{
+		sim:          sim,
+		logger:       sim.logger.With(fields.OperatorID(operatorID)),
+		operatorID:   operatorID,
+		peerID:       peer.ID(fmt.Sprintf("simulated-operator-%d", operatorID)),
+		ctx:          ctx,
+		cancel:       cancel,
+		topics:       make(map[string]struct{}),
+		fixedSubnets: make(records.Subnets, commons.SubnetsCount),
+		seen:         make(map[[32]byte]struct{}),
+		reported:     make(map[protocolp2p.MsgValidationResult]int),
+	}
+}
+
+// OperatorID returns the ID of the operator which runs the node.
+func (n *Node) OperatorID() spectypes.OperatorID {
+	return n.operatorID
+}
+
+// PeerID returns the peer ID which the node's messages are received from.
+func (n *Node) PeerID() peer.ID {
+	return n.peerID
+}
+
+// UseMessageValidator makes the node validate the messages it receives before routing them,
+// as pubsub would. Without one, messages are only decoded.
+func (n *Node) UseMessageValidator(validator validation.MessageValidator) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.validator = validator
+}
+
+// ValidationStats returns the validation results of the messages delivered to the node.
+func (n *Node) ValidationStats() ValidationStats {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.validation
+}
+
+// Reported returns how many messages were reported with the given validation result.
+func (n *Node) Reported(result protocolp2p.MsgValidationResult) int {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return n.reported[result]
+}
+
+// Setup implements network.P2PNetwork.
+func (n *Node) Setup(*zap.Logger) error {
+	return nil
+}
+
+// Start implements network.P2PNetwork.
+func (n *Node) Start(*zap.Logger) error {
+	return nil
+}
+
+// Close disconnects the node, so that it neither sends nor receives messages.
+func (n *Node) Close() error {
+	n.cancel()
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.topics = make(map[string]struct{})
+	return nil
+}
+
+// UseMessageRouter implements network.MessageRouting.
+func (n *Node) UseMessageRouter(router network.MessageRouter) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.router = router
+}
+
+// Broadcast sends the message to the nodes which are subscribed to the topic of its committee.
+func (n *Node) Broadcast(msgID spectypes.MessageID, msg *spectypes.SignedSSVMessage) error {
+	if n.ctx.Err() != nil {
+		return protocolp2p.ErrNetworkIsNotReady
+	}
+
+	topics, err := n.messageTopics(msgID)
+	if err != nil {
+		return err
+	}
+	for _, topic := range topics {
+		if err := n.sim.broadcast(n, topic, msg); err != nil {
+			return fmt.Errorf("could not broadcast msg: %w", err)
+		}
+	}
+	return nil
+}
+
+func (n *Node) messageTopics(msgID spectypes.MessageID) ([]string, error) {
+	var topics []string
+	if msgID.GetRoleType() == spectypes.RoleCommittee {
+		topics = commons.CommitteeTopicID(spectypes.CommitteeID(msgID.GetDutyExecutorID()[16:]))
+	} else {
+		if n.sim.cfg.Validators == nil {
+			return nil, fmt.Errorf("validators aren't configured")
+		}
+		share, exists := n.sim.cfg.Validators.Validator(msgID.GetDutyExecutorID())
+		if !exists {
+			return nil, fmt.Errorf("could not find share for validator %s", hex.EncodeToString(msgID.GetDutyExecutorID()))
+		}
+		topics = commons.CommitteeTopicID(share.CommitteeID())
+	}
+
+	fullNames := make([]string, len(topics))
+	for i, topic := range topics {
+		fullNames[i] = commons.GetTopicFullName(topic)
+	}
+	return fullNames, nil
+}
+
+// Subscribe subscribes to the topic of the validator's committee.
+func (n *Node) Subscribe(pk spectypes.ValidatorPK) error {
+	if n.sim.cfg.Validators == nil {
+		return fmt.Errorf("validators aren't configured")
+	}
+	share, exists := n.sim.cfg.Validators.Validator(pk[:])
+	if !exists {
+		return fmt.Errorf("could not find share for validator %s", hex.EncodeToString(pk[:]))
+	}
+	for _, topic := range commons.CommitteeTopicID(share.CommitteeID()) {
+		n.subscribe(commons.GetTopicFullName(topic))
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the topic of the validator's committee.
+func (n *Node) Unsubscribe(_ *zap.Logger, pk spectypes.ValidatorPK) error {
+	if n.sim.cfg.Validators == nil {
+		return fmt.Errorf("validators aren't configured")
+	}
+	share, exists := n.sim.cfg.Validators.Validator(pk[:])
+	if !exists {
+		return fmt.Errorf("could not find share for validator %s", hex.EncodeToString(pk[:]))
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for _, topic := range commons.CommitteeTopicID(share.CommitteeID()) {
+		delete(n.topics, commons.GetTopicFullName(topic))
+	}
+	return nil
+}
+
+// SubscribeAll subscribes to all subnets.
+func (n *Node) SubscribeAll(*zap.Logger) error {
+	n.lock.Lock()
+	for subnet := range n.fixedSubnets {
+		n.fixedSubnets[subnet] = 1
+	}
+	n.lock.Unlock()
+
+	for subnet := uint64(0); subnet < commons.SubnetsCount; subnet++ {
+		n.subscribe(commons.GetTopicFullName(commons.SubnetTopicID(subnet)))
+	}
+	return nil
+}
+
+// SubscribeRandoms subscribes to random subnets, which are determined by the seed and the operator.
+func (n *Node) SubscribeRandoms(_ *zap.Logger, numSubnets int) error {
+	// #nosec G404 -- determinism is the point
+	r := rand.New(rand.NewSource(n.sim.cfg.Seed + int64(n.operatorID)))
+	subnets := r.Perm(commons.Subnets())[:min(numSubnets, commons.Subnets())]
+
+	n.lock.Lock()
+	for _, subnet := range subnets {
+		n.fixedSubnets[subnet] = 1
+	}
+	n.lock.Unlock()
+
+	for _, subnet := range subnets {
+		n.subscribe(commons.GetTopicFullName(commons.SubnetTopicID(uint64(subnet)))) // #nosec G115
+	}
+	return nil
+}
+
+func (n *Node) subscribe(topic string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.ctx.Err() != nil {
+		return
+	}
+	n.topics[topic] = struct{}{}
+}
+
+func (n *Node) subscribed(topic string) bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	_, ok := n.topics[topic]
+	return ok
+}
+
+// UpdateSubnets implements network.P2PNetwork. Subscriptions are already up-to-date.
+func (n *Node) UpdateSubnets(*zap.Logger) {}
+
+// UpdateScoreParams implements network.P2PNetwork. There is no peer scoring.
+func (n *Node) UpdateScoreParams(*zap.Logger) {}
+
+// ActiveSubnets returns the subnets which the node is subscribed to.
+func (n *Node) ActiveSubnets() records.Subnets {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	subnets := make(records.Subnets, commons.SubnetsCount)
+	for topic := range n.topics {
+		subnet, err := strconv.ParseUint(commons.GetTopicBaseName(topic), 10, 64)
+		if err == nil && subnet < commons.SubnetsCount {
+			subnets[subnet] = 1
+		}
+	}
+	return subnets
+}
+
+// FixedSubnets returns the subnets which the node subscribed to regardless of its validators.
+func (n *Node) FixedSubnets() records.Subnets {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return append(records.Subnets(nil), n.fixedSubnets...)
+}
+
+// ReportValidation counts the reported validation results.
+func (n *Node) ReportValidation(_ *zap.Logger, _ *spectypes.SSVMessage, res protocolp2p.MsgValidationResult) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.reported[res]++
+}
+
+// PeersByTopic returns the peers of the other nodes, by the topics they share with this node.
+func (n *Node) PeersByTopic() ([]peer.ID, map[string][]peer.ID) {
+	n.sim.lock.Lock()
+	nodes := n.sim.sortedNodes()
+	n.sim.lock.Unlock()
+
+	n.lock.RLock()
+	topics := make([]string, 0, len(n.topics))
+	for topic := range n.topics {
+		topics = append(topics, topic)
+	}
+	n.lock.RUnlock()
+	sort.Strings(topics)
+
+	var all []peer.ID
+	byTopic := make(map[string][]peer.ID, len(topics))
+	for _, node := range nodes {
+		if node == n {
+			continue
+		}
+		all = append(all, node.peerID)
+		for _, topic := range topics {
+			if node.subscribed(topic) {
+				byTopic[topic] = append(byTopic[topic], node.peerID)
+			}
+		}
+	}
+	return all, byTopic
+}
+
+// deliver validates and routes a message, unless the node has already seen it.
+// It returns whether the message was routed.
+func (n *Node) deliver(d *delivery) bool {
+	n.lock.Lock()
+	if _, seen := n.seen[d.digest]; seen || n.ctx.Err() != nil {
+		n.lock.Unlock()
+		return false
+	}
+	n.seen[d.digest] = struct{}{}
+	router, validator := n.router, n.validator
+	n.lock.Unlock()
+
+	topic := d.topic
+	pmsg := &pubsub.Message{
+		Message:      &pspb.Message{Data: d.data, Topic: &topic},
+		ReceivedFrom: d.from.peerID,
+	}
+
+	if validator != nil {
+		result := validator.Validate(n.ctx, d.from.peerID, pmsg)
+		n.lock.Lock()
+		switch result {
+		case pubsub.ValidationAccept:
+			n.validation.Accepted++
+		case pubsub.ValidationIgnore:
+			n.validation.Ignored++
+		default:
+			n.validation.Rejected++
+		}
+		n.lock.Unlock()
+		if result != pubsub.ValidationAccept {
+			return false
+		}
+	} else {
+		signedMsg := &spectypes.SignedSSVMessage{}
+		if err := signedMsg.Decode(d.data); err != nil {
+			n.logger.Debug("could not decode message", zap.Error(err))
+			return false
+		}
+		decoded, err := queue.DecodeSignedSSVMessage(signedMsg)
+		if err != nil {
+			n.logger.Debug("could not decode message", zap.Error(err))
+			return false
+		}
+		pmsg.ValidatorData = decoded
+	}
+
+	decoded, ok := pmsg.ValidatorData.(*queue.SSVMessage)
+	if !ok || router == nil {
+		return false
+	}
+	router.Route(n.ctx, decoded)
+	return true
+}
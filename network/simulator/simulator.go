@@ -0,0 +1,453 @@
+// Package simulator provides an in-memory network of SSV nodes, which delivers messages
+// on a virtual clock with configurable latency, message drops, partitions and Byzantine senders.
+//
+// The schedule of deliveries is deterministic given the seed: the latency and the fate of every message
+// derive from its content and its endpoints, rather than from the order in which nodes happen to broadcast.
+// Nodes whose round timers follow the network's Clock (see roundtimer.WithClock) time out in virtual time,
+// which skips ahead to their timeouts once they're idle, rather than waiting for them.
+// The nodes themselves still process messages concurrently.
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+const (
+	defaultLatency = 50 * time.Millisecond
+	defaultSettle  = 5 * time.Millisecond
+	defaultIdle    = 50 * time.Millisecond
+)
+
+// ValidatorProvider looks up the shares of validators, to find the topics of their messages.
+type ValidatorProvider interface {
+	Validator(pubKey []byte) (*types.SSVShare, bool)
+}
+
+// Byzantine tampers with the messages a node broadcasts, separately for each recipient.
+// It returns the message to deliver instead, or nil to withhold it.
+type Byzantine func(to spectypes.OperatorID, msg *spectypes.SignedSSVMessage) *spectypes.SignedSSVMessage
+
+// Silent is a Byzantine behavior which withholds every message.
+func Silent(spectypes.OperatorID, *spectypes.SignedSSVMessage) *spectypes.SignedSSVMessage {
+	return nil
+}
+
+// Config is the configuration of a simulated network.
+type Config struct {
+	Logger *zap.Logger
+	// Seed determines the latency jitter and the dropped messages.
+	Seed int64
+	// Start is the initial time of the virtual clock, defaults to now.
+	Start time.Time
+	// Latency is the one-way latency between every two nodes, defaults to 50ms.
+	Latency time.Duration
+	// Jitter is the maximum random latency which is added to every message.
+	Jitter time.Duration
+	// DropRate is the probability of a message to be lost on the way to each of its recipients.
+	DropRate float64
+	// Settle is how long to wait for nodes to process the previous deliveries before the next ones, defaults to 5ms.
+	Settle time.Duration
+	// Idle is how long nodes have to go without broadcasting before the clock skips ahead to the next timer,
+	// defaults to 50ms.
+	Idle time.Duration
+	// Validators finds the topics of messages which aren't committee messages.
+	Validators ValidatorProvider
+}
+
+// Stats counts what happened to the messages in the simulated network.
+type Stats struct {
+	Broadcasts int
+	Delivered  int
+	Dropped    int
+	Withheld   int
+}
+
+type link struct {
+	from, to spectypes.OperatorID
+}
+
+// Simulator is an in-memory network of SSV nodes.
+type Simulator struct {
+	logger *zap.Logger
+	cfg    Config
+	clock  *Clock
+
+	lock      sync.Mutex
+	nodes     map[spectypes.OperatorID]*Node
+	queue     deliveryQueue
+	seq       uint64
+	latency   map[link]time.Duration
+	dropRate  float64
+	partition map[spectypes.OperatorID]int
+	byzantine map[spectypes.OperatorID]Byzantine
+	stats     Stats
+}
+
+// New creates a simulated network without nodes.
+func New(cfg Config) *Simulator {
+	if cfg.Logger == nil {
+		cfg.Logger = zap.NewNop()
+	}
+	if cfg.Start.IsZero() {
+		cfg.Start = time.Now()
+	}
+	if cfg.Latency == 0 {
+		cfg.Latency = defaultLatency
+	}
+	if cfg.Settle == 0 {
+		cfg.Settle = defaultSettle
+	}
+	if cfg.Idle == 0 {
+		cfg.Idle = defaultIdle
+	}
+	return &Simulator{
+		logger:    cfg.Logger.Named("simulator"),
+		cfg:       cfg,
+		clock:     NewClock(cfg.Start),
+		nodes:     make(map[spectypes.OperatorID]*Node),
+		latency:   make(map[link]time.Duration),
+		dropRate:  cfg.DropRate,
+		partition: make(map[spectypes.OperatorID]int),
+		byzantine: make(map[spectypes.OperatorID]Byzantine),
+	}
+}
+
+// Clock returns the virtual clock of the network.
+func (s *Simulator) Clock() *Clock {
+	return s.clock
+}
+
+// AddNode adds a node for the given operator, or returns the existing one.
+func (s *Simulator) AddNode(operatorID spectypes.OperatorID) *Node {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if node, ok := s.nodes[operatorID]; ok {
+		return node
+	}
+	node := newNode(s, operatorID)
+	s.nodes[operatorID] = node
+	return node
+}
+
+// Node returns the node of the given operator, or nil if there is none.
+func (s *Simulator) Node(operatorID spectypes.OperatorID) *Node {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.nodes[operatorID]
+}
+
+// SetLatency overrides the one-way latency of messages from one node to another.
+func (s *Simulator) SetLatency(from, to spectypes.OperatorID, latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.latency[link{from: from, to: to}] = latency
+}
+
+// SetDropRate sets the probability of a message to be lost on the way to each of its recipients.
+func (s *Simulator) SetDropRate(rate float64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.dropRate = rate
+}
+
+// Partition splits the network, so that messages are only delivered between nodes of the same group.
+// Nodes which aren't in any of the groups form another group together.
+func (s *Simulator) Partition(groups ...[]spectypes.OperatorID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.partition = make(map[spectypes.OperatorID]int)
+	for i, group := range groups {
+		for _, operatorID := range group {
+			s.partition[operatorID] = i + 1
+		}
+	}
+}
+
+// Heal removes the partitions of the network. Messages which were lost to them aren't recovered.
+func (s *Simulator) Heal() {
+	s.Partition()
+}
+
+// SetByzantine makes a node tamper with the messages it broadcasts, or behave honestly again if it's nil.
+func (s *Simulator) SetByzantine(operatorID spectypes.OperatorID, byzantine Byzantine) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if byzantine == nil {
+		delete(s.byzantine, operatorID)
+		return
+	}
+	s.byzantine[operatorID] = byzantine
+}
+
+// Stats returns what happened to the messages so far.
+func (s *Simulator) Stats() Stats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.stats
+}
+
+// Inject broadcasts a message on a topic as if the given node sent it, regardless of its Byzantine behavior.
+// It's meant for messages which nodes wouldn't send themselves, such as forged ones.
+func (s *Simulator) Inject(from spectypes.OperatorID, topic string, msg *spectypes.SignedSSVMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	node, ok := s.nodes[from]
+	if !ok {
+		return fmt.Errorf("unknown node %d", from)
+	}
+	return s.publish(node, topic, msg, nil)
+}
+
+// broadcast schedules the delivery of a message to the nodes which are subscribed to its topic.
+func (s *Simulator) broadcast(from *Node, topic string, msg *spectypes.SignedSSVMessage) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.publish(from, topic, msg, s.byzantine[from.operatorID])
+}
+
+func (s *Simulator) publish(from *Node, topic string, msg *spectypes.SignedSSVMessage, byzantine Byzantine) error {
+	encoded, err := msg.Encode()
+	if err != nil {
+		return fmt.Errorf("could not encode message: %w", err)
+	}
+	s.stats.Broadcasts++
+
+	now := s.clock.Now()
+	for _, to := range s.sortedNodes() {
+		if !to.subscribed(topic) {
+			continue
+		}
+
+		// Nodes receive their own messages right away, as they would from pubsub.
+		if to == from {
+			s.schedule(now, from, to, topic, encoded)
+			continue
+		}
+
+		data := encoded
+		if byzantine != nil {
+			tampered := byzantine(to.operatorID, msg)
+			if tampered == nil {
+				s.stats.Withheld++
+				continue
+			}
+			if data, err = tampered.Encode(); err != nil {
+				return fmt.Errorf("could not encode tampered message: %w", err)
+			}
+		}
+
+		digest := sha256.Sum256(data)
+		if s.partition[from.operatorID] != s.partition[to.operatorID] || s.random("drop", digest, from, to) < s.dropRate {
+			s.stats.Dropped++
+			continue
+		}
+
+		latency, ok := s.latency[link{from: from.operatorID, to: to.operatorID}]
+		if !ok {
+			latency = s.cfg.Latency
+		}
+		latency += time.Duration(s.random("jitter", digest, from, to) * float64(s.cfg.Jitter))
+		s.schedule(now.Add(latency), from, to, topic, data)
+	}
+	return nil
+}
+
+func (s *Simulator) schedule(at time.Time, from, to *Node, topic string, data []byte) {
+	s.seq++
+	heap.Push(&s.queue, &delivery{
+		at:     at,
+		from:   from,
+		to:     to,
+		topic:  topic,
+		data:   data,
+		digest: sha256.Sum256(data),
+		seq:    s.seq,
+	})
+}
+
+// random returns a number in [0, 1) which is determined by the seed, the message and its endpoints.
+func (s *Simulator) random(purpose string, digest [32]byte, from, to *Node) float64 {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, s.cfg.Seed)
+	h.Write([]byte(purpose))
+	h.Write(digest[:])
+	_ = binary.Write(h, binary.BigEndian, from.operatorID)
+	_ = binary.Write(h, binary.BigEndian, to.operatorID)
+	return float64(binary.BigEndian.Uint64(h.Sum(nil))>>11) / (1 << 53)
+}
+
+func (s *Simulator) sortedNodes() []*Node {
+	nodes := make([]*Node, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b *Node) int {
+		return int(a.operatorID) - int(b.operatorID)
+	})
+	return nodes
+}
+
+// Pending returns the number of messages in flight.
+func (s *Simulator) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.queue.Len()
+}
+
+// Step waits for the nodes to settle, then advances the clock to the earliest message in flight or timer,
+// and delivers the messages or fires the timers which are due by then.
+// It returns false if there are neither messages in flight nor timers.
+func (s *Simulator) Step() bool {
+	return s.step(time.Time{})
+}
+
+// Advance moves the clock forward by the given duration, delivering the messages and firing the timers
+// which are due on the way.
+func (s *Simulator) Advance(d time.Duration) {
+	until := s.clock.Now().Add(d)
+	for s.step(until) {
+	}
+	s.clock.advanceTo(until)
+}
+
+// RunUntil delivers messages and fires timers until the condition is met, or fails when the context is done.
+func (s *Simulator) RunUntil(ctx context.Context, condition func() bool) error {
+	for !condition() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("condition wasn't met: %w", err)
+		}
+		s.Step()
+	}
+	return nil
+}
+
+// step advances the clock to the next message in flight or timer, unless it's after until, if given.
+// Timers only fire once no node has broadcast for the idle period, since nodes may still be processing
+// the messages which were delivered to them, and a timeout would preempt the messages they're about to send.
+func (s *Simulator) step(until time.Time) bool {
+	broadcasts := -1
+	var quietSince time.Time
+	for {
+		time.Sleep(s.cfg.Settle)
+
+		s.lock.Lock()
+		if s.stats.Broadcasts != broadcasts {
+			broadcasts = s.stats.Broadcasts
+			quietSince = time.Now()
+		}
+
+		timerAt, hasTimer := s.clock.nextTimer()
+		if s.queue.Len() > 0 && (!hasTimer || !s.queue[0].at.After(timerAt)) {
+			at := s.queue[0].at
+			if !until.IsZero() && at.After(until) {
+				s.lock.Unlock()
+				return false
+			}
+			s.clock.advanceTo(at)
+			due := s.popDue(at)
+			s.lock.Unlock()
+
+			s.deliver(due)
+			return true
+		}
+
+		if !hasTimer || !until.IsZero() && timerAt.After(until) {
+			s.lock.Unlock()
+			return false
+		}
+		if time.Since(quietSince) < s.cfg.Idle {
+			s.lock.Unlock()
+			continue
+		}
+		s.clock.advanceTo(timerAt)
+		s.lock.Unlock()
+		return true
+	}
+}
+
+func (s *Simulator) popDue(at time.Time) []*delivery {
+	var due []*delivery
+	for s.queue.Len() > 0 && !s.queue[0].at.After(at) {
+		due = append(due, heap.Pop(&s.queue).(*delivery))
+	}
+	return due
+}
+
+func (s *Simulator) deliver(deliveries []*delivery) {
+	for _, d := range deliveries {
+		if d.to.deliver(d) {
+			s.lock.Lock()
+			s.stats.Delivered++
+			s.lock.Unlock()
+		}
+	}
+}
+
+// delivery is a message in flight to a node.
+type delivery struct {
+	at     time.Time
+	from   *Node
+	to     *Node
+	topic  string
+	data   []byte
+	digest [32]byte
+	seq    uint64
+}
+
+// deliveryQueue orders deliveries by time, then by their endpoints and content,
+// so that concurrent broadcasts are delivered in the same order regardless of which came first.
+type deliveryQueue []*delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+
+func (q deliveryQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if !a.at.Equal(b.at) {
+		return a.at.Before(b.at)
+	}
+	if a.from.operatorID != b.from.operatorID {
+		return a.from.operatorID < b.from.operatorID
+	}
+	if a.to.operatorID != b.to.operatorID {
+		return a.to.operatorID < b.to.operatorID
+	}
+	if c := slices.Compare(a.digest[:], b.digest[:]); c != 0 {
+		return c < 0
+	}
+	return a.seq < b.seq
+}
+
+func (q deliveryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deliveryQueue) Push(x any) { *q = append(*q, x.(*delivery)) }
+
+func (q *deliveryQueue) Pop() any {
+	old := *q
+	n := len(old)
+	d := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return d
+}
@@ -0,0 +1,232 @@
+package simulator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/network"
+	"github.com/ssvlabs/ssv/network/commons"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+type received struct {
+	at   time.Time
+	from spectypes.OperatorID
+	data string
+}
+
+type testRouter struct {
+	clock *Clock
+	lock  sync.Mutex
+	msgs  []received
+}
+
+func (r *testRouter) Route(_ context.Context, message network.DecodedSSVMessage) {
+	msg := message.(*queue.SSVMessage)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.msgs = append(r.msgs, received{at: r.clock.Now(), from: msg.SignedSSVMessage.OperatorIDs[0], data: string(msg.Body.(*types.EventMsg).Data)})
+}
+
+func (r *testRouter) received() []received {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]received(nil), r.msgs...)
+}
+
+var testCommitteeID = spectypes.CommitteeID{1, 2, 3}
+
+func newTestNetwork(t *testing.T, cfg Config, operators int) (*Simulator, map[spectypes.OperatorID]*testRouter) {
+	cfg.Settle = time.Microsecond
+	cfg.Idle = time.Millisecond
+	sim := New(cfg)
+	routers := make(map[spectypes.OperatorID]*testRouter)
+	topic := commons.GetTopicFullName(commons.CommitteeTopicID(testCommitteeID)[0])
+	for id := spectypes.OperatorID(1); id <= spectypes.OperatorID(operators); id++ {
+		node := sim.AddNode(id)
+		routers[id] = &testRouter{clock: sim.Clock()}
+		node.UseMessageRouter(routers[id])
+		node.subscribe(topic)
+	}
+	return sim, routers
+}
+
+func testMessage(t *testing.T, from spectypes.OperatorID, data string) (spectypes.MessageID, *spectypes.SignedSSVMessage) {
+	msgID := spectypes.NewMsgID(networkconfig.TestNetwork.DomainType, testCommitteeID[:], spectypes.RoleCommittee)
+	event, err := (&types.EventMsg{Type: types.Timeout, Data: []byte(data)}).Encode()
+	require.NoError(t, err)
+	return msgID, &spectypes.SignedSSVMessage{
+		Signatures:  [][]byte{make([]byte, 256)},
+		OperatorIDs: []spectypes.OperatorID{from},
+		SSVMessage: &spectypes.SSVMessage{
+			MsgType: message.SSVEventMsgType,
+			MsgID:   msgID,
+			Data:    event,
+		},
+	}
+}
+
+func broadcast(t *testing.T, sim *Simulator, from spectypes.OperatorID, data string) {
+	msgID, msg := testMessage(t, from, data)
+	require.NoError(t, sim.Node(from).Broadcast(msgID, msg))
+}
+
+func TestSimulatorLatency(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	sim, routers := newTestNetwork(t, Config{Start: start, Latency: 100 * time.Millisecond}, 4)
+	sim.SetLatency(1, 4, time.Second)
+
+	broadcast(t, sim, 1, "hello")
+	require.Equal(t, 4, sim.Pending())
+
+	// The sender receives its own message right away, and the others after the latency.
+	require.True(t, sim.Step())
+	require.Equal(t, []received{{at: start, from: 1, data: "hello"}}, routers[1].received())
+	require.True(t, sim.Step())
+	require.Equal(t, start.Add(100*time.Millisecond), sim.Clock().Now())
+	require.Len(t, routers[2].received(), 1)
+	require.Len(t, routers[3].received(), 1)
+	require.Empty(t, routers[4].received())
+
+	sim.Advance(time.Second)
+	require.Equal(t, start.Add(1100*time.Millisecond), sim.Clock().Now())
+	require.Equal(t, []received{{at: start.Add(time.Second), from: 1, data: "hello"}}, routers[4].received())
+	require.False(t, sim.Step())
+
+	// Duplicates aren't delivered again.
+	broadcast(t, sim, 1, "hello")
+	sim.Advance(time.Second)
+	require.Len(t, routers[2].received(), 1)
+	require.Equal(t, Stats{Broadcasts: 2, Delivered: 4}, sim.Stats())
+}
+
+func TestSimulatorPartition(t *testing.T) {
+	sim, routers := newTestNetwork(t, Config{}, 4)
+
+	sim.Partition([]spectypes.OperatorID{1, 2})
+	broadcast(t, sim, 1, "partitioned")
+	broadcast(t, sim, 3, "other side")
+	sim.Advance(time.Second)
+	require.Len(t, routers[2].received(), 1)
+	require.Len(t, routers[4].received(), 1)
+	require.Equal(t, "other side", routers[4].received()[0].data)
+
+	sim.Heal()
+	broadcast(t, sim, 1, "healed")
+	sim.Advance(time.Second)
+	require.Len(t, routers[4].received(), 2)
+	require.Equal(t, 4, sim.Stats().Dropped)
+}
+
+func TestSimulatorByzantine(t *testing.T) {
+	sim, routers := newTestNetwork(t, Config{}, 4)
+
+	sim.SetByzantine(1, Silent)
+	broadcast(t, sim, 1, "withheld")
+	sim.SetByzantine(2, func(to spectypes.OperatorID, msg *spectypes.SignedSSVMessage) *spectypes.SignedSSVMessage {
+		_, equivocation := testMessage(t, 2, "equivocation")
+		if to == 4 {
+			return equivocation
+		}
+		return msg
+	})
+	broadcast(t, sim, 2, "honest")
+	sim.Advance(time.Second)
+
+	require.Len(t, routers[1].received(), 2)
+	require.Equal(t, []received{{at: routers[3].received()[0].at, from: 2, data: "honest"}}, routers[3].received())
+	require.Equal(t, "equivocation", routers[4].received()[0].data)
+	require.Equal(t, 3, sim.Stats().Withheld)
+
+	_, forged := testMessage(t, 3, "forged")
+	require.NoError(t, sim.Inject(1, commons.GetTopicFullName(commons.CommitteeTopicID(testCommitteeID)[0]), forged))
+	sim.Advance(time.Second)
+	require.Equal(t, "forged", routers[4].received()[1].data)
+}
+
+func TestSimulatorDeterminism(t *testing.T) {
+	run := func(seed int64) ([]received, Stats) {
+		sim, routers := newTestNetwork(t, Config{Start: time.Unix(1700000000, 0), Seed: seed, Jitter: 100 * time.Millisecond, DropRate: 0.3}, 7)
+		// Broadcast concurrently, as nodes would.
+		var wg sync.WaitGroup
+		for id := spectypes.OperatorID(1); id <= 7; id++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				broadcast(t, sim, id, "message")
+			}()
+		}
+		wg.Wait()
+		sim.Advance(time.Second)
+		require.Zero(t, sim.Pending())
+		return routers[7].received(), sim.Stats()
+	}
+
+	received, stats := run(1)
+	require.NotZero(t, stats.Dropped)
+	for i := 0; i < 3; i++ {
+		again, againStats := run(1)
+		require.Equal(t, received, again)
+		require.Equal(t, stats, againStats)
+	}
+	_, otherStats := run(2)
+	require.NotEqual(t, stats, otherStats)
+}
+
+func TestSimulatorRunUntil(t *testing.T) {
+	sim, routers := newTestNetwork(t, Config{Latency: time.Second}, 4)
+	start := sim.Clock().Now()
+
+	// While nothing is in flight, the clock stands still.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, sim.RunUntil(ctx, func() bool { return false }), context.DeadlineExceeded)
+	require.Equal(t, start, sim.Clock().Now())
+
+	// Messages are delivered by the virtual clock, without waiting for their latency.
+	broadcast(t, sim, 1, "hello")
+	began := time.Now()
+	require.NoError(t, sim.RunUntil(context.Background(), func() bool { return len(routers[4].received()) == 1 }))
+	require.Less(t, time.Since(began), time.Second)
+	require.Equal(t, start.Add(time.Second), routers[4].received()[0].at)
+}
+
+func TestSimulatorTimers(t *testing.T) {
+	sim, routers := newTestNetwork(t, Config{Latency: time.Second}, 2)
+	clock := sim.Clock()
+	start := clock.Now()
+
+	late := clock.After(time.Minute)
+	early := clock.After(500 * time.Millisecond)
+	broadcast(t, sim, 1, "hello")
+	began := time.Now()
+
+	// Timers fire in order with the messages in flight.
+	require.True(t, sim.Step())
+	require.Len(t, routers[1].received(), 1)
+	require.True(t, sim.Step())
+	require.Equal(t, start.Add(500*time.Millisecond), <-early)
+	require.Empty(t, routers[2].received())
+	require.True(t, sim.Step())
+	require.Len(t, routers[2].received(), 1)
+
+	// With nothing in flight, the clock skips ahead to the next timer rather than waiting for it.
+	require.True(t, sim.Step())
+	require.Equal(t, start.Add(time.Minute), <-late)
+	require.False(t, sim.Step())
+	require.Less(t, time.Since(began), time.Second)
+
+	// Advancing fires the timers on the way.
+	timer := clock.After(time.Second)
+	sim.Advance(2 * time.Second)
+	require.Equal(t, start.Add(time.Minute+time.Second), <-timer)
+	require.Equal(t, start.Add(time.Minute+2*time.Second), clock.Now())
+}
@@ -2,6 +2,7 @@ package topics
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -9,11 +10,13 @@ import (
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/network/commons"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	"github.com/ssvlabs/ssv/utils/async"
 )
 
 var (
@@ -31,10 +34,12 @@ type Controller interface {
 	Peers(topicName string) ([]peer.ID, error)
 	// Topics lists all the available topics
 	Topics() []string
-	// Broadcast publishes the message on the given topic
-	Broadcast(topicName string, data []byte, timeout time.Duration) error
+	// Broadcast publishes the message of the given committee on the given topic
+	Broadcast(topicName string, data []byte, committeeID spectypes.CommitteeID, timeout time.Duration) error
 	// UpdateScoreParams refreshes the score params for every subscribed topic
 	UpdateScoreParams(logger *zap.Logger) error
+	// Traffic returns the topics, peers or committees with the most inbound messages
+	Traffic(by TrafficKind, limit int) ([]*Talker, error)
 
 	io.Closer
 }
@@ -56,8 +61,10 @@ type topicsCtrl struct {
 	msgValidator       messageValidator
 	msgHandler         PubsubMessageHandler
 	subFilter          SubFilter
+	committees         CommitteesProvider
 
 	container *topicsContainer
+	traffic   *traffic
 }
 
 // NewTopicsController creates an instance of Controller
@@ -69,6 +76,7 @@ func NewTopicsController(
 	subFilter SubFilter,
 	pubSub *pubsub.PubSub,
 	scoreParams func(string) *pubsub.TopicScoreParams,
+	committees CommitteesProvider,
 ) Controller {
 	ctrl := &topicsCtrl{
 		ctx:                ctx,
@@ -78,11 +86,21 @@ func NewTopicsController(
 		msgValidator:       msgValidator,
 		msgHandler:         msgHandler,
 
-		subFilter: subFilter,
+		subFilter:  subFilter,
+		committees: committees,
 	}
 
 	ctrl.container = newTopicsContainer(pubSub, ctrl.onNewTopic(logger))
 
+	var expectedRate func(string) float64
+	if committees != nil {
+		expectedRate = expectedMessageRate(committees)
+	}
+	ctrl.traffic = newTraffic(ctx, expectedRate)
+	async.Interval(ctx, trafficWindow, func() {
+		ctrl.traffic.rollWindow(logger)
+	})
+
 	return ctrl
 }
 
@@ -132,6 +150,32 @@ func (ctrl *topicsCtrl) UpdateScoreParams(logger *zap.Logger) error {
 	return errs
 }
 
+// Traffic returns the topics, peers or committees with the most inbound messages,
+// with the messages and bytes they sent and received.
+func (ctrl *topicsCtrl) Traffic(by TrafficKind, limit int) ([]*Talker, error) {
+	return ctrl.traffic.top(by, limit)
+}
+
+// committee returns the ID of the committee which the message belongs to, or empty if it's unknown.
+func (ctrl *topicsCtrl) committee(msgID spectypes.MessageID) string {
+	executorID := msgID.GetDutyExecutorID()
+	if msgID.GetRoleType() == spectypes.RoleCommittee {
+		if len(executorID) < 16 {
+			return ""
+		}
+		return hex.EncodeToString(executorID[16:])
+	}
+	if ctrl.committees == nil {
+		return ""
+	}
+	share, exists := ctrl.committees.Validator(executorID)
+	if !exists {
+		return ""
+	}
+	committeeID := share.CommitteeID()
+	return hex.EncodeToString(committeeID[:])
+}
+
 // Close implements io.Closer
 func (ctrl *topicsCtrl) Close() error {
 	topics := ctrl.ps.GetTopics()
@@ -182,8 +226,9 @@ func (ctrl *topicsCtrl) Subscribe(logger *zap.Logger, name string) error {
 	return nil
 }
 
-// Broadcast publishes the message on the given topic
-func (ctrl *topicsCtrl) Broadcast(name string, data []byte, timeout time.Duration) error {
+// Broadcast publishes the message of the given committee on the given topic.
+// The committee is given by the caller, which already knows it, so that the message isn't decoded again.
+func (ctrl *topicsCtrl) Broadcast(name string, data []byte, committeeID spectypes.CommitteeID, timeout time.Duration) error {
 	name = commons.GetTopicFullName(name)
 
 	topic, err := ctrl.container.Join(name)
@@ -198,6 +243,7 @@ func (ctrl *topicsCtrl) Broadcast(name string, data []byte, timeout time.Duratio
 		err := topic.Publish(ctx, data)
 		if err == nil {
 			outboundMessageCounter.Add(ctrl.ctx, 1)
+			ctrl.traffic.outbound(name, hex.EncodeToString(committeeID[:]), len(data))
 		}
 	}()
 
@@ -276,6 +322,7 @@ func (ctrl *topicsCtrl) listen(logger *zap.Logger, sub *pubsub.Subscription) err
 		case *queue.SSVMessage:
 			inboundMessageCounter.Add(ctrl.ctx, 1,
 				metric.WithAttributes(messageTypeAttribute(uint64(m.MsgType))))
+			ctrl.traffic.inbound(topicName, msg.ReceivedFrom, ctrl.committee(m.MsgID), len(msg.Data))
 		default:
 			logger.Warn("unknown message type", zap.Any("message", m))
 		}
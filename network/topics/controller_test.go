@@ -139,7 +139,7 @@ func baseTest(t *testing.T, ctx context.Context, logger *zap.Logger, peers []*P,
 				require.NoError(t, err)
 				raw, err := msg.Encode()
 				require.NoError(t, err)
-				require.NoError(t, p.tm.Broadcast(committeeTopic(cid), raw, time.Second*10))
+				require.NoError(t, p.tm.Broadcast(committeeTopic(cid), raw, committeeID(cid), time.Second*10))
 				<-time.After(time.Second * 5)
 			}(p, cids[i], j)
 		}
@@ -255,7 +255,7 @@ func banningTest(t *testing.T, logger *zap.Logger, peers []*P, cids []string, sc
 			raw, err := msg.Encode()
 			require.NoError(t, err)
 
-			require.NoError(t, p.tm.Broadcast(committeeTopic(cid), raw, time.Second*10))
+			require.NoError(t, p.tm.Broadcast(committeeTopic(cid), raw, committeeID(cid), time.Second*10))
 
 			<-time.After(time.Second * 5)
 		}(peers[0], cids[i%len(cids)], msg)
@@ -302,13 +302,20 @@ func banningTest(t *testing.T, logger *zap.Logger, peers []*P, cids []string, sc
 	//wg.Wait()
 }
 
-func committeeTopic(cidHex string) string {
+func committeeID(cidHex string) spectypes.CommitteeID {
 	cid, err := hex.DecodeString(cidHex)
 	if err != nil {
+		return spectypes.CommitteeID{}
+	}
+	return spectypes.CommitteeID(cid)
+}
+
+func committeeTopic(cidHex string) string {
+	if _, err := hex.DecodeString(cidHex); err != nil {
 		return "invalid"
 	}
 
-	return commons.CommitteeTopicID(spectypes.CommitteeID(cid))[0]
+	return commons.CommitteeTopicID(committeeID(cidHex))[0]
 }
 
 type P struct {
@@ -29,6 +29,24 @@ var (
 			metricName("out"),
 			metric.WithUnit("{message}"),
 			metric.WithDescription("total number of outbound(broadcasted) messages")))
+
+	inboundBytesCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("in.size"),
+			metric.WithUnit("By"),
+			metric.WithDescription("total size of inbound messages")))
+
+	outboundBytesCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("out.size"),
+			metric.WithUnit("By"),
+			metric.WithDescription("total size of outbound(broadcasted) messages")))
+
+	rateAlertsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("rate_alerts"),
+			metric.WithUnit("{alert}"),
+			metric.WithDescription("number of times the message rate of a topic exceeded the rate expected by its score params")))
 )
 
 func metricName(name string) string {
@@ -41,3 +59,7 @@ func messageTypeAttribute(value uint64) attribute.KeyValue {
 		Value: observability.Uint64AttributeValue(value),
 	}
 }
+
+func topicAttribute(topic string) attribute.KeyValue {
+	return attribute.String("ssv.p2p.topic.name", topic)
+}
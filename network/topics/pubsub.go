@@ -19,6 +19,7 @@ import (
 	"github.com/ssvlabs/ssv/network/peers"
 	"github.com/ssvlabs/ssv/network/topics/params"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/types"
 	"github.com/ssvlabs/ssv/registry/storage"
 )
 
@@ -114,6 +115,7 @@ func (cfg *PubSubConfig) initScoring() {
 
 type CommitteesProvider interface {
 	Committees() []*storage.Committee
+	Validator(pubKey []byte) (*types.SSVShare, bool)
 }
 
 // NewPubSub creates a new pubsub router and the necessary components
@@ -202,7 +204,7 @@ func NewPubSub(ctx context.Context, logger *zap.Logger, cfg *PubSubConfig, commi
 		return nil, nil, err
 	}
 
-	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory)
+	ctrl := NewTopicsController(ctx, logger, cfg.MsgHandler, cfg.MsgValidator, sf, ps, topicScoreFactory, committeesProvider)
 
 	return ps, ctrl, nil
 }
@@ -220,6 +220,14 @@ func topicScoreParams(logger *zap.Logger, cfg *PubSubConfig, committeesProvider
 	}
 }
 
+// expectedMessageRate returns the rate of messages per second which the score params of a topic assume.
+func expectedMessageRate(committeesProvider CommitteesProvider) func(string) float64 {
+	return func(topic string) float64 {
+		topicCommittees := filterCommitteesForTopic(topic, committeesProvider.Committees())
+		return params.NewSubnetTopicOpts(0, commons.Subnets(), topicCommittees).Topic.ExpectedMsgRate
+	}
+}
+
 // Returns a new committee list with only the committees that belong to the given topic
 func filterCommitteesForTopic(topic string, committees []*storage.Committee) []*storage.Committee {
 
@@ -0,0 +1,225 @@
+package topics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+const (
+	// trafficWindow is the interval over which message rates are measured.
+	trafficWindow = 5 * time.Minute
+	// trafficRetention is how long the traffic of idle peers and committees is kept.
+	trafficRetention = time.Hour
+	// rateAlertFactor is how many times the expected message rate of a topic
+	// can be observed before it's alerted on, since the expected rate is an average over epochs.
+	rateAlertFactor = 3.0
+)
+
+// TrafficKind is what traffic is accounted by.
+type TrafficKind string
+
+const (
+	TrafficByTopic     TrafficKind = "topic"
+	TrafficByPeer      TrafficKind = "peer"
+	TrafficByCommittee TrafficKind = "committee"
+)
+
+// TrafficStats counts the messages and bytes received and sent.
+type TrafficStats struct {
+	MessagesIn  uint64 `json:"messages_in"`
+	MessagesOut uint64 `json:"messages_out"`
+	BytesIn     uint64 `json:"bytes_in"`
+	BytesOut    uint64 `json:"bytes_out"`
+}
+
+// Talker is the traffic of a topic, a peer or a committee.
+type Talker struct {
+	ID string `json:"id"`
+	TrafficStats
+	// MessageRate is the rate of inbound messages per second during the last window.
+	MessageRate float64 `json:"message_rate"`
+	// ExpectedMessageRate is the rate of messages per second which the score params of a topic assume.
+	ExpectedMessageRate float64 `json:"expected_message_rate,omitempty"`
+}
+
+type trafficEntry struct {
+	stats          TrafficStats
+	windowMessages uint64
+	rate           float64
+	expectedRate   float64
+	lastSeen       time.Time
+}
+
+// traffic accounts the messages and bytes on topics, per topic, per peer and per committee.
+// Outbound messages are accounted per topic and committee only, as pubsub doesn't tell which peers they're sent to.
+type traffic struct {
+	ctx          context.Context
+	expectedRate func(topic string) float64
+	now          func() time.Time
+
+	lock        sync.Mutex
+	entries     map[TrafficKind]map[string]*trafficEntry
+	windowStart time.Time
+}
+
+func newTraffic(ctx context.Context, expectedRate func(topic string) float64) *traffic {
+	t := &traffic{
+		ctx:          ctx,
+		expectedRate: expectedRate,
+		now:          time.Now,
+		entries: map[TrafficKind]map[string]*trafficEntry{
+			TrafficByTopic:     {},
+			TrafficByPeer:      {},
+			TrafficByCommittee: {},
+		},
+	}
+	t.windowStart = t.now()
+	return t
+}
+
+// inbound accounts a message received on a topic from a peer. The committee is empty if it's unknown.
+func (t *traffic) inbound(topic string, from peer.ID, committee string, size int) {
+	inboundBytesCounter.Add(t.ctx, int64(size), metric.WithAttributes(topicAttribute(topic)))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.add(TrafficByTopic, topic, now, true, size)
+	t.add(TrafficByPeer, from.String(), now, true, size)
+	if committee != "" {
+		t.add(TrafficByCommittee, committee, now, true, size)
+	}
+}
+
+// outbound accounts a message published on a topic. The committee is empty if it's unknown.
+func (t *traffic) outbound(topic string, committee string, size int) {
+	outboundBytesCounter.Add(t.ctx, int64(size), metric.WithAttributes(topicAttribute(topic)))
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.add(TrafficByTopic, topic, now, false, size)
+	if committee != "" {
+		t.add(TrafficByCommittee, committee, now, false, size)
+	}
+}
+
+func (t *traffic) add(kind TrafficKind, id string, now time.Time, inbound bool, size int) {
+	entry, ok := t.entries[kind][id]
+	if !ok {
+		entry = &trafficEntry{}
+		t.entries[kind][id] = entry
+	}
+	if inbound {
+		entry.stats.MessagesIn++
+		entry.stats.BytesIn += uint64(size)
+		entry.windowMessages++
+	} else {
+		entry.stats.MessagesOut++
+		entry.stats.BytesOut += uint64(size)
+	}
+	entry.lastSeen = now
+}
+
+// rollWindow measures the message rates of the ending window and alerts on the topics
+// whose rate exceeds the rate their score params expect. Idle peers and committees are forgotten.
+func (t *traffic) rollWindow(logger *zap.Logger) {
+	expectedRates := t.expectedRates()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	elapsed := now.Sub(t.windowStart).Seconds()
+	t.windowStart = now
+	if elapsed <= 0 {
+		return
+	}
+
+	for kind, entries := range t.entries {
+		for id, entry := range entries {
+			if kind != TrafficByTopic && now.Sub(entry.lastSeen) > trafficRetention {
+				delete(entries, id)
+				continue
+			}
+			entry.rate = float64(entry.windowMessages) / elapsed
+			entry.windowMessages = 0
+			if kind != TrafficByTopic {
+				continue
+			}
+
+			entry.expectedRate = expectedRates[id]
+			if entry.expectedRate > 0 && entry.rate > entry.expectedRate*rateAlertFactor {
+				logger.Warn("message rate of topic exceeds the expected rate",
+					zap.String("topic", id),
+					zap.Float64("rate", entry.rate),
+					zap.Float64("expected_rate", entry.expectedRate))
+				rateAlertsCounter.Add(t.ctx, 1, metric.WithAttributes(topicAttribute(id)))
+			}
+		}
+	}
+}
+
+// expectedRates returns the expected message rates of the accounted topics.
+// They're computed without holding the lock, as they go over all the committees.
+func (t *traffic) expectedRates() map[string]float64 {
+	if t.expectedRate == nil {
+		return nil
+	}
+
+	t.lock.Lock()
+	topics := make([]string, 0, len(t.entries[TrafficByTopic]))
+	for topic := range t.entries[TrafficByTopic] {
+		topics = append(topics, topic)
+	}
+	t.lock.Unlock()
+
+	rates := make(map[string]float64, len(topics))
+	for _, topic := range topics {
+		rates[topic] = t.expectedRate(topic)
+	}
+	return rates
+}
+
+// top returns the talkers with the most inbound messages, up to the given limit if it's positive.
+func (t *traffic) top(kind TrafficKind, limit int) ([]*Talker, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	entries, ok := t.entries[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown traffic kind %q", kind)
+	}
+
+	talkers := make([]*Talker, 0, len(entries))
+	for id, entry := range entries {
+		talkers = append(talkers, &Talker{
+			ID:                  id,
+			TrafficStats:        entry.stats,
+			MessageRate:         entry.rate,
+			ExpectedMessageRate: entry.expectedRate,
+		})
+	}
+	sort.Slice(talkers, func(i, j int) bool {
+		if talkers[i].MessagesIn != talkers[j].MessagesIn {
+			return talkers[i].MessagesIn > talkers[j].MessagesIn
+		}
+		if talkers[i].MessagesOut != talkers[j].MessagesOut {
+			return talkers[i].MessagesOut > talkers[j].MessagesOut
+		}
+		return talkers[i].ID < talkers[j].ID
+	})
+	if limit > 0 && len(talkers) > limit {
+		talkers = talkers[:limit]
+	}
+	return talkers, nil
+}
@@ -0,0 +1,75 @@
+package topics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+)
+
+func TestTraffic(t *testing.T) {
+	logger := logging.TestLogger(t)
+	now := time.Now()
+	tr := newTraffic(context.Background(), func(topic string) float64 {
+		if topic == "busy" {
+			return 0.01
+		}
+		return 10
+	})
+	tr.now = func() time.Time { return now }
+	tr.windowStart = now
+
+	quietPeer, busyPeer := test.RandPeerIDFatal(t), test.RandPeerIDFatal(t)
+	tr.inbound("quiet", quietPeer, "", 100)
+	for i := 0; i < 10; i++ {
+		tr.inbound("busy", busyPeer, "committee", 10)
+	}
+	tr.outbound("quiet", "committee", 50)
+
+	peers, err := tr.top(TrafficByPeer, 0)
+	require.NoError(t, err)
+	require.Len(t, peers, 2)
+	require.Equal(t, busyPeer.String(), peers[0].ID)
+	require.Equal(t, TrafficStats{MessagesIn: 10, BytesIn: 100}, peers[0].TrafficStats)
+
+	committees, err := tr.top(TrafficByCommittee, 1)
+	require.NoError(t, err)
+	require.Len(t, committees, 1)
+	require.Equal(t, TrafficStats{MessagesIn: 10, MessagesOut: 1, BytesIn: 100, BytesOut: 50}, committees[0].TrafficStats)
+
+	_, err = tr.top("unknown", 0)
+	require.Error(t, err)
+
+	// Rates are measured over the window, and compared with the expected rates of topics.
+	now = now.Add(10 * time.Second)
+	tr.rollWindow(logger)
+	topics, err := tr.top(TrafficByTopic, 0)
+	require.NoError(t, err)
+	require.Len(t, topics, 2)
+	require.Equal(t, "busy", topics[0].ID)
+	require.Equal(t, 1.0, topics[0].MessageRate)
+	require.Equal(t, 0.01, topics[0].ExpectedMessageRate)
+	require.Equal(t, 0.1, topics[1].MessageRate)
+	require.Equal(t, TrafficStats{MessagesIn: 1, MessagesOut: 1, BytesIn: 100, BytesOut: 50}, topics[1].TrafficStats)
+
+	// Idle peers and committees are forgotten, while topics are kept.
+	tr.inbound("busy", busyPeer, "", 10)
+	now = now.Add(trafficRetention / 2)
+	tr.inbound("busy", busyPeer, "", 10)
+	now = now.Add(trafficRetention / 2)
+	tr.rollWindow(logger)
+	peers, err = tr.top(TrafficByPeer, 0)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	require.Equal(t, busyPeer.String(), peers[0].ID)
+	committees, err = tr.top(TrafficByCommittee, 0)
+	require.NoError(t, err)
+	require.Empty(t, committees)
+	topics, err = tr.top(TrafficByTopic, 0)
+	require.NoError(t, err)
+	require.Len(t, topics, 2)
+}
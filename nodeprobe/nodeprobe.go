@@ -2,6 +2,7 @@ package nodeprobe
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -167,3 +168,58 @@ func (p *Prober) CheckEventSyncerHealth(ctx context.Context) error {
 	}
 	return es.Healthy(ctx)
 }
+
+// FailoverGroup is a Node made of several nodes which back each other up, such as multiple beacon nodes.
+// Each member is probed and logged under its own name, and the group is healthy as long as any member is.
+type FailoverGroup struct {
+	logger  *zap.Logger
+	members map[string]Node
+}
+
+func NewFailoverGroup(logger *zap.Logger, members map[string]Node) *FailoverGroup {
+	return &FailoverGroup{
+		logger:  logger,
+		members: members,
+	}
+}
+
+func (g *FailoverGroup) Healthy(ctx context.Context) error {
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(g.members))
+	for name, member := range g.members {
+		go func(name string, member Node) {
+			var err error
+			defer func() {
+				// Catch panics.
+				if e := recover(); e != nil {
+					err = fmt.Errorf("panic: %v", e)
+				}
+				results <- result{name: name, err: err}
+			}()
+
+			err = member.Healthy(ctx)
+		}(name, member)
+	}
+
+	var errs error
+	var healthy int
+	for range g.members {
+		r := <-results
+		if r.err != nil {
+			g.logger.Warn("node is not healthy", zap.String("node", r.name), zap.Error(r.err))
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", r.name, r.err))
+			continue
+		}
+		healthy++
+	}
+	if healthy == 0 {
+		if errs == nil {
+			return fmt.Errorf("no nodes in group")
+		}
+		return errs
+	}
+	return nil
+}
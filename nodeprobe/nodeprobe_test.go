@@ -70,6 +70,29 @@ func TestProber_UnhealthyHandler(t *testing.T) {
 	require.False(t, healthy)
 }
 
+func TestFailoverGroup(t *testing.T) {
+	ctx := context.Background()
+
+	node1, node2 := &node{}, &node{}
+	node1.healthy.Store(nil)
+	node2.healthy.Store(nil)
+	group := NewFailoverGroup(zap.L(), map[string]Node{"node 1": node1, "node 2": node2})
+	require.NoError(t, group.Healthy(ctx))
+
+	notHealthy := fmt.Errorf("not healthy")
+	node1.healthy.Store(&notHealthy)
+	require.NoError(t, group.Healthy(ctx))
+
+	node2.healthy.Store(&notHealthy)
+	err := group.Healthy(ctx)
+	require.ErrorIs(t, err, notHealthy)
+	require.ErrorContains(t, err, "node 1")
+	require.ErrorContains(t, err, "node 2")
+
+	node1.healthy.Store(nil)
+	require.NoError(t, group.Healthy(ctx))
+}
+
 type node struct {
 	healthy atomic.Pointer[error]
 }
@@ -1,12 +1,14 @@
 package observability
 
 import (
+	"encoding/hex"
 	"math"
 	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/ssvlabs/ssv-spec/qbft"
 	"github.com/ssvlabs/ssv-spec/types"
@@ -25,6 +27,13 @@ func RunnerRoleAttribute(role types.RunnerRole) attribute.KeyValue {
 	return attribute.String(RunnerRoleAttrKey, role.String())
 }
 
+func BeaconSlotAttribute(slot phase0.Slot) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   "ssv.beacon.slot",
+		Value: Uint64AttributeValue(uint64(slot)),
+	}
+}
+
 func DutyRoundAttribute(round qbft.Round) attribute.KeyValue {
 	return attribute.KeyValue{
 		Key:   "ssv.validator.duty.round",
@@ -32,6 +41,19 @@ func DutyRoundAttribute(round qbft.Round) attribute.KeyValue {
 	}
 }
 
+func CommitteeIDAttribute(id types.CommitteeID) attribute.KeyValue {
+	return attribute.String("ssv.validator.committee_id", hex.EncodeToString(id[:]))
+}
+
+// ValidatorIndexAttribute should only label metrics when ValidatorAttributes is enabled,
+// as it adds time series for every validator of the node.
+func ValidatorIndexAttribute(index uint64) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   "ssv.validator.index",
+		Value: Uint64AttributeValue(index),
+	}
+}
+
 func NetworkDirectionAttribute(direction network.Direction) attribute.KeyValue {
 	return attribute.String("ssv.p2p.connection.direction", strings.ToLower(direction.String()))
 }
@@ -0,0 +1,27 @@
+package observability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitteeIDAttribute(t *testing.T) {
+	id := types.GetCommitteeID([]types.OperatorID{1, 2, 3, 4})
+
+	attr := CommitteeIDAttribute(id)
+
+	assert.Equal(t, "ssv.validator.committee_id", string(attr.Key))
+	assert.Len(t, attr.Value.AsString(), 64)
+	assert.Equal(t, strings.ToLower(attr.Value.AsString()), attr.Value.AsString())
+}
+
+func TestWithValidatorAttributes(t *testing.T) {
+	var cfg Config
+	WithValidatorAttributes()(&cfg)
+
+	assert.True(t, cfg.validatorAttributes)
+	assert.False(t, cfg.metricsEnabled)
+}
@@ -1,5 +1,22 @@
 package observability
 
 type Config struct {
-	metricsEnabled bool
+	metricsEnabled      bool
+	validatorAttributes bool
+	traces              *TracesConfig
+}
+
+// TracesConfig configures the export of traces to an OTLP collector.
+type TracesConfig struct {
+	// Endpoint is the host:port of the collector's OTLP gRPC receiver.
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool
+	// SampleRatio is the ratio of traces to export, from 0 to 1.
+	SampleRatio float64
+}
+
+// ValidatorAttributes returns whether metrics may be labeled by validator.
+func ValidatorAttributes() bool {
+	return config.validatorAttributes
 }
@@ -0,0 +1,163 @@
+// Package events publishes the lifecycle of the duties this operator performs,
+// from their scheduling to their submission to the Beacon node, to subscribers such as the SSV API.
+package events
+
+import (
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+)
+
+// Type is the lifecycle stage an event reports.
+type Type string
+
+const (
+	// DutyScheduled is published when the scheduler executes a duty.
+	DutyScheduled Type = "duty_scheduled"
+	// DutySkipped is published when a scheduled duty isn't started, with the reason as the error.
+	DutySkipped Type = "duty_skipped"
+	// PreConsensusQuorum is published when the pre-consensus partial signatures, such as RANDAO reveals, reach a quorum.
+	PreConsensusQuorum Type = "pre_consensus_quorum"
+	// QBFTStarted is published when a QBFT instance starts.
+	QBFTStarted Type = "qbft_started"
+	// QBFTRoundChanged is published when a QBFT instance moves to a higher round.
+	QBFTRoundChanged Type = "qbft_round_changed"
+	// QBFTDecided is published when a QBFT instance decides.
+	QBFTDecided Type = "qbft_decided"
+	// PartialSignatureQuorum is published when the post-consensus partial signatures reach a quorum.
+	PartialSignatureQuorum Type = "partial_signature_quorum"
+	// BeaconSubmission is published when a duty is submitted to the Beacon node, with an error if it failed.
+	BeaconSubmission Type = "beacon_submission"
+)
+
+// Types lists all the event types.
+var Types = []Type{DutyScheduled, DutySkipped, PreConsensusQuorum, QBFTStarted, QBFTRoundChanged, QBFTDecided, PartialSignatureQuorum, BeaconSubmission}
+
+// Event is a stage in the lifecycle of a duty.
+type Event struct {
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+	// Role is a beacon role (such as ATTESTER) for duties and submissions,
+	// and a runner role (such as COMMITTEE) for consensus.
+	Role  string         `json:"role"`
+	Slot  phase0.Slot    `json:"slot"`
+	Round specqbft.Round `json:"round,omitempty"`
+	// CommitteeID is the hex encoded ID of the committee running the duty, if known.
+	CommitteeID string             `json:"committee_id,omitempty"`
+	Validators  []phase0.BLSPubKey `json:"validators,omitempty"`
+	// Signers are the operators whose signatures decided a QBFT instance,
+	// or whose partial signatures reached a quorum.
+	Signers []spectypes.OperatorID `json:"signers,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// QBFT returns an event of the QBFT instance with the given identifier.
+func QBFT(typ Type, identifier []byte, height specqbft.Height, round specqbft.Round) Event {
+	var msgID spectypes.MessageID
+	copy(msgID[:], identifier)
+	event := Event{
+		Type:  typ,
+		Role:  message.RunnerRoleToString(msgID.GetRoleType()),
+		Slot:  phase0.Slot(height),
+		Round: round,
+	}
+
+	executorID := msgID.GetDutyExecutorID()
+	if msgID.GetRoleType() == spectypes.RoleCommittee {
+		event.CommitteeID = hex.EncodeToString(executorID[16:])
+	} else {
+		event.Validators = []phase0.BLSPubKey{phase0.BLSPubKey(executorID)}
+	}
+	return event
+}
+
+// Bus fans events out to its subscribers.
+// A nil Bus has no subscribers, so that components publish to it without checking whether one was given.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	// active is the number of subscribers, read by publishers without locking.
+	active atomic.Int32
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Active returns whether anyone is subscribed, so that publishers may skip building events nobody receives.
+func (b *Bus) Active() bool {
+	return b != nil && b.active.Load() > 0
+}
+
+// Publish sends an event to all subscribers without blocking,
+// dropping it for subscribers whose buffer is full.
+func (b *Bus) Publish(event Event) {
+	if !b.Active() {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers {
+		select {
+		case s.events <- event:
+		default:
+			s.dropped.Add(1)
+		}
+	}
+}
+
+// Subscribe returns a subscription buffering up to the given number of events.
+func (b *Bus) Subscribe(buffer int) *Subscription {
+	s := &Subscription{
+		bus:    b,
+		events: make(chan Event, buffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[s] = struct{}{}
+	b.active.Add(1)
+	return s
+}
+
+// Subscription receives the events published on a Bus.
+type Subscription struct {
+	bus     *Bus
+	events  chan Event
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// Events returns the channel of events, which is closed once unsubscribed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the subscriber was too slow to receive them.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops the delivery of events and closes the channel.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		defer s.bus.mu.Unlock()
+		delete(s.bus.subscribers, s)
+		s.bus.active.Add(-1)
+		close(s.events)
+	})
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+	require.False(t, bus.Active())
+
+	// Publishing without subscribers is a no-op.
+	bus.Publish(Event{Type: DutyScheduled})
+
+	fast := bus.Subscribe(2)
+	slow := bus.Subscribe(1)
+	require.True(t, bus.Active())
+
+	bus.Publish(Event{Type: DutyScheduled, Slot: 1})
+	bus.Publish(Event{Type: QBFTStarted, Slot: 1})
+
+	event := <-fast.Events()
+	require.Equal(t, DutyScheduled, event.Type)
+	require.False(t, event.Time.IsZero())
+	require.Equal(t, QBFTStarted, (<-fast.Events()).Type)
+	require.Zero(t, fast.Dropped())
+
+	require.Equal(t, DutyScheduled, (<-slow.Events()).Type)
+	require.Equal(t, uint64(1), slow.Dropped())
+
+	fast.Unsubscribe()
+	fast.Unsubscribe()
+	_, ok := <-fast.Events()
+	require.False(t, ok)
+	require.True(t, bus.Active())
+
+	slow.Unsubscribe()
+	require.False(t, bus.Active())
+}
+
+func TestQBFT(t *testing.T) {
+	pubKey := phase0.BLSPubKey{1, 2, 3}
+	identifier := spectypes.NewMsgID(spectypes.DomainType{}, pubKey[:], spectypes.RoleProposer)
+	event := QBFT(QBFTDecided, identifier[:], 10, 2)
+	require.Equal(t, Event{
+		Type:       QBFTDecided,
+		Role:       "PROPOSER",
+		Slot:       10,
+		Round:      2,
+		Validators: []phase0.BLSPubKey{pubKey},
+	}, event)
+
+	committeeID := spectypes.CommitteeID{4, 5, 6}
+	identifier = spectypes.NewMsgID(spectypes.DomainType{}, committeeID[:], spectypes.RoleCommittee)
+	event = QBFT(QBFTStarted, identifier[:], 11, specqbft.FirstRound)
+	require.Equal(t, "COMMITTEE", event.Role)
+	require.Equal(t, hex.EncodeToString(committeeID[:]), event.CommitteeID)
+	require.Empty(t, event.Validators)
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
@@ -45,5 +47,37 @@ func Initialize(appName, appVersion string, options ...Option) (shutdown func(co
 		shutdown = meterProvider.Shutdown
 	}
 
+	if config.traces != nil {
+		var tracerProvider *sdktrace.TracerProvider
+		tracerProvider, err = newTracerProvider(resources, config.traces)
+		if err != nil {
+			err = errors.Join(errors.New("failed to instantiate trace OTLP exporter"), err)
+			return shutdown, err
+		}
+		otel.SetTracerProvider(tracerProvider)
+		shutdownMetrics := shutdown
+		shutdown = func(ctx context.Context) error {
+			return errors.Join(shutdownMetrics(ctx), tracerProvider.Shutdown(ctx))
+		}
+	}
+
 	return shutdown, err
 }
+
+func newTracerProvider(resources *resource.Resource, traces *TracesConfig) (*sdktrace.TracerProvider, error) {
+	options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(traces.Endpoint)}
+	if traces.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	// The exporter connects to the collector in the background, so it doesn't fail if it's unavailable.
+	exporter, err := otlptracegrpc.New(context.Background(), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithResource(resources),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(traces.SampleRatio))),
+	), nil
+}
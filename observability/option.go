@@ -9,3 +9,18 @@ func WithMetrics() Option {
 		cfg.metricsEnabled = true
 	}
 }
+
+// WithTraces exports traces to the given OTLP collector.
+func WithTraces(traces TracesConfig) Option {
+	return func(cfg *Config) {
+		cfg.traces = &traces
+	}
+}
+
+// WithValidatorAttributes labels duty metrics with validator indices,
+// adding time series for every validator of the node.
+func WithValidatorAttributes() Option {
+	return func(cfg *Config) {
+		cfg.validatorAttributes = true
+	}
+}
@@ -0,0 +1,223 @@
+// Package doppelganger implements doppelganger protection for the operator: after startup, the node
+// refrains from signing until it's clear that no other instance with the same operator ID is live on the network.
+//
+// Beacon chain liveness of the node's validators isn't checked: a validator is live whenever a quorum of its committee
+// signs its duties, which the other operators keep doing while this one is offline, so liveness can't tell
+// another instance of this operator apart from the rest of the committee.
+// Only the SSV messages signed with the node's operator ID can.
+package doppelganger
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging/fields"
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	beaconprotocol "github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// ErrSigningNotAllowed is returned when signing is refused because doppelganger protection is active.
+var ErrSigningNotAllowed = errors.New("signing is not allowed: doppelganger protection is active")
+
+// State is the state of doppelganger protection.
+type State string
+
+const (
+	// StateDisabled means doppelganger protection is disabled and signing is always allowed.
+	StateDisabled State = "disabled"
+	// StateObserving means the node is observing the network for messages signed by its operator ID.
+	StateObserving State = "observing"
+	// StateDetected means messages signed by the node's operator ID were observed during the observation period,
+	// so another instance is likely live. Observation restarts until the network has been quiet for a whole period.
+	StateDetected State = "detected"
+	// StateSafe means no other instance was observed during the observation period and signing is allowed.
+	StateSafe State = "safe"
+)
+
+// Status describes the current state of doppelganger protection.
+type Status struct {
+	State State `json:"state"`
+	// ObserveUntil is the time at which signing becomes allowed, unless another instance is detected until then.
+	ObserveUntil *time.Time `json:"observe_until,omitempty"`
+	// LastDetection is the time at which a message signed by the node's operator ID was last observed.
+	LastDetection *time.Time `json:"last_detection,omitempty"`
+}
+
+// Handler tracks whether the node may sign.
+type Handler interface {
+	// Start begins the observation period. Signing isn't allowed before it's started.
+	Start(ctx context.Context)
+	// CanSign returns ErrSigningNotAllowed until the node is safe to sign.
+	CanSign() error
+	// ObserveMessage inspects the signers of a message received from the network.
+	ObserveMessage(signers []spectypes.OperatorID)
+	// Status returns the current status of doppelganger protection.
+	Status() Status
+}
+
+// Options for the doppelganger handler.
+type Options struct {
+	Network           beaconprotocol.BeaconNetwork
+	OperatorDataStore operatordatastore.OperatorDataStore
+	// ObservationEpochs is the number of epochs without messages signed by the node's operator ID
+	// which must pass before signing is allowed.
+	ObservationEpochs uint64
+}
+
+type handler struct {
+	logger            *zap.Logger
+	operatorDataStore operatordatastore.OperatorDataStore
+	observationPeriod time.Duration
+	now               func() time.Time
+
+	mu            sync.Mutex
+	state         State
+	observeUntil  time.Time
+	lastDetection time.Time
+}
+
+// New returns a Handler which refuses signing until no other instance has been observed for the configured number of epochs.
+func New(logger *zap.Logger, opts Options) Handler {
+	h := &handler{
+		logger:            logger.Named("doppelganger"),
+		operatorDataStore: opts.OperatorDataStore,
+		observationPeriod: opts.Network.SlotDurationSec() * time.Duration(opts.Network.SlotsPerEpoch()*opts.ObservationEpochs), // #nosec G115
+		now:               time.Now,
+		state:             StateObserving,
+	}
+
+	h.logger.Info("doppelganger protection is enabled, signing is postponed until no other instance is observed",
+		zap.Uint64("epochs", opts.ObservationEpochs),
+		zap.Duration("observation_period", h.observationPeriod))
+
+	return h
+}
+
+func (h *handler) Start(ctx context.Context) {
+	h.mu.Lock()
+	h.observeUntil = h.now().Add(h.observationPeriod)
+	h.logger.Info("started observing the network for other instances with the same operator ID",
+		zap.Time("observe_until", h.observeUntil))
+	h.mu.Unlock()
+
+	// Switch to safe state and log it as soon as the observation period is over.
+	go func() {
+		for {
+			h.mu.Lock()
+			wait := h.observeUntil.Sub(h.now())
+			h.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+
+			if h.CanSign() == nil {
+				return
+			}
+		}
+	}()
+}
+
+func (h *handler) CanSign() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.state == StateSafe {
+		return nil
+	}
+	if h.observeUntil.IsZero() || h.now().Before(h.observeUntil) {
+		return ErrSigningNotAllowed
+	}
+
+	h.state = StateSafe
+	h.logger.Info("no other instance with the same operator ID observed, signing is allowed")
+	return nil
+}
+
+func (h *handler) ObserveMessage(signers []spectypes.OperatorID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Once safe, the node signs messages itself, so messages with its operator ID are expected.
+	if h.state == StateSafe {
+		return
+	}
+
+	operatorID := h.operatorDataStore.GetOperatorID()
+	if operatorID == 0 || !slices.Contains(signers, operatorID) {
+		return
+	}
+
+	now := h.now()
+	alreadyDetected := h.state == StateDetected
+	h.state = StateDetected
+	h.lastDetection = now
+	h.observeUntil = now.Add(h.observationPeriod)
+
+	// Avoid flooding the logs, since the other instance keeps producing messages.
+	if alreadyDetected {
+		return
+	}
+	h.logger.Warn("observed a message signed by this operator, another instance is likely running with the same operator key",
+		fields.OperatorID(operatorID),
+		zap.Time("observe_until", h.observeUntil))
+}
+
+func (h *handler) Status() Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := Status{
+		State: h.state,
+	}
+	if h.state != StateSafe && !h.observeUntil.IsZero() {
+		observeUntil := h.observeUntil
+		status.ObserveUntil = &observeUntil
+	}
+	if !h.lastDetection.IsZero() {
+		lastDetection := h.lastDetection
+		status.LastDetection = &lastDetection
+	}
+	return status
+}
+
+type noOpHandler struct{}
+
+// NoOpHandler returns a Handler which always allows signing.
+func NoOpHandler() Handler {
+	return noOpHandler{}
+}
+
+func (noOpHandler) Start(context.Context)                 {}
+func (noOpHandler) CanSign() error                        { return nil }
+func (noOpHandler) ObserveMessage([]spectypes.OperatorID) {}
+func (noOpHandler) Status() Status                        { return Status{State: StateDisabled} }
+
+type guardedOperatorSigner struct {
+	ssvtypes.OperatorSigner
+	handler Handler
+}
+
+// GuardOperatorSigner returns an OperatorSigner which refuses to sign SSV messages while the handler doesn't allow signing.
+func GuardOperatorSigner(signer ssvtypes.OperatorSigner, handler Handler) ssvtypes.OperatorSigner {
+	return &guardedOperatorSigner{
+		OperatorSigner: signer,
+		handler:        handler,
+	}
+}
+
+func (s *guardedOperatorSigner) SignSSVMessage(ssvMsg *spectypes.SSVMessage) ([]byte, error) {
+	if err := s.handler.CanSign(); err != nil {
+		return nil, err
+	}
+	return s.OperatorSigner.SignSSVMessage(ssvMsg)
+}
@@ -0,0 +1,100 @@
+package doppelganger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	registrystorage "github.com/ssvlabs/ssv/registry/storage"
+)
+
+func newTestHandler() (*handler, *time.Time) {
+	now := time.Now()
+
+	h := New(zap.NewNop(), Options{
+		Network:           beacon.NewNetwork(spectypes.MainNetwork),
+		OperatorDataStore: operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+		ObservationEpochs: 2,
+	}).(*handler)
+	h.now = func() time.Time { return now }
+	// Start the observation period without the background goroutine, which would race with the clock updates.
+	h.observeUntil = now.Add(h.observationPeriod)
+
+	return h, &now
+}
+
+func TestHandler(t *testing.T) {
+	observationPeriod := 2 * 32 * 12 * time.Second
+
+	t.Run("signing is allowed after the observation period", func(t *testing.T) {
+		h, now := newTestHandler()
+		require.Equal(t, observationPeriod, h.observationPeriod)
+
+		require.ErrorIs(t, h.CanSign(), ErrSigningNotAllowed)
+		require.Equal(t, StateObserving, h.Status().State)
+
+		h.ObserveMessage([]spectypes.OperatorID{2, 3, 4})
+		require.Equal(t, StateObserving, h.Status().State)
+
+		*now = now.Add(observationPeriod)
+		require.NoError(t, h.CanSign())
+		require.Equal(t, Status{State: StateSafe}, h.Status())
+	})
+
+	t.Run("observing own operator ID restarts the observation period", func(t *testing.T) {
+		h, now := newTestHandler()
+
+		*now = now.Add(observationPeriod / 2)
+		h.ObserveMessage([]spectypes.OperatorID{1, 2, 3})
+
+		status := h.Status()
+		require.Equal(t, StateDetected, status.State)
+		require.Equal(t, *now, *status.LastDetection)
+		require.Equal(t, now.Add(observationPeriod), *status.ObserveUntil)
+
+		*now = now.Add(observationPeriod / 2)
+		require.ErrorIs(t, h.CanSign(), ErrSigningNotAllowed)
+
+		*now = now.Add(observationPeriod / 2)
+		require.NoError(t, h.CanSign())
+
+		// Own messages are expected once signing is allowed.
+		h.ObserveMessage([]spectypes.OperatorID{1})
+		require.NoError(t, h.CanSign())
+		require.Equal(t, StateSafe, h.Status().State)
+	})
+
+	t.Run("signing is not allowed before start", func(t *testing.T) {
+		h := New(zap.NewNop(), Options{
+			Network:           beacon.NewNetwork(spectypes.MainNetwork),
+			OperatorDataStore: operatordatastore.New(&registrystorage.OperatorData{ID: 1}),
+			ObservationEpochs: 0,
+		})
+		require.ErrorIs(t, h.CanSign(), ErrSigningNotAllowed)
+	})
+
+	t.Run("operator signer is guarded", func(t *testing.T) {
+		h, now := newTestHandler()
+		signer := GuardOperatorSigner(nil, h)
+
+		_, err := signer.SignSSVMessage(&spectypes.SSVMessage{})
+		require.ErrorIs(t, err, ErrSigningNotAllowed)
+
+		*now = now.Add(observationPeriod)
+		require.NoError(t, h.CanSign())
+	})
+}
+
+func TestNoOpHandler(t *testing.T) {
+	h := NoOpHandler()
+	h.Start(context.Background())
+	h.ObserveMessage([]spectypes.OperatorID{1})
+	require.NoError(t, h.CanSign())
+	require.Equal(t, StateDisabled, h.Status().State)
+}
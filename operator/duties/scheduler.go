@@ -3,6 +3,7 @@ package duties
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync"
@@ -14,6 +15,8 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -22,6 +25,8 @@ import (
 	"github.com/ssvlabs/ssv/logging/fields"
 	"github.com/ssvlabs/ssv/network"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/observability"
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/operator/duties/dutystore"
 	"github.com/ssvlabs/ssv/operator/slotticker"
 	"github.com/ssvlabs/ssv/protocol/v2/types"
@@ -86,6 +91,8 @@ type SchedulerOptions struct {
 	SlotTickerProvider  slotticker.Provider
 	DutyStore           *dutystore.Store
 	P2PNetwork          network.P2PNetwork
+	// EventBus receives an event for each duty executed. Optional.
+	EventBus *events.Bus
 }
 
 type Scheduler struct {
@@ -96,6 +103,7 @@ type Scheduler struct {
 	validatorController ValidatorController
 	slotTickerProvider  slotticker.Provider
 	dutyExecutor        DutyExecutor
+	eventBus            *events.Bus
 
 	handlers            []dutyHandler
 	blockPropagateDelay time.Duration
@@ -124,6 +132,7 @@ func NewScheduler(opts *SchedulerOptions) *Scheduler {
 		network:             opts.Network,
 		slotTickerProvider:  opts.SlotTickerProvider,
 		dutyExecutor:        opts.DutyExecutor,
+		eventBus:            opts.EventBus,
 		validatorProvider:   opts.ValidatorProvider,
 		validatorController: opts.ValidatorController,
 		indicesChg:          opts.IndicesChg,
@@ -368,10 +377,22 @@ func (s *Scheduler) ExecuteDuties(ctx context.Context, logger *zap.Logger, dutie
 		}
 		slotDelayHistogram.Record(ctx, slotDelay.Seconds())
 		go func() {
+			ctx, span := tracer.Start(ctx, "ssv.duties.execute", trace.WithAttributes(
+				observability.BeaconRoleAttribute(duty.Type),
+				observability.BeaconSlotAttribute(duty.Slot),
+				observability.ValidatorIndexAttribute(uint64(duty.ValidatorIndex))))
+			defer span.End()
+
 			if duty.Type == spectypes.BNRoleAttester || duty.Type == spectypes.BNRoleSyncCommittee {
 				s.waitOneThirdOrValidBlock(duty.Slot)
 			}
 			recordDutyExecuted(ctx, duty.RunnerRole())
+			s.eventBus.Publish(events.Event{
+				Type:       events.DutyScheduled,
+				Role:       duty.Type.String(),
+				Slot:       duty.Slot,
+				Validators: []phase0.BLSPubKey{duty.PubKey},
+			})
 			s.dutyExecutor.ExecuteDuty(ctx, logger, duty)
 		}()
 	}
@@ -391,13 +412,42 @@ func (s *Scheduler) ExecuteCommitteeDuties(ctx context.Context, logger *zap.Logg
 		}
 		slotDelayHistogram.Record(ctx, slotDelay.Seconds())
 		go func() {
+			ctx, span := tracer.Start(ctx, "ssv.duties.execute_committee", trace.WithAttributes(
+				observability.RunnerRoleAttribute(duty.RunnerRole()),
+				observability.BeaconSlotAttribute(duty.Slot),
+				observability.CommitteeIDAttribute(committee.id),
+				attribute.Int("ssv.duties.validators", len(duty.ValidatorDuties))))
+			defer span.End()
+
 			s.waitOneThirdOrValidBlock(duty.Slot)
 			recordDutyExecuted(ctx, duty.RunnerRole())
+			s.publishCommitteeDutyScheduled(committee)
 			s.dutyExecutor.ExecuteCommitteeDuty(ctx, logger, committee.id, duty)
 		}()
 	}
 }
 
+// publishCommitteeDutyScheduled publishes an event for each beacon role of a committee duty.
+func (s *Scheduler) publishCommitteeDutyScheduled(committee *committeeDuty) {
+	if !s.eventBus.Active() {
+		return
+	}
+
+	validators := make(map[spectypes.BeaconRole][]phase0.BLSPubKey)
+	for _, duty := range committee.duty.ValidatorDuties {
+		validators[duty.Type] = append(validators[duty.Type], duty.PubKey)
+	}
+	for role, pubKeys := range validators {
+		s.eventBus.Publish(events.Event{
+			Type:        events.DutyScheduled,
+			Role:        role.String(),
+			Slot:        committee.duty.Slot,
+			CommitteeID: hex.EncodeToString(committee.id[:]),
+			Validators:  pubKeys,
+		})
+	}
+}
+
 // loggerWithDutyContext returns an instance of logger with the given duty's information
 func (s *Scheduler) loggerWithDutyContext(logger *zap.Logger, duty *spectypes.ValidatorDuty) *zap.Logger {
 	return logger.
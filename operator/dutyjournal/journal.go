@@ -0,0 +1,351 @@
+// Package dutyjournal keeps an append-only journal of the duties this operator performs,
+// recording for each validator the lifecycle events of its duties so that they can be audited later.
+package dutyjournal
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/observability/events"
+	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/slotprune"
+)
+
+var (
+	journalPrefix          = []byte("duty_journal/")
+	journalEntryPrefix     = []byte("duty_journal/entry/")
+	journalCommitteePrefix = []byte("duty_journal/committee/")
+)
+
+const (
+	// subscriptionBuffer is the number of events buffered while the journal is writing.
+	subscriptionBuffer = 4096
+	// recordBatchSize is the maximum number of buffered events recorded at once.
+	recordBatchSize = 256
+)
+
+// Journal records the lifecycle events of duties per validator.
+// Consensus events of a committee are recorded once for the committee, and read along with the entries
+// of the validators whose duties of the committee were scheduled in the same slot.
+// Entries are keyed by slot first, so that they can be pruned by slot.
+type Journal struct {
+	logger *zap.Logger
+	db     basedb.Database
+	pruner *slotprune.Pruner
+	// seq orders the entries of a slot. It starts at the current time,
+	// so that entries written after a restart are ordered after the previous ones.
+	seq atomic.Uint64
+}
+
+// New creates a new journal.
+func New(logger *zap.Logger, db basedb.Database) *Journal {
+	j := &Journal{
+		logger: logger,
+		db:     db,
+		pruner: slotprune.New(db, "duty journal", journalPrefix, journalEntryPrefix, journalCommitteePrefix),
+	}
+	j.seq.Store(uint64(time.Now().UnixNano())) // #nosec G115
+	return j
+}
+
+// Run records the duty events published on the bus until the context is done.
+// Events which were buffered meanwhile are recorded together.
+func (j *Journal) Run(ctx context.Context, bus *events.Bus) {
+	sub := bus.Subscribe(subscriptionBuffer)
+	defer sub.Unsubscribe()
+
+	var dropped uint64
+	batch := make([]events.Event, 0, recordBatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-sub.Events():
+			batch = append(batch[:0], event)
+		drain:
+			for len(batch) < recordBatchSize {
+				select {
+				case event := <-sub.Events():
+					batch = append(batch, event)
+				default:
+					break drain
+				}
+			}
+
+			if err := j.Record(batch...); err != nil {
+				j.logger.Error("failed to record duty events", zap.Int("events", len(batch)), zap.Error(err))
+			}
+			if n := sub.Dropped(); n > dropped {
+				j.logger.Warn("duty journal dropped events", zap.Uint64("dropped", n-dropped))
+				dropped = n
+			}
+		}
+	}
+}
+
+// Record appends events to the journal in a single transaction.
+// Events of validators are appended to the journal of each of them,
+// and consensus events of a committee are appended once to the journal of the committee.
+func (j *Journal) Record(batch ...events.Event) error {
+	return j.db.Update(func(txn basedb.Txn) error {
+		for _, event := range batch {
+			if err := j.record(txn, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (j *Journal) record(txn basedb.Txn, event events.Event) error {
+	if len(event.Validators) == 0 && event.CommitteeID == "" {
+		return nil
+	}
+
+	entry := event
+	entry.Validators = nil
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	if len(event.Validators) == 0 {
+		return txn.Set(journalCommitteePrefix, committeeEntryKey(event.Slot, event.CommitteeID, j.seq.Add(1)), encoded)
+	}
+	for _, pubKey := range event.Validators {
+		if err := txn.Set(journalEntryPrefix, entryKey(event.Slot, pubKey, j.seq.Add(1)), encoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Entries returns the journal entries of a validator within the given slot range, in the order they were recorded.
+// They include the consensus events of the committees which the validator's duties of each slot were scheduled with.
+func (j *Journal) Entries(pubKey phase0.BLSPubKey, from, to phase0.Slot) ([]events.Event, error) {
+	type seqEntry struct {
+		seq   uint64
+		entry events.Event
+	}
+	read := func(prefix []byte) ([]seqEntry, error) {
+		var entries []seqEntry
+		err := j.db.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+			var entry events.Event
+			if err := json.Unmarshal(obj.Value, &entry); err != nil {
+				return fmt.Errorf("decode entry: %w", err)
+			}
+			entries = append(entries, seqEntry{seq: keySeq(obj.Key), entry: entry})
+			return nil
+		})
+		return entries, err
+	}
+
+	entries := make([]events.Event, 0)
+	for slot := from; slot <= to; slot++ {
+		slotEntries, err := read(slices.Concat(journalEntryPrefix, slotprune.SlotKey(slot), pubKey[:]))
+		if err != nil {
+			return nil, err
+		}
+
+		var committees []string
+		for _, e := range slotEntries {
+			if e.entry.CommitteeID != "" && !slices.Contains(committees, e.entry.CommitteeID) {
+				committees = append(committees, e.entry.CommitteeID)
+			}
+		}
+		for _, committeeID := range committees {
+			committeeEntries, err := read(slices.Concat(journalCommitteePrefix, slotprune.SlotKey(slot), []byte(committeeID)))
+			if err != nil {
+				return nil, err
+			}
+			slotEntries = append(slotEntries, committeeEntries...)
+		}
+
+		slices.SortStableFunc(slotEntries, func(a, b seqEntry) int {
+			return cmp.Compare(a.seq, b.seq)
+		})
+		for _, e := range slotEntries {
+			entries = append(entries, e.entry)
+		}
+	}
+	return entries, nil
+}
+
+// Duties returns the duties of a validator within the given slot range, summarized from its journal entries.
+func (j *Journal) Duties(pubKey phase0.BLSPubKey, from, to phase0.Slot) ([]*Duty, error) {
+	entries, err := j.Entries(pubKey, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return Summarize(pubKey, entries), nil
+}
+
+// Prune deletes the entries of slots before the given slot and returns how many were deleted.
+// Pruning continues from the slot the previous pruning stopped at,
+// and scans the entire journal only the first time.
+func (j *Journal) Prune(before phase0.Slot) (int, error) {
+	return j.pruner.Prune(before)
+}
+
+// PruneLoop prunes entries older than the retention period once every epoch, until the context is done.
+func (j *Journal) PruneLoop(ctx context.Context, beaconNetwork beacon.BeaconNetwork, retention phase0.Epoch) {
+	interval := beaconNetwork.SlotDurationSec() * time.Duration(beaconNetwork.SlotsPerEpoch()) // #nosec G115
+	j.pruner.PruneLoop(ctx, j.logger, interval, func() phase0.Slot {
+		currentEpoch := beaconNetwork.EstimatedCurrentEpoch()
+		if currentEpoch <= retention {
+			return 0
+		}
+		return beaconNetwork.FirstSlotAtEpoch(currentEpoch - retention)
+	})
+}
+
+func entryKey(slot phase0.Slot, pubKey phase0.BLSPubKey, seq uint64) []byte {
+	key := slices.Concat(slotprune.SlotKey(slot), pubKey[:])
+	return binary.BigEndian.AppendUint64(key, seq)
+}
+
+func committeeEntryKey(slot phase0.Slot, committeeID string, seq uint64) []byte {
+	key := slices.Concat(slotprune.SlotKey(slot), []byte(committeeID))
+	return binary.BigEndian.AppendUint64(key, seq)
+}
+
+// keySeq decodes the sequence number an entry key ends with.
+func keySeq(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[len(key)-8:])
+}
+
+// Duty is the journal of a validator's duty, with the timing of each of its phases.
+type Duty struct {
+	Role         string           `json:"role"`
+	Slot         phase0.Slot      `json:"slot"`
+	PubKey       phase0.BLSPubKey `json:"pub_key"`
+	CommitteeID  string           `json:"committee_id,omitempty"`
+	ScheduledAt  *time.Time       `json:"scheduled_at,omitempty"`
+	Skipped      *Outcome         `json:"skipped,omitempty"`
+	PreConsensus *Quorum          `json:"pre_consensus,omitempty"`
+	// ConsensusStartedAt is when the QBFT instance of the duty started,
+	// which for committee duties is shared by the validators of the committee.
+	ConsensusStartedAt *time.Time    `json:"consensus_started_at,omitempty"`
+	RoundChanges       []RoundChange `json:"round_changes,omitempty"`
+	Decided            *Decided      `json:"decided,omitempty"`
+	PostConsensus      *Quorum       `json:"post_consensus,omitempty"`
+	// Submission is the response of the Beacon node to the submission of the duty.
+	Submission *Outcome `json:"submission,omitempty"`
+	// Entries are the journal entries the duty was summarized from.
+	Entries []events.Event `json:"entries"`
+}
+
+// Quorum is when partial signatures reached a quorum, and the operators who contributed them.
+type Quorum struct {
+	Time    time.Time              `json:"time"`
+	Signers []spectypes.OperatorID `json:"signers"`
+}
+
+// RoundChange is when a QBFT instance moved to a higher round.
+type RoundChange struct {
+	Time  time.Time      `json:"time"`
+	Round specqbft.Round `json:"round"`
+}
+
+// Decided is when and in which round a QBFT instance decided, and the operators who signed the decision.
+type Decided struct {
+	Time    time.Time              `json:"time"`
+	Round   specqbft.Round         `json:"round"`
+	Signers []spectypes.OperatorID `json:"signers,omitempty"`
+}
+
+// Outcome is when a step completed, with an error if it failed.
+type Outcome struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error,omitempty"`
+}
+
+// Summarize groups the journal entries of a validator, ordered by slot, into duties by slot and beacon role.
+// Entries of committee consensus are attributed to the attester and sync committee duties of their slot.
+func Summarize(pubKey phase0.BLSPubKey, entries []events.Event) []*Duty {
+	type dutyKey struct {
+		slot phase0.Slot
+		role string
+	}
+	duties := make(map[dutyKey]*Duty)
+	var order []dutyKey
+	get := func(slot phase0.Slot, role string) *Duty {
+		key := dutyKey{slot: slot, role: role}
+		if duty, ok := duties[key]; ok {
+			return duty
+		}
+		duty := &Duty{Role: role, Slot: slot, PubKey: pubKey}
+		duties[key] = duty
+		order = append(order, key)
+		return duty
+	}
+
+	committeeRole := message.RunnerRoleToString(spectypes.RoleCommittee)
+	var committeeEntries []events.Event
+	for _, entry := range entries {
+		if entry.Role == committeeRole {
+			committeeEntries = append(committeeEntries, entry)
+			continue
+		}
+		get(entry.Slot, entry.Role).add(entry)
+	}
+
+	attesterRole, syncCommitteeRole := spectypes.BNRoleAttester.String(), spectypes.BNRoleSyncCommittee.String()
+	for _, entry := range committeeEntries {
+		attributed := false
+		for _, role := range []string{attesterRole, syncCommitteeRole} {
+			if duty, ok := duties[dutyKey{slot: entry.Slot, role: role}]; ok {
+				duty.add(entry)
+				attributed = true
+			}
+		}
+		if !attributed {
+			get(entry.Slot, committeeRole).add(entry)
+		}
+	}
+
+	result := make([]*Duty, 0, len(order))
+	for _, key := range order {
+		result = append(result, duties[key])
+	}
+	return result
+}
+
+func (d *Duty) add(entry events.Event) {
+	d.Entries = append(d.Entries, entry)
+	if d.CommitteeID == "" {
+		d.CommitteeID = entry.CommitteeID
+	}
+
+	switch entry.Type {
+	case events.DutyScheduled:
+		d.ScheduledAt = &entry.Time
+	case events.DutySkipped:
+		d.Skipped = &Outcome{Time: entry.Time, Error: entry.Error}
+	case events.PreConsensusQuorum:
+		d.PreConsensus = &Quorum{Time: entry.Time, Signers: entry.Signers}
+	case events.QBFTStarted:
+		d.ConsensusStartedAt = &entry.Time
+	case events.QBFTRoundChanged:
+		d.RoundChanges = append(d.RoundChanges, RoundChange{Time: entry.Time, Round: entry.Round})
+	case events.QBFTDecided:
+		d.Decided = &Decided{Time: entry.Time, Round: entry.Round, Signers: entry.Signers}
+	case events.PartialSignatureQuorum:
+		d.PostConsensus = &Quorum{Time: entry.Time, Signers: entry.Signers}
+	case events.BeaconSubmission:
+		d.Submission = &Outcome{Time: entry.Time, Error: entry.Error}
+	}
+}
@@ -0,0 +1,113 @@
+package dutyjournal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/observability/events"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestJournal(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	journal := New(logger, db)
+
+	validator1, validator2 := phase0.BLSPubKey{1}, phase0.BLSPubKey{2}
+	committeeID := "0102"
+	start := time.Unix(1700000000, 0)
+	at := func(ms int) time.Time {
+		return start.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	record := []events.Event{
+		// A committee duty of both validators in slot 10, decided in round 2.
+		{Type: events.DutyScheduled, Time: at(0), Role: "ATTESTER", Slot: 10, CommitteeID: committeeID, Validators: []phase0.BLSPubKey{validator1, validator2}},
+		{Type: events.DutyScheduled, Time: at(1), Role: "SYNC_COMMITTEE", Slot: 10, CommitteeID: committeeID, Validators: []phase0.BLSPubKey{validator1}},
+		{Type: events.QBFTStarted, Time: at(2), Role: "COMMITTEE", Slot: 10, Round: 1, CommitteeID: committeeID},
+		{Type: events.QBFTRoundChanged, Time: at(3), Role: "COMMITTEE", Slot: 10, Round: 2, CommitteeID: committeeID},
+		{Type: events.QBFTDecided, Time: at(4), Role: "COMMITTEE", Slot: 10, Round: 2, CommitteeID: committeeID, Signers: []spectypes.OperatorID{1, 2, 3}},
+		{Type: events.PartialSignatureQuorum, Time: at(5), Role: "COMMITTEE", Slot: 10, Round: 2, CommitteeID: committeeID, Validators: []phase0.BLSPubKey{validator1, validator2}, Signers: []spectypes.OperatorID{1, 2, 4}},
+		{Type: events.BeaconSubmission, Time: at(6), Role: "ATTESTER", Slot: 10, CommitteeID: committeeID, Validators: []phase0.BLSPubKey{validator1, validator2}},
+		{Type: events.BeaconSubmission, Time: at(7), Role: "SYNC_COMMITTEE", Slot: 10, CommitteeID: committeeID, Validators: []phase0.BLSPubKey{validator1}, Error: "timeout"},
+		// A proposal of validator 1 in slot 11 with a RANDAO quorum.
+		{Type: events.DutyScheduled, Time: at(10), Role: "PROPOSER", Slot: 11, Validators: []phase0.BLSPubKey{validator1}},
+		{Type: events.PreConsensusQuorum, Time: at(11), Role: "PROPOSER", Slot: 11, Validators: []phase0.BLSPubKey{validator1}, Signers: []spectypes.OperatorID{2, 3, 4}},
+		// A skipped aggregation of validator 1 in slot 12.
+		{Type: events.DutyScheduled, Time: at(20), Role: "AGGREGATOR", Slot: 12, Validators: []phase0.BLSPubKey{validator1}},
+		{Type: events.DutySkipped, Time: at(21), Role: "AGGREGATOR", Slot: 12, Validators: []phase0.BLSPubKey{validator1}, Error: "validator not found"},
+		// Consensus of another committee, whose duties weren't scheduled.
+		{Type: events.QBFTStarted, Time: at(30), Role: "COMMITTEE", Slot: 12, Round: 1, CommitteeID: "0304"},
+	}
+	require.NoError(t, journal.Record(record[:5]...))
+	for _, event := range record[5:] {
+		require.NoError(t, journal.Record(event))
+	}
+
+	duties, err := journal.Duties(validator1, 10, 12)
+	require.NoError(t, err)
+	require.Len(t, duties, 4)
+
+	attester := duties[0]
+	require.Equal(t, "ATTESTER", attester.Role)
+	require.Equal(t, phase0.Slot(10), attester.Slot)
+	require.Equal(t, committeeID, attester.CommitteeID)
+	require.True(t, attester.ScheduledAt.Equal(at(0)))
+	require.True(t, attester.ConsensusStartedAt.Equal(at(2)))
+	require.Len(t, attester.RoundChanges, 1)
+	require.EqualValues(t, 2, attester.RoundChanges[0].Round)
+	require.EqualValues(t, 2, attester.Decided.Round)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 3}, attester.Decided.Signers)
+	require.Equal(t, []spectypes.OperatorID{1, 2, 4}, attester.PostConsensus.Signers)
+	require.True(t, attester.Submission.Time.Equal(at(6)))
+	require.Empty(t, attester.Submission.Error)
+	require.Len(t, attester.Entries, 6)
+
+	syncCommittee := duties[1]
+	require.Equal(t, "SYNC_COMMITTEE", syncCommittee.Role)
+	require.NotNil(t, syncCommittee.Decided)
+	require.Equal(t, "timeout", syncCommittee.Submission.Error)
+
+	proposer := duties[2]
+	require.Equal(t, "PROPOSER", proposer.Role)
+	require.Equal(t, []spectypes.OperatorID{2, 3, 4}, proposer.PreConsensus.Signers)
+	require.Nil(t, proposer.Decided)
+
+	aggregator := duties[3]
+	require.Equal(t, "AGGREGATOR", aggregator.Role)
+	require.Equal(t, "validator not found", aggregator.Skipped.Error)
+
+	// Validator 2 only has the attestation, and none of its entries leak across validators.
+	duties, err = journal.Duties(validator2, 0, 20)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Equal(t, "ATTESTER", duties[0].Role)
+	require.Len(t, duties[0].Entries, 6)
+
+	// Pruning removes the entries before the given slot, and continues from there.
+	// Consensus events of a committee are stored once rather than for each of its validators.
+	deleted, err := journal.Prune(11)
+	require.NoError(t, err)
+	require.Equal(t, 11, deleted)
+
+	duties, err = journal.Duties(validator1, 10, 12)
+	require.NoError(t, err)
+	require.Len(t, duties, 2)
+
+	deleted, err = journal.Prune(13)
+	require.NoError(t, err)
+	require.Equal(t, 5, deleted)
+
+	entries, err := journal.Entries(validator1, 0, 20)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
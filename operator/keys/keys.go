@@ -18,10 +18,7 @@ type OperatorPublicKey interface {
 }
 
 type OperatorPrivateKey interface {
-	OperatorSigner
-	OperatorDecrypter
-	StorageHash() (string, error)
-	EKMHash() (string, error)
+	OperatorKey
 	Bytes() []byte
 	Base64() []byte
 }
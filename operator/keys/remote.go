@@ -0,0 +1,347 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	remoteKeyPublicKeyPath = "/v1/operator/public-key"
+	remoteKeySignPath      = "/v1/operator/sign"
+	remoteKeyDecryptPath   = "/v1/operator/decrypt"
+	remoteKeyHashesPath    = "/v1/operator/key-hashes"
+)
+
+// OperatorKey is the operator private key as used by the node. Unlike OperatorPrivateKey,
+// it may be held outside of the node, so the key material itself is not available.
+type OperatorKey interface {
+	OperatorSigner
+	OperatorDecrypter
+	StorageHash() (string, error)
+	EKMHash() (string, error)
+}
+
+// BatchDecrypter is an OperatorDecrypter which can decrypt many payloads at once.
+type BatchDecrypter interface {
+	OperatorDecrypter
+	DecryptBatch(data [][]byte) ([][]byte, error)
+}
+
+// RemoteKeyOptions configures a remote operator key.
+type RemoteKeyOptions struct {
+	// URL is the base URL of the keyholder service.
+	URL string
+	// Timeout is the timeout of requests to the keyholder service.
+	Timeout time.Duration
+	// MaxBatchSize is the maximum number of signatures requested at once.
+	MaxBatchSize int
+	// BatchWindow is how long to wait for more messages to sign before requesting a batch.
+	BatchWindow time.Duration
+	// CacheSize is the number of signatures to keep.
+	CacheSize int
+}
+
+type remotePublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+type remoteSignRequest struct {
+	Digests [][]byte `json:"digests"`
+}
+
+type remoteSignResponse struct {
+	Signatures [][]byte `json:"signatures"`
+}
+
+type remoteDecryptRequest struct {
+	Ciphertexts [][]byte `json:"ciphertexts"`
+}
+
+type remoteDecryptResponse struct {
+	Plaintexts [][]byte `json:"plaintexts"`
+}
+
+// remoteKeyHashesResponse holds the hashes of the private key, which the keyholder computes
+// the same way as a local key does, so that switching between them keeps the database usable.
+type remoteKeyHashesResponse struct {
+	StorageHash string `json:"storage_hash"`
+	EKMHash     string `json:"ekm_hash"`
+}
+
+type pendingSignature struct {
+	data      []byte
+	digest    [32]byte
+	done      chan struct{}
+	signature []byte
+	err       error
+}
+
+// remoteKey is an OperatorKey held by an external keyholder service, such as a process fronting an HSM.
+// Its API takes SHA-256 digests to sign with RSA PKCS#1 v1.5 and ciphertexts to decrypt with RSA PKCS#1 v1.5,
+// both in batches. Concurrent signing requests are batched, and signatures are cached,
+// so the service doesn't become the bottleneck of signing committee messages.
+// It also provides the hashes of the private key, which must equal those of the key held locally:
+// the SHA-256 of its PEM encoding and of its PKCS#1 DER encoding.
+type remoteKey struct {
+	ctx          context.Context
+	logger       *zap.Logger
+	client       *http.Client
+	url          string
+	maxBatchSize int
+	batchWindow  time.Duration
+	public       OperatorPublicKey
+	hashes       remoteKeyHashesResponse
+	signatures   *lru.Cache[[32]byte, []byte]
+	pending      chan *pendingSignature
+}
+
+// NewRemoteKey connects to the keyholder service and verifies it signs with the private key of its public key.
+func NewRemoteKey(ctx context.Context, logger *zap.Logger, opts RemoteKeyOptions) (OperatorKey, error) {
+	if opts.MaxBatchSize <= 0 {
+		return nil, fmt.Errorf("invalid max batch size: %d", opts.MaxBatchSize)
+	}
+
+	signatures, err := lru.New[[32]byte, []byte](opts.CacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create signature cache: %w", err)
+	}
+
+	k := &remoteKey{
+		ctx:          ctx,
+		logger:       logger.Named("RemoteOperatorKey"),
+		client:       &http.Client{Timeout: opts.Timeout},
+		url:          strings.TrimSuffix(opts.URL, "/"),
+		maxBatchSize: opts.MaxBatchSize,
+		batchWindow:  opts.BatchWindow,
+		signatures:   signatures,
+		pending:      make(chan *pendingSignature, opts.MaxBatchSize),
+	}
+
+	var resp remotePublicKeyResponse
+	if err := k.request(http.MethodGet, remoteKeyPublicKeyPath, nil, &resp); err != nil {
+		return nil, fmt.Errorf("could not get public key: %w", err)
+	}
+	k.public, err = PublicKeyFromString(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode public key: %w", err)
+	}
+
+	go k.batchSignatures()
+
+	// Signatures are verified as they're received, so this fails if the service holds a different key.
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("could not generate challenge: %w", err)
+	}
+	if _, err := k.Sign(challenge); err != nil {
+		return nil, fmt.Errorf("could not sign with remote key: %w", err)
+	}
+
+	if err := k.request(http.MethodGet, remoteKeyHashesPath, nil, &k.hashes); err != nil {
+		return nil, fmt.Errorf("could not get key hashes: %w", err)
+	}
+	if k.hashes.StorageHash == "" || k.hashes.EKMHash == "" {
+		return nil, fmt.Errorf("remote key hashes are missing")
+	}
+
+	return k, nil
+}
+
+func (k *remoteKey) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	if signature, ok := k.signatures.Get(digest); ok {
+		return signature, nil
+	}
+
+	p := &pendingSignature{
+		data:   data,
+		digest: digest,
+		done:   make(chan struct{}),
+	}
+	select {
+	case k.pending <- p:
+	case <-k.ctx.Done():
+		return nil, k.ctx.Err()
+	}
+
+	select {
+	case <-p.done:
+		return p.signature, p.err
+	case <-k.ctx.Done():
+		return nil, k.ctx.Err()
+	}
+}
+
+func (k *remoteKey) Public() OperatorPublicKey {
+	return k.public
+}
+
+func (k *remoteKey) Decrypt(data []byte) ([]byte, error) {
+	plaintexts, err := k.DecryptBatch([][]byte{data})
+	if err != nil {
+		return nil, err
+	}
+	return plaintexts[0], nil
+}
+
+// DecryptBatch decrypts the payloads in requests of up to the maximum batch size.
+// Decrypted payloads aren't cached, since they're secrets which the caller should keep only as long as it needs them.
+func (k *remoteKey) DecryptBatch(data [][]byte) ([][]byte, error) {
+	// Identical payloads are decrypted once.
+	indices := make([]int, len(data))
+	seen := make(map[string]int)
+	var ciphertexts [][]byte
+	for i, ciphertext := range data {
+		index, ok := seen[string(ciphertext)]
+		if !ok {
+			index = len(ciphertexts)
+			seen[string(ciphertext)] = index
+			ciphertexts = append(ciphertexts, ciphertext)
+		}
+		indices[i] = index
+	}
+
+	decrypted := make([][]byte, 0, len(ciphertexts))
+	for start := 0; start < len(ciphertexts); start += k.maxBatchSize {
+		batch := ciphertexts[start:min(start+k.maxBatchSize, len(ciphertexts))]
+
+		var resp remoteDecryptResponse
+		if err := k.request(http.MethodPost, remoteKeyDecryptPath, remoteDecryptRequest{Ciphertexts: batch}, &resp); err != nil {
+			return nil, fmt.Errorf("could not decrypt: %w", err)
+		}
+		if len(resp.Plaintexts) != len(batch) {
+			return nil, fmt.Errorf("unexpected number of plaintexts: %d, expected %d", len(resp.Plaintexts), len(batch))
+		}
+		decrypted = append(decrypted, resp.Plaintexts...)
+	}
+
+	plaintexts := make([][]byte, len(data))
+	for i, index := range indices {
+		plaintexts[i] = decrypted[index]
+	}
+	return plaintexts, nil
+}
+
+func (k *remoteKey) StorageHash() (string, error) {
+	return k.hashes.StorageHash, nil
+}
+
+func (k *remoteKey) EKMHash() (string, error) {
+	return k.hashes.EKMHash, nil
+}
+
+// batchSignatures collects pending signatures until the batch is full or the batch window has passed,
+// and requests each batch concurrently.
+func (k *remoteKey) batchSignatures() {
+	for {
+		var batch []*pendingSignature
+		select {
+		case p := <-k.pending:
+			batch = append(batch, p)
+		case <-k.ctx.Done():
+			return
+		}
+
+		timer := time.NewTimer(k.batchWindow)
+	collect:
+		for len(batch) < k.maxBatchSize {
+			select {
+			case p := <-k.pending:
+				batch = append(batch, p)
+			case <-timer.C:
+				break collect
+			case <-k.ctx.Done():
+				break collect
+			}
+		}
+		timer.Stop()
+
+		go k.signBatch(batch)
+	}
+}
+
+func (k *remoteKey) signBatch(batch []*pendingSignature) {
+	// Identical messages are signed once.
+	indices := make(map[[32]byte]int, len(batch))
+	var req remoteSignRequest
+	for _, p := range batch {
+		if _, ok := indices[p.digest]; !ok {
+			indices[p.digest] = len(req.Digests)
+			req.Digests = append(req.Digests, p.digest[:])
+		}
+	}
+
+	var resp remoteSignResponse
+	err := k.request(http.MethodPost, remoteKeySignPath, req, &resp)
+	if err == nil && len(resp.Signatures) != len(req.Digests) {
+		err = fmt.Errorf("unexpected number of signatures: %d, expected %d", len(resp.Signatures), len(req.Digests))
+	}
+	if err != nil {
+		k.logger.Debug("failed to sign batch", zap.Int("size", len(req.Digests)), zap.Error(err))
+	}
+
+	for _, p := range batch {
+		switch {
+		case err != nil:
+			p.err = fmt.Errorf("could not sign: %w", err)
+		default:
+			signature := resp.Signatures[indices[p.digest]]
+			if verifyErr := k.public.Verify(p.data, signature); verifyErr != nil {
+				p.err = fmt.Errorf("invalid signature from remote key: %w", verifyErr)
+				break
+			}
+			k.signatures.Add(p.digest, signature)
+			p.signature = signature
+		}
+		close(p.done)
+	}
+}
+
+func (k *remoteKey) request(method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("could not marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(k.ctx, method, k.url+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("could not unmarshal response: %w", err)
+	}
+	return nil
+}
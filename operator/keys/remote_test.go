@@ -0,0 +1,223 @@
+package keys_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/operator/keys"
+	"github.com/ssvlabs/ssv/utils/rsaencryption"
+)
+
+// testKeyholder is a stand-in for a keyholder service holding the operator private key.
+type testKeyholder struct {
+	t               *testing.T
+	server          *httptest.Server
+	privKey         *rsa.PrivateKey
+	pubKey          *rsa.PublicKey
+	signRequests    atomic.Int64
+	signedCount     atomic.Int64
+	decryptRequests atomic.Int64
+}
+
+func newTestKeyholder(t *testing.T) *testKeyholder {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	h := &testKeyholder{t: t, privKey: privKey, pubKey: &privKey.PublicKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/operator/public-key", h.publicKey)
+	mux.HandleFunc("POST /v1/operator/sign", h.sign)
+	mux.HandleFunc("POST /v1/operator/decrypt", h.decrypt)
+	mux.HandleFunc("GET /v1/operator/key-hashes", h.keyHashes)
+	h.server = httptest.NewServer(mux)
+	t.Cleanup(h.server.Close)
+
+	return h
+}
+
+func (h *testKeyholder) publicKey(w http.ResponseWriter, r *http.Request) {
+	pubKey, err := rsaencryption.ExtractPublicKey(h.pubKey)
+	require.NoError(h.t, err)
+	h.respond(w, map[string]string{"public_key": pubKey})
+}
+
+func (h *testKeyholder) sign(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Digests [][]byte `json:"digests"`
+	}
+	require.NoError(h.t, json.NewDecoder(r.Body).Decode(&req))
+	h.signRequests.Add(1)
+	h.signedCount.Add(int64(len(req.Digests)))
+
+	signatures := make([][]byte, 0, len(req.Digests))
+	for _, digest := range req.Digests {
+		signature, err := rsa.SignPKCS1v15(rand.Reader, h.privKey, crypto.SHA256, digest)
+		require.NoError(h.t, err)
+		signatures = append(signatures, signature)
+	}
+	h.respond(w, map[string][][]byte{"signatures": signatures})
+}
+
+func (h *testKeyholder) decrypt(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Ciphertexts [][]byte `json:"ciphertexts"`
+	}
+	require.NoError(h.t, json.NewDecoder(r.Body).Decode(&req))
+	h.decryptRequests.Add(1)
+
+	plaintexts := make([][]byte, 0, len(req.Ciphertexts))
+	for _, ciphertext := range req.Ciphertexts {
+		plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, h.privKey, ciphertext)
+		require.NoError(h.t, err)
+		plaintexts = append(plaintexts, plaintext)
+	}
+	h.respond(w, map[string][][]byte{"plaintexts": plaintexts})
+}
+
+// keyHashes hashes the private key the same way as a local key does.
+func (h *testKeyholder) keyHashes(w http.ResponseWriter, r *http.Request) {
+	storageHash := sha256.Sum256(rsaencryption.PrivateKeyToByte(h.privKey))
+	ekmHash := sha256.Sum256(x509.MarshalPKCS1PrivateKey(h.privKey))
+	h.respond(w, map[string]string{
+		"storage_hash": hex.EncodeToString(storageHash[:]),
+		"ekm_hash":     hex.EncodeToString(ekmHash[:]),
+	})
+}
+
+func (h *testKeyholder) respond(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(h.t, json.NewEncoder(w).Encode(body))
+}
+
+func TestRemoteKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keyholder := newTestKeyholder(t)
+	remoteKey, err := keys.NewRemoteKey(ctx, zap.NewNop(), keys.RemoteKeyOptions{
+		URL:          keyholder.server.URL,
+		Timeout:      5 * time.Second,
+		MaxBatchSize: 16,
+		BatchWindow:  50 * time.Millisecond,
+		CacheSize:    1024,
+	})
+	require.NoError(t, err)
+
+	t.Run("concurrent messages are signed in batches", func(t *testing.T) {
+		requestsBefore := keyholder.signRequests.Load()
+
+		const messages = 64
+		signatures := make([][]byte, messages)
+		var wg sync.WaitGroup
+		for i := 0; i < messages; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				signature, err := remoteKey.Sign([]byte(fmt.Sprintf("message %d", i)))
+				require.NoError(t, err)
+				signatures[i] = signature
+			}(i)
+		}
+		wg.Wait()
+
+		for i, signature := range signatures {
+			require.NoError(t, remoteKey.Public().Verify([]byte(fmt.Sprintf("message %d", i)), signature))
+		}
+		require.Less(t, keyholder.signRequests.Load()-requestsBefore, int64(messages))
+	})
+
+	t.Run("signatures are cached", func(t *testing.T) {
+		signedBefore := keyholder.signedCount.Load()
+
+		signature, err := remoteKey.Sign([]byte("message 0"))
+		require.NoError(t, err)
+		require.NoError(t, remoteKey.Public().Verify([]byte("message 0"), signature))
+		require.Equal(t, signedBefore, keyholder.signedCount.Load())
+	})
+
+	t.Run("shares are decrypted", func(t *testing.T) {
+		ciphertext, err := remoteKey.Public().Encrypt([]byte("share secret key"))
+		require.NoError(t, err)
+
+		plaintext, err := remoteKey.Decrypt(ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, []byte("share secret key"), plaintext)
+	})
+
+	t.Run("shares are decrypted in batches", func(t *testing.T) {
+		requestsBefore := keyholder.decryptRequests.Load()
+
+		const shares = 40
+		ciphertexts := make([][]byte, 0, shares+1)
+		for i := 0; i < shares; i++ {
+			ciphertext, err := remoteKey.Public().Encrypt([]byte(fmt.Sprintf("share %d", i)))
+			require.NoError(t, err)
+			ciphertexts = append(ciphertexts, ciphertext)
+		}
+		// Duplicates are decrypted once.
+		ciphertexts = append(ciphertexts, ciphertexts[0])
+
+		plaintexts, err := remoteKey.(keys.BatchDecrypter).DecryptBatch(ciphertexts)
+		require.NoError(t, err)
+		require.Len(t, plaintexts, shares+1)
+		for i := 0; i < shares; i++ {
+			require.Equal(t, []byte(fmt.Sprintf("share %d", i)), plaintexts[i])
+		}
+		require.Equal(t, plaintexts[0], plaintexts[shares])
+		// 40 shares in batches of up to 16.
+		require.EqualValues(t, 3, keyholder.decryptRequests.Load()-requestsBefore)
+
+		// Decrypted shares aren't kept.
+		plaintext, err := remoteKey.Decrypt(ciphertexts[1])
+		require.NoError(t, err)
+		require.Equal(t, []byte("share 1"), plaintext)
+		require.EqualValues(t, 4, keyholder.decryptRequests.Load()-requestsBefore)
+	})
+
+	t.Run("hashes match the local key", func(t *testing.T) {
+		localKey, err := keys.PrivateKeyFromBytes(rsaencryption.PrivateKeyToByte(keyholder.privKey))
+		require.NoError(t, err)
+
+		localStorageHash, err := localKey.StorageHash()
+		require.NoError(t, err)
+		storageHash, err := remoteKey.StorageHash()
+		require.NoError(t, err)
+		require.Equal(t, localStorageHash, storageHash)
+
+		localEKMHash, err := localKey.EKMHash()
+		require.NoError(t, err)
+		ekmHash, err := remoteKey.EKMHash()
+		require.NoError(t, err)
+		require.Equal(t, localEKMHash, ekmHash)
+	})
+
+	t.Run("key mismatch is detected", func(t *testing.T) {
+		otherKeyholder := newTestKeyholder(t)
+		otherKeyholder.pubKey = &keyholder.privKey.PublicKey
+
+		_, err := keys.NewRemoteKey(ctx, zap.NewNop(), keys.RemoteKeyOptions{
+			URL:          otherKeyholder.server.URL,
+			Timeout:      5 * time.Second,
+			MaxBatchSize: 1,
+			CacheSize:    1,
+		})
+		require.ErrorContains(t, err, "invalid signature")
+	})
+}
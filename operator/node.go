@@ -39,8 +39,14 @@ type Options struct {
 	ValidatorStore      storage2.ValidatorStore
 	ValidatorOptions    validator.ControllerOptions `yaml:"ValidatorOptions"`
 	DutyStore           *dutystore.Store
-	WS                  api.WebSocketServer
-	WsAPIPort           int
+	// SyncedQBFTStores store the decided participants synced from peers, which the exporter APIs serve
+	// where none were observed. Optional.
+	SyncedQBFTStores   *qbftstorage.QBFTStores
+	WS                 api.WebSocketServer
+	WsAPIPort          int
+	GRPCAPIPort        int
+	GRPCAPITLSCertFile string
+	GRPCAPITLSKeyFile  string
 }
 
 type Node struct {
@@ -53,11 +59,15 @@ type Node struct {
 	net              network.P2PNetwork
 	storage          storage.Storage
 	qbftStorage      *qbftstorage.QBFTStores
+	syncedStorage    *qbftstorage.QBFTStores
 	dutyScheduler    *duties.Scheduler
 	feeRecipientCtrl fee_recipient.RecipientController
 
-	ws        api.WebSocketServer
-	wsAPIPort int
+	ws                 api.WebSocketServer
+	wsAPIPort          int
+	grpcAPIPort        int
+	grpcAPITLSCertFile string
+	grpcAPITLSKeyFile  string
 }
 
 // New is the constructor of Node
@@ -72,6 +82,7 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 		net:              opts.P2PNetwork,
 		storage:          opts.ValidatorOptions.RegistryStorage,
 		qbftStorage:      qbftStorage,
+		syncedStorage:    opts.SyncedQBFTStores,
 		dutyScheduler: duties.NewScheduler(&duties.SchedulerOptions{
 			Ctx:                 opts.Context,
 			BeaconNode:          opts.BeaconNode,
@@ -85,6 +96,7 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 			DutyStore:           opts.DutyStore,
 			SlotTickerProvider:  slotTickerProvider,
 			P2PNetwork:          opts.P2PNetwork,
+			EventBus:            opts.ValidatorOptions.EventBus,
 		}),
 		feeRecipientCtrl: fee_recipient.NewController(&fee_recipient.ControllerOptions{
 			Ctx:                opts.Context,
@@ -96,8 +108,11 @@ func New(logger *zap.Logger, opts Options, slotTickerProvider slotticker.Provide
 			SlotTickerProvider: slotTickerProvider,
 		}),
 
-		ws:        opts.WS,
-		wsAPIPort: opts.WsAPIPort,
+		ws:                 opts.WS,
+		wsAPIPort:          opts.WsAPIPort,
+		grpcAPIPort:        opts.GRPCAPIPort,
+		grpcAPITLSCertFile: opts.GRPCAPITLSCertFile,
+		grpcAPITLSKeyFile:  opts.GRPCAPITLSKeyFile,
 	}
 
 	return node
@@ -116,6 +131,11 @@ func (n *Node) Start(logger *zap.Logger) error {
 			return
 		}
 	}()
+	go func() {
+		if err := n.startGRPCServer(logger); err != nil {
+			logger.Fatal("failed to start gRPC server", zap.Error(err))
+		}
+	}()
 
 	// Start the duty scheduler, and a background goroutine to crash the node
 	// in case there were any errors.
@@ -165,7 +185,9 @@ func (n *Node) handleQueryRequests(logger *zap.Logger, nm *api.NetworkMessage) {
 		zap.String("type", string(nm.Msg.Type)))
 	switch nm.Msg.Type {
 	case api.TypeDecided:
-		api.HandleParticipantsQuery(logger, n.qbftStorage, nm, n.network.DomainType)
+		api.HandleParticipantsQuery(logger, n.qbftStorage, n.syncedStorage, nm, n.network.DomainType)
+	case api.TypeDuties:
+		api.HandleDutiesQuery(logger, n.validatorOptions.Archive, nm)
 	case api.TypeError:
 		api.HandleErrorQuery(logger, nm)
 	default:
@@ -174,10 +196,11 @@ func (n *Node) handleQueryRequests(logger *zap.Logger, nm *api.NetworkMessage) {
 }
 
 func (n *Node) startWSServer(logger *zap.Logger) error {
-	if n.ws != nil {
+	if n.ws != nil && n.wsAPIPort != 0 {
 		logger.Info("starting WS server")
 
 		n.ws.UseQueryHandler(n.handleQueryRequests)
+		n.ws.UseStreamStorage(n.streamStorage())
 
 		if err := n.ws.Start(logger, fmt.Sprintf(":%d", n.wsAPIPort)); err != nil {
 			return err
@@ -187,6 +210,29 @@ func (n *Node) startWSServer(logger *zap.Logger) error {
 	return nil
 }
 
+func (n *Node) startGRPCServer(logger *zap.Logger) error {
+	if n.ws != nil && n.grpcAPIPort != 0 {
+		logger.Info("starting gRPC server")
+
+		server := api.NewGRPCServer(logger, n.ws.BroadcastFeed(), n.network.DomainType, n.streamStorage())
+		if err := server.Start(n.context, fmt.Sprintf(":%d", n.grpcAPIPort), n.grpcAPITLSCertFile, n.grpcAPITLSKeyFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *Node) streamStorage() *api.StreamStorage {
+	return &api.StreamStorage{
+		DomainType:       n.network.DomainType,
+		Shares:           n.storage.Shares(),
+		QBFTStores:       n.qbftStorage,
+		SyncedQBFTStores: n.syncedStorage,
+		CurrentSlot:      n.network.Beacon.EstimatedCurrentSlot,
+	}
+}
+
 func (n *Node) reportOperators(logger *zap.Logger) {
 	operators, err := n.storage.ListOperators(nil, 0, 1000) // TODO more than 1000?
 	if err != nil {
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// BackupHeader describes the content of a database backup,
+// so that it's only restored for the same network and operator key.
+type BackupHeader struct {
+	CreatedAt          time.Time   `json:"created_at"`
+	Config             *ConfigLock `json:"config"`
+	PrivateKeyHash     string      `json:"private_key_hash"`
+	LastProcessedBlock *big.Int    `json:"last_processed_block,omitempty"`
+}
+
+// NewBackupHeader describes the current content of the storage.
+func NewBackupHeader(s Storage) (*BackupHeader, error) {
+	config, found, err := s.GetConfig(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("config not found, the node must be started at least once")
+	}
+
+	privateKeyHash, found, err := s.GetPrivateKeyHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private key hash: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("private key hash not found, the node must be started at least once")
+	}
+
+	lastProcessedBlock, _, err := s.GetLastProcessedBlock(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last processed block: %w", err)
+	}
+
+	return &BackupHeader{
+		CreatedAt:          time.Now().UTC(),
+		Config:             config,
+		PrivateKeyHash:     privateKeyHash,
+		LastProcessedBlock: lastProcessedBlock,
+	}, nil
+}
+
+// MarshalBackupHeader returns the encoded header describing the current content of the storage.
+func MarshalBackupHeader(s Storage) ([]byte, error) {
+	header, err := NewBackupHeader(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(header)
+}
+
+// ValidateRestore returns an error if the backup isn't of the given config,
+// or of an operator key with one of the given private key hashes.
+func (h *BackupHeader) ValidateRestore(current *ConfigLock, privateKeyHashes ...string) error {
+	if h.Config == nil {
+		return fmt.Errorf("backup has no config")
+	}
+	if err := h.Config.ValidateCompatibility(current); err != nil {
+		return fmt.Errorf("backup config is incompatible: %w", err)
+	}
+
+	for _, privateKeyHash := range privateKeyHashes {
+		if privateKeyHash != "" && privateKeyHash == h.PrivateKeyHash {
+			return nil
+		}
+	}
+	return fmt.Errorf("backup is of a different operator key")
+}
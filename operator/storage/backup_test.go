@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestBackupHeader(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	storage, err := NewNodeStorage(logger, db)
+	require.NoError(t, err)
+
+	_, err = NewBackupHeader(storage)
+	require.ErrorContains(t, err, "config not found")
+
+	config := &ConfigLock{NetworkName: networkconfig.TestNetwork.Name}
+	require.NoError(t, storage.SaveConfig(nil, config))
+	require.NoError(t, storage.SavePrivateKeyHash("hash"))
+	require.NoError(t, storage.SaveLastProcessedBlock(nil, big.NewInt(123)))
+
+	encodedHeader, err := MarshalBackupHeader(storage)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, db.Backup(&buf, encodedHeader))
+	backup := buf.Bytes()
+
+	_, restoredHeader, err := kv.ReadBackupHeader(bytes.NewReader(backup))
+	require.NoError(t, err)
+	var header BackupHeader
+	require.NoError(t, json.Unmarshal(restoredHeader, &header))
+	require.Equal(t, config, header.Config)
+	require.Equal(t, "hash", header.PrivateKeyHash)
+	require.EqualValues(t, 123, header.LastProcessedBlock.Uint64())
+
+	t.Run("restore is validated", func(t *testing.T) {
+		require.NoError(t, header.ValidateRestore(config, "other hash", "hash"))
+		require.ErrorContains(t, header.ValidateRestore(config, "other hash", ""), "different operator key")
+		require.ErrorContains(t, header.ValidateRestore(&ConfigLock{NetworkName: "other"}, "hash"), "network mismatch")
+		require.ErrorContains(t, header.ValidateRestore(&ConfigLock{NetworkName: config.NetworkName, UsingLocalEvents: true}, "hash"), "local events")
+	})
+
+	t.Run("backup is restored", func(t *testing.T) {
+		restoredDB, err := kv.NewInMemory(logger, basedb.Options{})
+		require.NoError(t, err)
+		defer func() {
+			_ = restoredDB.Close()
+		}()
+		require.NoError(t, restoredDB.Restore(bytes.NewReader(backup)))
+
+		restoredStorage, err := NewNodeStorage(logger, restoredDB)
+		require.NoError(t, err)
+		restored, err := NewBackupHeader(restoredStorage)
+		require.NoError(t, err)
+		require.Equal(t, header.Config, restored.Config)
+		require.Equal(t, header.PrivateKeyHash, restored.PrivateKeyHash)
+		require.Equal(t, header.LastProcessedBlock, restored.LastProcessedBlock)
+	})
+}
@@ -130,6 +130,10 @@ func (s *storage) GetRecipientDataMany(r basedb.Reader, owners []common.Address)
 	return s.recipientStore.GetRecipientDataMany(r, owners)
 }
 
+func (s *storage) ListRecipients(r basedb.Reader) ([]registrystorage.RecipientData, error) {
+	return s.recipientStore.ListRecipients(r)
+}
+
 func (s *storage) SaveRecipientData(rw basedb.ReadWriter, recipientData *registrystorage.RecipientData) (*registrystorage.RecipientData, error) {
 	return s.recipientStore.SaveRecipientData(rw, recipientData)
 }
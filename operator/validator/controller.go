@@ -17,6 +17,7 @@ import (
 	"github.com/pkg/errors"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/exporter/convert"
@@ -28,7 +29,9 @@ import (
 	networkcommons "github.com/ssvlabs/ssv/network/commons"
 	"github.com/ssvlabs/ssv/network/records"
 	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/observability/events"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/doppelganger"
 	"github.com/ssvlabs/ssv/operator/duties"
 	nodestorage "github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/operator/validators"
@@ -70,21 +73,28 @@ type ControllerOptions struct {
 	MinPeers                   int           `yaml:"MinimumPeers" env:"MINIMUM_PEERS" env-default:"2" env-description:"The required minimum peers for sync"`
 	Network                    P2PNetwork
 	Beacon                     beaconprotocol.BeaconNode
-	FullNode                   bool `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
-	Exporter                   bool `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
+	FullNode                   bool   `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
+	Exporter                   bool   `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
+	ExporterArchive            bool   `yaml:"ExporterArchive" env:"EXPORTER_ARCHIVE" env-default:"false" env-description:"Archive the decided messages and signatures of duties, rather than just their participants (requires Exporter)"`
+	ExporterArchiveRetention   uint64 `yaml:"ExporterArchiveRetention" env:"EXPORTER_ARCHIVE_RETENTION" env-default:"0" env-description:"Number of epochs to keep archived duties for, or 0 to keep them forever"`
 	BeaconSigner               spectypes.BeaconSigner
 	OperatorSigner             ssvtypes.OperatorSigner
 	OperatorDataStore          operatordatastore.OperatorDataStore
 	RegistryStorage            nodestorage.Storage
 	RecipientsStorage          Recipients
 	NewDecidedHandler          qbftcontroller.NewDecidedHandler
+	DoppelgangerHandler        doppelganger.Handler
 	DutyRoles                  []spectypes.BeaconRole
 	StorageMap                 *storage.QBFTStores
+	Archive                    *storage.Archive
 	ValidatorStore             registrystorage.ValidatorStore
 	MessageValidator           validation.MessageValidator
 	ValidatorsMap              *validators.ValidatorsMap
 	NetworkConfig              networkconfig.NetworkConfig
 	Graffiti                   []byte
+	EventBus                   *events.Bus
+	// RoundTimerClock drives the round timers of QBFT instances instead of the wall clock, such as in simulations. Optional.
+	RoundTimerClock roundtimer.Clock
 
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"256" env-description:"Number of goroutines to use for message workers"`
@@ -155,12 +165,15 @@ type controller struct {
 	operatorsStorage  registrystorage.Operators
 	recipientsStorage Recipients
 	ibftStorageMap    *storage.QBFTStores
+	archive           *storage.Archive
 
 	beacon         beaconprotocol.BeaconNode
 	beaconSigner   spectypes.BeaconSigner
 	operatorSigner ssvtypes.OperatorSigner
 
-	operatorDataStore operatordatastore.OperatorDataStore
+	operatorDataStore   operatordatastore.OperatorDataStore
+	doppelgangerHandler doppelganger.Handler
+	eventBus            *events.Bus
 
 	validatorOptions        validator.Options
 	validatorStore          registrystorage.ValidatorStore
@@ -218,6 +231,8 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		GasLimit:          options.GasLimit,
 		MessageValidator:  options.MessageValidator,
 		Graffiti:          options.Graffiti,
+		EventBus:          options.EventBus,
+		RoundTimerClock:   options.RoundTimerClock,
 	}
 
 	// If full node, increase queue size to make enough room
@@ -229,6 +244,11 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		}
 	}
 
+	doppelgangerHandler := options.DoppelgangerHandler
+	if doppelgangerHandler == nil {
+		doppelgangerHandler = doppelganger.NoOpHandler()
+	}
+
 	beaconNetwork := options.NetworkConfig.Beacon
 	cacheTTL := beaconNetwork.SlotDurationSec() * time.Duration(beaconNetwork.SlotsPerEpoch()*2) // #nosec G115
 
@@ -239,6 +259,7 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		operatorsStorage:  options.RegistryStorage,
 		recipientsStorage: options.RegistryStorage,
 		ibftStorageMap:    options.StorageMap,
+		archive:           options.Archive,
 		validatorStore:    options.ValidatorStore,
 		ctx:               options.Context,
 		beacon:            options.Beacon,
@@ -247,6 +268,9 @@ func NewController(logger *zap.Logger, options ControllerOptions) Controller {
 		operatorSigner:    options.OperatorSigner,
 		network:           options.Network,
 
+		doppelgangerHandler: doppelgangerHandler,
+		eventBus:            options.EventBus,
+
 		validatorsMap:    options.ValidatorsMap,
 		validatorOptions: validatorOptions,
 
@@ -335,6 +359,10 @@ func (c *controller) handleRouterMessages() {
 					continue
 				}
 
+				if m.SignedSSVMessage != nil {
+					c.doppelgangerHandler.ObserveMessage(m.SignedSSVMessage.OperatorIDs)
+				}
+
 				// TODO: only try copying clusterid if validator failed
 				dutyExecutorID := m.GetID().GetDutyExecutorID()
 				var cid spectypes.CommitteeID
@@ -388,6 +416,7 @@ func (c *controller) handleWorkerMessages(msg network.DecodedSSVMessage) error {
 			AttesterRoots:     c.attesterRoots,
 			SyncCommRoots:     c.syncCommRoots,
 			DomainCache:       c.domainCache,
+			Archive:           c.archive,
 		}
 		ncv = &committeeObserver{
 			CommitteeObserver: validator.NewCommitteeObserver(convert.MessageID(ssvMsg.MsgID), committeeObserverOptions),
@@ -412,13 +441,18 @@ func (c *controller) handleNonCommitteeMessages(msg *queue.SSVMessage, ncv *comm
 	defer c.committeesObserversMutex.Unlock()
 
 	if msg.MsgType == spectypes.SSVConsensusMsgType {
-		// Process proposal messages for committee consensus only to get the roots
-		if msg.MsgID.GetRoleType() != spectypes.RoleCommittee {
+		subMsg, ok := msg.Body.(*specqbft.Message)
+		if !ok {
 			return nil
 		}
 
-		subMsg, ok := msg.Body.(*specqbft.Message)
-		if !ok || subMsg.MsgType != specqbft.ProposalMsgType {
+		// Archive decided messages of all roles, if enabled.
+		if err := ncv.OnConsensusMsg(msg); err != nil {
+			return err
+		}
+
+		// Process proposal messages for committee consensus only to get the roots
+		if msg.MsgID.GetRoleType() != spectypes.RoleCommittee || subMsg.MsgType != specqbft.ProposalMsgType {
 			return nil
 		}
 
@@ -592,6 +626,8 @@ func (c *controller) startValidators(validators []*validator.Validator, committe
 
 // StartNetworkHandlers init msg worker that handles network messages
 func (c *controller) StartNetworkHandlers() {
+	c.doppelgangerHandler.Start(c.ctx)
+
 	c.network.UseMessageRouter(c.messageRouter)
 	for i := 0; i < networkRouterConcurrency; i++ {
 		go c.handleRouterMessages()
@@ -662,6 +698,12 @@ func (c *controller) GetValidator(pubKey spectypes.ValidatorPK) (*validator.Vali
 }
 
 func (c *controller) ExecuteDuty(ctx context.Context, logger *zap.Logger, duty *spectypes.ValidatorDuty) {
+	if err := c.doppelgangerHandler.CanSign(); err != nil {
+		logger.Debug("skipping duty", zap.Error(err))
+		c.publishDutySkipped(duty.Type, duty.Slot, nil, []phase0.BLSPubKey{duty.PubKey}, err)
+		return
+	}
+
 	// because we're using the same duty for more than 1 duty (e.g. attest + aggregator) there is an error in bls.Deserialize func for cgo pointer to pointer.
 	// so we need to copy the pubkey val to avoid pointer
 	pk := make([]byte, 48)
@@ -671,39 +713,86 @@ func (c *controller) ExecuteDuty(ctx context.Context, logger *zap.Logger, duty *
 		ssvMsg, err := CreateDutyExecuteMsg(duty, pk, c.networkConfig.DomainType)
 		if err != nil {
 			logger.Error("could not create duty execute msg", zap.Error(err))
+			c.publishDutySkipped(duty.Type, duty.Slot, nil, []phase0.BLSPubKey{duty.PubKey}, err)
 			return
 		}
 		dec, err := queue.DecodeSSVMessage(ssvMsg)
 		if err != nil {
 			logger.Error("could not decode duty execute msg", zap.Error(err))
+			c.publishDutySkipped(duty.Type, duty.Slot, nil, []phase0.BLSPubKey{duty.PubKey}, err)
 			return
 		}
+		dec.TraceContext = trace.SpanContextFromContext(ctx)
 		if pushed := v.Queues[duty.RunnerRole()].Q.TryPush(dec); !pushed {
 			logger.Warn("dropping ExecuteDuty message because the queue is full")
+			c.publishDutySkipped(duty.Type, duty.Slot, nil, []phase0.BLSPubKey{duty.PubKey}, errors.New("validator queue is full"))
 		}
 		// logger.Debug("📬 queue: pushed message", fields.MessageID(dec.MsgID), fields.MessageType(dec.MsgType))
 	} else {
 		logger.Warn("could not find validator")
+		c.publishDutySkipped(duty.Type, duty.Slot, nil, []phase0.BLSPubKey{duty.PubKey}, errors.New("validator not found"))
 	}
 }
 
 func (c *controller) ExecuteCommitteeDuty(ctx context.Context, logger *zap.Logger, committeeID spectypes.CommitteeID, duty *spectypes.CommitteeDuty) {
+	if err := c.doppelgangerHandler.CanSign(); err != nil {
+		logger.Debug("skipping committee duty", zap.Error(err))
+		c.publishCommitteeDutySkipped(committeeID, duty, err)
+		return
+	}
+
 	if cm, ok := c.validatorsMap.GetCommittee(committeeID); ok {
 		ssvMsg, err := CreateCommitteeDutyExecuteMsg(duty, committeeID, c.networkConfig.DomainType)
 		if err != nil {
 			logger.Error("could not create duty execute msg", zap.Error(err))
+			c.publishCommitteeDutySkipped(committeeID, duty, err)
 			return
 		}
 		dec, err := queue.DecodeSSVMessage(ssvMsg)
 		if err != nil {
 			logger.Error("could not decode duty execute msg", zap.Error(err))
+			c.publishCommitteeDutySkipped(committeeID, duty, err)
 			return
 		}
 		if err := cm.OnExecuteDuty(ctx, logger, dec.Body.(*ssvtypes.EventMsg)); err != nil {
 			logger.Error("could not execute committee duty", zap.Error(err))
+			c.publishCommitteeDutySkipped(committeeID, duty, err)
 		}
 	} else {
 		logger.Warn("could not find committee", fields.CommitteeID(committeeID))
+		c.publishCommitteeDutySkipped(committeeID, duty, errors.New("committee not found"))
+	}
+}
+
+// publishDutySkipped publishes that a duty of the given validators won't be performed, and why.
+func (c *controller) publishDutySkipped(role spectypes.BeaconRole, slot phase0.Slot, committeeID *spectypes.CommitteeID, validators []phase0.BLSPubKey, reason error) {
+	if !c.eventBus.Active() {
+		return
+	}
+	event := events.Event{
+		Type:       events.DutySkipped,
+		Role:       role.String(),
+		Slot:       slot,
+		Validators: validators,
+		Error:      reason.Error(),
+	}
+	if committeeID != nil {
+		event.CommitteeID = hex.EncodeToString(committeeID[:])
+	}
+	c.eventBus.Publish(event)
+}
+
+// publishCommitteeDutySkipped publishes that a committee duty won't be performed, for each of its beacon roles.
+func (c *controller) publishCommitteeDutySkipped(committeeID spectypes.CommitteeID, duty *spectypes.CommitteeDuty, reason error) {
+	if !c.eventBus.Active() {
+		return
+	}
+	validators := make(map[spectypes.BeaconRole][]phase0.BLSPubKey)
+	for _, validatorDuty := range duty.ValidatorDuties {
+		validators[validatorDuty.Type] = append(validators[validatorDuty.Type], validatorDuty.PubKey)
+	}
+	for role, pubKeys := range validators {
+		c.publishDutySkipped(role, duty.Slot, &committeeID, pubKeys, reason)
 	}
 }
 
@@ -1163,8 +1252,9 @@ func SetupCommitteeRunners(
 				return leader
 			},
 			Network:     options.Network,
-			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil),
+			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil, roundtimer.WithClock(options.RoundTimerClock)),
 			CutOffRound: roundtimer.CutOffRound,
+			EventBus:    options.EventBus,
 		}
 
 		identifier := spectypes.NewMsgID(options.NetworkConfig.DomainType, options.Operator.CommitteeID[:], role)
@@ -1190,6 +1280,7 @@ func SetupCommitteeRunners(
 		if err != nil {
 			return nil, err
 		}
+		crunner.GetBaseRunner().EventBus = options.EventBus
 		return crunner.(*runner.CommitteeRunner), nil
 	}
 }
@@ -1226,8 +1317,9 @@ func SetupRunners(
 				return leader
 			},
 			Network:     options.Network,
-			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil),
+			Timer:       roundtimer.New(ctx, options.NetworkConfig.Beacon, role, nil, roundtimer.WithClock(options.RoundTimerClock)),
 			CutOffRound: roundtimer.CutOffRound,
+			EventBus:    options.EventBus,
 		}
 		config.ValueCheckF = valueCheckF
 
@@ -1264,6 +1356,9 @@ func SetupRunners(
 		if err != nil {
 			return nil, errors.Wrap(err, "could not create duty runner")
 		}
+		if r, ok := runners[role]; ok {
+			r.GetBaseRunner().EventBus = options.EventBus
+		}
 	}
 	return runners, nil
 }
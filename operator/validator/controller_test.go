@@ -30,6 +30,7 @@ import (
 	"github.com/ssvlabs/ssv/network/records"
 	"github.com/ssvlabs/ssv/networkconfig"
 	operatordatastore "github.com/ssvlabs/ssv/operator/datastore"
+	"github.com/ssvlabs/ssv/operator/doppelganger"
 	"github.com/ssvlabs/ssv/operator/keys"
 	"github.com/ssvlabs/ssv/operator/storage"
 	"github.com/ssvlabs/ssv/operator/validator/mocks"
@@ -1015,6 +1016,7 @@ func setupController(logger *zap.Logger, opts MockControllerOptions) controller
 		network:                 opts.network,
 		ibftStorageMap:          opts.StorageMap,
 		operatorDataStore:       opts.operatorDataStore,
+		doppelgangerHandler:     doppelganger.NoOpHandler(),
 		sharesStorage:           opts.sharesStorage,
 		operatorsStorage:        opts.operatorStorage,
 		validatorsMap:           opts.validatorsMap,
@@ -19,10 +19,11 @@ type ValidatorMetadataStorage interface {
 
 // ValidatorMetadata represents validator metdata from beacon
 type ValidatorMetadata struct {
-	Balance         phase0.Gwei              `json:"balance"`
-	Status          eth2apiv1.ValidatorState `json:"status"`
-	Index           phase0.ValidatorIndex    `json:"index"` // pointer in order to support nil
-	ActivationEpoch phase0.Epoch             `json:"activation_epoch"`
+	Balance          phase0.Gwei              `json:"balance"`
+	EffectiveBalance phase0.Gwei              `json:"effective_balance"`
+	Status           eth2apiv1.ValidatorState `json:"status"`
+	Index            phase0.ValidatorIndex    `json:"index"` // pointer in order to support nil
+	ActivationEpoch  phase0.Epoch             `json:"activation_epoch"`
 }
 
 // Equals returns true if the given metadata is equal to current
@@ -31,6 +32,7 @@ func (m *ValidatorMetadata) Equals(other *ValidatorMetadata) bool {
 		m.Status == other.Status &&
 		m.Index == other.Index &&
 		m.Balance == other.Balance &&
+		m.EffectiveBalance == other.EffectiveBalance &&
 		m.ActivationEpoch == other.ActivationEpoch
 }
 
@@ -104,10 +106,11 @@ func FetchValidatorsMetadata(bc BeaconNode, pubKeys [][]byte) (map[spectypes.Val
 	ret := make(map[spectypes.ValidatorPK]*ValidatorMetadata)
 	for _, v := range validatorsIndexMap {
 		meta := &ValidatorMetadata{
-			Balance:         v.Balance,
-			Status:          v.Status,
-			Index:           v.Index,
-			ActivationEpoch: v.Validator.ActivationEpoch,
+			Balance:          v.Balance,
+			EffectiveBalance: v.Validator.EffectiveBalance,
+			Status:           v.Status,
+			Index:            v.Index,
+			ActivationEpoch:  v.Validator.ActivationEpoch,
 		}
 		ret[spectypes.ValidatorPK(v.Validator.PublicKey)] = meta
 	}
@@ -0,0 +1,64 @@
+package protocolp2p
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+)
+
+// StatusCode is the outcome of a sync request
+type StatusCode int32
+
+const (
+	// StatusSuccess means the request was served
+	StatusSuccess StatusCode = iota
+	// StatusNotFound means the peer has no decided participants for the request
+	StatusNotFound
+	// StatusBadRequest means the request was invalid
+	StatusBadRequest
+	// StatusInternalError means the peer failed to serve the request
+	StatusInternalError
+	// StatusBackoff means the peer's limits were exceeded
+	StatusBackoff
+)
+
+// SyncRequest requests the decided participants of a QBFT identifier from a peer
+type SyncRequest struct {
+	Protocol   SyncProtocol `json:"protocol"`
+	Identifier []byte       `json:"identifier"`
+	// Params are the first and last slot of a decided history request
+	Params []phase0.Slot `json:"params,omitempty"`
+}
+
+// SyncResponse is the response of a peer to a SyncRequest
+type SyncResponse struct {
+	Protocol   SyncProtocol `json:"protocol"`
+	Identifier []byte       `json:"identifier"`
+	// Params are the first and last slot covered by a decided history response,
+	// which ends before the requested range when the response is paged
+	Params     []phase0.Slot         `json:"params,omitempty"`
+	StatusCode StatusCode            `json:"statusCode"`
+	Data       []DecidedParticipants `json:"data,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+// DecidedParticipants are the operators whose signatures reached a quorum for a duty in a slot
+type DecidedParticipants struct {
+	Slot    phase0.Slot            `json:"slot"`
+	Signers []spectypes.OperatorID `json:"signers"`
+	// Synced is whether the serving peer synced the participants from its own peers rather than observed them.
+	Synced bool `json:"synced,omitempty"`
+}
+
+// Syncer fetches the decided participants of QBFT identifiers from peers
+type Syncer interface {
+	// SyncHighestDecided returns the highest decided participants of the given identifier among the peers asked,
+	// or nil if none of them has any
+	SyncHighestDecided(logger *zap.Logger, identifier convert.MessageID) (*DecidedParticipants, error)
+	// SyncDecidedByRange fetches the decided participants of the given identifier within the slot range from peers
+	// and saves those which enough of them agree on apart from the observed ones, returning how many were saved.
+	// It's a no-op unless the node is a full node.
+	SyncDecidedByRange(logger *zap.Logger, identifier convert.MessageID, from, to phase0.Slot) (int, error)
+}
@@ -3,6 +3,8 @@ package qbft
 import (
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/roundtimer"
 )
 
@@ -27,6 +29,8 @@ type IConfig interface {
 	GetTimer() roundtimer.Timer
 	// GetRoundCutOff returns the round cut off
 	GetCutOffRound() specqbft.Round
+	// GetEventBus returns the bus to publish the lifecycle events of instances to
+	GetEventBus() *events.Bus
 }
 
 type Config struct {
@@ -37,6 +41,7 @@ type Config struct {
 	Network      specqbft.Network
 	Timer        roundtimer.Timer
 	CutOffRound  specqbft.Round
+	EventBus     *events.Bus
 }
 
 // GetShareSigner returns a BeaconSigner instance
@@ -72,3 +77,8 @@ func (c *Config) GetTimer() roundtimer.Timer {
 func (c *Config) GetCutOffRound() specqbft.Round {
 	return c.CutOffRound
 }
+
+// GetEventBus returns the bus to publish the lifecycle events of instances to
+func (c *Config) GetEventBus() *events.Bus {
+	return c.EventBus
+}
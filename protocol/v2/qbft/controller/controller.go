@@ -12,6 +12,7 @@ import (
 
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/instance"
 	qbftstorage "github.com/ssvlabs/ssv/protocol/v2/qbft/storage"
@@ -73,6 +74,7 @@ func (c *Controller) StartNewInstance(ctx context.Context, logger *zap.Logger, h
 	newInstance := c.addAndStoreNewInstance()
 	newInstance.Start(ctx, logger, value, height)
 	c.forceStopAllInstanceExceptCurrent()
+	c.GetConfig().GetEventBus().Publish(events.QBFT(events.QBFTStarted, c.Identifier, height, newInstance.State.Round))
 	return nil
 }
 
@@ -143,6 +145,7 @@ func (c *Controller) UponExistingInstanceMsg(ctx context.Context, logger *zap.Lo
 	if !decided {
 		return nil, nil
 	}
+	c.publishDecided(decidedMsg)
 
 	if err := c.broadcastDecided(decidedMsg); err != nil {
 		// no need to fail processing instance deciding if failed to save/ broadcast
@@ -8,6 +8,7 @@ import (
 
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/instance"
 )
@@ -62,11 +63,27 @@ func (c *Controller) UponDecided(logger *zap.Logger, msg *specqbft.ProcessingMes
 	}
 
 	if !prevDecided {
+		c.publishDecided(msg.SignedMessage)
 		return msg.SignedMessage, nil
 	}
 	return nil, nil
 }
 
+// publishDecided publishes an event of a decided message of this controller.
+func (c *Controller) publishDecided(decidedMsg *spectypes.SignedSSVMessage) {
+	bus := c.GetConfig().GetEventBus()
+	if !bus.Active() {
+		return
+	}
+	qbftMsg := &specqbft.Message{}
+	if err := qbftMsg.Decode(decidedMsg.SSVMessage.Data); err != nil {
+		return
+	}
+	event := events.QBFT(events.QBFTDecided, c.Identifier, qbftMsg.Height, qbftMsg.Round)
+	event.Signers = decidedMsg.OperatorIDs
+	bus.Publish(event)
+}
+
 func ValidateDecided(
 	config qbft.IConfig,
 	msg *specqbft.ProcessingMessage,
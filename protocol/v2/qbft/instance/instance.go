@@ -9,9 +9,13 @@ import (
 	"github.com/pkg/errors"
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/observability"
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft"
 	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
 )
@@ -30,6 +34,10 @@ type Instance struct {
 	StartValue []byte
 
 	metrics *metrics
+
+	// traceParent is the span context of starting the instance, parenting the span of each round.
+	traceParent trace.SpanContext
+	roundSpan   trace.Span
 }
 
 func NewInstance(
@@ -67,14 +75,16 @@ func NewInstance(
 
 func (i *Instance) ForceStop() {
 	i.forceStop = true
+	i.endRoundSpan(false)
 }
 
 // Start is an interface implementation
 func (i *Instance) Start(ctx context.Context, logger *zap.Logger, value []byte, height specqbft.Height) {
 	i.startOnce.Do(func() {
 		i.StartValue = value
-		i.bumpToRound(ctx, specqbft.FirstRound)
 		i.State.Height = height
+		i.traceParent = trace.SpanContextFromContext(ctx)
+		i.bumpToRound(ctx, specqbft.FirstRound)
 		i.metrics.StartStage()
 		i.config.GetTimer().TimeoutForRound(height, specqbft.FirstRound)
 
@@ -148,6 +158,7 @@ func (i *Instance) ProcessMsg(ctx context.Context, logger *zap.Logger, msg *spec
 			if decided {
 				i.State.Decided = decided
 				i.State.DecidedValue = decidedValue
+				i.endRoundSpan(true)
 			}
 			return err
 		case specqbft.RoundChangeMsgType:
@@ -249,9 +260,30 @@ func (i *Instance) Decode(data []byte) error {
 	return json.Unmarshal(data, &i)
 }
 
-// bumpToRound sets round and sends current round metrics.
+// bumpToRound sets round, publishes round changes and traces the new round.
 func (i *Instance) bumpToRound(ctx context.Context, round specqbft.Round) {
 	i.State.Round = round
+	if round > specqbft.FirstRound {
+		i.config.GetEventBus().Publish(events.QBFT(events.QBFTRoundChanged, i.State.ID, i.State.Height, round))
+	}
+
+	i.endRoundSpan(false)
+	// Rounds changed by timeouts or messages of other operators are traced as part of the duty which started the instance.
+	ctx = trace.ContextWithSpanContext(ctx, i.traceParent)
+	_, i.roundSpan = tracer.Start(ctx, "ssv.qbft.round", trace.WithAttributes(
+		roleAttribute(i.metrics.role),
+		heightAttribute(i.State.Height),
+		observability.DutyRoundAttribute(round)))
+}
+
+// endRoundSpan ends the span of the current round, if any.
+func (i *Instance) endRoundSpan(decided bool) {
+	if i.roundSpan == nil {
+		return
+	}
+	i.roundSpan.SetAttributes(attribute.Bool("ssv.qbft.decided", decided))
+	i.roundSpan.End()
+	i.roundSpan = nil
 }
 
 // CanProcessMessages will return true if instance can process messages
@@ -7,6 +7,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+
 	"github.com/ssvlabs/ssv/observability"
 )
 
@@ -24,7 +26,8 @@ const (
 )
 
 var (
-	meter = otel.Meter(observabilityName)
+	meter  = otel.Meter(observabilityName)
+	tracer = otel.Tracer(observabilityName)
 
 	validatorStageDurationHistogram = observability.NewMetric(
 		meter.Float64Histogram(
@@ -45,3 +48,10 @@ func stageAttribute(stage stage) attribute.KeyValue {
 func roleAttribute(role string) attribute.KeyValue {
 	return attribute.String(observability.RunnerRoleAttrKey, role)
 }
+
+func heightAttribute(height specqbft.Height) attribute.KeyValue {
+	return attribute.KeyValue{
+		Key:   "ssv.qbft.height",
+		Value: observability.Uint64AttributeValue(uint64(height)),
+	}
+}
@@ -0,0 +1,43 @@
+package instance
+
+import (
+	"context"
+	"testing"
+
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	"github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ssvlabs/ssv/protocol/v2/qbft"
+)
+
+func TestInstance_RoundSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	ctx, dutySpan := provider.Tracer("test").Start(context.Background(), "duty")
+	defer dutySpan.End()
+
+	i := NewInstance(&qbft.Config{}, testingutils.TestingCommitteeMember(testingutils.Testing4SharesSet()), []byte{1, 2, 3, 4}, 1, nil)
+	i.traceParent = dutySpan.SpanContext()
+	i.bumpToRound(ctx, specqbft.FirstRound)
+	// Rounds changed outside the duty's context are still traced as part of it.
+	i.bumpToRound(context.Background(), 2)
+	i.endRoundSpan(true)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	for n, span := range spans {
+		require.Equal(t, "ssv.qbft.round", span.Name())
+		require.Equal(t, dutySpan.SpanContext().SpanID(), span.Parent().SpanID())
+		require.Contains(t, span.Attributes(), attribute.Int64("ssv.validator.duty.round", int64(n+1)))
+		require.Contains(t, span.Attributes(), attribute.Bool("ssv.qbft.decided", n == 1))
+	}
+}
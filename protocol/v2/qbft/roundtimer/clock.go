@@ -0,0 +1,33 @@
+package roundtimer
+
+import "time"
+
+// Clock is the source of time of round timers.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel which receives the time once the given duration has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type wallClock struct{}
+
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+func (wallClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Option configures a RoundTimer.
+type Option func(*RoundTimer)
+
+// WithClock makes the timer follow the given clock rather than the wall clock,
+// such as the virtual clock of a simulated network. A nil clock is ignored.
+func WithClock(clock Clock) Option {
+	return func(t *RoundTimer) {
+		if clock != nil {
+			t.clock = clock
+		}
+	}
+}
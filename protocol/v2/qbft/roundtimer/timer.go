@@ -47,8 +47,10 @@ type RoundTimer struct {
 	ctx context.Context
 	// cancelCtx cancels the current context, will be called from Kill()
 	cancelCtx context.CancelFunc
-	// timer is the underlying time.Timer
-	timer *time.Timer
+	// clock is the source of time of the timer
+	clock Clock
+	// stopRound stops waiting for the timeout of the current round
+	stopRound context.CancelFunc
 	// result holds the result of the timer
 	done OnRoundTimeoutF
 	// round is the current round of the timer
@@ -62,13 +64,13 @@ type RoundTimer struct {
 }
 
 // New creates a new instance of RoundTimer.
-func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.RunnerRole, done OnRoundTimeoutF) *RoundTimer {
+func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.RunnerRole, done OnRoundTimeoutF, opts ...Option) *RoundTimer {
 	ctx, cancelCtx := context.WithCancel(pctx)
-	return &RoundTimer{
+	t := &RoundTimer{
 		mtx:           &sync.RWMutex{},
 		ctx:           ctx,
 		cancelCtx:     cancelCtx,
-		timer:         nil,
+		clock:         wallClock{},
 		done:          done,
 		role:          role,
 		beaconNetwork: beaconNetwork,
@@ -78,6 +80,10 @@ func New(pctx context.Context, beaconNetwork BeaconNetwork, role spectypes.Runne
 			slow:           SlowTimeout,
 		},
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
 }
 
 // RoundTimeout calculates the timeout duration for a specific role, height, and round.
@@ -137,7 +143,7 @@ func (t *RoundTimer) RoundTimeout(height specqbft.Height, round specqbft.Round)
 	dutyStartTime := t.beaconNetwork.GetSlotStartTime(phase0.Slot(height))
 
 	// Calculate the time until the duty should start plus the timeout duration
-	return time.Until(dutyStartTime.Add(timeoutDuration))
+	return dutyStartTime.Add(timeoutDuration).Sub(t.clock.Now())
 }
 
 // OnTimeout sets a function called on timeout.
@@ -158,26 +164,20 @@ func (t *RoundTimer) TimeoutForRound(height specqbft.Height, round specqbft.Roun
 	atomic.StoreUint64(&t.round, uint64(round))
 	timeout := t.RoundTimeout(height, round)
 
-	// preparing the underlying timer
-	timer := t.timer
-	if timer == nil {
-		timer = time.NewTimer(timeout)
-	} else {
-		timer.Stop()
-		// draining the channel of existing timer
-		select {
-		case <-timer.C:
-		default:
-		}
+	// stops waiting for the timeout of the previous round
+	t.mtx.Lock()
+	if t.stopRound != nil {
+		t.stopRound()
 	}
-	timer.Reset(timeout)
+	ctx, stopRound := context.WithCancel(t.ctx)
+	t.stopRound = stopRound
+	t.mtx.Unlock()
+
 	// spawns a new goroutine to listen to the timer
-	go t.waitForRound(round, timer.C)
+	go t.waitForRound(ctx, round, t.clock.After(timeout))
 }
 
-func (t *RoundTimer) waitForRound(round specqbft.Round, timeout <-chan time.Time) {
-	ctx, cancel := context.WithCancel(t.ctx)
-	defer cancel()
+func (t *RoundTimer) waitForRound(ctx context.Context, round specqbft.Round, timeout <-chan time.Time) {
 	select {
 	case <-ctx.Done():
 	case <-timeout:
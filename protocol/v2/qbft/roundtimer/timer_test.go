@@ -164,3 +164,79 @@ func testTimeoutForRoundMulti(t *testing.T, role spectypes.RunnerRole, threshold
 	}
 	mu.Unlock()
 }
+
+// manualClock is a Clock which only moves when advanced.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []manualTimer
+}
+
+type manualTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := manualTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	return timer.c
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- c.now
+	}
+	c.timers = pending
+}
+
+func TestTimeoutForRoundWithClock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockBeaconNetwork := mocks.NewMockBeaconNetwork(ctrl)
+
+	slotStart := time.Unix(1700000000, 0)
+	mockBeaconNetwork.EXPECT().SlotDurationSec().Return(12 * time.Second).AnyTimes()
+	mockBeaconNetwork.EXPECT().GetSlotStartTime(gomock.Any()).Return(slotStart).AnyTimes()
+
+	clock := &manualClock{now: slotStart}
+	timeouts := make(chan specqbft.Round, 2)
+	timer := New(context.Background(), mockBeaconNetwork, spectypes.RoleCommittee, func(round specqbft.Round) {
+		timeouts <- round
+	}, WithClock(clock))
+
+	// Timeouts are relative to the clock rather than to the wall clock.
+	timer.TimeoutForRound(specqbft.FirstHeight, specqbft.FirstRound)
+	require.Equal(t, 6*time.Second, timer.RoundTimeout(specqbft.FirstHeight, specqbft.FirstRound))
+
+	clock.advance(5 * time.Second)
+	timer.TimeoutForRound(specqbft.FirstHeight, specqbft.Round(2)) // reset before elapsed
+	clock.advance(5 * time.Second)
+
+	select {
+	case round := <-timeouts:
+		require.Equal(t, specqbft.Round(2), round)
+	case <-time.After(time.Second):
+		require.Fail(t, "round 2 didn't time out")
+	}
+	select {
+	case round := <-timeouts:
+		require.Fail(t, "unexpected timeout", "round %d", round)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
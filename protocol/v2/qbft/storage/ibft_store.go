@@ -30,6 +30,8 @@ type ParticipantsRangeEntry struct {
 	Slot       phase0.Slot
 	Signers    []spectypes.OperatorID
 	Identifier convert.MessageID
+	// Synced is whether the participants were synced from peers rather than observed by this node.
+	Synced bool
 }
 
 // QBFTStore is the store used by QBFT components
@@ -43,6 +45,13 @@ type QBFTStore interface {
 	// GetParticipantsInRange returns participants in quorum for the given slot range.
 	GetParticipantsInRange(identifier convert.MessageID, from, to phase0.Slot) ([]ParticipantsRangeEntry, error)
 
+	// GetAllParticipantsInRange returns participants in quorum of all identifiers for the given slot range.
+	GetAllParticipantsInRange(from, to phase0.Slot) ([]ParticipantsRangeEntry, error)
+
+	// GetHighestParticipants returns participants in quorum of the highest slot of the given identifier,
+	// or nil if there are none.
+	GetHighestParticipants(identifier convert.MessageID) (*ParticipantsRangeEntry, error)
+
 	// GetParticipants returns participants in quorum for the given slot.
 	GetParticipants(identifier convert.MessageID, slot phase0.Slot) ([]spectypes.OperatorID, error)
 }
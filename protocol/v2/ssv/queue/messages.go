@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.opentelemetry.io/otel/trace"
 
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
@@ -23,6 +24,10 @@ type SSVMessage struct {
 
 	// Body is the decoded Data.
 	Body interface{} // *specqbft.Message | *spectypes.PartialSignatureMessages | *EventMsg
+
+	// TraceContext is the span context of the local operation which created the message,
+	// such as the scheduling of a duty, to trace its processing as a continuation of it.
+	TraceContext trace.SpanContext
 }
 
 func (d *SSVMessage) DecodedSSVMessage() {}
@@ -87,7 +87,7 @@ func (r *AggregatorRunner) ProcessPreConsensus(ctx context.Context, logger *zap.
 	}
 
 	r.measurements.EndPreConsensus()
-	recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime(), spectypes.RoleAggregator)
+	r.BaseRunner.recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime())
 
 	// only 1 root, verified by basePreConsensusMsgProcessing
 	root := roots[0]
@@ -144,7 +144,7 @@ func (r *AggregatorRunner) ProcessConsensus(ctx context.Context, logger *zap.Log
 	}
 
 	r.measurements.EndConsensus()
-	recordConsensusDuration(ctx, r.measurements.ConsensusTime(), spectypes.RoleAggregator)
+	r.BaseRunner.recordConsensusDuration(ctx, r.measurements.ConsensusTime())
 
 	r.measurements.StartPostConsensus()
 
@@ -208,7 +208,7 @@ func (r *AggregatorRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	}
 
 	r.measurements.EndPostConsensus()
-	recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime(), spectypes.RoleAggregator)
+	r.BaseRunner.recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime())
 
 	var successfullySubmittedAggregates uint32
 	for _, root := range roots {
@@ -240,20 +240,26 @@ func (r *AggregatorRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 
 		start := time.Now()
 
-		if err := r.GetBeaconNode().SubmitSignedAggregateSelectionProof(msg); err != nil {
+		if err := traceSubmission(ctx, spectypes.BNRoleAggregator, func() error {
+			return r.GetBeaconNode().SubmitSignedAggregateSelectionProof(msg)
+		}); err != nil {
 			recordFailedSubmission(ctx, spectypes.BNRoleAggregator)
+			r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleAggregator, nil, err)
+			r.BaseRunner.failDutySpan(err)
 			logger.Error("❌ could not submit to Beacon chain reconstructed contribution and proof",
 				fields.SubmissionTime(time.Since(start)),
 				zap.Error(err))
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed signed aggregate")
 		}
 		successfullySubmittedAggregates++
+		r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleAggregator, nil, nil)
 		logger.Debug("✅ successful submitted aggregate",
 			fields.SubmissionTime(time.Since(start)),
 		)
 	}
 
 	r.GetState().Finished = true
+	r.BaseRunner.endDutySpan()
 
 	r.measurements.EndDutyFlow()
 
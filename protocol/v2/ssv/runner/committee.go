@@ -210,7 +210,7 @@ func (cr *CommitteeRunner) ProcessConsensus(ctx context.Context, logger *zap.Log
 	}
 
 	cr.measurements.EndConsensus()
-	recordConsensusDuration(ctx, cr.measurements.ConsensusTime(), spectypes.RoleCommittee)
+	cr.BaseRunner.recordConsensusDuration(ctx, cr.measurements.ConsensusTime())
 
 	cr.measurements.StartPostConsensus()
 	// decided means consensus is done
@@ -268,6 +268,7 @@ func (cr *CommitteeRunner) ProcessConsensus(ctx context.Context, logger *zap.Log
 	}
 	if validDuties == 0 {
 		cr.BaseRunner.State.Finished = true
+		cr.BaseRunner.endDutySpan()
 		return ErrNoValidDuties
 	}
 
@@ -337,6 +338,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	}
 	if len(beaconObjects) == 0 {
 		cr.BaseRunner.State.Finished = true
+		cr.BaseRunner.endDutySpan()
 		return ErrNoValidDuties
 	}
 
@@ -436,7 +438,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	}
 
 	cr.measurements.EndPostConsensus()
-	recordPostConsensusDuration(ctx, cr.measurements.PostConsensusTime(), spectypes.RoleCommittee)
+	cr.BaseRunner.recordPostConsensusDuration(ctx, cr.measurements.PostConsensusTime())
 
 	logger = logger.With(fields.PostConsensusTime(cr.measurements.PostConsensusTime()))
 
@@ -450,13 +452,18 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 
 	if len(attestations) > 0 {
 		submissionStart := time.Now()
-		if err := cr.beacon.SubmitAttestations(attestations); err != nil {
+		if err := traceSubmission(ctx, spectypes.BNRoleAttester, func() error {
+			return cr.beacon.SubmitAttestations(attestations)
+		}); err != nil {
 			logger.Error("❌ failed to submit attestation", zap.Error(err))
 			recordFailedSubmission(ctx, spectypes.BNRoleAttester)
+			cr.BaseRunner.reportSubmission(ctx, spectypes.BNRoleAttester, validatorIndices(attestationsToSubmit), err)
+			cr.BaseRunner.failDutySpan(err)
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed attestation")
 		}
 
 		recordDutyDuration(ctx, cr.measurements.DutyDurationTime(), spectypes.BNRoleAttester, cr.BaseRunner.State.RunningInstance.State.Round)
+		cr.BaseRunner.reportSubmission(ctx, spectypes.BNRoleAttester, validatorIndices(attestationsToSubmit), nil)
 
 		attestationsCount := len(attestations)
 		if attestationsCount <= math.MaxUint32 {
@@ -488,13 +495,18 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 
 	if len(syncCommitteeMessages) > 0 {
 		submissionStart := time.Now()
-		if err := cr.beacon.SubmitSyncMessages(syncCommitteeMessages); err != nil {
+		if err := traceSubmission(ctx, spectypes.BNRoleSyncCommittee, func() error {
+			return cr.beacon.SubmitSyncMessages(syncCommitteeMessages)
+		}); err != nil {
 			logger.Error("❌ failed to submit sync committee", zap.Error(err))
 			recordFailedSubmission(ctx, spectypes.BNRoleSyncCommittee)
+			cr.BaseRunner.reportSubmission(ctx, spectypes.BNRoleSyncCommittee, validatorIndices(syncCommitteeMessagesToSubmit), err)
+			cr.BaseRunner.failDutySpan(err)
 			return errors.Wrap(err, "could not submit to Beacon chain reconstructed signed sync committee")
 		}
 
 		recordDutyDuration(ctx, cr.measurements.DutyDurationTime(), spectypes.BNRoleSyncCommittee, cr.BaseRunner.State.RunningInstance.State.Round)
+		cr.BaseRunner.reportSubmission(ctx, spectypes.BNRoleSyncCommittee, validatorIndices(syncCommitteeMessagesToSubmit), nil)
 
 		syncMsgsCount := len(syncCommitteeMessages)
 		if syncMsgsCount <= math.MaxUint32 {
@@ -524,6 +536,7 @@ func (cr *CommitteeRunner) ProcessPostConsensus(ctx context.Context, logger *zap
 	// Check if duty has terminated (runner has submitted for all duties)
 	if cr.HasSubmittedAllValidatorDuties(attestationMap, committeeMap) {
 		cr.BaseRunner.State.Finished = true
+		cr.BaseRunner.endDutySpan()
 	}
 	return nil
 }
@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/observability/events"
+	"github.com/ssvlabs/ssv/protocol/v2/message"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv"
+)
+
+// publishPreConsensusQuorum publishes the validators whose pre-consensus partial signatures reached a quorum.
+func (b *BaseRunner) publishPreConsensusQuorum(signedMsg *spectypes.PartialSignatureMessages, roots [][32]byte) {
+	b.publishQuorum(events.PreConsensusQuorum, b.State.PreConsensusContainer, signedMsg, roots)
+}
+
+// publishPostConsensusQuorum publishes the validators whose post-consensus partial signatures reached a quorum.
+func (b *BaseRunner) publishPostConsensusQuorum(signedMsg *spectypes.PartialSignatureMessages, roots [][32]byte) {
+	b.publishQuorum(events.PartialSignatureQuorum, b.State.PostConsensusContainer, signedMsg, roots)
+}
+
+// publishQuorum publishes the validators of the message whose signing roots reached a quorum,
+// with the operators whose partial signatures make up the quorum.
+func (b *BaseRunner) publishQuorum(typ events.Type, container *ssv.PartialSigContainer, signedMsg *spectypes.PartialSignatureMessages, roots [][32]byte) {
+	if !b.EventBus.Active() {
+		return
+	}
+
+	var validators []phase0.ValidatorIndex
+	signers := make(map[spectypes.OperatorID]struct{})
+	for _, msg := range signedMsg.Messages {
+		for _, root := range roots {
+			if msg.SigningRoot == root {
+				validators = append(validators, msg.ValidatorIndex)
+				for signer := range container.GetSignatures(msg.ValidatorIndex, root) {
+					signers[signer] = struct{}{}
+				}
+				break
+			}
+		}
+	}
+	event := b.newEvent(typ, message.RunnerRoleToString(b.RunnerRoleType), validators, nil)
+	for signer := range signers {
+		event.Signers = append(event.Signers, signer)
+	}
+	slices.Sort(event.Signers)
+	b.EventBus.Publish(event)
+}
+
+// reportSubmission records and publishes the result of submitting the given validators' duty to the Beacon node.
+func (b *BaseRunner) reportSubmission(ctx context.Context, role spectypes.BeaconRole, validators []phase0.ValidatorIndex, err error) {
+	b.recordSubmission(ctx, role, validators, err)
+	if !b.EventBus.Active() {
+		return
+	}
+	b.publishEvent(events.BeaconSubmission, role.String(), validators, err)
+}
+
+func validatorIndices[T any](m map[phase0.ValidatorIndex]T) []phase0.ValidatorIndex {
+	indices := make([]phase0.ValidatorIndex, 0, len(m))
+	for index := range m {
+		indices = append(indices, index)
+	}
+	return indices
+}
+
+// publishEvent publishes an event of the running duty of the given validators,
+// or of all the validators of the runner if none are given.
+func (b *BaseRunner) publishEvent(typ events.Type, role string, validators []phase0.ValidatorIndex, err error) {
+	b.EventBus.Publish(b.newEvent(typ, role, validators, err))
+}
+
+func (b *BaseRunner) newEvent(typ events.Type, role string, validators []phase0.ValidatorIndex, err error) events.Event {
+	event := events.Event{
+		Type: typ,
+		Role: role,
+	}
+	if b.State != nil && b.State.StartingDuty != nil {
+		event.Slot = b.State.StartingDuty.DutySlot()
+	}
+	if b.State != nil && b.State.RunningInstance != nil {
+		event.Round = b.State.RunningInstance.State.Round
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	if len(validators) == 0 {
+		for index := range b.Share {
+			validators = append(validators, index)
+		}
+	}
+	seen := make(map[phase0.ValidatorIndex]struct{}, len(validators))
+	for _, index := range validators {
+		share, ok := b.Share[index]
+		if _, dup := seen[index]; !ok || dup {
+			continue
+		}
+		seen[index] = struct{}{}
+		event.Validators = append(event.Validators, phase0.BLSPubKey(share.ValidatorPubKey))
+
+		if event.CommitteeID == "" {
+			committeeID := shareCommitteeID(share)
+			event.CommitteeID = hex.EncodeToString(committeeID[:])
+		}
+	}
+	return event
+}
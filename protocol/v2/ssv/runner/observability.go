@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ssvlabs/ssv-spec/qbft"
@@ -31,8 +33,12 @@ var (
 	lock        sync.Mutex
 )
 
+// validatorAttributes returns whether metrics may be labeled by validator. Tests replace it.
+var validatorAttributes = observability.ValidatorAttributes
+
 var (
-	meter = otel.Meter(observabilityName)
+	meter  = otel.Meter(observabilityName)
+	tracer = otel.Tracer(observabilityName)
 
 	consensusDurationHistogram = observability.NewMetric(
 		meter.Float64Histogram(
@@ -73,6 +79,31 @@ var (
 			metricName("submissions.failed"),
 			metric.WithUnit("{submission}"),
 			metric.WithDescription("total number of failed duty submissions")))
+
+	dutyStartsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("duty.starts"),
+			metric.WithUnit("{duty}"),
+			metric.WithDescription("number of started duties")))
+
+	decidedRoundHistogram = observability.NewMetric(
+		meter.Int64Histogram(
+			metricName("consensus.decided_round"),
+			metric.WithUnit("{round}"),
+			metric.WithDescription("QBFT round in which duties were decided"),
+			metric.WithExplicitBucketBoundaries(1, 2, 3, 4, 5, 6, 8, 10, 12)))
+
+	dutySubmissionsCounter = observability.NewMetric(
+		meter.Int64Counter(
+			metricName("duty.submissions"),
+			metric.WithUnit("{submission}"),
+			metric.WithDescription("number of validator duties submitted to the beacon node, by outcome")))
+)
+
+const (
+	submissionStatusAttrKey = "ssv.validator.duty.submission.status"
+	submissionSucceeded     = "success"
+	submissionFailed        = "failure"
 )
 
 func recordSuccessfulSubmission(ctx context.Context, count uint32, epoch phase0.Epoch, role types.BeaconRole) {
@@ -106,25 +137,139 @@ func recordFailedSubmission(ctx context.Context, role types.BeaconRole) {
 	failedSubmissionCounter.Add(ctx, 1, metric.WithAttributes(observability.BeaconRoleAttribute(role)))
 }
 
-func recordConsensusDuration(ctx context.Context, duration time.Duration, role types.RunnerRole) {
-	consensusDurationHistogram.Record(ctx, duration.Seconds(),
-		metric.WithAttributes(
-			observability.RunnerRoleAttribute(role),
-		))
+func (b *BaseRunner) recordDutyStart(ctx context.Context) {
+	dutyStartsCounter.Add(ctx, 1, metric.WithAttributes(b.dutyAttributes(nil)...))
 }
 
-func recordPreConsensusDuration(ctx context.Context, duration time.Duration, role types.RunnerRole) {
-	preConsensusDurationHistogram.Record(ctx, duration.Seconds(),
-		metric.WithAttributes(
-			observability.RunnerRoleAttribute(role),
-		))
+func (b *BaseRunner) recordPreConsensusDuration(ctx context.Context, duration time.Duration) {
+	preConsensusDurationHistogram.Record(ctx, duration.Seconds(), metric.WithAttributes(b.dutyAttributes(nil)...))
 }
 
-func recordPostConsensusDuration(ctx context.Context, duration time.Duration, role types.RunnerRole) {
-	postConsensusDurationHistogram.Record(ctx, duration.Seconds(),
-		metric.WithAttributes(
-			observability.RunnerRoleAttribute(role),
-		))
+// recordConsensusDuration records how long consensus took and the round it was decided in.
+func (b *BaseRunner) recordConsensusDuration(ctx context.Context, duration time.Duration) {
+	attributes := metric.WithAttributes(b.dutyAttributes(nil)...)
+	consensusDurationHistogram.Record(ctx, duration.Seconds(), attributes)
+	if b.State != nil && b.State.RunningInstance != nil {
+		decidedRoundHistogram.Record(ctx, int64(b.State.RunningInstance.State.Round), attributes)
+	}
+}
+
+func (b *BaseRunner) recordPostConsensusDuration(ctx context.Context, duration time.Duration) {
+	postConsensusDurationHistogram.Record(ctx, duration.Seconds(), metric.WithAttributes(b.dutyAttributes(nil)...))
+}
+
+// recordSubmission records the outcome of submitting the given validators' duty to the Beacon node,
+// or of all the validators of the runner if none are given.
+func (b *BaseRunner) recordSubmission(ctx context.Context, role types.BeaconRole, validators []phase0.ValidatorIndex, err error) {
+	status := submissionSucceeded
+	if err != nil {
+		status = submissionFailed
+	}
+	if len(validators) == 0 {
+		validators = validatorIndices(b.Share)
+	}
+
+	if !validatorAttributes() || len(validators) == 1 {
+		attributes := append(b.dutyAttributes(validators),
+			observability.BeaconRoleAttribute(role),
+			attribute.String(submissionStatusAttrKey, status))
+		dutySubmissionsCounter.Add(ctx, int64(len(validators)), metric.WithAttributes(attributes...))
+		return
+	}
+	for _, index := range validators {
+		attributes := append(b.dutyAttributes([]phase0.ValidatorIndex{index}),
+			observability.BeaconRoleAttribute(role),
+			attribute.String(submissionStatusAttrKey, status))
+		dutySubmissionsCounter.Add(ctx, 1, metric.WithAttributes(attributes...))
+	}
+}
+
+// dutyAttributes labels the metrics of the running duty with the runner's role and committee,
+// and with the validator index if validator attributes are enabled and the duty is of a single validator.
+// Committee runners are labeled by validator only when the given validators are a single validator.
+func (b *BaseRunner) dutyAttributes(validators []phase0.ValidatorIndex) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{observability.RunnerRoleAttribute(b.RunnerRoleType)}
+	for _, share := range b.Share {
+		attributes = append(attributes, observability.CommitteeIDAttribute(shareCommitteeID(share)))
+		break
+	}
+
+	if !validatorAttributes() {
+		return attributes
+	}
+	if len(validators) == 0 && b.RunnerRoleType != types.RoleCommittee {
+		validators = validatorIndices(b.Share)
+	}
+	if len(validators) == 1 {
+		attributes = append(attributes, observability.ValidatorIndexAttribute(uint64(validators[0])))
+	}
+	return attributes
+}
+
+// startDutySpan starts the span of a new duty, ending the span of the previous duty if it didn't finish.
+func (b *BaseRunner) startDutySpan(ctx context.Context, duty types.Duty) context.Context {
+	b.endDutySpan()
+
+	attributes := []attribute.KeyValue{
+		observability.RunnerRoleAttribute(b.RunnerRoleType),
+		observability.BeaconSlotAttribute(duty.DutySlot()),
+	}
+	for _, share := range b.Share {
+		attributes = append(attributes, observability.CommitteeIDAttribute(shareCommitteeID(share)))
+		break
+	}
+	switch duty := duty.(type) {
+	case *types.ValidatorDuty:
+		attributes = append(attributes, observability.ValidatorIndexAttribute(uint64(duty.ValidatorIndex)))
+	case *types.CommitteeDuty:
+		attributes = append(attributes, attribute.Int("ssv.validator.duty.validators", len(duty.ValidatorDuties)))
+	}
+
+	ctx, b.dutySpan = tracer.Start(ctx, "ssv.runner.duty", trace.WithAttributes(attributes...))
+	return ctx
+}
+
+// endDutySpan ends the span of the running duty, if any.
+func (b *BaseRunner) endDutySpan() {
+	if b.dutySpan != nil {
+		b.dutySpan.End()
+		b.dutySpan = nil
+	}
+}
+
+// failDutySpan ends the span of the running duty, if any, with the error which failed it,
+// rather than leaving it open until the next duty starts.
+func (b *BaseRunner) failDutySpan(err error) {
+	if b.dutySpan != nil {
+		observability.EndSpan(b.dutySpan, err)
+		b.dutySpan = nil
+	}
+}
+
+// TraceContext returns ctx with the span of the running duty,
+// parenting the spans of processing its messages.
+func (b *BaseRunner) TraceContext(ctx context.Context) context.Context {
+	if b.dutySpan == nil {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, b.dutySpan)
+}
+
+// traceSubmission traces submitting the duty to the Beacon node.
+func traceSubmission(ctx context.Context, role types.BeaconRole, submit func() error) error {
+	_, span := tracer.Start(ctx, "ssv.beacon.submit", trace.WithAttributes(observability.BeaconRoleAttribute(role)))
+	err := submit()
+	observability.EndSpan(span, err)
+	return err
+}
+
+// shareCommitteeID returns the ID of the committee of operators of the share.
+func shareCommitteeID(share *types.Share) types.CommitteeID {
+	operators := make([]types.OperatorID, 0, len(share.Committee))
+	for _, member := range share.Committee {
+		operators = append(operators, member.Signer)
+	}
+	return types.GetCommitteeID(operators)
 }
 
 func recordDutyDuration(ctx context.Context, duration time.Duration, role types.BeaconRole, round qbft.Round) {
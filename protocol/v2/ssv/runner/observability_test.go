@@ -0,0 +1,115 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ssvlabs/ssv-spec/types"
+	spectestingutils "github.com/ssvlabs/ssv-spec/types/testingutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+func TestSubmissionMetricAttributes(t *testing.T) {
+	// Delta temporality makes every collection return only what was recorded since the previous one.
+	reader := sdkmetric.NewManualReader(sdkmetric.WithTemporalitySelector(func(sdkmetric.InstrumentKind) metricdata.Temporality {
+		return metricdata.DeltaTemporality
+	}))
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	setValidatorAttributes := func(t *testing.T, enabled bool) {
+		validatorAttributes = func() bool { return enabled }
+		t.Cleanup(func() { validatorAttributes = observability.ValidatorAttributes })
+	}
+
+	// submissions collects the duty submissions counted since the previous collection, by their attributes.
+	submissions := func(t *testing.T) map[attribute.Distinct]int64 {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+
+		counts := make(map[attribute.Distinct]int64)
+		for _, scope := range rm.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				if m.Name != metricName("duty.submissions") {
+					continue
+				}
+				for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+					counts[point.Attributes.Equivalent()] = point.Value
+				}
+			}
+		}
+		return counts
+	}
+
+	keySet := spectestingutils.Testing4SharesSet()
+	committee := &BaseRunner{
+		RunnerRoleType: types.RoleCommittee,
+		Share: map[phase0.ValidatorIndex]*types.Share{
+			1: spectestingutils.TestingShare(keySet, 1),
+			2: spectestingutils.TestingShare(keySet, 2),
+			3: spectestingutils.TestingShare(keySet, 3),
+		},
+	}
+	proposer := &BaseRunner{
+		RunnerRoleType: types.RoleProposer,
+		Share:          map[phase0.ValidatorIndex]*types.Share{4: spectestingutils.TestingShare(keySet, 4)},
+	}
+	committeeID := observability.CommitteeIDAttribute(shareCommitteeID(committee.Share[1]))
+
+	labels := func(status string, extra ...attribute.KeyValue) attribute.Distinct {
+		attributes := append([]attribute.KeyValue{
+			observability.RunnerRoleAttribute(types.RoleCommittee),
+			committeeID,
+			observability.BeaconRoleAttribute(types.BNRoleAttester),
+			attribute.String(submissionStatusAttrKey, status),
+		}, extra...)
+		set := attribute.NewSet(attributes...)
+		return set.Equivalent()
+	}
+
+	t.Run("validator attributes off", func(t *testing.T) {
+		setValidatorAttributes(t, false)
+
+		require.Equal(t, []attribute.KeyValue{observability.RunnerRoleAttribute(types.RoleCommittee), committeeID}, committee.dutyAttributes(nil))
+		require.Equal(t, []attribute.KeyValue{observability.RunnerRoleAttribute(types.RoleCommittee), committeeID}, committee.dutyAttributes([]phase0.ValidatorIndex{2}))
+		require.Len(t, proposer.dutyAttributes(nil), 2)
+
+		committee.recordSubmission(context.Background(), types.BNRoleAttester, nil, nil)
+		committee.recordSubmission(context.Background(), types.BNRoleAttester, []phase0.ValidatorIndex{2}, errors.New("rejected"))
+		require.Equal(t, map[attribute.Distinct]int64{
+			labels(submissionSucceeded): 3,
+			labels(submissionFailed):    1,
+		}, submissions(t))
+	})
+
+	t.Run("validator attributes on", func(t *testing.T) {
+		setValidatorAttributes(t, true)
+
+		// Committee duties are labeled by validator only when they're of a single validator.
+		require.Equal(t, []attribute.KeyValue{observability.RunnerRoleAttribute(types.RoleCommittee), committeeID}, committee.dutyAttributes(nil))
+		require.Equal(t, []attribute.KeyValue{
+			observability.RunnerRoleAttribute(types.RoleCommittee),
+			committeeID,
+			observability.ValidatorIndexAttribute(2),
+		}, committee.dutyAttributes([]phase0.ValidatorIndex{2}))
+		require.Contains(t, proposer.dutyAttributes(nil), observability.ValidatorIndexAttribute(4))
+
+		committee.recordSubmission(context.Background(), types.BNRoleAttester, nil, nil)
+		committee.recordSubmission(context.Background(), types.BNRoleAttester, []phase0.ValidatorIndex{2}, errors.New("rejected"))
+		require.Equal(t, map[attribute.Distinct]int64{
+			labels(submissionSucceeded, observability.ValidatorIndexAttribute(1)): 1,
+			labels(submissionSucceeded, observability.ValidatorIndexAttribute(2)): 1,
+			labels(submissionSucceeded, observability.ValidatorIndexAttribute(3)): 1,
+			labels(submissionFailed, observability.ValidatorIndexAttribute(2)):    1,
+		}, submissions(t))
+	})
+}
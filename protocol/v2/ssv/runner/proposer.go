@@ -102,7 +102,7 @@ func (r *ProposerRunner) ProcessPreConsensus(ctx context.Context, logger *zap.Lo
 	}
 
 	r.measurements.EndPreConsensus()
-	recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime(), spectypes.RoleProposer)
+	r.BaseRunner.recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime())
 
 	// only 1 root, verified in basePreConsensusMsgProcessing
 	root := roots[0]
@@ -166,7 +166,7 @@ func (r *ProposerRunner) ProcessConsensus(ctx context.Context, logger *zap.Logge
 	}
 
 	r.measurements.EndConsensus()
-	recordConsensusDuration(ctx, r.measurements.ConsensusTime(), spectypes.RoleProposer)
+	r.BaseRunner.recordConsensusDuration(ctx, r.measurements.ConsensusTime())
 
 	r.measurements.StartPostConsensus()
 
@@ -255,7 +255,7 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 		copy(specSig[:], sig)
 
 		r.measurements.EndPostConsensus()
-		recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime(), spectypes.RoleProposer)
+		r.BaseRunner.recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime())
 
 		logger.Debug("🧩 reconstructed partial post consensus signatures proposer",
 			zap.Uint64s("signers", getPostConsensusProposerSigners(r.GetState(), root)),
@@ -293,8 +293,12 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 				zap.NamedError("summarize_err", summarizeErr),
 			)
 
-			if err := r.GetBeaconNode().SubmitBlindedBeaconBlock(vBlindedBlk, specSig); err != nil {
+			if err := traceSubmission(ctx, spectypes.BNRoleProposer, func() error {
+				return r.GetBeaconNode().SubmitBlindedBeaconBlock(vBlindedBlk, specSig)
+			}); err != nil {
 				recordFailedSubmission(ctx, spectypes.BNRoleProposer)
+				r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleProposer, nil, err)
+				r.BaseRunner.failDutySpan(err)
 				logger.Error("❌ could not submit blinded Beacon block",
 					fields.SubmissionTime(time.Since(start)),
 					zap.Error(err))
@@ -311,8 +315,12 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 				zap.NamedError("summarize_err", summarizeErr),
 			)
 
-			if err := r.GetBeaconNode().SubmitBeaconBlock(vBlk, specSig); err != nil {
+			if err := traceSubmission(ctx, spectypes.BNRoleProposer, func() error {
+				return r.GetBeaconNode().SubmitBeaconBlock(vBlk, specSig)
+			}); err != nil {
 				recordFailedSubmission(ctx, spectypes.BNRoleProposer)
+				r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleProposer, nil, err)
+				r.BaseRunner.failDutySpan(err)
 				logger.Error("❌ could not submit Beacon block",
 					fields.SubmissionTime(time.Since(start)),
 					zap.Error(err))
@@ -321,6 +329,7 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 		}
 
 		successfullySubmittedProposals++
+		r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleProposer, nil, nil)
 		logger.Info("✅ successfully submitted block proposal",
 			fields.Slot(validatorConsensusData.Duty.Slot),
 			fields.Height(r.BaseRunner.QBFTController.Height),
@@ -332,6 +341,7 @@ func (r *ProposerRunner) ProcessPostConsensus(ctx context.Context, logger *zap.L
 	}
 
 	r.GetState().Finished = true
+	r.BaseRunner.endDutySpan()
 
 	r.measurements.EndDutyFlow()
 
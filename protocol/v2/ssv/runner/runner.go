@@ -8,10 +8,13 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	specqbft "github.com/ssvlabs/ssv-spec/qbft"
 	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/observability/events"
 	"github.com/ssvlabs/ssv/protocol/v2/blockchain/beacon"
 	"github.com/ssvlabs/ssv/protocol/v2/qbft/controller"
 	"github.com/ssvlabs/ssv/protocol/v2/ssv"
@@ -64,9 +67,14 @@ type BaseRunner struct {
 
 	// implementation vars
 	TimeoutF TimeoutF `json:"-"`
+	// EventBus receives the lifecycle events of the runner's duties. Optional.
+	EventBus *events.Bus `json:"-"`
 
 	// highestDecidedSlot holds the highest decided duty slot and gets updated after each decided is reached
 	highestDecidedSlot phase0.Slot
+
+	// dutySpan traces the running duty, parenting the spans of processing its messages
+	dutySpan trace.Span
 }
 
 func (b *BaseRunner) Encode() ([]byte, error) {
@@ -148,6 +156,8 @@ func (b *BaseRunner) baseStartNewDuty(ctx context.Context, logger *zap.Logger, r
 	}
 
 	b.baseSetupForNewDuty(duty, quorum)
+	b.recordDutyStart(ctx)
+	ctx = b.startDutySpan(ctx, duty)
 
 	return runner.executeDuty(ctx, logger, duty)
 }
@@ -158,6 +168,8 @@ func (b *BaseRunner) baseStartNewNonBeaconDuty(ctx context.Context, logger *zap.
 		return errors.Wrap(err, "can't start non-beacon duty")
 	}
 	b.baseSetupForNewDuty(duty, quorum)
+	b.recordDutyStart(ctx)
+	ctx = b.startDutySpan(ctx, duty)
 	return runner.executeDuty(ctx, logger, duty)
 }
 
@@ -168,6 +180,9 @@ func (b *BaseRunner) basePreConsensusMsgProcessing(runner Runner, signedMsg *spe
 	}
 
 	hasQuorum, roots := b.basePartialSigMsgProcessing(signedMsg, b.State.PreConsensusContainer)
+	if hasQuorum {
+		b.publishPreConsensusQuorum(signedMsg, roots)
+	}
 	return hasQuorum, roots, nil
 }
 
@@ -223,6 +238,9 @@ func (b *BaseRunner) basePostConsensusMsgProcessing(logger *zap.Logger, runner R
 	}
 
 	hasQuorum, roots := b.basePartialSigMsgProcessing(signedMsg, b.State.PostConsensusContainer)
+	if hasQuorum {
+		b.publishPostConsensusQuorum(signedMsg, roots)
+	}
 	return hasQuorum, roots, nil
 }
 
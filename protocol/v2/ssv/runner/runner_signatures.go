@@ -25,10 +25,17 @@ func (b *BaseRunner) signBeaconObject(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get beacon domain")
 	}
-	if _, ok := runner.GetBaseRunner().Share[duty.ValidatorIndex]; !ok {
+	share, ok := runner.GetBaseRunner().Share[duty.ValidatorIndex]
+	if !ok {
 		return nil, fmt.Errorf("unknown validator index %d", duty.ValidatorIndex)
 	}
-	sig, r, err := runner.GetSigner().SignBeaconObject(obj, domain, runner.GetBaseRunner().Share[duty.ValidatorIndex].SharePubKey, domainType)
+	var sig spectypes.Signature
+	var r [32]byte
+	if signer, ok := runner.GetSigner().(types.SlotBeaconSigner); ok {
+		sig, r, err = signer.SignBeaconObjectAtSlot(obj, domain, share.SharePubKey, domainType, slot)
+	} else {
+		sig, r, err = runner.GetSigner().SignBeaconObject(obj, domain, share.SharePubKey, domainType)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not sign beacon object")
 	}
@@ -88,7 +88,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPreConsensus(ctx context.Context,
 	}
 
 	r.measurements.EndPreConsensus()
-	recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime(), spectypes.RoleSyncCommitteeContribution)
+	r.BaseRunner.recordPreConsensusDuration(ctx, r.measurements.PreConsensusTime())
 
 	// collect selection proofs and subnets
 	var (
@@ -129,6 +129,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPreConsensus(ctx context.Context,
 
 	if len(selectionProofs) == 0 {
 		r.GetState().Finished = true
+		r.BaseRunner.endDutySpan()
 		return nil
 	}
 
@@ -173,7 +174,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessConsensus(ctx context.Context, lo
 	}
 
 	r.measurements.EndConsensus()
-	recordConsensusDuration(ctx, r.measurements.ConsensusTime(), spectypes.RoleSyncCommitteeContribution)
+	r.BaseRunner.recordConsensusDuration(ctx, r.measurements.ConsensusTime())
 
 	r.measurements.StartPostConsensus()
 
@@ -245,7 +246,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 	}
 
 	r.measurements.EndPostConsensus()
-	recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime(), spectypes.RoleSyncCommitteeContribution)
+	r.BaseRunner.recordPostConsensusDuration(ctx, r.measurements.PostConsensusTime())
 
 	// get contributions
 	validatorConsensusData := &spectypes.ValidatorConsensusData{}
@@ -293,8 +294,12 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 				Signature: blsSignedContribAndProof,
 			}
 
-			if err := r.GetBeaconNode().SubmitSignedContributionAndProof(signedContribAndProof); err != nil {
+			if err := traceSubmission(ctx, spectypes.BNRoleSyncCommitteeContribution, func() error {
+				return r.GetBeaconNode().SubmitSignedContributionAndProof(signedContribAndProof)
+			}); err != nil {
 				recordFailedSubmission(ctx, spectypes.BNRoleSyncCommitteeContribution)
+				r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleSyncCommitteeContribution, nil, err)
+				r.BaseRunner.failDutySpan(err)
 				logger.Error("❌ could not submit to Beacon chain reconstructed contribution and proof",
 					fields.SubmissionTime(time.Since(start)),
 					zap.Error(err))
@@ -302,6 +307,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 			}
 
 			successfullySubmittedContributions++
+			r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleSyncCommitteeContribution, nil, nil)
 			logger.Debug("✅ successfully submitted sync committee aggregator",
 				fields.SubmissionTime(time.Since(start)),
 			)
@@ -310,6 +316,7 @@ func (r *SyncCommitteeAggregatorRunner) ProcessPostConsensus(ctx context.Context
 	}
 
 	r.GetState().Finished = true
+	r.BaseRunner.endDutySpan()
 
 	r.measurements.EndDutyFlow()
 
@@ -98,16 +98,21 @@ func (r *ValidatorRegistrationRunner) ProcessPreConsensus(ctx context.Context, l
 		return errors.New("no share to get validator public key")
 	}
 
-	if err := r.beacon.SubmitValidatorRegistration(share.ValidatorPubKey[:],
-		share.FeeRecipientAddress, specSig); err != nil {
+	if err := traceSubmission(ctx, spectypes.BNRoleValidatorRegistration, func() error {
+		return r.beacon.SubmitValidatorRegistration(share.ValidatorPubKey[:], share.FeeRecipientAddress, specSig)
+	}); err != nil {
+		r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleValidatorRegistration, nil, err)
+		r.BaseRunner.failDutySpan(err)
 		return errors.Wrap(err, "could not submit validator registration")
 	}
+	r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleValidatorRegistration, nil, nil)
 
 	logger.Debug("validator registration submitted successfully",
 		fields.FeeRecipient(share.FeeRecipientAddress[:]),
 		zap.String("signature", hex.EncodeToString(specSig[:])))
 
 	r.GetState().Finished = true
+	r.BaseRunner.endDutySpan()
 	return nil
 }
 
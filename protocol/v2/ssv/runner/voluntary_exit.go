@@ -98,9 +98,14 @@ func (r *VoluntaryExitRunner) ProcessPreConsensus(ctx context.Context, logger *z
 		Message:   r.voluntaryExit,
 		Signature: specSig,
 	}
-	if err := r.beacon.SubmitVoluntaryExit(signedVoluntaryExit); err != nil {
+	if err := traceSubmission(ctx, spectypes.BNRoleVoluntaryExit, func() error {
+		return r.beacon.SubmitVoluntaryExit(signedVoluntaryExit)
+	}); err != nil {
+		r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleVoluntaryExit, nil, err)
+		r.BaseRunner.failDutySpan(err)
 		return errors.Wrap(err, "could not submit voluntary exit")
 	}
+	r.BaseRunner.reportSubmission(ctx, spectypes.BNRoleVoluntaryExit, nil, nil)
 
 	logger.Debug("✅ successfully submitted voluntary exit",
 		fields.Epoch(r.voluntaryExit.Epoch),
@@ -109,6 +114,7 @@ func (r *VoluntaryExitRunner) ProcessPreConsensus(ctx context.Context, logger *z
 	)
 
 	r.GetState().Finished = true
+	r.BaseRunner.endDutySpan()
 	return nil
 }
 
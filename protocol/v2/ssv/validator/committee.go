@@ -237,7 +237,7 @@ func (c *Committee) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 		if !exists {
 			return errors.New("no runner found for message's slot")
 		}
-		return runner.ProcessConsensus(ctx, logger, msg.SignedSSVMessage)
+		return runner.ProcessConsensus(runner.BaseRunner.TraceContext(ctx), logger, msg.SignedSSVMessage)
 	case spectypes.SSVPartialSignatureMsgType:
 		pSigMessages := &spectypes.PartialSignatureMessages{}
 		if err := pSigMessages.Decode(msg.SignedSSVMessage.SSVMessage.GetData()); err != nil {
@@ -260,7 +260,9 @@ func (c *Committee) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 			if !exists {
 				return errors.New("no runner found for message's slot")
 			}
-			return runner.ProcessPostConsensus(ctx, logger, pSigMessages)
+			return tracePartialSignatures(runner.BaseRunner.TraceContext(ctx), pSigMessages, msg.SignedSSVMessage.OperatorIDs[0], func(ctx context.Context) error {
+				return runner.ProcessPostConsensus(ctx, logger, pSigMessages)
+			})
 		}
 	case message.SSVEventMsgType:
 		return c.handleEventMessage(ctx, logger, msg)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/ssvlabs/ssv/logging/fields"
@@ -24,6 +25,8 @@ func (v *Validator) handleEventMessage(ctx context.Context, logger *zap.Logger,
 		}
 		return nil
 	case types.ExecuteDuty:
+		// Trace the duty as a continuation of its scheduling.
+		ctx = trace.ContextWithSpanContext(ctx, msg.TraceContext)
 		if err := v.OnExecuteDuty(ctx, logger, eventMsg); err != nil {
 			return fmt.Errorf("execute duty event: %w", err)
 		}
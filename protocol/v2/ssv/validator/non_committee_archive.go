@@ -0,0 +1,221 @@
+package validator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging/fields"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+)
+
+// archivedInstanceHeights is the number of recent heights whose consensus messages are collected for archiving.
+const archivedInstanceHeights = 64
+
+// archivedInstance collects the consensus messages of a QBFT instance until it's decided.
+type archivedInstance struct {
+	fullData map[[32]byte][]byte
+	commits  map[archivedCommitKey][]*spectypes.SignedSSVMessage
+	decided  bool
+}
+
+type archivedCommitKey struct {
+	Round specqbft.Round
+	Root  [32]byte
+}
+
+// OnConsensusMsg archives the decided message of a QBFT instance once
+// a quorum of commits and the proposal of the decided value are observed.
+func (ncv *CommitteeObserver) OnConsensusMsg(msg *queue.SSVMessage) error {
+	if ncv.archive == nil {
+		return nil
+	}
+
+	qbftMsg, ok := msg.Body.(*specqbft.Message)
+	if !ok {
+		return fmt.Errorf("not a qbft message")
+	}
+
+	instance := ncv.archivedInstance(qbftMsg.Height)
+	if instance.decided {
+		return nil
+	}
+
+	switch qbftMsg.MsgType {
+	case specqbft.ProposalMsgType:
+		if len(msg.SignedSSVMessage.FullData) == 0 {
+			return nil
+		}
+		instance.fullData[qbftMsg.Root] = msg.SignedSSVMessage.FullData
+
+		// Commits may arrive before the proposal.
+		for key := range instance.commits {
+			if key.Root != qbftMsg.Root {
+				continue
+			}
+			if err := ncv.archiveDecided(msg.MsgID, qbftMsg.Height, instance, key); err != nil || instance.decided {
+				return err
+			}
+		}
+
+	case specqbft.CommitMsgType:
+		key := archivedCommitKey{Round: qbftMsg.Round, Root: qbftMsg.Root}
+		for _, commit := range instance.commits[key] {
+			if commit.CommonSigners(msg.SignedSSVMessage.OperatorIDs) {
+				return nil
+			}
+		}
+		instance.commits[key] = append(instance.commits[key], msg.SignedSSVMessage)
+
+		return ncv.archiveDecided(msg.MsgID, qbftMsg.Height, instance, key)
+	}
+
+	return nil
+}
+
+func (ncv *CommitteeObserver) archivedInstance(height specqbft.Height) *archivedInstance {
+	instance, ok := ncv.archivedInstances[height]
+	if ok {
+		return instance
+	}
+
+	for h := range ncv.archivedInstances {
+		if h+archivedInstanceHeights < height {
+			delete(ncv.archivedInstances, h)
+		}
+	}
+
+	instance = &archivedInstance{
+		fullData: make(map[[32]byte][]byte),
+		commits:  make(map[archivedCommitKey][]*spectypes.SignedSSVMessage),
+	}
+	ncv.archivedInstances[height] = instance
+	return instance
+}
+
+// archiveDecided saves the decided message of an instance if the given commits have a quorum and their value is known.
+func (ncv *CommitteeObserver) archiveDecided(msgID spectypes.MessageID, height specqbft.Height, instance *archivedInstance, key archivedCommitKey) error {
+	fullData, ok := instance.fullData[key.Root]
+	if !ok {
+		return nil
+	}
+
+	quorum, err := ncv.consensusQuorum(msgID)
+	if err != nil {
+		return err
+	}
+
+	commits := instance.commits[key]
+	var signers uint64
+	for _, commit := range commits {
+		signers += uint64(len(commit.OperatorIDs))
+	}
+	if signers < quorum {
+		return nil
+	}
+
+	decided, err := aggregateCommits(commits, fullData)
+	if err != nil {
+		return fmt.Errorf("aggregate commits: %w", err)
+	}
+
+	// The instance is done, so its messages aren't needed anymore.
+	instance.decided = true
+	instance.fullData = nil
+	instance.commits = nil
+
+	if err := ncv.archive.SaveDecided(phase0.Slot(height), decided); err != nil {
+		return fmt.Errorf("save decided: %w", err)
+	}
+
+	ncv.logger.Debug("📦 archived decided message",
+		fields.Role(msgID.GetRoleType()),
+		fields.Height(height),
+		fields.Round(key.Round),
+		zap.Any("signers", decided.OperatorIDs),
+		zap.String("msg_id", hex.EncodeToString(msgID[:])),
+	)
+	return nil
+}
+
+// consensusQuorum returns the quorum of the committee running the QBFT instances of the given identifier.
+func (ncv *CommitteeObserver) consensusQuorum(msgID spectypes.MessageID) (uint64, error) {
+	if msgID.GetRoleType() == spectypes.RoleCommittee {
+		cid := spectypes.CommitteeID(msgID.GetDutyExecutorID()[16:])
+		committee, ok := ncv.ValidatorStore.Committee(cid)
+		if !ok {
+			return 0, fmt.Errorf("could not find committee %s", hex.EncodeToString(cid[:]))
+		}
+		quorum, _ := ssvtypes.ComputeQuorumAndPartialQuorum(uint64(len(committee.Operators)))
+		return quorum, nil
+	}
+
+	share, ok := ncv.ValidatorStore.Validator(msgID.GetDutyExecutorID())
+	if !ok {
+		return 0, fmt.Errorf("could not find share for validator %s", hex.EncodeToString(msgID.GetDutyExecutorID()))
+	}
+	return share.Quorum(), nil
+}
+
+// archiveDuty saves the post-consensus result of a validator's duty, reconstructing its signature from the partial signatures.
+func (ncv *CommitteeObserver) archiveDuty(msgID spectypes.MessageID, role convert.RunnerRole, slot phase0.Slot, key validatorIndexAndRoot, share *ssvtypes.SSVShare) error {
+	container := ncv.postConsensusContainer[key.ValidatorIndex]
+
+	signature, err := container.ReconstructSignature(key.Root, share.ValidatorPubKey[:], key.ValidatorIndex)
+	if err != nil {
+		return fmt.Errorf("reconstruct signature: %w", err)
+	}
+
+	duty := &storage.ArchivedDuty{
+		Role:              role,
+		Slot:              slot,
+		PubKey:            share.ValidatorPubKey,
+		Index:             key.ValidatorIndex,
+		DecidedIdentifier: msgID[:],
+		BeaconRoot:        key.Root,
+		PartialSignatures: maps.Clone(container.GetSignatures(key.ValidatorIndex, key.Root)),
+	}
+	copy(duty.Signature[:], signature)
+
+	if err := ncv.archive.SaveDuty(duty); err != nil {
+		return fmt.Errorf("save duty: %w", err)
+	}
+	return nil
+}
+
+// aggregateCommits aggregates commits into a decided message, ordering its signers like the QBFT instance does.
+func aggregateCommits(commits []*spectypes.SignedSSVMessage, fullData []byte) (*spectypes.SignedSSVMessage, error) {
+	aggregated := commits[0].DeepCopy()
+	for _, commit := range commits[1:] {
+		if err := aggregated.Aggregate(commit); err != nil {
+			return nil, err
+		}
+	}
+
+	order := make([]int, len(aggregated.OperatorIDs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return aggregated.OperatorIDs[order[i]] < aggregated.OperatorIDs[order[j]]
+	})
+
+	decided := &spectypes.SignedSSVMessage{
+		SSVMessage: aggregated.SSVMessage,
+		FullData:   fullData,
+	}
+	for _, i := range order {
+		decided.OperatorIDs = append(decided.OperatorIDs, aggregated.OperatorIDs[i])
+		decided.Signatures = append(decided.Signatures, aggregated.Signatures[i])
+	}
+	return decided, nil
+}
@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/ssvlabs/ssv-spec/qbft"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ssvlabs/ssv/exporter/convert"
+	"github.com/ssvlabs/ssv/ibft/storage"
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/networkconfig"
+	"github.com/ssvlabs/ssv/protocol/v2/ssv/queue"
+	ssvtypes "github.com/ssvlabs/ssv/protocol/v2/types"
+	"github.com/ssvlabs/ssv/registry/storage/mocks"
+	"github.com/ssvlabs/ssv/storage/basedb"
+	"github.com/ssvlabs/ssv/storage/kv"
+)
+
+func TestCommitteeObserver_OnConsensusMsg(t *testing.T) {
+	logger := logging.TestLogger(t)
+	db, err := kv.NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	share := &ssvtypes.SSVShare{}
+	for id := spectypes.OperatorID(1); id <= 4; id++ {
+		share.Committee = append(share.Committee, &spectypes.ShareMember{Signer: id})
+	}
+	validatorStore := mocks.NewMockValidatorStore(gomock.NewController(t))
+	validatorStore.EXPECT().Validator(gomock.Any()).Return(share, true).AnyTimes()
+
+	archive := storage.NewArchive(db)
+	identifier := convert.NewMsgID(networkconfig.TestNetwork.DomainType, share.ValidatorPubKey[:], convert.RoleProposer)
+	observer := NewCommitteeObserver(identifier, CommitteeObserverOptions{
+		Logger:         logger,
+		NetworkConfig:  networkconfig.TestNetwork,
+		ValidatorStore: validatorStore,
+		Archive:        archive,
+	})
+
+	fullData := []byte{1, 2, 3}
+	root := [32]byte{4, 5, 6}
+	send := func(msgType specqbft.MessageType, height specqbft.Height, signer spectypes.OperatorID) {
+		qbftMsg := &specqbft.Message{
+			MsgType:    msgType,
+			Height:     height,
+			Round:      specqbft.FirstRound,
+			Identifier: identifier[:],
+			Root:       root,
+		}
+		data, err := qbftMsg.Encode()
+		require.NoError(t, err)
+
+		signedMsg := &spectypes.SignedSSVMessage{
+			OperatorIDs: []spectypes.OperatorID{signer},
+			Signatures:  [][]byte{{byte(signer)}},
+			SSVMessage: &spectypes.SSVMessage{
+				MsgType: spectypes.SSVConsensusMsgType,
+				MsgID:   spectypes.MessageID(identifier),
+				Data:    data,
+			},
+		}
+		if msgType == specqbft.ProposalMsgType {
+			signedMsg.FullData = fullData
+		}
+
+		msg, err := queue.DecodeSignedSSVMessage(signedMsg)
+		require.NoError(t, err)
+		require.NoError(t, observer.OnConsensusMsg(msg))
+	}
+	requireDecided := func(slot phase0.Slot, expected bool) {
+		decided, found, err := archive.GetDecided(identifier[:], slot)
+		require.NoError(t, err)
+		require.Equal(t, expected, found)
+		if expected {
+			require.Equal(t, []spectypes.OperatorID{1, 2, 3}, decided.OperatorIDs)
+			require.Equal(t, [][]byte{{1}, {2}, {3}}, decided.Signatures)
+			require.Equal(t, fullData, decided.FullData)
+		}
+	}
+
+	t.Run("proposal before commits", func(t *testing.T) {
+		send(specqbft.ProposalMsgType, 1, 1)
+		send(specqbft.CommitMsgType, 1, 2)
+		send(specqbft.CommitMsgType, 1, 1)
+		send(specqbft.CommitMsgType, 1, 2)
+		requireDecided(1, false)
+
+		send(specqbft.CommitMsgType, 1, 3)
+		requireDecided(1, true)
+	})
+
+	t.Run("commits before proposal", func(t *testing.T) {
+		send(specqbft.CommitMsgType, 2, 3)
+		send(specqbft.CommitMsgType, 2, 1)
+		send(specqbft.CommitMsgType, 2, 2)
+		requireDecided(2, false)
+
+		send(specqbft.ProposalMsgType, 2, 1)
+		requireDecided(2, true)
+	})
+}
@@ -42,6 +42,8 @@ type CommitteeObserver struct {
 	syncCommRoots          *ttlcache.Cache[phase0.Root, struct{}]
 	domainCache            *DomainCache
 	postConsensusContainer map[phase0.ValidatorIndex]*ssv.PartialSigContainer
+	archive                *storage.Archive
+	archivedInstances      map[specqbft.Height]*archivedInstance
 }
 
 type CommitteeObserverOptions struct {
@@ -57,6 +59,8 @@ type CommitteeObserverOptions struct {
 	AttesterRoots     *ttlcache.Cache[phase0.Root, struct{}]
 	SyncCommRoots     *ttlcache.Cache[phase0.Root, struct{}]
 	DomainCache       *DomainCache
+	// Archive, if set, stores the decided messages and signatures of observed duties.
+	Archive *storage.Archive
 }
 
 func NewCommitteeObserver(identifier convert.MessageID, opts CommitteeObserverOptions) *CommitteeObserver {
@@ -83,6 +87,8 @@ func NewCommitteeObserver(identifier convert.MessageID, opts CommitteeObserverOp
 		syncCommRoots:          opts.SyncCommRoots,
 		domainCache:            opts.DomainCache,
 		postConsensusContainer: make(map[phase0.ValidatorIndex]*ssv.PartialSigContainer),
+		archive:                opts.Archive,
+		archivedInstances:      make(map[specqbft.Height]*archivedInstance),
 	}
 }
 
@@ -160,6 +166,17 @@ func (ncv *CommitteeObserver) ProcessMessage(msg *queue.SSVMessage) error {
 				continue
 			}
 
+			if ncv.archive != nil {
+				if err := ncv.archiveDuty(msg.MsgID, beaconRole, slot, key, validator); err != nil {
+					logger.Warn("failed to archive duty",
+						zap.String("converted_role", beaconRole.ToBeaconRole()),
+						fields.Validator(validator.ValidatorPubKey[:]),
+						fields.BlockRoot(key.Root),
+						zap.Error(err),
+					)
+				}
+			}
+
 			logger.Info("✅ saved participants",
 				zap.String("converted_role", beaconRole.ToBeaconRole()),
 				zap.Uint64("validator_index", uint64(key.ValidatorIndex)),
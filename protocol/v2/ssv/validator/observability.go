@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+
+	"github.com/ssvlabs/ssv/observability"
+)
+
+const observabilityName = "github.com/ssvlabs/ssv/protocol/v2/ssv"
+
+var tracer = otel.Tracer(observabilityName)
+
+// tracePartialSignatures traces processing the partial signatures of the duty traced by ctx.
+func tracePartialSignatures(ctx context.Context, msg *spectypes.PartialSignatureMessages, signer spectypes.OperatorID, process func(context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "ssv.runner.partial_signatures", trace.WithAttributes(
+		attribute.String("ssv.validator.partial_signature.type", partialSigMsgTypeName(msg.Type)),
+		attribute.Int64("ssv.validator.partial_signature.signer", int64(signer)),
+		attribute.Int("ssv.validator.partial_signature.count", len(msg.Messages))))
+	err := process(ctx)
+	observability.EndSpan(span, err)
+	return err
+}
+
+func partialSigMsgTypeName(typ spectypes.PartialSigMsgType) string {
+	if typ == spectypes.PostConsensusPartialSig {
+		return "post_consensus"
+	}
+	return "pre_consensus"
+}
@@ -142,6 +142,7 @@ func (v *Validator) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 	if err := validateMessage(v.Share.Share, msg); err != nil {
 		return fmt.Errorf("message invalid for msg ID %v: %w", messageID, err)
 	}
+	ctx = dutyRunner.GetBaseRunner().TraceContext(ctx)
 
 	switch msg.GetType() {
 	case spectypes.SSVConsensusMsgType:
@@ -174,10 +175,12 @@ func (v *Validator) ProcessMessage(ctx context.Context, logger *zap.Logger, msg
 			return errors.Wrap(err, "invalid PartialSignatureMessages")
 		}
 
-		if signedMsg.Type == spectypes.PostConsensusPartialSig {
-			return dutyRunner.ProcessPostConsensus(ctx, logger, signedMsg)
-		}
-		return dutyRunner.ProcessPreConsensus(ctx, logger, signedMsg)
+		return tracePartialSignatures(ctx, signedMsg, msg.SignedSSVMessage.OperatorIDs[0], func(ctx context.Context) error {
+			if signedMsg.Type == spectypes.PostConsensusPartialSig {
+				return dutyRunner.ProcessPostConsensus(ctx, logger, signedMsg)
+			}
+			return dutyRunner.ProcessPreConsensus(ctx, logger, signedMsg)
+		})
 	case message.SSVEventMsgType:
 		return v.handleEventMessage(ctx, logger, msg, dutyRunner)
 	default:
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	spectypes "github.com/ssvlabs/ssv-spec/types"
+)
+
+// SlotBeaconSigner is implemented by beacon signers which need the slot of the duty to sign objects
+// which don't include it, such as the block root of sync committee messages.
+type SlotBeaconSigner interface {
+	// SignBeaconObjectAtSlot signs the object like spectypes.BeaconSigner.SignBeaconObject, for a duty of the given slot.
+	SignBeaconObjectAtSlot(obj ssz.HashRoot, domain phase0.Domain, pk []byte, domainType phase0.DomainType, slot phase0.Slot) (spectypes.Signature, [32]byte, error)
+}
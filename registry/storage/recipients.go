@@ -63,6 +63,7 @@ type Recipients interface {
 	GetRecipientData(r basedb.Reader, owner common.Address) (*RecipientData, bool, error)
 	GetRecipientDataMany(r basedb.Reader, owners []common.Address) (map[common.Address]bellatrix.ExecutionAddress, error)
 	GetNextNonce(r basedb.Reader, owner common.Address) (Nonce, error)
+	ListRecipients(r basedb.Reader) ([]RecipientData, error)
 	BumpNonce(rw basedb.ReadWriter, owner common.Address) error
 	SaveRecipientData(rw basedb.ReadWriter, recipientData *RecipientData) (*RecipientData, error)
 	DeleteRecipientData(rw basedb.ReadWriter, owner common.Address) error
@@ -113,6 +114,24 @@ func (s *recipientsStorage) getRecipientData(r basedb.Reader, owner common.Addre
 	return &recipientData, found, err
 }
 
+// ListRecipients returns the data of all known recipients
+func (s *recipientsStorage) ListRecipients(r basedb.Reader) ([]RecipientData, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var recipients []RecipientData
+	prefix := bytes.Join([][]byte{s.prefix, recipientsPrefix, []byte("/")}, nil)
+	err := s.db.UsingReader(r).GetAll(prefix, func(i int, obj basedb.Obj) error {
+		var recipient RecipientData
+		if err := json.Unmarshal(obj.Value, &recipient); err != nil {
+			return errors.Wrap(err, "could not unmarshal recipient data")
+		}
+		recipients = append(recipients, recipient)
+		return nil
+	})
+	return recipients, err
+}
+
 func (s *recipientsStorage) GetRecipientDataMany(r basedb.Reader, owners []common.Address) (map[common.Address]bellatrix.ExecutionAddress, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
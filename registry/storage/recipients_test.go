@@ -66,6 +66,36 @@ func TestStorage_GetRecipientsPrefix(t *testing.T) {
 	require.Equal(t, []byte("recipients"), storageCollection.GetRecipientsPrefix())
 }
 
+func TestStorage_ListRecipients(t *testing.T) {
+	logger := logging.TestLogger(t)
+	storageCollection, done := newRecipientStorageForTest(logger)
+	require.NotNil(t, storageCollection)
+	defer done()
+
+	recipients, err := storageCollection.ListRecipients(nil)
+	require.NoError(t, err)
+	require.Empty(t, recipients)
+
+	owners := []common.Address{
+		common.BytesToAddress([]byte("0x1")),
+		common.BytesToAddress([]byte("0x2")),
+	}
+	for _, owner := range owners {
+		require.NoError(t, storageCollection.BumpNonce(nil, owner))
+	}
+	require.NoError(t, storageCollection.BumpNonce(nil, owners[1]))
+
+	recipients, err = storageCollection.ListRecipients(nil)
+	require.NoError(t, err)
+	require.Len(t, recipients, len(owners))
+	nonces := make(map[common.Address]storage.Nonce)
+	for _, r := range recipients {
+		require.NotNil(t, r.Nonce)
+		nonces[r.Owner] = *r.Nonce
+	}
+	require.Equal(t, map[common.Address]storage.Nonce{owners[0]: 0, owners[1]: 1}, nonces)
+}
+
 func TestStorage_SaveAndGetRecipientData(t *testing.T) {
 	logger := logging.TestLogger(t)
 	storageCollection, done := newRecipientStorageForTest(logger)
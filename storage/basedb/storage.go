@@ -8,9 +8,17 @@ import (
 // Options for creating all db type
 type Options struct {
 	Ctx        context.Context
+	Engine     string        `yaml:"Engine" env:"DB_ENGINE" env-default:"badger" env-description:"Storage engine, either badger or pebble. Switching engines requires migrating the database"`
 	Path       string        `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting  bool          `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	GCInterval time.Duration `yaml:"GCInterval" env:"DB_GC_INTERVAL" env-default:"6m" env-description:"Interval between garbage collection cycles. Set to 0 to disable."`
+
+	SnapshotInterval time.Duration `yaml:"SnapshotInterval" env:"DB_SNAPSHOT_INTERVAL" env-default:"0" env-description:"Interval between online snapshots of the database. Set to 0 to disable."`
+	SnapshotDir      string        `yaml:"SnapshotDir" env:"DB_SNAPSHOT_DIR" env-default:"./data/snapshots" env-description:"Directory to write database snapshots into"`
+	SnapshotRetain   int           `yaml:"SnapshotRetain" env:"DB_SNAPSHOT_RETAIN" env-default:"3" env-description:"Number of latest database snapshots to keep"`
+
+	// ReadOnly opens an existing database without writing to it, for inspecting it.
+	ReadOnly bool `yaml:"-"`
 }
 
 // Reader is a read-only accessor to the database.
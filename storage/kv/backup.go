@@ -0,0 +1,244 @@
+package kv
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// backupMagic prefixes every backup, to tell it apart from other files.
+	backupMagic = "SSVDBBAK"
+	// backupFormatVersion is the version of the backup framing, not of the data within.
+	backupFormatVersion uint32 = 1
+	// maxBackupHeaderSize bounds the header, to fail early on corrupted backups.
+	maxBackupHeaderSize = 1 << 20
+	// maxPendingRestoreWrites is the number of pending writes while restoring a backup.
+	maxPendingRestoreWrites = 256
+
+	snapshotFilePrefix = "snapshot-"
+	snapshotFileSuffix = ".ssvbak"
+	snapshotTimeFormat = "20060102T150405Z"
+)
+
+// writeBackupFraming writes the framing preceding the data of a backup of the given engine.
+func writeBackupFraming(w io.Writer, engine Engine, header []byte) error {
+	if len(header) > maxBackupHeaderSize {
+		return fmt.Errorf("backup header is too large: %d bytes", len(header))
+	}
+
+	if _, err := io.WriteString(w, backupMagic); err != nil {
+		return errors.Wrap(err, "failed to write backup magic")
+	}
+	if err := binary.Write(w, binary.BigEndian, backupFormatVersion); err != nil {
+		return errors.Wrap(err, "failed to write backup format version")
+	}
+	for _, field := range [][]byte{[]byte(engine), header} {
+		if err := binary.Write(w, binary.BigEndian, uint32(len(field))); err != nil {
+			return errors.Wrap(err, "failed to write backup header size")
+		}
+		if _, err := w.Write(field); err != nil {
+			return errors.Wrap(err, "failed to write backup header")
+		}
+	}
+	return nil
+}
+
+// ReadBackupHeader reads the framing of a backup written by Backup,
+// and returns the engine it was taken from and its header, leaving r at the start of the backed up data.
+func ReadBackupHeader(r io.Reader) (Engine, []byte, error) {
+	magic := make([]byte, len(backupMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return "", nil, errors.Wrap(err, "failed to read backup magic")
+	}
+	if string(magic) != backupMagic {
+		return "", nil, errors.New("not a database backup")
+	}
+
+	var version uint32
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return "", nil, errors.Wrap(err, "failed to read backup format version")
+	}
+	if version != backupFormatVersion {
+		return "", nil, fmt.Errorf("unsupported backup format version: %d", version)
+	}
+
+	fields := make([][]byte, 2)
+	for i := range fields {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return "", nil, errors.Wrap(err, "failed to read backup header size")
+		}
+		if size > maxBackupHeaderSize {
+			return "", nil, fmt.Errorf("backup header is too large: %d bytes", size)
+		}
+		fields[i] = make([]byte, size)
+		if _, err := io.ReadFull(r, fields[i]); err != nil {
+			return "", nil, errors.Wrap(err, "failed to read backup header")
+		}
+	}
+	return Engine(fields[0]), fields[1], nil
+}
+
+// prepareRestore reads the framing of a backup from r, and checks it can be restored into db.
+func prepareRestore(db DB, r io.Reader) error {
+	engine, _, err := ReadBackupHeader(r)
+	if err != nil {
+		return err
+	}
+	if engine != db.Engine() {
+		return fmt.Errorf("backup is of a %s db, which can't be restored into a %s db", engine, db.Engine())
+	}
+
+	count, err := db.CountPrefix(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to count existing keys")
+	}
+	if count > 0 {
+		return fmt.Errorf("db is not empty (%d keys), remove it before restoring", count)
+	}
+	return nil
+}
+
+// Backup writes a full backup of the database to w, prefixed by the given header describing its content.
+// The backup is read from a consistent snapshot, so the database may be used meanwhile.
+func (b *BadgerDB) Backup(w io.Writer, header []byte) error {
+	bw := bufio.NewWriter(w)
+	if err := writeBackupFraming(bw, EngineBadger, header); err != nil {
+		return err
+	}
+	if _, err := b.db.Backup(bw, 0); err != nil {
+		return errors.Wrap(err, "failed to back up db")
+	}
+	return bw.Flush()
+}
+
+// Restore loads a backup written by Backup from r into the database, which must be empty.
+func (b *BadgerDB) Restore(r io.Reader) error {
+	if err := prepareRestore(b, r); err != nil {
+		return err
+	}
+	return errors.Wrap(b.db.Load(r, maxPendingRestoreWrites), "failed to load backup")
+}
+
+// StartPeriodicSnapshots periodically writes backups of the database into dir,
+// keeping the latest retain ones. The header is called for every snapshot to describe its content.
+func (b *BadgerDB) StartPeriodicSnapshots(interval time.Duration, dir string, retain int, header func() ([]byte, error)) error {
+	return startPeriodicSnapshots(b.ctx, &b.wg, b.logger, b, interval, dir, retain, header)
+}
+
+func startPeriodicSnapshots(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	logger *zap.Logger,
+	db DB,
+	interval time.Duration,
+	dir string,
+	retain int,
+	header func() ([]byte, error),
+) error {
+	if interval <= 0 {
+		return nil
+	}
+	if retain <= 0 {
+		return fmt.Errorf("invalid number of snapshots to retain: %d", retain)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create snapshot directory")
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				start := time.Now()
+				path, err := snapshot(db, dir, retain, header)
+				if err != nil {
+					logger.Error("periodic db snapshot failed", zap.Error(err))
+				} else {
+					logger.Info("periodic db snapshot completed",
+						zap.String("path", path),
+						zap.Duration("took", time.Since(start)))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// snapshot writes a backup into dir and removes the snapshots beyond the latest retain ones.
+func snapshot(db DB, dir string, retain int, header func() ([]byte, error)) (string, error) {
+	h, err := header()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build snapshot header")
+	}
+
+	name := snapshotFilePrefix + time.Now().UTC().Format(snapshotTimeFormat) + snapshotFileSuffix
+	path := filepath.Join(dir, name)
+	if err := WriteBackupFile(path, func(w io.Writer) error { return db.Backup(w, h) }); err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return path, errors.Wrap(err, "failed to list snapshots")
+	}
+	var snapshots []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), snapshotFilePrefix) && strings.HasSuffix(entry.Name(), snapshotFileSuffix) {
+			snapshots = append(snapshots, entry.Name())
+		}
+	}
+	// Names sort by time, oldest first.
+	sort.Strings(snapshots)
+	for len(snapshots) > retain {
+		if err := os.Remove(filepath.Join(dir, snapshots[0])); err != nil {
+			return path, errors.Wrap(err, "failed to remove old snapshot")
+		}
+		snapshots = snapshots[1:]
+	}
+	return path, nil
+}
+
+// WriteBackupFile writes a backup to path through a temporary file,
+// so that an interrupted backup never leaves a partial file behind.
+func WriteBackupFile(path string, write func(io.Writer) error) error {
+	tmpPath := path + ".tmp"
+	// nolint: gosec
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create backup file")
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(err, "failed to sync backup file")
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrap(err, "failed to close backup file")
+	}
+	return errors.Wrap(os.Rename(tmpPath, path), "failed to rename backup file")
+}
@@ -0,0 +1,116 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestDb_BackupRestore(t *testing.T) {
+	forEachEngine(t, testBackupRestore)
+}
+
+func testBackupRestore(t *testing.T, newDB newTestDB) {
+	logger := logging.TestLogger(t)
+	db, err := newDB(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set(prefix, []byte("key1"), []byte("value1")))
+	require.NoError(t, db.Set(prefix, []byte("key2"), []byte("value2")))
+
+	var buf bytes.Buffer
+	require.NoError(t, db.Backup(&buf, []byte("header")))
+	backup := buf.Bytes()
+
+	t.Run("header is read", func(t *testing.T) {
+		engine, header, err := ReadBackupHeader(bytes.NewReader(backup))
+		require.NoError(t, err)
+		require.Equal(t, db.Engine(), engine)
+		require.Equal(t, []byte("header"), header)
+
+		_, _, err = ReadBackupHeader(bytes.NewReader([]byte("not a backup")))
+		require.ErrorContains(t, err, "not a database backup")
+	})
+
+	t.Run("backup is restored", func(t *testing.T) {
+		restored, err := newDB(logger, basedb.Options{})
+		require.NoError(t, err)
+		defer restored.Close()
+
+		require.NoError(t, restored.Restore(bytes.NewReader(backup)))
+
+		obj, found, err := restored.Get(prefix, []byte("key2"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value2"), obj.Value)
+		count, err := restored.CountPrefix(prefix)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+	})
+
+	t.Run("restore refuses non-empty db", func(t *testing.T) {
+		require.ErrorContains(t, db.Restore(bytes.NewReader(backup)), "db is not empty")
+	})
+
+	t.Run("restore refuses backup of another engine", func(t *testing.T) {
+		for _, engine := range testEngines {
+			if engine.name == string(db.Engine()) {
+				continue
+			}
+			other, err := engine.newDB(logger, basedb.Options{})
+			require.NoError(t, err)
+			defer other.Close()
+
+			require.ErrorContains(t, other.Restore(bytes.NewReader(backup)), "can't be restored")
+		}
+	})
+}
+
+func TestDb_Snapshot(t *testing.T) {
+	forEachEngine(t, testSnapshot)
+}
+
+func testSnapshot(t *testing.T, newDB newTestDB) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := logging.TestLogger(t)
+	db, err := newDB(logger, basedb.Options{Ctx: ctx})
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+
+	dir := t.TempDir()
+	header := func() ([]byte, error) { return []byte("header"), nil }
+
+	// Pre-existing snapshots, older than any new one.
+	for _, name := range []string{"snapshot-20000101T000000Z.ssvbak", "snapshot-20000102T000000Z.ssvbak"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+
+	path, err := snapshot(db, dir, 2, header)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "snapshot-20000102T000000Z.ssvbak", entries[0].Name())
+	require.Equal(t, filepath.Base(path), entries[1].Name())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, h, err := ReadBackupHeader(f)
+	require.NoError(t, err)
+	require.Equal(t, []byte("header"), h)
+}
@@ -50,6 +50,7 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 		opt.Dir = ""
 		opt.ValueDir = ""
 	}
+	opt.ReadOnly = options.ReadOnly
 
 	// TODO: we should set the default logger here to log Error and higher levels
 	opt.Logger = newLogger(zap.NewNop())
@@ -94,6 +95,11 @@ func createDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*Badge
 	return &badgerDB, nil
 }
 
+// Engine returns the storage engine of the database.
+func (b *BadgerDB) Engine() Engine {
+	return EngineBadger
+}
+
 // Badger returns the underlying badger.DB
 func (b *BadgerDB) Badger() *badger.DB {
 	return b.db
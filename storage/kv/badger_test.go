@@ -16,7 +16,47 @@ import (
 	"github.com/ssvlabs/ssv/storage/basedb"
 )
 
+// testEngine creates in-memory databases of an engine, to run the same tests on each engine.
+type testEngine struct {
+	name  string
+	newDB newTestDB
+}
+
+type newTestDB func(*zap.Logger, basedb.Options) (testDB, error)
+
+type testDB interface {
+	DB
+	report()
+}
+
+var testEngines = []testEngine{
+	{
+		name: string(EngineBadger),
+		newDB: func(logger *zap.Logger, options basedb.Options) (testDB, error) {
+			return NewInMemory(logger, options)
+		},
+	},
+	{
+		name: string(EnginePebble),
+		newDB: func(logger *zap.Logger, options basedb.Options) (testDB, error) {
+			return NewPebbleInMemory(logger, options)
+		},
+	},
+}
+
+func forEachEngine(t *testing.T, test func(t *testing.T, newDB newTestDB)) {
+	for _, engine := range testEngines {
+		t.Run(engine.name, func(t *testing.T) {
+			test(t, engine.newDB)
+		})
+	}
+}
+
 func TestBadgerEndToEnd(t *testing.T) {
+	forEachEngine(t, testEndToEnd)
+}
+
+func testEndToEnd(t *testing.T, newDB newTestDB) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -27,7 +67,7 @@ func TestBadgerEndToEnd(t *testing.T) {
 		Ctx:       ctx,
 	}
 
-	db, err := NewInMemory(logger, options)
+	db, err := newDB(logger, options)
 	require.NoError(t, err)
 
 	toSave := []struct {
@@ -90,10 +130,14 @@ func TestBadgerEndToEnd(t *testing.T) {
 }
 
 func TestBadgerDb_GetAll(t *testing.T) {
+	forEachEngine(t, testGetAll)
+}
+
+func testGetAll(t *testing.T, newDB newTestDB) {
 	logger := logging.TestLogger(t)
 
 	t.Run("100_items", func(t *testing.T) {
-		db, err := NewInMemory(logger, basedb.Options{})
+		db, err := newDB(logger, basedb.Options{})
 		require.NoError(t, err)
 		defer db.Close()
 
@@ -101,7 +145,7 @@ func TestBadgerDb_GetAll(t *testing.T) {
 	})
 
 	t.Run("10K_items", func(t *testing.T) {
-		db, err := NewInMemory(logger, basedb.Options{})
+		db, err := newDB(logger, basedb.Options{})
 		require.NoError(t, err)
 		defer db.Close()
 
@@ -109,7 +153,7 @@ func TestBadgerDb_GetAll(t *testing.T) {
 	})
 
 	t.Run("100K_items", func(t *testing.T) {
-		db, err := NewInMemory(logger, basedb.Options{})
+		db, err := newDB(logger, basedb.Options{})
 		require.NoError(t, err)
 		defer db.Close()
 
@@ -118,8 +162,12 @@ func TestBadgerDb_GetAll(t *testing.T) {
 }
 
 func TestBadgerDb_GetMany(t *testing.T) {
+	forEachEngine(t, testGetMany)
+}
+
+func testGetMany(t *testing.T, newDB newTestDB) {
 	logger := logging.TestLogger(t)
-	db, err := NewInMemory(logger, basedb.Options{})
+	db, err := newDB(logger, basedb.Options{})
 	require.NoError(t, err)
 	defer db.Close()
 
@@ -141,8 +189,12 @@ func TestBadgerDb_GetMany(t *testing.T) {
 }
 
 func TestBadgerDb_SetMany(t *testing.T) {
+	forEachEngine(t, testSetMany)
+}
+
+func testSetMany(t *testing.T, newDB newTestDB) {
 	logger := logging.TestLogger(t)
-	db, err := NewInMemory(logger, basedb.Options{})
+	db, err := newDB(logger, basedb.Options{})
 	require.NoError(t, err)
 	defer db.Close()
 
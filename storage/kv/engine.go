@@ -0,0 +1,121 @@
+package kv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+// Engine is a storage engine implementing basedb.Database.
+type Engine string
+
+const (
+	EngineBadger Engine = "badger"
+	EnginePebble Engine = "pebble"
+)
+
+// copyBatchSize is the number of items written at once when copying a database.
+const copyBatchSize = 1000
+
+// DB is a database of any of the engines in this package.
+type DB interface {
+	basedb.Database
+	basedb.GarbageCollector
+
+	// Engine returns the storage engine of the database.
+	Engine() Engine
+
+	// Backup writes a full backup of the database to w, prefixed by the given header describing its content.
+	// The backup is read from a consistent snapshot, so the database may be used meanwhile.
+	Backup(w io.Writer, header []byte) error
+
+	// Restore loads a backup written by Backup from r into the database, which must be empty.
+	Restore(r io.Reader) error
+
+	// StartPeriodicSnapshots periodically writes backups of the database into dir,
+	// keeping the latest retain ones. The header is called for every snapshot to describe its content.
+	StartPeriodicSnapshots(interval time.Duration, dir string, retain int, header func() ([]byte, error)) error
+}
+
+// Open opens a persistent database of the engine selected by the options.
+// It refuses to open a database created by another engine, which must be migrated instead.
+func Open(logger *zap.Logger, options basedb.Options) (DB, error) {
+	engine := Engine(options.Engine)
+	if engine == "" {
+		engine = EngineBadger
+	}
+
+	existing, err := DetectEngine(options.Path)
+	if err != nil {
+		return nil, err
+	}
+	if existing == "" && options.ReadOnly {
+		return nil, fmt.Errorf("no db at %s to open read-only", options.Path)
+	}
+	if existing != "" && existing != engine {
+		return nil, fmt.Errorf("db at %s was created by %s, not %s, migrate it to switch engines", options.Path, existing, engine)
+	}
+
+	switch engine {
+	case EngineBadger:
+		return New(logger, options)
+	case EnginePebble:
+		return NewPebble(logger, options)
+	default:
+		return nil, fmt.Errorf("unknown db engine: %s", engine)
+	}
+}
+
+// DetectEngine returns the engine of the database at path, or an empty engine if there's none.
+func DetectEngine(path string) (Engine, error) {
+	// Files which only exist in a database of the respective engine.
+	markers := map[string]Engine{
+		"KEYREGISTRY": EngineBadger,
+		"CURRENT":     EnginePebble,
+	}
+	for marker, engine := range markers {
+		_, err := os.Stat(filepath.Join(path, marker))
+		if err == nil {
+			return engine, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to detect db engine: %w", err)
+		}
+	}
+	return "", nil
+}
+
+// Copy copies all items from src into dst, and returns the number of items copied.
+func Copy(src, dst basedb.Database) (int, error) {
+	batch := make([]basedb.Obj, 0, copyBatchSize)
+	flush := func() error {
+		err := dst.SetMany(nil, len(batch), func(i int) (basedb.Obj, error) {
+			return batch[i], nil
+		})
+		batch = batch[:0]
+		return err
+	}
+
+	count := 0
+	err := src.GetAll(nil, func(_ int, obj basedb.Obj) error {
+		batch = append(batch, obj)
+		count++
+		if len(batch) == copyBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to copy items: %w", err)
+	}
+	if err := flush(); err != nil {
+		return 0, fmt.Errorf("failed to copy items: %w", err)
+	}
+	return count, nil
+}
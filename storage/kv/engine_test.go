@@ -0,0 +1,144 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+func TestDb_Txn(t *testing.T) {
+	forEachEngine(t, testTxn)
+}
+
+func testTxn(t *testing.T, newDB newTestDB) {
+	logger := logging.TestLogger(t)
+	db, err := newDB(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set(prefix, []byte("key1"), []byte("value1")))
+
+	readTxn := db.BeginRead()
+	defer readTxn.Discard()
+
+	txn := db.Begin()
+	require.NoError(t, txn.Set(prefix, []byte("key2"), []byte("value2")))
+	obj, found, err := txn.Get(prefix, []byte("key2"))
+	require.NoError(t, err)
+	require.True(t, found, "txn should read its own writes")
+	require.Equal(t, []byte("value2"), obj.Value)
+
+	_, found, err = db.Get(prefix, []byte("key2"))
+	require.NoError(t, err)
+	require.False(t, found, "uncommitted writes should not be visible")
+
+	require.NoError(t, txn.Commit())
+	txn.Discard()
+
+	_, found, err = db.Get(prefix, []byte("key2"))
+	require.NoError(t, err)
+	require.True(t, found, "committed writes should be visible")
+
+	_, found, err = readTxn.Get(prefix, []byte("key2"))
+	require.NoError(t, err)
+	require.False(t, found, "read txn should read from a snapshot")
+
+	discarded := db.Begin()
+	require.NoError(t, discarded.Delete(prefix, []byte("key1")))
+	discarded.Discard()
+	_, found, err = db.Get(prefix, []byte("key1"))
+	require.NoError(t, err)
+	require.True(t, found, "discarded writes should not be applied")
+
+	require.NoError(t, db.Set([]byte("other"), []byte("key"), []byte("value")))
+	require.NoError(t, db.DropPrefix(prefix))
+	count, err := db.CountPrefix(prefix)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	count, err = db.CountPrefix(nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+}
+
+func TestCopy(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	src, err := NewInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer src.Close()
+
+	const n = copyBatchSize + 10
+	require.NoError(t, src.SetMany([]byte("prefix1/"), n, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: uInt64ToByteSlice(uint64(i)), Value: uInt64ToByteSlice(uint64(i))}, nil
+	}))
+	require.NoError(t, src.Set([]byte("prefix2/"), []byte("key"), []byte("value")))
+
+	dst, err := NewPebbleInMemory(logger, basedb.Options{})
+	require.NoError(t, err)
+	defer dst.Close()
+
+	count, err := Copy(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, n+1, count)
+
+	dstCount, err := dst.CountPrefix([]byte("prefix1/"))
+	require.NoError(t, err)
+	require.EqualValues(t, n, dstCount)
+	obj, found, err := dst.Get([]byte("prefix2/"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+}
+
+func TestOpen(t *testing.T) {
+	logger := logging.TestLogger(t)
+	path := t.TempDir()
+
+	db, err := Open(logger, basedb.Options{Engine: string(EnginePebble), Path: path})
+	require.NoError(t, err)
+	require.Equal(t, EnginePebble, db.Engine())
+	require.NoError(t, db.Close())
+
+	engine, err := DetectEngine(path)
+	require.NoError(t, err)
+	require.Equal(t, EnginePebble, engine)
+
+	_, err = Open(logger, basedb.Options{Engine: string(EngineBadger), Path: path})
+	require.ErrorContains(t, err, "migrate it to switch engines")
+
+	_, err = Open(logger, basedb.Options{Engine: "leveldb", Path: t.TempDir()})
+	require.ErrorContains(t, err, "unknown db engine")
+}
+
+func TestOpen_ReadOnly(t *testing.T) {
+	logger := logging.TestLogger(t)
+
+	for _, engine := range []Engine{EngineBadger, EnginePebble} {
+		t.Run(string(engine), func(t *testing.T) {
+			path := t.TempDir()
+
+			db, err := Open(logger, basedb.Options{Engine: string(engine), Path: path})
+			require.NoError(t, err)
+			require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+			require.NoError(t, db.Close())
+
+			db, err = Open(logger, basedb.Options{Engine: string(engine), Path: path, ReadOnly: true})
+			require.NoError(t, err)
+			defer func() { require.NoError(t, db.Close()) }()
+
+			obj, found, err := db.Get([]byte("prefix"), []byte("key"))
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, []byte("value"), obj.Value)
+
+			require.Error(t, db.Set([]byte("prefix"), []byte("key"), []byte("other")))
+		})
+	}
+
+	_, err := Open(logger, basedb.Options{Path: t.TempDir(), ReadOnly: true})
+	require.ErrorContains(t, err, "no db at")
+}
@@ -0,0 +1,529 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/logging"
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+const (
+	// restoreBatchSize is the number of items written at once when restoring a backup.
+	restoreBatchSize = 1000
+	// maxBackupItemSize bounds the size of keys and values, to fail early on corrupted backups.
+	maxBackupItemSize = 1 << 30
+)
+
+// PebbleDB is a database on Pebble, which compacts in the background
+// and so doesn't need periodic garbage collection like Badger.
+// Writes are synced to disk.
+type PebbleDB struct {
+	logger *zap.Logger
+
+	db *pebble.DB
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPebble creates a persistent Pebble DB instance.
+func NewPebble(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, false)
+}
+
+// NewPebbleInMemory creates an in-memory Pebble DB instance.
+func NewPebbleInMemory(logger *zap.Logger, options basedb.Options) (*PebbleDB, error) {
+	return createPebbleDB(logger, options, true)
+}
+
+func createPebbleDB(logger *zap.Logger, options basedb.Options, inMemory bool) (*PebbleDB, error) {
+	opt := &pebble.Options{
+		Logger:   newPebbleLogger(zap.NewNop()),
+		ReadOnly: options.ReadOnly,
+	}
+	if logger != nil && options.Reporting {
+		opt.Logger = newPebbleLogger(logger)
+	}
+	path := options.Path
+	if inMemory {
+		opt.FS = vfs.NewMem()
+		path = ""
+	}
+
+	db, err := pebble.Open(path, opt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open pebble")
+	}
+
+	// Set up context/cancel to control background goroutines.
+	parentCtx := options.Ctx
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parentCtx)
+
+	pebbleDB := &PebbleDB{
+		logger: logger,
+		db:     db,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	// Start periodic reporting.
+	if options.Reporting && options.Ctx != nil {
+		pebbleDB.wg.Add(1)
+		go pebbleDB.periodicallyReport(1 * time.Minute)
+	}
+
+	return pebbleDB, nil
+}
+
+// Engine returns the storage engine of the database.
+func (p *PebbleDB) Engine() Engine {
+	return EnginePebble
+}
+
+// Begin creates a read-write transaction.
+// Unlike Badger transactions, it reads the latest committed data rather than a snapshot.
+func (p *PebbleDB) Begin() basedb.Txn {
+	return &pebbleTxn{batch: p.db.NewIndexedBatch(), db: p}
+}
+
+// BeginRead creates a read-only transaction, reading from a snapshot.
+func (p *PebbleDB) BeginRead() basedb.ReadTxn {
+	return &pebbleReadTxn{snapshot: p.db.NewSnapshot(), db: p}
+}
+
+// Set save value with key to storage
+func (p *PebbleDB) Set(prefix []byte, key []byte, value []byte) error {
+	return p.db.Set(append(prefix, key...), value, pebble.Sync)
+}
+
+// SetMany save many values with the given keys in a single batch
+func (p *PebbleDB) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	batch := p.db.NewBatch()
+	defer func() { _ = batch.Close() }()
+	if err := setMany(batch, prefix, n, next); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+// Get return value for specified key
+func (p *PebbleDB) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(p.db, prefix, key)
+}
+
+// GetMany return values for the given keys
+func (p *PebbleDB) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return p.getMany(p.db, prefix, keys, iterator)
+}
+
+// GetAll returns all the items of a given collection
+func (p *PebbleDB) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	// Read from a snapshot, so that the handler may write meanwhile.
+	snapshot := p.db.NewSnapshot()
+	defer func() { _ = snapshot.Close() }()
+	return pebbleGetAll(snapshot, prefix, handler)
+}
+
+// Delete key in specific prefix
+func (p *PebbleDB) Delete(prefix []byte, key []byte) error {
+	return p.db.Delete(append(prefix, key...), pebble.Sync)
+}
+
+// CountPrefix return the object count for all keys under specified prefix(bucket)
+func (p *PebbleDB) CountPrefix(prefix []byte) (int64, error) {
+	it, err := p.db.NewIter(prefixIterOptions(prefix))
+	if err != nil {
+		return 0, err
+	}
+	var res int64
+	for it.First(); it.Valid(); it.Next() {
+		res++
+	}
+	return res, it.Close()
+}
+
+// DropPrefix cleans all items in a collection
+func (p *PebbleDB) DropPrefix(prefix []byte) error {
+	opts := prefixIterOptions(prefix)
+	if opts.UpperBound == nil {
+		// The prefix has no upper bound, so delete its keys one by one.
+		batch := p.db.NewBatch()
+		defer func() { _ = batch.Close() }()
+		err := pebbleGetAll(p.db, prefix, func(_ int, obj basedb.Obj) error {
+			return batch.Delete(append(prefix, obj.Key...), nil)
+		})
+		if err != nil {
+			return err
+		}
+		return batch.Commit(pebble.Sync)
+	}
+	return p.db.DeleteRange(opts.LowerBound, opts.UpperBound, pebble.Sync)
+}
+
+// Update runs fn in a read-write transaction, committing it if fn succeeds.
+func (p *PebbleDB) Update(fn func(basedb.Txn) error) error {
+	txn := p.Begin()
+	defer txn.Discard()
+	if err := fn(txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
+// Using returns the given ReadWriter, falling back to the database if it's nil.
+func (p *PebbleDB) Using(rw basedb.ReadWriter) basedb.ReadWriter {
+	if rw == nil {
+		return p
+	}
+	return rw
+}
+
+// UsingReader returns the given Reader, falling back to the database if it's nil.
+func (p *PebbleDB) UsingReader(r basedb.Reader) basedb.Reader {
+	if r == nil {
+		return p
+	}
+	return r
+}
+
+// Close closes the database.
+func (p *PebbleDB) Close() error {
+	// Stop & wait for background goroutines.
+	p.cancel()
+	p.wg.Wait()
+
+	// Close the database.
+	err := p.db.Close()
+	if err != nil {
+		p.logger.Fatal("failed to close db", zap.Error(err))
+	}
+	return err
+}
+
+// QuickGC does nothing, since Pebble reclaims disk space by compacting in the background.
+func (p *PebbleDB) QuickGC(context.Context) error {
+	return nil
+}
+
+// FullGC compacts the whole database to reclaim (ideally) all unused disk space.
+// Designed to be called when the database is not being used.
+func (p *PebbleDB) FullGC(context.Context) error {
+	first, last, err := p.keyRange()
+	if err != nil || first == nil {
+		return err
+	}
+	// The end of the compaction is exclusive, so it's extended past the last key.
+	return errors.Wrap(p.db.Compact(first, append(last, 0), true), "failed to compact")
+}
+
+// keyRange returns the first and last keys of the database, or nil if it's empty.
+func (p *PebbleDB) keyRange() (first, last []byte, err error) {
+	it, err := p.db.NewIter(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if it.First() {
+		first = bytes.Clone(it.Key())
+	}
+	if it.Last() {
+		last = bytes.Clone(it.Key())
+	}
+	return first, last, it.Close()
+}
+
+// Backup writes a full backup of the database to w, prefixed by the given header describing its content.
+// The backup is read from a consistent snapshot, so the database may be used meanwhile.
+func (p *PebbleDB) Backup(w io.Writer, header []byte) error {
+	bw := bufio.NewWriter(w)
+	if err := writeBackupFraming(bw, EnginePebble, header); err != nil {
+		return err
+	}
+
+	snapshot := p.db.NewSnapshot()
+	defer func() { _ = snapshot.Close() }()
+	it, err := snapshot.NewIter(nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate db")
+	}
+	for it.First(); it.Valid(); it.Next() {
+		if err := writeBackupItem(bw, it.Key(), it.Value()); err != nil {
+			_ = it.Close()
+			return errors.Wrap(err, "failed to back up db")
+		}
+	}
+	if err := it.Close(); err != nil {
+		return errors.Wrap(err, "failed to iterate db")
+	}
+	return bw.Flush()
+}
+
+// Restore loads a backup written by Backup from r into the database, which must be empty.
+func (p *PebbleDB) Restore(r io.Reader) error {
+	if err := prepareRestore(p, r); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	batch := p.db.NewBatch()
+	for {
+		key, value, err := readBackupItem(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = batch.Close()
+			return errors.Wrap(err, "failed to load backup")
+		}
+		if err := batch.Set(key, value, nil); err != nil {
+			_ = batch.Close()
+			return err
+		}
+		if batch.Count() >= restoreBatchSize {
+			if err := batch.Commit(pebble.Sync); err != nil {
+				_ = batch.Close()
+				return errors.Wrap(err, "failed to load backup")
+			}
+			_ = batch.Close()
+			batch = p.db.NewBatch()
+		}
+	}
+	defer func() { _ = batch.Close() }()
+	return errors.Wrap(batch.Commit(pebble.Sync), "failed to load backup")
+}
+
+// StartPeriodicSnapshots periodically writes backups of the database into dir,
+// keeping the latest retain ones. The header is called for every snapshot to describe its content.
+func (p *PebbleDB) StartPeriodicSnapshots(interval time.Duration, dir string, retain int, header func() ([]byte, error)) error {
+	return startPeriodicSnapshots(p.ctx, &p.wg, p.logger, p, interval, dir, retain, header)
+}
+
+// report the db size and metrics
+func (p *PebbleDB) report() {
+	logger := p.logger.Named(logging.NamePebbleDBReporting)
+	metrics := p.db.Metrics()
+	logger.Debug("DB Report",
+		zap.Uint64("disk_space_usage", metrics.DiskSpaceUsage()),
+		zap.Int64("compactions", metrics.Compact.Count),
+		zap.Uint64("wal_size", metrics.WAL.Size))
+}
+
+func (p *PebbleDB) periodicallyReport(interval time.Duration) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.report()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *PebbleDB) getMany(r pebble.Reader, prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	for _, k := range keys {
+		obj, found, err := pebbleGet(r, prefix, k)
+		if err != nil {
+			p.logger.Warn("failed to get item", zap.String("key", string(k)))
+			return err
+		}
+		if !found {
+			p.logger.Debug("item not found", zap.String("key", string(k)))
+			continue
+		}
+		if err := iterator(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type pebbleTxn struct {
+	batch  *pebble.Batch
+	db     *PebbleDB
+	closed bool
+}
+
+func (t *pebbleTxn) Commit() error {
+	return t.batch.Commit(pebble.Sync)
+}
+
+func (t *pebbleTxn) Discard() {
+	if t.closed {
+		return
+	}
+	t.closed = true
+	_ = t.batch.Close()
+}
+
+func (t *pebbleTxn) Set(prefix []byte, key []byte, value []byte) error {
+	return t.batch.Set(append(prefix, key...), value, nil)
+}
+
+func (t *pebbleTxn) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return setMany(t.batch, prefix, n, next)
+}
+
+func (t *pebbleTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(t.batch, prefix, key)
+}
+
+func (t *pebbleTxn) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return t.db.getMany(t.batch, prefix, keys, iterator)
+}
+
+func (t *pebbleTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return pebbleGetAll(t.batch, prefix, handler)
+}
+
+func (t *pebbleTxn) Delete(prefix []byte, key []byte) error {
+	return t.batch.Delete(append(prefix, key...), nil)
+}
+
+type pebbleReadTxn struct {
+	snapshot *pebble.Snapshot
+	db       *PebbleDB
+	closed   bool
+}
+
+func (t *pebbleReadTxn) Discard() {
+	if t.closed {
+		return
+	}
+	t.closed = true
+	_ = t.snapshot.Close()
+}
+
+func (t *pebbleReadTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	return pebbleGet(t.snapshot, prefix, key)
+}
+
+func (t *pebbleReadTxn) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	return t.db.getMany(t.snapshot, prefix, keys, iterator)
+}
+
+func (t *pebbleReadTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return pebbleGetAll(t.snapshot, prefix, handler)
+}
+
+func pebbleGet(r pebble.Reader, prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	value, closer, err := r.Get(append(prefix, key...))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return basedb.Obj{}, false, nil
+		}
+		return basedb.Obj{}, true, err
+	}
+	defer func() { _ = closer.Close() }()
+	return basedb.Obj{
+		Key:   key,
+		Value: bytes.Clone(value),
+	}, true, nil
+}
+
+func pebbleGetAll(r pebble.Reader, prefix []byte, handler func(int, basedb.Obj) error) error {
+	it, err := r.NewIter(prefixIterOptions(prefix))
+	if err != nil {
+		return err
+	}
+	i := 0
+	for it.First(); it.Valid(); it.Next() {
+		if err := handler(i, basedb.Obj{
+			Key:   bytes.Clone(bytes.TrimPrefix(it.Key(), prefix)),
+			Value: bytes.Clone(it.Value()),
+		}); err != nil {
+			_ = it.Close()
+			return err
+		}
+		i++
+	}
+	return it.Close()
+}
+
+func setMany(batch *pebble.Batch, prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	for i := 0; i < n; i++ {
+		item, err := next(i)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(append(prefix, item.Key...), item.Value, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefixIterOptions returns the options to iterate over the keys with the given prefix.
+func prefixIterOptions(prefix []byte) *pebble.IterOptions {
+	if len(prefix) == 0 {
+		return &pebble.IterOptions{}
+	}
+	return &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	}
+}
+
+// prefixUpperBound returns the smallest key greater than all keys with the given prefix,
+// or nil if there's none.
+func prefixUpperBound(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+func writeBackupItem(w io.Writer, key, value []byte) error {
+	var buf [binary.MaxVarintLen64]byte
+	for _, field := range [][]byte{key, value} {
+		n := binary.PutUvarint(buf[:], uint64(len(field)))
+		if _, err := w.Write(buf[:n]); err != nil {
+			return err
+		}
+		if _, err := w.Write(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readBackupItem(r *bufio.Reader) (key, value []byte, err error) {
+	fields := make([][]byte, 2)
+	for i := range fields {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			if i > 0 && errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, nil, err
+		}
+		if size > maxBackupItemSize {
+			return nil, nil, errors.Errorf("backup item is too large: %d bytes", size)
+		}
+		fields[i] = make([]byte, size)
+		if _, err := io.ReadFull(r, fields[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return fields[0], fields[1], nil
+}
@@ -0,0 +1,154 @@
+// Package slotprune deletes old records which are keyed by slot first.
+package slotprune
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"go.uber.org/zap"
+
+	"github.com/ssvlabs/ssv/storage/basedb"
+)
+
+var prunedKey = []byte("pruned")
+
+const deleteBatchSize = 1000
+
+// Pruner deletes old records which are keyed by slot first, as encoded by SlotKey.
+// It saves the slot it pruned up to, so that pruning continues from there
+// and scans the entire records only the first time.
+type Pruner struct {
+	db basedb.Database
+	// name describes the records in logs.
+	name string
+	// prefix is where the pruned slot is saved.
+	prefix []byte
+	// recordPrefixes are the prefixes of the records to prune.
+	recordPrefixes [][]byte
+}
+
+// New creates a pruner of the records under the given prefixes,
+// saving the slot it pruned up to under prefix.
+func New(db basedb.Database, name string, prefix []byte, recordPrefixes ...[]byte) *Pruner {
+	return &Pruner{
+		db:             db,
+		name:           name,
+		prefix:         prefix,
+		recordPrefixes: recordPrefixes,
+	}
+}
+
+// Prune deletes the records of slots before the given slot and returns how many were deleted.
+func (p *Pruner) Prune(before phase0.Slot) (int, error) {
+	obj, found, err := p.db.Get(p.prefix, prunedKey)
+	if err != nil {
+		return 0, fmt.Errorf("get pruned slot: %w", err)
+	}
+
+	var deleted int
+	if !found {
+		for _, prefix := range p.recordPrefixes {
+			n, err := p.deleteWhere(prefix, func(key []byte) bool {
+				return KeySlot(key) < before
+			})
+			if err != nil {
+				return deleted, err
+			}
+			deleted += n
+		}
+	} else {
+		for slot := KeySlot(obj.Value); slot < before; slot++ {
+			for _, prefix := range p.recordPrefixes {
+				n, err := p.deleteWhere(slices.Concat(prefix, SlotKey(slot)), func([]byte) bool {
+					return true
+				})
+				if err != nil {
+					return deleted, err
+				}
+				deleted += n
+			}
+		}
+	}
+
+	if err := p.db.Set(p.prefix, prunedKey, SlotKey(before)); err != nil {
+		return deleted, fmt.Errorf("save pruned slot: %w", err)
+	}
+	return deleted, nil
+}
+
+// PruneLoop prunes the records of slots before the slot returned by retainFrom right away and then once every interval,
+// until the context is done. There's nothing to prune while retainFrom returns 0.
+func (p *Pruner) PruneLoop(ctx context.Context, logger *zap.Logger, interval time.Duration, retainFrom func() phase0.Slot) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if before := retainFrom(); before > 0 {
+			start := time.Now()
+			deleted, err := p.Prune(before)
+			if err != nil {
+				logger.Error("failed to prune "+p.name, zap.Error(err))
+			} else if deleted > 0 {
+				logger.Debug("pruned "+p.name,
+					zap.Uint64("before_slot", uint64(before)),
+					zap.Int("deleted", deleted),
+					zap.Duration("took", time.Since(start)))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pruner) deleteWhere(prefix []byte, match func(key []byte) bool) (int, error) {
+	var keys [][]byte
+	err := p.db.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+		if match(obj.Key) {
+			keys = append(keys, obj.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("get records: %w", err)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	// Delete in batches to keep transactions small.
+	for start := 0; start < len(keys); start += deleteBatchSize {
+		batch := keys[start:min(start+deleteBatchSize, len(keys))]
+		err := p.db.Update(func(txn basedb.Txn) error {
+			for _, key := range batch {
+				if err := txn.Delete(prefix, key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("delete records: %w", err)
+		}
+	}
+	return len(keys), nil
+}
+
+// SlotKey encodes a slot in big-endian, so that keys starting with it are ordered by slot.
+func SlotKey(slot phase0.Slot) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(slot))
+	return b
+}
+
+// KeySlot decodes the slot a key starts with.
+func KeySlot(key []byte) phase0.Slot {
+	return phase0.Slot(binary.BigEndian.Uint64(key[:8]))
+}